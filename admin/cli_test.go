@@ -0,0 +1,201 @@
+//go:build unitTests
+// +build unitTests
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+)
+
+var _ = Describe("cli", func() {
+	var mockController *gomock.Controller
+	var serviceMock *service.MockIAdminService
+	var credentials *GrafanaCredentials
+	var adminCreated bool
+	var stdin *bytes.Buffer
+	var stdout *bytes.Buffer
+	var stderr *bytes.Buffer
+	var c *cli
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockIAdminService(mockController)
+		credentials = &GrafanaCredentials{User: "admin", Password: "secret"}
+		adminCreated = false
+		stdin = &bytes.Buffer{}
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		logger, _ := logrustest.NewNullLogger()
+		c = &cli{
+			newAdmin: func() (*Admin, error) {
+				adminCreated = true
+				return &Admin{Service: serviceMock, Credentials: credentials, Log: logger}, nil
+			},
+			stdin:  stdin,
+			stdout: stdout,
+			stderr: stderr,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Context("with an invalid command line", func() {
+		It("prints the commands without a command", func() {
+			Expect(c.run([]string{})).To(Equal(exitUsage))
+			Expect(stderr.String()).To(ContainSubstring("slo export"))
+			Expect(stderr.String()).To(ContainSubstring("users sync"))
+			Expect(adminCreated).To(BeFalse())
+		})
+
+		It("rejects an unknown command", func() {
+			Expect(c.run([]string{"slo", "delete"})).To(Equal(exitUsage))
+			Expect(stderr.String()).To(ContainSubstring(`unknown command "slo delete"`))
+			Expect(adminCreated).To(BeFalse())
+		})
+
+		It("requires -org for dashboards rerender", func() {
+			Expect(c.run([]string{"dashboards", "rerender"})).To(Equal(exitUsage))
+			Expect(stderr.String()).To(ContainSubstring("-org is required"))
+			Expect(adminCreated).To(BeFalse())
+		})
+
+		It("rejects -all together with -org for plugin enable", func() {
+			Expect(c.run([]string{"plugin", "enable", "-all", "-org", "1"})).To(Equal(exitUsage))
+			Expect(stderr.String()).To(ContainSubstring("either -all or -org is required"))
+		})
+
+		It("rejects invalid organization ids", func() {
+			Expect(c.run([]string{"plugin", "enable", "-org", "1,x"})).To(Equal(exitUsage))
+			Expect(stderr.String()).To(ContainSubstring("invalid id x"))
+		})
+	})
+
+	Context("slo export", func() {
+		It("writes the SLOs of the organization as JSON", func() {
+			serviceMock.EXPECT().ExportSlos(int64(2)).Return([]*model.Slo{{ID: 5, OrgID: 2, Name: "checkout"}}, nil)
+
+			Expect(c.run([]string{"slo", "export", "-org", "2"})).To(Equal(exitOK))
+
+			slos := []*model.Slo{}
+			Expect(json.Unmarshal(stdout.Bytes(), &slos)).To(Succeed())
+			Expect(slos).To(HaveLen(1))
+			Expect(slos[0].Name).To(Equal("checkout"))
+		})
+
+		It("fails if the service fails", func() {
+			serviceMock.EXPECT().ExportSlos(int64(0)).Return(nil, errors.New("db down"))
+
+			Expect(c.run([]string{"slo", "export"})).To(Equal(exitFailure))
+			Expect(stderr.String()).To(ContainSubstring("db down"))
+		})
+	})
+
+	Context("slo import", func() {
+		It("validates the SLOs of stdin on dry run without login", func() {
+			stdin.WriteString(`[{"id": 5, "orgId": 2, "name": "checkout"}]`)
+			serviceMock.EXPECT().ImportSlos(nil, gomock.Any(), true).
+				DoAndReturn(func(_ *auth.UserContext, slos []*model.Slo, _ bool) []*model.AdminTaskResult {
+					Expect(slos).To(HaveLen(1))
+					return []*model.AdminTaskResult{{Entity: model.AdminEntitySlo, OrgID: 2, Name: "checkout", Action: "create", Status: model.AdminTaskStatusPlanned}}
+				})
+
+			Expect(c.run([]string{"slo", "import", "-dry-run"})).To(Equal(exitOK))
+			Expect(stdout.String()).To(ContainSubstring("planned"))
+			Expect(stdout.String()).To(ContainSubstring("0 done, 1 planned, 0 failed"))
+		})
+
+		It("signs in and exits with failure if an SLO failed", func() {
+			stdin.WriteString(`[{"orgId": 2, "name": "checkout"}, {"orgId": 2, "name": "payment"}]`)
+			userContext := &auth.UserContext{ID: 1, Login: "admin"}
+			serviceMock.EXPECT().Login("admin", "secret").Return(userContext, nil)
+			serviceMock.EXPECT().ImportSlos(userContext, gomock.Any(), false).Return([]*model.AdminTaskResult{
+				{Entity: model.AdminEntitySlo, ID: 7, OrgID: 2, Name: "checkout", Action: "create", Status: model.AdminTaskStatusDone},
+				{Entity: model.AdminEntitySlo, OrgID: 2, Name: "payment", Action: "create", Status: model.AdminTaskStatusFailed, Message: "name taken"},
+			})
+
+			Expect(c.run([]string{"slo", "import"})).To(Equal(exitFailure))
+			Expect(stdout.String()).To(ContainSubstring("name taken"))
+			Expect(stdout.String()).To(ContainSubstring("1 done, 0 planned, 1 failed"))
+		})
+
+		It("requires the Grafana credentials without dry run", func() {
+			stdin.WriteString(`[]`)
+			credentials.Password = ""
+
+			Expect(c.run([]string{"slo", "import"})).To(Equal(exitFailure))
+			Expect(stderr.String()).To(ContainSubstring("GRAFANA_ADMIN_USER and GRAFANA_ADMIN_PASSWORD have to be set"))
+		})
+
+		It("fails on invalid input", func() {
+			stdin.WriteString(`{`)
+
+			Expect(c.run([]string{"slo", "import", "-dry-run"})).To(Equal(exitFailure))
+			Expect(stderr.String()).To(ContainSubstring("cannot read stdin"))
+		})
+	})
+
+	Context("plugin enable", func() {
+		It("passes the organization ids and prints the results as JSON", func() {
+			userContext := &auth.UserContext{ID: 1, Login: "admin"}
+			serviceMock.EXPECT().Login("admin", "secret").Return(userContext, nil)
+			serviceMock.EXPECT().EnablePlugin(userContext, []int64{2, 3}, true, false).Return([]*model.AdminTaskResult{
+				{Entity: model.AdminEntityOrganization, ID: 2, Name: "team a", Action: "enable plugin", Status: model.AdminTaskStatusDone},
+				{Entity: model.AdminEntityOrganization, ID: 3, Name: "team b", Action: "enable plugin", Status: model.AdminTaskStatusDone},
+			}, nil)
+
+			Expect(c.run([]string{"-json", "plugin", "enable", "-org", "2,3", "-skip-datasources"})).To(Equal(exitOK))
+
+			results := []*model.AdminTaskResult{}
+			Expect(json.Unmarshal(stdout.Bytes(), &results)).To(Succeed())
+			Expect(results).To(HaveLen(2))
+			Expect(results[1].Name).To(Equal("team b"))
+		})
+
+		It("enables the plugin in all organizations with -all", func() {
+			serviceMock.EXPECT().EnablePlugin(nil, nil, false, true).Return([]*model.AdminTaskResult{}, nil)
+
+			Expect(c.run([]string{"plugin", "enable", "-all", "-dry-run"})).To(Equal(exitOK))
+		})
+	})
+
+	Context("history purge and users sync", func() {
+		It("runs the purge without login", func() {
+			serviceMock.EXPECT().PurgeOrphanedHistory(false).Return([]*model.AdminTaskResult{
+				{Entity: model.AdminEntitySloHistory, ID: 9, Action: "delete", Status: model.AdminTaskStatusDone, Message: "task node:1"},
+			}, nil)
+
+			Expect(c.run([]string{"history", "purge"})).To(Equal(exitOK))
+			lines := strings.Split(stdout.String(), "\n")
+			Expect(lines[0]).To(HavePrefix("STATUS"))
+			Expect(lines[1]).To(ContainSubstring("task node:1"))
+		})
+
+		It("syncs the users on dry run", func() {
+			serviceMock.EXPECT().SyncUsers(true).Return([]*model.AdminTaskResult{}, nil)
+
+			Expect(c.run([]string{"users", "sync", "-dry-run"})).To(Equal(exitOK))
+			Expect(stdout.String()).To(ContainSubstring("0 done, 0 planned, 0 failed"))
+		})
+	})
+
+	It("fails if the admin cannot be created", func() {
+		c.newAdmin = func() (*Admin, error) { return nil, errors.New("no database") }
+
+		Expect(c.run([]string{"users", "sync"})).To(Equal(exitFailure))
+		Expect(stderr.String()).To(ContainSubstring("no database"))
+	})
+})
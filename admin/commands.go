@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+// task runs a parsed command, the results of the entities are written to out.
+type task func(admin *Admin, out *output) error
+
+type command struct {
+	args        string
+	description string
+	parse       func(flags *flag.FlagSet, args []string) (task, error)
+}
+
+var commands = map[string]*command{
+	"slo export": {
+		args:        "[-org id] [-out file]",
+		description: "Writes the SLOs with labels, ownership and children as JSON, of all organizations without -org.",
+		parse:       parseSloExport,
+	},
+	"slo import": {
+		args: "[-in file] [-dry-run]",
+		description: "Creates the SLOs of a slo export in the organizations they name, with new IDs.\n" +
+			"Composite SLOs keep the IDs of their children, a dry run only validates the SLOs.",
+		parse: parseSloImport,
+	},
+	"dashboards rerender": {
+		args:        "-org id [-dry-run]",
+		description: "Renders the dashboards of all SLOs of the organization again, e.g. after a template fix.",
+		parse:       parseDashboardsRerender,
+	},
+	"plugin enable": {
+		args:        "(-all | -org id,...) [-skip-datasources] [-dry-run]",
+		description: "Enables the plugin with its datasources in the organizations.",
+		parse:       parsePluginEnable,
+	},
+	"history purge": {
+		args: "[-dry-run]",
+		description: "Deletes the SLO history of SLOs which no longer exist.\n" +
+			"The deletions run as Elasticsearch tasks, a dry run counts the documents.",
+		parse: parseHistoryPurge,
+	},
+	"users sync": {
+		args: "[-dry-run]",
+		description: "Grants the organization roles of the token login to all users: Grafana server admins\n" +
+			"become Admin of every organization and all users Viewer of the main organization.",
+		parse: parseUsersSync,
+	},
+}
+
+func parseSloExport(flags *flag.FlagSet, args []string) (task, error) {
+	orgID := flags.Int64("org", 0, "organization `id` to export, all organizations if not set")
+	file := flags.String("out", "", "`file` to write the SLOs to instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return func(admin *Admin, out *output) error {
+		slos, err := admin.Service.ExportSlos(*orgID)
+		if err != nil {
+			return err
+		}
+		return out.writeJSON(*file, slos)
+	}, nil
+}
+
+func parseSloImport(flags *flag.FlagSet, args []string) (task, error) {
+	file := flags.String("in", "", "`file` of a slo export to read instead of stdin")
+	dryRun := flags.Bool("dry-run", false, "only validate the SLOs")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return func(admin *Admin, out *output) error {
+		slos := []*model.Slo{}
+		if err := out.readJSON(*file, &slos); err != nil {
+			return err
+		}
+		userContext, err := admin.login(*dryRun)
+		if err != nil {
+			return err
+		}
+		return out.results(admin.Service.ImportSlos(userContext, slos, *dryRun))
+	}, nil
+}
+
+func parseDashboardsRerender(flags *flag.FlagSet, args []string) (task, error) {
+	orgID := flags.Int64("org", 0, "organization `id` whose dashboards are rendered")
+	dryRun := flags.Bool("dry-run", false, "only list the dashboards")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if *orgID <= 0 {
+		return nil, errors.New("-org is required")
+	}
+
+	return func(admin *Admin, out *output) error {
+		userContext, err := admin.login(*dryRun)
+		if err != nil {
+			return err
+		}
+		results, err := admin.Service.RerenderDashboards(userContext, *orgID, *dryRun)
+		if err != nil {
+			return err
+		}
+		return out.results(results)
+	}, nil
+}
+
+func parsePluginEnable(flags *flag.FlagSet, args []string) (task, error) {
+	all := flags.Bool("all", false, "enable the plugin in all organizations")
+	var orgIDs idList
+	flags.Var(&orgIDs, "org", "comma separated organization `ids`")
+	skipDatasources := flags.Bool("skip-datasources", false, "do not create the datasources")
+	dryRun := flags.Bool("dry-run", false, "only list the organizations")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if *all == (orgIDs != nil) {
+		return nil, errors.New("either -all or -org is required")
+	}
+
+	return func(admin *Admin, out *output) error {
+		userContext, err := admin.login(*dryRun)
+		if err != nil {
+			return err
+		}
+		results, err := admin.Service.EnablePlugin(userContext, orgIDs, *skipDatasources, *dryRun)
+		if err != nil {
+			return err
+		}
+		return out.results(results)
+	}, nil
+}
+
+func parseHistoryPurge(flags *flag.FlagSet, args []string) (task, error) {
+	dryRun := flags.Bool("dry-run", false, "only count the documents")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return func(admin *Admin, out *output) error {
+		results, err := admin.Service.PurgeOrphanedHistory(*dryRun)
+		if err != nil {
+			return err
+		}
+		return out.results(results)
+	}, nil
+}
+
+func parseUsersSync(flags *flag.FlagSet, args []string) (task, error) {
+	dryRun := flags.Bool("dry-run", false, "only list the missing roles")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return func(admin *Admin, out *output) error {
+		results, err := admin.Service.SyncUsers(*dryRun)
+		if err != nil {
+			return err
+		}
+		return out.results(results)
+	}, nil
+}
+
+// idList is a flag of comma separated IDs.
+type idList []int64
+
+func (l *idList) String() string {
+	ids := make([]string, 0, len(*l))
+	for _, id := range *l {
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(ids, ",")
+}
+
+func (l *idList) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || id <= 0 {
+			return errors.New("invalid id " + part)
+		}
+		*l = append(*l, id)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/wiring"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// Admin runs the bulk tasks of the admin command line tool with the services of the controller.
+type Admin struct {
+	Service     service.IAdminService
+	Credentials *GrafanaCredentials
+	Log         logrus.FieldLogger
+}
+
+// GrafanaCredentials sign in the tasks which change Grafana, they need a Grafana server admin.
+type GrafanaCredentials struct {
+	User     string
+	Password string
+}
+
+func newGrafanaCredentials() *GrafanaCredentials {
+	return &GrafanaCredentials{
+		User:     viper.GetString("grafana_admin_user"),
+		Password: viper.GetString("grafana_admin_password"),
+	}
+}
+
+// login signs in to Grafana, dry runs change nothing and get no user context.
+func (a *Admin) login(dryRun bool) (*auth.UserContext, error) {
+	if dryRun {
+		return nil, nil
+	}
+	if a.Credentials.User == "" || a.Credentials.Password == "" {
+		return nil, fmt.Errorf("GRAFANA_ADMIN_USER and GRAFANA_ADMIN_PASSWORD have to be set")
+	}
+	return a.Service.Login(a.Credentials.User, a.Credentials.Password)
+}
+
+func main() {
+	cli := &cli{
+		newAdmin: func() (*Admin, error) {
+			wiring.InitConfig()
+			return initAdmin()
+		},
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	os.Exit(cli.run(os.Args[1:]))
+}
+
+// cli parses the command line, newAdmin connects to the database and the clients only once
+// the command line is valid.
+type cli struct {
+	newAdmin func() (*Admin, error)
+	stdin    io.Reader
+	stdout   io.Writer
+	stderr   io.Writer
+}
+
+func (c *cli) run(args []string) int {
+	global := flag.NewFlagSet("cruiser-admin", flag.ContinueOnError)
+	global.SetOutput(c.stderr)
+	jsonOutput := global.Bool("json", false, "print the results as JSON")
+	global.Usage = func() { c.usage(global) }
+	if err := global.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if global.NArg() < 2 {
+		global.Usage()
+		return exitUsage
+	}
+	name := global.Arg(0) + " " + global.Arg(1)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(c.stderr, "unknown command %q\n", name)
+		global.Usage()
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(c.stderr, "usage: cruiser-admin [-json] %s %s\n\n%s\n\n", name, cmd.args, cmd.description)
+		flags.PrintDefaults()
+	}
+	task, err := cmd.parse(flags, global.Args()[2:])
+	if err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(c.stderr, err)
+			flags.Usage()
+		}
+		return exitUsage
+	}
+
+	admin, err := c.newAdmin()
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitFailure
+	}
+	out := &output{stdin: c.stdin, stdout: c.stdout, json: *jsonOutput}
+	if err = task(admin, out); err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return exitFailure
+	}
+	if out.failed > 0 {
+		return exitFailure
+	}
+	return exitOK
+}
+
+func (c *cli) usage(global *flag.FlagSet) {
+	fmt.Fprintf(c.stderr, "usage: cruiser-admin [-json] <command> <subcommand> [flags]\n\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(c.stderr, "  %-20s %s\n", name, strings.SplitN(commands[name].description, "\n", 2)[0])
+	}
+	fmt.Fprintf(c.stderr, "\nflags:\n")
+	global.PrintDefaults()
+}
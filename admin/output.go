@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+// output reads the input of the commands and writes their results as table or as JSON, failed
+// counts the entities the tasks failed for.
+type output struct {
+	stdin  io.Reader
+	stdout io.Writer
+	json   bool
+	failed int
+}
+
+func (o *output) results(results []*model.AdminTaskResult) error {
+	counts := map[model.AdminTaskStatus]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	o.failed += counts[model.AdminTaskStatusFailed]
+
+	if o.json {
+		return o.writeJSON("", results)
+	}
+
+	w := tabwriter.NewWriter(o.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tENTITY\tID\tORG\tNAME\tACTION\tMESSAGE")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", result.Status, result.Entity, optionalID(result.ID),
+			optionalID(result.OrgID), result.Name, result.Action, result.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(o.stdout, "\n%d done, %d planned, %d failed\n",
+		counts[model.AdminTaskStatusDone], counts[model.AdminTaskStatusPlanned], counts[model.AdminTaskStatusFailed])
+	return err
+}
+
+// writeJSON writes the value indented to the file, or to stdout if file is empty.
+func (o *output) writeJSON(file string, value interface{}) error {
+	w := o.stdout
+	if file != "" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// readJSON reads the value from the file, or from stdin if file is empty.
+func (o *output) readJSON(file string, value interface{}) error {
+	r := o.stdin
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	if err := json.NewDecoder(r).Decode(value); err != nil {
+		return fmt.Errorf("cannot read %s: %w", inputName(file), err)
+	}
+	return nil
+}
+
+func inputName(file string) string {
+	if file == "" {
+		return "stdin"
+	}
+	return file
+}
+
+func optionalID(id int64) string {
+	if id == 0 {
+		return "-"
+	}
+	return fmt.Sprint(id)
+}
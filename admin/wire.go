@@ -0,0 +1,24 @@
+//go:build wireinject
+// +build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	pluginService "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service/plugin"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/wiring"
+)
+
+var adminSet = wire.NewSet(
+	wire.Struct(new(service.AdminService), "*"), wire.Bind(new(service.IAdminService), new(*service.AdminService)),
+	wire.Bind(new(service.IPluginEnabler), new(*pluginService.Plugin)),
+	newGrafanaCredentials,
+)
+
+func initAdmin() (*Admin, error) {
+	wire.Build(wiring.ConfigsSet, wiring.ClientsSet, wiring.ServicesSet, wiring.ValidatorsSet, wiring.ProviderSet,
+		adminSet, wire.Struct(new(Admin), "*"))
+
+	return &Admin{}, nil
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type BudgetPolicyAPI struct {
+	Service   service.IBudgetPolicyService
+	Validator validator.ITranslatedValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Get Error Budget Policies
+// @Description Returns all error budget policies of the Organization
+// @Tags budget policies
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {array} model.BudgetPolicy
+// @Router /org/{id}/budget_policy [get]
+func (api *BudgetPolicyAPI) GetAll(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get budget policies").Create(), api.Log)
+		return
+	}
+
+	policies, err := api.Service.GetByOrgID(orgID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get budget policies").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// @Summary Get Error Budget Policy
+// @Description Returns Error Budget Policy
+// @Tags budget policies
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param policyId path int true "Budget Policy ID"
+// @Success 200 {object} model.BudgetPolicy
+// @Router /org/{id}/budget_policy/{policyId} [get]
+func (api *BudgetPolicyAPI) Get(c *gin.Context) {
+	orgID, policyID, err := getBudgetPolicyParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get budget policy").Create(), api.Log)
+		return
+	}
+
+	policy, err := api.Service.Get(orgID, policyID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get budget policy").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// @Summary Add Error Budget Policy
+// @Description Creates an Error Budget Policy for the SLOs of the Organization
+// @Tags budget policies
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param budgetPolicy body model.BudgetPolicy true "id and orgId are not checked"
+// @Success 201 {object} api.ID
+// @Router /org/{id}/budget_policy [post]
+func (api *BudgetPolicyAPI) Create(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create budget policy").Create(), api.Log)
+		return
+	}
+
+	policy, err := api.extractAndValidateBudgetPolicy(c, orgID, 0)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create budget policy").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Create(policy); err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create budget policy").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusCreated, policy.ID, c)
+}
+
+// @Summary Update Error Budget Policy
+// @Description Updates Error Budget Policy
+// @Tags budget policies
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param policyId path int true "Budget Policy ID"
+// @Param budgetPolicy body model.BudgetPolicy true "id and orgId are not checked"
+// @Success 200 {object} api.ID
+// @Router /org/{id}/budget_policy/{policyId} [put]
+func (api *BudgetPolicyAPI) Update(c *gin.Context) {
+	orgID, policyID, err := getBudgetPolicyParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update budget policy").Create(), api.Log)
+		return
+	}
+
+	policy, err := api.extractAndValidateBudgetPolicy(c, orgID, policyID)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update budget policy").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Update(policy); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update budget policy").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusOK, policy.ID, c)
+}
+
+// @Summary Delete Error Budget Policy
+// @Description Deletes Error Budget Policy
+// @Tags budget policies
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param policyId path int true "Budget Policy ID"
+// @Success 200 {object} api.ID
+// @Router /org/{id}/budget_policy/{policyId} [delete]
+func (api *BudgetPolicyAPI) Delete(c *gin.Context) {
+	orgID, policyID, err := getBudgetPolicyParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete budget policy").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Delete(orgID, policyID); err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete budget policy").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusOK, policyID, c)
+}
+
+// @Summary Get Deploy Gate
+// @Description Evaluates the error budget policies of the Organization and returns whether deploys are allowed,
+// @Description with the triggered policies as reasons. Meant to be queried by CI pipelines.
+// @Tags budget policies
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {object} model.DeployGate
+// @Router /org/{id}/deploy-gate [get]
+func (api *BudgetPolicyAPI) GetDeployGate(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get deploy gate").Create(), api.Log)
+		return
+	}
+
+	gate, err := api.Service.GetDeployGate(orgID, time.Now())
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get deploy gate").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, gate)
+}
+
+// @Summary Get Deploy Freezes
+// @Description Returns the deploy freezes of the Organization, the latest first
+// @Tags budget policies
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {array} model.DeployFreeze
+// @Router /org/{id}/deploy_freeze [get]
+func (api *BudgetPolicyAPI) GetDeployFreezes(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get deploy freezes").Create(), api.Log)
+		return
+	}
+
+	freezes, err := api.Service.GetDeployFreezes(orgID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get deploy freezes").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, freezes)
+}
+
+func getBudgetPolicyParams(c *gin.Context) (int64, int64, error) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	policyID, err := GetInt64Param(c, "policyId")
+	if err != nil {
+		return 0, 0, err
+	}
+	return orgID, policyID, nil
+}
+
+func (api *BudgetPolicyAPI) extractAndValidateBudgetPolicy(c *gin.Context, orgID, policyID int64) (*model.BudgetPolicy, error) {
+	var policy model.BudgetPolicy
+	if err := c.ShouldBindBodyWith(&policy, binding.JSON); err != nil {
+		return nil, errory.GetValidationError(err, policy)
+	}
+	policy.ID = policyID
+	policy.OrgID = orgID
+
+	if err := api.Validator.Validate(context.Background(), policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
@@ -0,0 +1,226 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("BudgetPolicyAPI", func() {
+	var mockController *gomock.Controller
+	var budgetPolicyAPI *BudgetPolicyAPI
+	var serviceMock *service.MockIBudgetPolicyService
+	var validatorMock *validator.MockITranslatedValidator
+	logger, _ := logrustest.NewNullLogger()
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var req *http.Request
+
+	policy := model.BudgetPolicy{
+		ID:           3,
+		OrgID:        99,
+		Name:         "freeze critical",
+		CriticalOnly: true,
+		Threshold:    0,
+		Action:       model.BudgetPolicyActionFreeze,
+	}
+	const body = `{"name": "freeze critical", "criticalOnly": true, "threshold": 0, "action": "freeze"}`
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockIBudgetPolicyService(mockController)
+		validatorMock = validator.NewMockITranslatedValidator(mockController)
+		budgetPolicyAPI = &BudgetPolicyAPI{
+			Service:   serviceMock,
+			Validator: validatorMock,
+			Log:       logger,
+		}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.GET("/v1/org/:id/budget_policy", budgetPolicyAPI.GetAll)
+		ginEngine.POST("/v1/org/:id/budget_policy", budgetPolicyAPI.Create)
+		ginEngine.PUT("/v1/org/:id/budget_policy/:policyId", budgetPolicyAPI.Update)
+		ginEngine.DELETE("/v1/org/:id/budget_policy/:policyId", budgetPolicyAPI.Delete)
+		ginEngine.GET("/v1/org/:id/deploy-gate", budgetPolicyAPI.GetDeployGate)
+		ginEngine.GET("/v1/org/:id/deploy_freeze", budgetPolicyAPI.GetDeployFreezes)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetAll()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/budget_policy", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().GetByOrgID(int64(99)).Return([]*model.BudgetPolicy{&policy}, nil)
+			})
+
+			It("returns 200 code with policies of the organization", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"id":3,"orgId":99,"name":"freeze critical","criticalOnly":true,"threshold":0,"action":"freeze"}]`))
+			})
+		})
+	})
+
+	Describe("Create()", func() {
+		var requestBody string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/v1/org/99/budget_policy", bytes.NewBufferString(requestBody))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				requestBody = body
+				expected := policy
+				expected.ID = 0
+				validatorMock.EXPECT().Validate(context.Background(), expected).Return(nil)
+				serviceMock.EXPECT().Create(&expected).DoAndReturn(func(created *model.BudgetPolicy) error {
+					created.ID = 3
+					return nil
+				})
+			})
+
+			It("returns 201 code with the id", func() {
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(w.Body.String()).To(MatchJSON(`{"id":3}`))
+			})
+		})
+
+		Context("when validator returns an error", func() {
+			BeforeEach(func() {
+				requestBody = `{"name": "no action"}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(errory.ValidationErrors.New("action is required"))
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Update()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("PUT", "/v1/org/99/budget_policy/3", bytes.NewBufferString(body))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when policy belongs to another organization", func() {
+			BeforeEach(func() {
+				validatorMock.EXPECT().Validate(context.Background(), policy).Return(nil)
+				serviceMock.EXPECT().Update(&policy).Return(errory.NotFoundErrors.New("budget policy"))
+			})
+
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("Delete()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("DELETE", "/v1/org/99/budget_policy/3", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().Delete(int64(99), int64(3)).Return(nil)
+			})
+
+			It("returns 200 code", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+	})
+
+	Describe("GetDeployGate()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/deploy-gate", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when deploys are denied", func() {
+			BeforeEach(func() {
+				frozenSince := time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC)
+				serviceMock.EXPECT().GetDeployGate(int64(99), gomock.Any()).Return(&model.DeployGate{
+					OrgID:       99,
+					FrozenSince: &frozenSince,
+					Reasons: []model.DeployGateReason{
+						{PolicyID: 3, Policy: "freeze critical", Action: model.BudgetPolicyActionFreeze, SloID: 7, SloName: "checkout", Remaining: -0.5},
+						{PolicyID: 3, Policy: "freeze critical", Action: model.BudgetPolicyActionFreeze, SloID: 8, SloName: "search", BudgetUnknown: true},
+					},
+				}, nil)
+			})
+
+			It("returns 200 code with the decision and its reasons", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"orgId":99,"allowed":false,"frozenSince":"2021-03-04T12:00:00Z","reasons":[
+					{"policyId":3,"policy":"freeze critical","action":"freeze","sloId":7,"sloName":"checkout","remaining":-0.5,"threshold":0},
+					{"policyId":3,"policy":"freeze critical","action":"freeze","sloId":8,"sloName":"search","remaining":0,"threshold":0,
+					"budgetUnknown":true}]}`))
+			})
+		})
+
+		Context("when service fails", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().GetDeployGate(int64(99), gomock.Any()).Return(nil, errory.ProviderErrors.New("db"))
+			})
+
+			It("returns 500 code", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("GetDeployFreezes()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/deploy_freeze", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().GetDeployFreezes(int64(99)).Return([]*model.DeployFreeze{
+					{ID: 1, OrgID: 99, Start: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), Reasons: "exhausted"},
+				}, nil)
+			})
+
+			It("returns 200 code with the freezes", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"id":1,"orgId":99,"start":"2021-03-01T00:00:00Z","end":null,"reasons":"exhausted"}]`))
+			})
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+var complianceReportContentTypes = map[model.ComplianceReportFormat]string{
+	model.ComplianceReportFormatCSV: "text/csv",
+	model.ComplianceReportFormatPDF: "application/pdf",
+}
+
+type ComplianceReportAPI struct {
+	Service   service.IComplianceReportService
+	Validator validator.ITranslatedValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Get Compliance Reports
+// @Description Returns the stored compliance reports of the Organization without their content, the latest period first
+// @Tags compliance reports
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {array} model.ComplianceReport
+// @Router /org/{id}/compliance_report [get]
+func (api *ComplianceReportAPI) GetAll(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get compliance reports").Create(), api.Log)
+		return
+	}
+
+	reports, err := api.Service.GetByOrgID(orgID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get compliance reports").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// @Summary Generate Compliance Report
+// @Description Generates and stores the compliance report of the SLOs of the Organization, or of one of its solutions,
+// @Description for a month. The month which is not over yet is reported until now.
+// @Tags compliance reports
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param complianceReport body model.ComplianceReportRequest true "month formatted as YYYY-MM, format csv or pdf"
+// @Success 201 {object} model.ComplianceReport
+// @Router /org/{id}/compliance_report [post]
+func (api *ComplianceReportAPI) Create(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot generate compliance report").Create(), api.Log)
+		return
+	}
+
+	var request model.ComplianceReportRequest
+	if err = c.ShouldBindBodyWith(&request, binding.JSON); err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(errory.GetValidationError(err, request)).
+			WithMessage("Cannot generate compliance report").Create(), api.Log)
+		return
+	}
+	if err = api.Validator.Validate(context.Background(), request); err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot generate compliance report").Create(), api.Log)
+		return
+	}
+
+	report, err := api.Service.Generate(orgID, &request, time.Now())
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot generate compliance report").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// @Summary Download Compliance Report
+// @Description Returns the content of the compliance report as CSV or PDF attachment
+// @Tags compliance reports
+// @Produce  text/csv,application/pdf
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param reportId path int true "Compliance Report ID"
+// @Success 200 {file} file
+// @Router /org/{id}/compliance_report/{reportId}/download [get]
+func (api *ComplianceReportAPI) Download(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot download compliance report").Create(), api.Log)
+		return
+	}
+	reportID, err := GetInt64Param(c, "reportId")
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot download compliance report").Create(), api.Log)
+		return
+	}
+
+	report, err := api.Service.Get(orgID, reportID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot download compliance report").Create(), api.Log)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", report.FileName()))
+	c.Data(http.StatusOK, complianceReportContentTypes[report.Format], report.Content)
+}
@@ -0,0 +1,170 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("ComplianceReportAPI", func() {
+	var mockController *gomock.Controller
+	var complianceReportAPI *ComplianceReportAPI
+	var serviceMock *service.MockIComplianceReportService
+	var validatorMock *validator.MockITranslatedValidator
+	logger, _ := logrustest.NewNullLogger()
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var req *http.Request
+
+	report := model.ComplianceReport{
+		ID:          12,
+		OrgID:       99,
+		PeriodStart: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		Format:      model.ComplianceReportFormatCSV,
+		CreatedAt:   time.Date(2021, 4, 1, 6, 0, 0, 0, time.UTC),
+		Content:     []byte("solution,slo_id\n"),
+	}
+	const reportJSON = `{"id":12,"orgId":99,"solutionId":null,"periodStart":"2021-03-01T00:00:00Z","periodEnd":"2021-04-01T00:00:00Z",
+		"format":"csv","scheduled":false,"createdAt":"2021-04-01T06:00:00Z"}`
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockIComplianceReportService(mockController)
+		validatorMock = validator.NewMockITranslatedValidator(mockController)
+		complianceReportAPI = &ComplianceReportAPI{
+			Service:   serviceMock,
+			Validator: validatorMock,
+			Log:       logger,
+		}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.GET("/v1/org/:id/compliance_report", complianceReportAPI.GetAll)
+		ginEngine.POST("/v1/org/:id/compliance_report", complianceReportAPI.Create)
+		ginEngine.GET("/v1/org/:id/compliance_report/:reportId/download", complianceReportAPI.Download)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetAll()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/compliance_report", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().GetByOrgID(int64(99)).Return([]*model.ComplianceReport{&report}, nil)
+			})
+
+			It("returns 200 code with reports without their content", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[` + reportJSON + `]`))
+			})
+		})
+	})
+
+	Describe("Create()", func() {
+		var requestBody string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/v1/org/99/compliance_report", bytes.NewBufferString(requestBody))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				requestBody = `{"month": "2021-03", "format": "csv"}`
+				request := model.ComplianceReportRequest{Month: "2021-03", Format: model.ComplianceReportFormatCSV}
+				validatorMock.EXPECT().Validate(context.Background(), request).Return(nil)
+				serviceMock.EXPECT().Generate(int64(99), &request, gomock.Any()).Return(&report, nil)
+			})
+
+			It("returns 201 code with the stored report", func() {
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(w.Body.String()).To(MatchJSON(reportJSON))
+			})
+		})
+
+		Context("when validator returns an error", func() {
+			BeforeEach(func() {
+				requestBody = `{"month": "2021-03", "format": "xlsx"}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(errory.ValidationErrors.New("format is invalid"))
+			})
+
+			It("returns 400 code without generating a report", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when solution is passed", func() {
+			BeforeEach(func() {
+				requestBody = `{"solutionId": 404, "month": "2021-03", "format": "csv"}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(nil)
+				serviceMock.EXPECT().Generate(int64(99), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ int64, request *model.ComplianceReportRequest, _ time.Time) (*model.ComplianceReport, error) {
+						Expect(*request.SolutionID).To(Equal(int64(404)))
+						return nil, errory.NotFoundErrors.New("solution")
+					})
+			})
+
+			It("returns 404 code when solution does not exist", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("Download()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/compliance_report/12/download", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().Get(int64(99), int64(12)).Return(&report, nil)
+			})
+
+			It("returns 200 code with the content as attachment", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("Content-Type")).To(Equal("text/csv"))
+				Expect(w.Header().Get("Content-Disposition")).To(Equal(`attachment; filename="compliance-org-99-2021-03.csv"`))
+				Expect(w.Body.String()).To(Equal("solution,slo_id\n"))
+			})
+		})
+
+		Context("when report belongs to another organization", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().Get(int64(99), int64(12)).Return(nil, errory.NotFoundErrors.New("complianceReport"))
+			})
+
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+})
@@ -29,7 +29,9 @@ type FeedbackAPI struct {
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param feedback body model.Feedback true "Feedback"
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response replayed"
 // @Success 201 {object} api.ID
+// @Failure 409 {object} model.Problem "Idempotency-Key was used for a different request or is still processed"
 // @Router /feedback/ [post]
 func (api *FeedbackAPI) Create(c *gin.Context) {
 	feedback, err := extractAndValidateFeedback(c, true, api.Validator)
@@ -18,6 +18,7 @@ import (
 
 type HappinessMetricAPI struct {
 	Service   service.IHappinessMetricService
+	Versions  service.IEntityVersionService
 	Validator validator.IHappinessMetricValidator
 	Log       logrus.FieldLogger
 }
@@ -29,7 +30,9 @@ type HappinessMetricAPI struct {
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param happinessMetric body model.HappinessMetric true "Happiness Metric"
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response replayed"
 // @Success 201 {object} api.ID
+// @Failure 409 {object} model.Problem "Idempotency-Key was used for a different request or is still processed"
 // @Router /happiness_metric/ [post]
 func (api *HappinessMetricAPI) Create(c *gin.Context) {
 	userContext, err := GetUserContext(c)
@@ -61,8 +64,12 @@ func (api *HappinessMetricAPI) Create(c *gin.Context) {
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param id path int true "Happiness Metric ID"
+// @Param If-Match header string true "ETag of the Happiness Metric the change is based on, * to overwrite any version"
 // @Param happinessMetric body model.HappinessMetric true "Happiness Metric"
 // @Success 200 {object} api.ID
+// @Header 200 {string} ETag "version of the updated Happiness Metric"
+// @Failure 412 {object} model.Problem "the Happiness Metric was changed since the version in If-Match"
+// @Failure 428 {object} model.Problem "If-Match is missing"
 // @Router /happiness_metric/{id} [put]
 func (api *HappinessMetricAPI) Update(c *gin.Context) {
 	userContext, err := GetUserContext(c)
@@ -76,12 +83,19 @@ func (api *HappinessMetricAPI) Update(c *gin.Context) {
 		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update happiness metric").Create(), api.Log)
 		return
 	}
+	version, err := extractIfMatch(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update happiness metric").Create(), api.Log)
+		return
+	}
 
-	if err = api.Service.Update(userContext, happinessMetric); err != nil {
+	newVersion, err := api.Service.Update(userContext, happinessMetric, version)
+	if err != nil {
 		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update happiness metric").Create(), api.Log)
 		return
 	}
 
+	setETag(c, newVersion)
 	c.JSON(http.StatusOK, gin.H{
 		"id": happinessMetric.ID,
 	})
@@ -123,6 +137,7 @@ func (api *HappinessMetricAPI) Delete(c *gin.Context) {
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param id path int true "Happiness Metric ID"
 // @Success 200 {object} api.ID
+// @Header 200 {string} ETag "version of the Happiness Metric, to be sent as If-Match on update"
 // @Router /happiness_metric/{id} [get]
 func (api *HappinessMetricAPI) Get(c *gin.Context) {
 	metricID, err := GetIDParam(c)
@@ -136,7 +151,13 @@ func (api *HappinessMetricAPI) Get(c *gin.Context) {
 		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get happiness metric").Create(), api.Log)
 		return
 	}
+	version, err := api.Versions.GetVersion(model.VersionedEntityHappinessMetric, metricID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get happiness metric").Create(), api.Log)
+		return
+	}
 
+	setETag(c, version)
 	c.JSON(http.StatusOK, metric)
 }
 
@@ -32,6 +32,7 @@ var _ = Describe("HappinessMetricAPI", func() {
 	var happinessMetricAPI *HappinessMetricAPI
 	var happinessMetricValidatorMock *validator.MockIHappinessMetricValidator
 	var happinessMetricServiceMock *service.MockIHappinessMetricService
+	var versionsMock *service.MockIEntityVersionService
 	logger, logHook := logrustest.NewNullLogger()
 
 	var ginEngine *gin.Engine
@@ -44,9 +45,11 @@ var _ = Describe("HappinessMetricAPI", func() {
 		mockController = gomock.NewController(GinkgoT())
 		happinessMetricServiceMock = service.NewMockIHappinessMetricService(mockController)
 		happinessMetricValidatorMock = validator.NewMockIHappinessMetricValidator(mockController)
+		versionsMock = service.NewMockIEntityVersionService(mockController)
 		happinessMetricAPI = &HappinessMetricAPI{
 			Validator: happinessMetricValidatorMock,
 			Service:   happinessMetricServiceMock,
+			Versions:  versionsMock,
 			Log:       logger,
 		}
 		gin.SetMode(gin.TestMode)
@@ -218,8 +221,11 @@ var _ = Describe("HappinessMetricAPI", func() {
 	Describe("Update()", func() {
 		const metricID int64 = 33
 		var requestBody string
+		var ifMatch string
 		var create = false
+		version := int64(2)
 		BeforeEach(func() {
+			ifMatch = `"2"`
 			requestBody = `{
 				"userId": 1,
 				"orgId": 2,
@@ -231,6 +237,9 @@ var _ = Describe("HappinessMetricAPI", func() {
 		JustBeforeEach(func() {
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("PUT", fmt.Sprintf("/v1/happiness_metric/%d", metricID), bytes.NewBufferString(requestBody))
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
 			ginEngine.ServeHTTP(w, req)
 		})
 
@@ -253,7 +262,7 @@ var _ = Describe("HappinessMetricAPI", func() {
 					Happiness: 2,
 					Safety:    3,
 					Date:      t,
-				}).Times(1)
+				}, &version).Times(1).Return(int64(3), nil)
 
 				ct := context.WithValue(context.Background(), ctx.Create, create)
 				happinessMetricValidatorMock.EXPECT().Validate(ct, model.HappinessMetric{
@@ -270,6 +279,7 @@ var _ = Describe("HappinessMetricAPI", func() {
 			It("returns 200 code and id of updated metric", func() {
 				Expect(w.Code).To(Equal(http.StatusOK))
 				Expect(w.Body.String()).To(Equal(fmt.Sprintf(`{"id":%d}`, metricID)))
+				Expect(w.Header().Get("ETag")).To(Equal(`"3"`))
 				assertions.AssertAPIResponse(w.Body.String(), "", expErr)
 				assertions.AssertLogger(logHook, "")
 			})
@@ -285,7 +295,7 @@ var _ = Describe("HappinessMetricAPI", func() {
 					Happiness: 2,
 					Safety:    3,
 					Date:      t,
-				}).Times(1).Return(expErr)
+				}, &version).Times(1).Return(int64(0), expErr)
 
 				ct := context.WithValue(context.Background(), ctx.Create, false)
 				happinessMetricValidatorMock.EXPECT().Validate(ct, model.HappinessMetric{
@@ -332,7 +342,7 @@ var _ = Describe("HappinessMetricAPI", func() {
 				err = json.Unmarshal([]byte(requestBody), &metric)
 				ct := context.WithValue(context.Background(), ctx.Create, false)
 				happinessMetricValidatorMock.EXPECT().Validate(ct, metric).Times(1)
-				happinessMetricServiceMock.EXPECT().Update(&userContext, &metric).Times(1).Return(someErr)
+				happinessMetricServiceMock.EXPECT().Update(&userContext, &metric, &version).Times(1).Return(int64(0), someErr)
 			})
 
 			It("returns 500 code and error message", func() {
@@ -342,6 +352,41 @@ var _ = Describe("HappinessMetricAPI", func() {
 				assertions.AssertLogger(logHook, "ebt.api_error.on_update_error: Cannot update happiness metric, cause: some error")
 			})
 		})
+
+		Context("when If-Match is missing", func() {
+			BeforeEach(func() {
+				ifMatch = ""
+				happinessMetricValidatorMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Times(1)
+			})
+
+			It("returns 428 code", func() {
+				Expect(w.Code).To(Equal(http.StatusPreconditionRequired))
+				Expect(w.Body.String()).To(ContainSubstring("If-Match header is required"))
+			})
+		})
+
+		Context("when the metric was changed since the version in If-Match", func() {
+			BeforeEach(func() {
+				happinessMetricValidatorMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Times(1)
+				happinessMetricServiceMock.EXPECT().Update(&userContext, gomock.Any(), &version).
+					Return(int64(0), errory.PreconditionFailedErrors.New("happiness_metric 33 was changed meanwhile"))
+			})
+
+			It("returns 412 code without updating the metric", func() {
+				Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+			})
+		})
+
+		Context("when If-Match cannot be parsed", func() {
+			BeforeEach(func() {
+				ifMatch = `"two"`
+				happinessMetricValidatorMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Times(1)
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
 	})
 
 	Describe("Get()", func() {
@@ -368,6 +413,7 @@ var _ = Describe("HappinessMetricAPI", func() {
 			BeforeEach(func() {
 				expErr = nil
 				happinessMetricServiceMock.EXPECT().Get(metricID).Times(1).Return(&foundMetric, nil)
+				versionsMock.EXPECT().GetVersion(model.VersionedEntityHappinessMetric, metricID).Return(int64(2), nil)
 			})
 
 			It("returns 200 code with proper message", func() {
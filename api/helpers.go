@@ -1,20 +1,42 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/middleware"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	defaultHistoryDeletionPeriod = 14 * 24 * time.Hour
+	defaultHistoryPeriod         = 7 * 24 * time.Hour
+	defaultHistoryInterval       = "1h"
+	maxHistoryBuckets            = 2000
+	maxForecastLookbackDays      = 90
+)
+
+var historyIntervalRegexp = regexp.MustCompile(`^([1-9][0-9]*)([smhd])$`)
+
 type ID struct {
 	ID int64 `json:"id"`
 }
+
+// SavedSlo is the ID of a created or updated SLO with the problems which did not prevent saving it.
+type SavedSlo struct {
+	ID       int64    `json:"id"`
+	Warnings []string `json:"warnings,omitempty"`
+}
 type Message struct {
 	Message string `json:"message"`
 }
@@ -27,6 +49,14 @@ func GetIDParam(c *gin.Context) (int64, error) {
 	return metricID, validator.ValidateID(metricID)
 }
 
+func GetInt64Param(c *gin.Context, name string) (int64, error) {
+	value, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, errory.ParseErrors.Builder().Wrap(err).WithMessage(name + " parameter cannot be parsed").Create()
+	}
+	return value, validator.ValidateID(value)
+}
+
 func GetUserContext(c *gin.Context) (*auth.UserContext, error) {
 	u, exists := c.Get(middleware.UserContextContextKey)
 	if !exists {
@@ -57,9 +87,214 @@ func setErrorResponse(c *gin.Context, err error, logger logrus.FieldLogger) {
 		errorBuilder.Warn(message)
 	}
 
-	c.JSON(details.Status, Message{Message: details.Message})
+	middleware.WriteProblem(c, middleware.NewProblem(c, err))
+}
+
+// extractIfMatch reads the version of the entity a change is based on from the If-Match header,
+// given as the ETag of the last read. The version is nil for If-Match: *.
+func extractIfMatch(c *gin.Context) (*int64, error) {
+	return parseIfMatch(c.GetHeader("If-Match"), "If-Match header")
+}
+
+// parseIfMatch parses an If-Match value, name tells the client where it was sent.
+func parseIfMatch(value, name string) (*int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, errory.PreconditionRequiredErrors.Builder().
+			WithMessage(name + " is required, send the ETag of the last read").Create()
+	}
+	if value == "*" {
+		return nil, nil
+	}
+
+	version, err := strconv.ParseInt(strings.Trim(strings.TrimPrefix(value, "W/"), `"`), 10, 64)
+	if err != nil {
+		return nil, errory.ParseErrors.Builder().Wrap(err).WithPayload("If-Match", value).
+			WithMessage(name + " cannot be parsed, send the ETag of the last read").Create()
+	}
+	return &version, nil
+}
+
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// extractHistoryRange reads RFC3339 from and to query params, the range ends now and
+// spans defaultPeriod when they are omitted.
+func extractHistoryRange(c *gin.Context, defaultPeriod time.Duration) (*elasticModel.SloHistoryRange, error) {
+	timeRange := &elasticModel.SloHistoryRange{To: time.Now().UTC()}
+	var err error
+
+	if to := c.Query("to"); to != "" {
+		if timeRange.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage("to parameter cannot be parsed").Create()
+		}
+	}
+
+	timeRange.From = timeRange.To.Add(-defaultPeriod)
+	if from := c.Query("from"); from != "" {
+		if timeRange.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage("from parameter cannot be parsed").Create()
+		}
+	}
+
+	if !timeRange.From.Before(timeRange.To) {
+		return nil, errory.ValidationErrors.New("from parameter has to be before to parameter")
+	}
+	return timeRange, nil
+}
+
+// extractLabelSelector reads the repeated label query param given as key:value.
+func extractLabelSelector(c *gin.Context) (map[string]string, error) {
+	selector := map[string]string{}
+	for _, label := range c.QueryArray("label") {
+		parts := strings.SplitN(label, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errory.ParseErrors.Builder().WithPayload("label", label).
+				WithMessage("label parameter cannot be parsed, expected key:value").Create()
+		}
+		selector[parts[0]] = parts[1]
+	}
+	return selector, nil
+}
+
+// extractSloPageQuery reads the filters, sort order and page of SLO list endpoints. The cursor
+// has to come from a page of the same sort order.
+func extractSloPageQuery(c *gin.Context) (*model.SloPageQuery, error) {
+	labels, err := extractLabelSelector(c)
+	if err != nil {
+		return nil, err
+	}
+	query := &model.SloPageQuery{
+		Name:       c.Query("name"),
+		NamePrefix: c.Query("namePrefix"),
+		OrgName:    c.Query("orgName"),
+		Labels:     labels,
+		Sort:       model.SloSortField(c.DefaultQuery("sort", string(model.SloSortFieldName))),
+	}
+
+	switch query.Sort {
+	case model.SloSortFieldName, model.SloSortFieldCreationDate, model.SloSortFieldCritical, model.SloSortFieldOrg:
+	default:
+		return nil, errory.ParseErrors.Builder().WithPayload("sort", query.Sort).
+			WithMessage("sort parameter has to be one of name, creationDate, critical, org").Create()
+	}
+	switch order := c.DefaultQuery("order", "asc"); order {
+	case "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return nil, errory.ParseErrors.Builder().WithPayload("order", order).
+			WithMessage("order parameter has to be asc or desc").Create()
+	}
+
+	if value := c.Query("critical"); value != "" {
+		critical, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage("critical parameter cannot be parsed").Create()
+		}
+		query.Critical = &critical
+	}
+	if value, ok := c.GetQuery("externalType"); ok {
+		externalType := model.ExternalSloType(value)
+		switch externalType {
+		case model.ExternalSloTypeNoType, model.ExternalSloTypeMetric, model.ExternalSloTypeMonitor, model.ExternalSloTypeTimeSlice:
+		default:
+			return nil, errory.ParseErrors.Builder().WithPayload("externalType", value).
+				WithMessage("externalType parameter has to be empty or one of metric, monitor, time_slice").Create()
+		}
+		query.ExternalType = &externalType
+	}
+	if query.DatasourceID, err = extractOptionalInt64Query(c, "datasourceId"); err != nil {
+		return nil, err
+	}
+	if query.SolutionID, err = extractOptionalInt64Query(c, "solutionId"); err != nil {
+		return nil, err
+	}
+
+	if value := c.Query("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil || query.Limit < 1 || query.Limit > model.MaxSloPageLimit {
+			return nil, errory.ParseErrors.Builder().WithPayload("limit", value).
+				WithMessage(fmt.Sprintf("limit parameter has to be between 1 and %d", model.MaxSloPageLimit)).Create()
+		}
+	}
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := model.ParseSloCursor(value)
+		if err != nil {
+			return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage("cursor parameter cannot be parsed").Create()
+		}
+		if cursor.Sort != query.Sort || cursor.Descending != query.Descending {
+			return nil, errory.ParseErrors.Builder().WithPayload("cursor", value).
+				WithMessage("cursor parameter belongs to another sort order").Create()
+		}
+		query.After = cursor
+	}
+	return query, nil
+}
+
+func extractOptionalInt64Query(c *gin.Context, name string) (*int64, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage(name + " parameter cannot be parsed").Create()
+	}
+	return &parsed, nil
+}
+
+// setSloPageHeaders tells clients the number of all matching SLOs and where the next page starts,
+// the body stays the plain array of SLOs.
+func setSloPageHeaders(c *gin.Context, page *model.SloPage) {
+	c.Header("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	if page.NextCursor != nil {
+		c.Header("X-Next-Cursor", page.NextCursor.Encode())
+	}
+}
+
+// extractHistoryInterval reads the interval query param as an elasticsearch fixed interval,
+// e.g. "30m" or "1d", and rejects intervals splitting the range into too many buckets.
+func extractHistoryInterval(c *gin.Context, timeRange *elasticModel.SloHistoryRange) (string, error) {
+	interval := c.DefaultQuery("interval", defaultHistoryInterval)
+
+	match := historyIntervalRegexp.FindStringSubmatch(interval)
+	if match == nil {
+		return "", errory.ParseErrors.New("interval parameter cannot be parsed, expected e.g. 30m, 1h or 1d")
+	}
+	value, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return "", errory.ParseErrors.Builder().Wrap(err).WithMessage("interval parameter cannot be parsed").Create()
+	}
+
+	unit := map[string]time.Duration{"s": time.Second, "m": time.Minute, "h": time.Hour, "d": 24 * time.Hour}[match[2]]
+	if timeRange.To.Sub(timeRange.From)/(time.Duration(value)*unit) > maxHistoryBuckets {
+		return "", errory.ValidationErrors.New("interval parameter is too small for the given range, at most %d points are returned", maxHistoryBuckets)
+	}
+	return interval, nil
+}
+
+// extractForecastLookback reads the lookback query param in days, 0 when it is omitted
+// so that the default look-back applies.
+func extractForecastLookback(c *gin.Context) (int, error) {
+	lookback := c.Query("lookback")
+	if lookback == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(lookback)
+	if err != nil {
+		return 0, errory.ParseErrors.Builder().Wrap(err).WithMessage("lookback parameter cannot be parsed").Create()
+	}
+	if days < 1 || days > maxForecastLookbackDays {
+		return 0, errory.ValidationErrors.New("lookback parameter has to be between 1 and %d days", maxForecastLookbackDays)
+	}
+	return days, nil
 }
 
 func setIDResponse(code int, id int64, c *gin.Context) {
 	c.JSON(code, ID{ID: id})
 }
+
+func setSavedSloResponse(code int, slo *model.Slo, c *gin.Context) {
+	c.JSON(code, SavedSlo{ID: slo.ID, Warnings: slo.Warnings})
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type MaintenanceWindowAPI struct {
+	Service   service.IMaintenanceWindowService
+	Validator validator.ITranslatedValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Get Maintenance Windows
+// @Description Returns all maintenance windows of the Organization
+// @Tags maintenance windows
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {array} model.MaintenanceWindow
+// @Router /org/{id}/maintenance_window [get]
+func (api *MaintenanceWindowAPI) GetAll(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get maintenance windows").Create(), api.Log)
+		return
+	}
+
+	windows, err := api.Service.GetByOrgID(orgID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get maintenance windows").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, windows)
+}
+
+// @Summary Get Maintenance Window
+// @Description Returns Maintenance Window
+// @Tags maintenance windows
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param windowId path int true "Maintenance Window ID"
+// @Success 200 {object} model.MaintenanceWindow
+// @Router /org/{id}/maintenance_window/{windowId} [get]
+func (api *MaintenanceWindowAPI) Get(c *gin.Context) {
+	orgID, windowID, err := getMaintenanceWindowParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get maintenance window").Create(), api.Log)
+		return
+	}
+
+	window, err := api.Service.Get(orgID, windowID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get maintenance window").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, window)
+}
+
+// @Summary Add Maintenance Window
+// @Description Creates a one-off or recurring Maintenance Window for an SLO or a product of the Organization
+// @Tags maintenance windows
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param maintenanceWindow body model.MaintenanceWindow true "either sloId or productId, id and orgId are not checked"
+// @Success 201 {object} api.ID
+// @Router /org/{id}/maintenance_window [post]
+func (api *MaintenanceWindowAPI) Create(c *gin.Context) {
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create maintenance window").Create(), api.Log)
+		return
+	}
+
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create maintenance window").Create(), api.Log)
+		return
+	}
+
+	window, err := api.extractAndValidateMaintenanceWindow(c, orgID, 0)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create maintenance window").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Create(userContext, window); err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create maintenance window").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusCreated, window.ID, c)
+}
+
+// @Summary Update Maintenance Window
+// @Description Updates Maintenance Window
+// @Tags maintenance windows
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param windowId path int true "Maintenance Window ID"
+// @Param maintenanceWindow body model.MaintenanceWindow true "either sloId or productId, id and orgId are not checked"
+// @Success 200 {object} api.ID
+// @Router /org/{id}/maintenance_window/{windowId} [put]
+func (api *MaintenanceWindowAPI) Update(c *gin.Context) {
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update maintenance window").Create(), api.Log)
+		return
+	}
+
+	orgID, windowID, err := getMaintenanceWindowParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update maintenance window").Create(), api.Log)
+		return
+	}
+
+	window, err := api.extractAndValidateMaintenanceWindow(c, orgID, windowID)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update maintenance window").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Update(userContext, window); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update maintenance window").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusOK, window.ID, c)
+}
+
+// @Summary Delete Maintenance Window
+// @Description Deletes Maintenance Window
+// @Tags maintenance windows
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param windowId path int true "Maintenance Window ID"
+// @Success 200 {object} api.ID
+// @Router /org/{id}/maintenance_window/{windowId} [delete]
+func (api *MaintenanceWindowAPI) Delete(c *gin.Context) {
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete maintenance window").Create(), api.Log)
+		return
+	}
+
+	orgID, windowID, err := getMaintenanceWindowParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete maintenance window").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Delete(userContext, orgID, windowID); err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete maintenance window").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusOK, windowID, c)
+}
+
+func getMaintenanceWindowParams(c *gin.Context) (int64, int64, error) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	windowID, err := GetInt64Param(c, "windowId")
+	if err != nil {
+		return 0, 0, err
+	}
+	return orgID, windowID, nil
+}
+
+func (api *MaintenanceWindowAPI) extractAndValidateMaintenanceWindow(c *gin.Context, orgID, windowID int64) (*model.MaintenanceWindow, error) {
+	var window model.MaintenanceWindow
+	if err := c.ShouldBindBodyWith(&window, binding.JSON); err != nil {
+		return nil, errory.GetValidationError(err, window)
+	}
+	window.ID = windowID
+	window.OrgID = orgID
+
+	if err := api.Validator.Validate(context.Background(), window); err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
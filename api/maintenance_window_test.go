@@ -0,0 +1,224 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/assertions"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("MaintenanceWindowAPI", func() {
+	var mockController *gomock.Controller
+	var maintenanceWindowAPI *MaintenanceWindowAPI
+	var serviceMock *service.MockIMaintenanceWindowService
+	var validatorMock *validator.MockITranslatedValidator
+	logger, logHook := logrustest.NewNullLogger()
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var req *http.Request
+	var userContext auth.UserContext
+
+	sloID := int64(7)
+	window := model.MaintenanceWindow{
+		ID:          3,
+		OrgID:       99,
+		SloID:       &sloID,
+		Description: "database upgrade",
+		Start:       time.Date(2021, 3, 6, 22, 0, 0, 0, time.UTC),
+		End:         time.Date(2021, 3, 7, 2, 0, 0, 0, time.UTC),
+		Recurrence:  model.MaintenanceRecurrenceWeekly,
+	}
+	const body = `{"sloId": 7, "description": "database upgrade", "start": "2021-03-06T22:00:00Z",
+		"end": "2021-03-07T02:00:00Z", "recurrence": "weekly"}`
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockIMaintenanceWindowService(mockController)
+		validatorMock = validator.NewMockITranslatedValidator(mockController)
+		maintenanceWindowAPI = &MaintenanceWindowAPI{
+			Service:   serviceMock,
+			Validator: validatorMock,
+			Log:       logger,
+		}
+		userContext = auth.UserContext{ID: 34}
+		logHook.Reset()
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+
+		userContextMiddleware := func(c *gin.Context) {
+			c.Set("UserContext", &userContext)
+			c.Next()
+		}
+
+		ginEngine.GET("/v1/org/:id/maintenance_window", maintenanceWindowAPI.GetAll)
+		ginEngine.POST("/v1/org/:id/maintenance_window", userContextMiddleware, maintenanceWindowAPI.Create)
+		ginEngine.GET("/v1/org/:id/maintenance_window/:windowId", maintenanceWindowAPI.Get)
+		ginEngine.PUT("/v1/org/:id/maintenance_window/:windowId", userContextMiddleware, maintenanceWindowAPI.Update)
+		ginEngine.DELETE("/v1/org/:id/maintenance_window/:windowId", userContextMiddleware, maintenanceWindowAPI.Delete)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetAll()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/maintenance_window", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().GetByOrgID(int64(99)).Times(1).Return([]*model.MaintenanceWindow{&window}, nil)
+			})
+
+			It("returns 200 code with maintenance windows of the organization", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"id":3,"orgId":99,"sloId":7,"productId":null,"description":"database upgrade",
+					"start":"2021-03-06T22:00:00Z","end":"2021-03-07T02:00:00Z","recurrence":"weekly","recurrenceEnd":null}]`))
+			})
+		})
+	})
+
+	Describe("Get()", func() {
+		var windowID string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/maintenance_window/"+windowID, nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when maintenance window is not found", func() {
+			BeforeEach(func() {
+				windowID = "3"
+				serviceMock.EXPECT().Get(int64(99), int64(3)).Times(1).Return(nil, errory.NotFoundErrors.New("maintenance window"))
+			})
+
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when windowId cannot be parsed", func() {
+			BeforeEach(func() {
+				windowID = "abc"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Create()", func() {
+		var requestBody string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/v1/org/99/maintenance_window", bytes.NewBufferString(requestBody))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				requestBody = body
+				expected := window
+				expected.ID = 0
+				validatorMock.EXPECT().Validate(context.Background(), expected).Times(1).Return(nil)
+				serviceMock.EXPECT().Create(&userContext, &expected).Times(1).DoAndReturn(func(_ *auth.UserContext, created *model.MaintenanceWindow) error {
+					created.ID = 3
+					return nil
+				})
+			})
+
+			It("returns 201 code with the id", func() {
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(w.Body.String()).To(MatchJSON(`{"id":3}`))
+			})
+		})
+
+		Context("when validator returns an error", func() {
+			BeforeEach(func() {
+				requestBody = `{"sloId": 7, "start": "2021-03-06T22:00:00Z", "end": "2021-03-06T21:00:00Z"}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Times(1).
+					Return(errory.ValidationErrors.New("end must be greater than start"))
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				assertions.AssertLogger(logHook, "ebt.api_error.on_create_error: Cannot create maintenance window, cause: ebt.validation_error: "+
+					"end must be greater than start")
+			})
+		})
+	})
+
+	Describe("Update()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("PUT", "/v1/org/99/maintenance_window/3", bytes.NewBufferString(body))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				validatorMock.EXPECT().Validate(context.Background(), window).Times(1).Return(nil)
+				serviceMock.EXPECT().Update(&userContext, &window).Times(1).Return(nil)
+			})
+
+			It("returns 200 code with the id taken from the path", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"id":3}`))
+			})
+		})
+	})
+
+	Describe("Delete()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("DELETE", "/v1/org/99/maintenance_window/3", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().Delete(&userContext, int64(99), int64(3)).Times(1).Return(nil)
+			})
+
+			It("returns 200 code", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when service fails", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().Delete(&userContext, int64(99), int64(3)).Times(1).Return(errory.ProviderErrors.New("db"))
+			})
+
+			It("returns 500 code", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
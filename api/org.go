@@ -3,6 +3,7 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -21,17 +22,33 @@ type OrgAPI struct {
 	OrgService        service.IOrganizationService
 	DatasourceService service.IDatasourceService
 	HappinessService  service.IHappinessMetricService
+	ImportService     service.IDatadogImportService
+	BudgetService     service.IErrorBudgetService
 	Validator         validator.ITranslatedValidator
 	Log               logrus.FieldLogger
 }
 
 // @Summary Get SLOs
-// @Description Returns all SLOs for Organization
+// @Description Returns all SLOs for Organization, or a page of them when limit is given. The cursor of the
+// @Description next page is returned in the X-Next-Cursor header as long as more SLOs follow.
 // @Tags organizations
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param id path int true "Organization ID"
+// @Param name query string false "filter by SLO name"
+// @Param namePrefix query string false "filter by the beginning of the SLO name"
+// @Param critical query bool false "filter by criticality"
+// @Param externalType query string false "filter by external type, empty for SLOs without external SLO" Enums(, metric, monitor, time_slice)
+// @Param datasourceId query int false "filter by datasource"
+// @Param solutionId query int false "filter by solution"
+// @Param label query []string false "filter by label as key:value, repeated labels have to match all" collectionFormat(multi)
+// @Param sort query string false "sort field, name by default" Enums(name, creationDate, critical, org)
+// @Param order query string false "sort order, asc by default" Enums(asc, desc)
+// @Param limit query int false "page size, 1 to 1000"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
 // @Success 200 {array} model.Slo
+// @Header 200 {integer} X-Total-Count "number of all SLOs matching the filters"
+// @Header 200 {string} X-Next-Cursor "cursor of the next page"
 // @Router /org/{id}/slo [get]
 func (api *OrgAPI) GetSlos(c *gin.Context) {
 	orgID, err := GetIDParam(c)
@@ -39,16 +56,59 @@ func (api *OrgAPI) GetSlos(c *gin.Context) {
 		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLOs for organization").Create(), api.Log)
 		return
 	}
+	query, err := extractSloPageQuery(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLOs for organization").Create(), api.Log)
+		return
+	}
+	query.OrgID, query.OrgName = &orgID, ""
 
-	slos, err := api.SloService.GetByOrgID(orgID)
+	page, err := api.SloService.GetSloPage(query)
 	if err != nil {
 		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLOs for organization").Create(), api.Log)
 		return
 	}
 
+	slos := make([]*model.Slo, 0, len(page.Slos))
+	for _, slo := range page.Slos {
+		slos = append(slos, &slo.Slo)
+	}
+	setSloPageHeaders(c, page)
 	c.JSON(http.StatusOK, slos)
 }
 
+// @Summary Get SLOs at Risk
+// @Description Returns the error budget forecasts of the SLOs of the Organization which run out of budget
+// @Description before the end of their window, the earliest exhaustion first
+// @Tags organizations
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param lookback query int false "look-back in days, 1 to 90, the configured default when omitted"
+// @Success 200 {array} model.BudgetForecast
+// @Router /org/{id}/slo/at-risk [get]
+func (api *OrgAPI) GetAtRiskSlos(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLOs at risk").Create(), api.Log)
+		return
+	}
+
+	lookback, err := extractForecastLookback(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLOs at risk").Create(), api.Log)
+		return
+	}
+
+	forecasts, err := api.BudgetService.GetAtRiskSlos(orgID, time.Now(), lookback)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLOs at risk").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, forecasts)
+}
+
 // @Summary Get Datasources
 // @Description Returns all datasources for Organization
 // @Tags organizations
@@ -240,3 +300,88 @@ func (api *OrgAPI) GetOrg(c *gin.Context) {
 
 	c.JSON(http.StatusOK, org)
 }
+
+// @Summary Discover Datadog SLOs
+// @Description Returns Datadog SLOs which are not linked to any SLO of the organization on the datasource
+// @Tags organizations
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param dsId path int true "Datadog datasource ID"
+// @Success 200 {array} datadog.SLO
+// @Router /org/{id}/datasource/{dsId}/discover [get]
+func (api *OrgAPI) DiscoverDatadogSlos(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot discover Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	dsID, err := GetInt64Param(c, "dsId")
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot discover Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	slos, err := api.ImportService.Discover(orgID, dsID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot discover Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, slos)
+}
+
+// @Summary Import Datadog SLOs
+// @Description Creates SLOs with dashboards for the given Datadog SLOs, returns a result per Datadog SLO
+// @Tags organizations
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param dsId path int true "Datadog datasource ID"
+// @Param import body model.SloImport true "Datadog SLO IDs"
+// @Success 200 {array} model.SloImportResult
+// @Router /org/{id}/datasource/{dsId}/import [post]
+func (api *OrgAPI) ImportDatadogSlos(c *gin.Context) {
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot import Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot import Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	dsID, err := GetInt64Param(c, "dsId")
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot import Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	var sloImport model.SloImport
+	if err = c.ShouldBindBodyWith(&sloImport, binding.JSON); err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.
+			Builder().
+			Wrap(errory.GetValidationError(err, sloImport)).
+			WithMessage("Cannot import Datadog SLOs").
+			Create(), api.Log)
+		return
+	}
+
+	if err = api.Validator.Validate(context.Background(), sloImport); err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot import Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	results, err := api.ImportService.Import(userContext, orgID, dsID, &sloImport)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot import Datadog SLOs").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
 	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
@@ -35,6 +36,8 @@ var _ = Describe("OrgAPI", func() {
 	var dataSourceServiceMock *service.MockIDatasourceService
 	var orgServiceMock *service.MockIOrganizationService
 	var happinessMetricServiceMock *service.MockIHappinessMetricService
+	var importServiceMock *service.MockIDatadogImportService
+	var budgetServiceMock *service.MockIErrorBudgetService
 	var validatorMock *validator.MockITranslatedValidator
 	logger, logHook := logrustest.NewNullLogger()
 
@@ -50,12 +53,16 @@ var _ = Describe("OrgAPI", func() {
 		dataSourceServiceMock = service.NewMockIDatasourceService(mockController)
 		orgServiceMock = service.NewMockIOrganizationService(mockController)
 		happinessMetricServiceMock = service.NewMockIHappinessMetricService(mockController)
+		importServiceMock = service.NewMockIDatadogImportService(mockController)
+		budgetServiceMock = service.NewMockIErrorBudgetService(mockController)
 		validatorMock = validator.NewMockITranslatedValidator(mockController)
 		orgAPI = &OrgAPI{
 			SloService:        sloServiceMock,
 			OrgService:        orgServiceMock,
 			DatasourceService: dataSourceServiceMock,
 			HappinessService:  happinessMetricServiceMock,
+			ImportService:     importServiceMock,
+			BudgetService:     budgetServiceMock,
 			Validator:         validatorMock,
 			Log:               logger,
 		}
@@ -75,8 +82,11 @@ var _ = Describe("OrgAPI", func() {
 
 		ginEngine.GET("/v1/org/:id", orgAPI.GetOrg)
 		ginEngine.GET("/v1/org/:id/slo", orgAPI.GetSlos)
+		ginEngine.GET("/v1/org/:id/slo/at-risk", orgAPI.GetAtRiskSlos)
 		ginEngine.POST("/v1/org/:id/slo", orgAPI.FindSlos)
 		ginEngine.GET("/v1/org/:id/datasource", userContextMiddleware, orgAPI.GetDatasources)
+		ginEngine.GET("/v1/org/:id/datasource/:dsId/discover", orgAPI.DiscoverDatadogSlos)
+		ginEngine.POST("/v1/org/:id/datasource/:dsId/import", userContextMiddleware, orgAPI.ImportDatadogSlos)
 		ginEngine.GET("/v1/org/:id/user_happiness", userContextMiddleware, orgAPI.GetAllHappinessMetricsForUser)
 		ginEngine.GET("/v1/org/:id/team_happiness", userContextMiddleware, orgAPI.GetAllHappinessMetricsForTeam)
 		ginEngine.POST("/v1/org/:id/team_happiness/average", userContextMiddleware, orgAPI.SaveTeamAverage)
@@ -108,6 +118,7 @@ var _ = Describe("OrgAPI", func() {
 
 		Context("when orgID param is in correct format", func() {
 			const correctOrgID int64 = 99
+			orgID := correctOrgID
 
 			JustBeforeEach(func() {
 				w = httptest.NewRecorder()
@@ -118,7 +129,7 @@ var _ = Describe("OrgAPI", func() {
 			Context("when slo service returns an error", func() {
 				BeforeEach(func() {
 					someErr := fmt.Errorf(`{"message":"some test error"}`)
-					sloServiceMock.EXPECT().GetByOrgID(correctOrgID).Times(1).Return(nil, someErr)
+					sloServiceMock.EXPECT().GetSloPage(&model.SloPageQuery{OrgID: &orgID, Labels: map[string]string{}, Sort: model.SloSortFieldName}).Times(1).Return(nil, someErr)
 					expErr = errory.OnGetErrors.Builder().Wrap(someErr).Create()
 				})
 				It("returns 500 code with proper message", func() {
@@ -129,7 +140,7 @@ var _ = Describe("OrgAPI", func() {
 			})
 			Context("when no slo was found", func() {
 				BeforeEach(func() {
-					sloServiceMock.EXPECT().GetByOrgID(correctOrgID).Times(1).Return([]*model.Slo{}, nil)
+					sloServiceMock.EXPECT().GetSloPage(&model.SloPageQuery{OrgID: &orgID, Labels: map[string]string{}, Sort: model.SloSortFieldName}).Times(1).Return(&model.SloPage{Slos: []*model.DetailedSlo{}}, nil)
 					expErr = nil
 				})
 				It("returns 200 code with empty JSON array", func() {
@@ -161,12 +172,14 @@ var _ = Describe("OrgAPI", func() {
 				foundSlosJSON, _ := json.Marshal(foundSlos)
 
 				BeforeEach(func() {
-					sloServiceMock.EXPECT().GetByOrgID(correctOrgID).Times(1).Return(foundSlos, nil)
+					sloServiceMock.EXPECT().GetSloPage(&model.SloPageQuery{OrgID: &orgID, Labels: map[string]string{}, Sort: model.SloSortFieldName}).Times(1).
+						Return(&model.SloPage{Slos: []*model.DetailedSlo{{Slo: *foundSlos[0]}, {Slo: *foundSlos[1]}}, Total: 2}, nil)
 					expErr = nil
 				})
 				It("returns 200 code with found slos", func() {
 					Expect(w.Code).To(Equal(http.StatusOK))
 					Expect(w.Body.String()).To(BeEquivalentTo(foundSlosJSON))
+					Expect(w.Header().Get("X-Total-Count")).To(Equal("2"))
 					assertions.AssertAPIResponse(w.Body.String(), "", expErr)
 					assertions.AssertLogger(logHook, "")
 				})
@@ -174,6 +187,31 @@ var _ = Describe("OrgAPI", func() {
 		})
 	})
 
+	Describe("GetAtRiskSlos()", func() {
+		It("returns 200 code with the ranked forecasts", func() {
+			budgetServiceMock.EXPECT().GetAtRiskSlos(int64(1), gomock.Any(), 0).Times(1).
+				Return([]*model.BudgetForecast{{SloID: 7, SloName: "checkout"}, {SloID: 3, SloName: "search"}}, nil)
+
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/1/slo/at-risk", nil)
+			ginEngine.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var forecasts []model.BudgetForecast
+			Expect(json.Unmarshal(w.Body.Bytes(), &forecasts)).To(Succeed())
+			Expect(forecasts[0].SloID).To(Equal(int64(7)))
+			Expect(forecasts[1].SloID).To(Equal(int64(3)))
+		})
+
+		It("returns 400 code when lookback cannot be parsed", func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/1/slo/at-risk?lookback=week", nil)
+			ginEngine.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
 	Describe("GetDatasources()", func() {
 		Context("when orgID param is in wrong format", func() {
 			const incorrectOrgID = "testId99"
@@ -729,4 +767,99 @@ var _ = Describe("OrgAPI", func() {
 		})
 
 	})
+
+	Describe("DiscoverDatadogSlos()", func() {
+		var dsID string
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", fmt.Sprintf("/v1/org/99/datasource/%s/discover", dsID), nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+		BeforeEach(func() {
+			dsID = "5"
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				importServiceMock.EXPECT().Discover(int64(99), int64(5)).Times(1).Return([]*datadogModel.SLO{
+					{ID: "abc123", Name: "Checkout", Type: "monitor", Thresholds: []datadogModel.SLOThreshold{{Timeframe: "30d", Target: 99.9}}},
+				}, nil)
+			})
+			It("returns 200 code with unlinked datadog slos", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"id":"abc123","name":"Checkout","type":"monitor","description":"",
+					"thresholds":[{"timeframe":"30d","target":99.9}],"tags":null}]`))
+			})
+		})
+
+		Context("when dsId param is in wrong format", func() {
+			BeforeEach(func() {
+				dsID = "abc"
+			})
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				assertions.AssertLogger(logHook, `ebt.api_error.on_get_error: Cannot discover Datadog SLOs, cause: ebt.parsing_error: dsId parameter cannot be parsed, cause: strconv.ParseInt: parsing "abc": invalid syntax`)
+			})
+		})
+
+		Context("when datasource does not belong to organization", func() {
+			BeforeEach(func() {
+				importServiceMock.EXPECT().Discover(int64(99), int64(5)).Times(1).Return(nil, errory.NotFoundErrors.New("datasource"))
+			})
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("ImportDatadogSlos()", func() {
+		var body string
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/v1/org/99/datasource/5/import", bytes.NewBufferString(body))
+			ginEngine.ServeHTTP(w, req)
+		})
+		BeforeEach(func() {
+			body = `{"externalIds": ["abc123", "def456"], "critical": true}`
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				sloImport := &model.SloImport{ExternalIDs: []string{"abc123", "def456"}, Critical: true}
+				validatorMock.EXPECT().Validate(context.Background(), *sloImport).Times(1).Return(nil)
+				importServiceMock.EXPECT().Import(&userContext, int64(99), int64(5), sloImport).Times(1).Return([]*model.SloImportResult{
+					{ExternalID: "abc123", ID: 12},
+					{ExternalID: "def456", Error: "datadog slo does not exist or is already linked"},
+				}, nil)
+			})
+			It("returns 200 code with a result per datadog slo", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"externalId":"abc123","id":12},
+					{"externalId":"def456","error":"datadog slo does not exist or is already linked"}]`))
+			})
+		})
+
+		Context("when validator returns an error", func() {
+			BeforeEach(func() {
+				body = `{"externalIds": []}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Times(1).
+					Return(errory.ValidationErrors.New("externalIds must contain at least 1 item"))
+			})
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when import service returns an error", func() {
+			BeforeEach(func() {
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Times(1).Return(nil)
+				importServiceMock.EXPECT().Import(&userContext, int64(99), int64(5), gomock.Any()).Times(1).
+					Return(nil, errory.FetchResourceErrors.New("datadog"))
+			})
+			It("returns 500 code", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				assertions.AssertLogger(logHook, "ebt.api_error.on_create_error: Cannot import Datadog SLOs, cause: ebt.fetch_resource_error: datadog")
+			})
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	gModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
 	pService "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service/plugin"
@@ -15,6 +16,7 @@ import (
 type PluginAPI struct {
 	Plugin              pService.IPluginService
 	OrganizationService service.IOrganizationService
+	Webhooks            service.IWebhookService
 	Log                 logrus.FieldLogger
 }
 
@@ -49,6 +51,12 @@ func (api *PluginAPI) Enable(c *gin.Context) {
 		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot enable plugin").Create(), api.Log)
 		return
 	}
+	// the plugin is enabled in Grafana already, a lost event must not report the request as failed
+	err = api.Webhooks.Publish(org.ID, model.WebhookEventPluginEnabled,
+		&model.WebhookPluginEnabled{OrgID: org.ID, OrgName: org.Name, DatasourcesSkipped: skip})
+	if err != nil {
+		api.Log.WithError(err).WithField("orgId", org.ID).Error("plugin enabled, but webhooks cannot be notified")
+	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"id": org.ID,
@@ -14,6 +14,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
 	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
@@ -28,6 +29,7 @@ var _ = Describe("PluginAPI", func() {
 	var pluginAPI *PluginAPI
 	var pluginServiceMock *pluginService.MockIPluginService
 	var organizationServiceMock *service.MockIOrganizationService
+	var webhookServiceMock *service.MockIWebhookService
 	logger, logHook := logrustest.NewNullLogger()
 
 	var ginEngine *gin.Engine
@@ -42,9 +44,11 @@ var _ = Describe("PluginAPI", func() {
 		mockController = gomock.NewController(GinkgoT())
 		pluginServiceMock = pluginService.NewMockIPluginService(mockController)
 		organizationServiceMock = service.NewMockIOrganizationService(mockController)
+		webhookServiceMock = service.NewMockIWebhookService(mockController)
 		pluginAPI = &PluginAPI{
 			Plugin:              pluginServiceMock,
 			OrganizationService: organizationServiceMock,
+			Webhooks:            webhookServiceMock,
 			Log:                 logger,
 		}
 
@@ -91,6 +95,8 @@ var _ = Describe("PluginAPI", func() {
 
 				organizationServiceMock.EXPECT().GetOrganizationByID(orgID).Times(1).Return(org, err)
 				pluginServiceMock.EXPECT().EnablePluginWithDataSources(org, cookie, false).Times(1)
+				webhookServiceMock.EXPECT().Publish(orgID, model.WebhookEventPluginEnabled,
+					&model.WebhookPluginEnabled{OrgID: orgID, OrgName: "mrc"}).Return(nil)
 			})
 
 			It("returns 201 code and id of modified organization", func() {
@@ -100,6 +106,24 @@ var _ = Describe("PluginAPI", func() {
 			})
 		})
 
+		Context("when the webhooks cannot be notified", func() {
+			BeforeEach(func() {
+				ginEngine = gin.New()
+				ginEngine.POST("/v1/plugin/:id", userContextMiddleware, pluginAPI.Enable)
+
+				org := &grafanaModel.Organization{ID: orgID, Name: "mrc"}
+				organizationServiceMock.EXPECT().GetOrganizationByID(orgID).Return(org, nil)
+				pluginServiceMock.EXPECT().EnablePluginWithDataSources(org, cookie, false)
+				webhookServiceMock.EXPECT().Publish(orgID, model.WebhookEventPluginEnabled, gomock.Any()).
+					Return(errory.ProviderErrors.New("connection refused"))
+			})
+
+			It("returns 201 code and logs the error", func() {
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(logHook.LastEntry().Message).To(Equal("plugin enabled, but webhooks cannot be notified"))
+			})
+		})
+
 		Context("no UserContext in context", func() {
 			BeforeEach(func() {
 				ginEngine = gin.New()
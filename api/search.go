@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+type SearchAPI struct {
+	Service service.ISearchService
+	Log     logrus.FieldLogger
+}
+
+// @Summary Search
+// @Description Searches SLO, organization, solution, product and datasource names in the organizations visible
+// @Description to the user. Returns the hits ranked best first, each with a link to its resource.
+// @Tags search
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param q query string true "search query, 2 to 100 characters"
+// @Param type query []string false "restrict to hit types, all by default" Enums(slo, organization, solution, product, datasource) collectionFormat(multi)
+// @Param limit query int false "maximal number of hits, 1 to 100, 20 by default"
+// @Success 200 {array} model.SearchHit
+// @Router /search [get]
+func (api *SearchAPI) Search(c *gin.Context) {
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot search").Create(), api.Log)
+		return
+	}
+	types, limit, err := extractSearchParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot search").Create(), api.Log)
+		return
+	}
+
+	hits, err := api.Service.Search(userContext, c.Query("q"), types, limit)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot search").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, hits)
+}
+
+func extractSearchParams(c *gin.Context) ([]model.SearchHitType, int, error) {
+	types := []model.SearchHitType{}
+	for _, value := range c.QueryArray("type") {
+		hitType := model.SearchHitType(value)
+		switch hitType {
+		case model.SearchHitTypeSlo, model.SearchHitTypeOrganization, model.SearchHitTypeSolution,
+			model.SearchHitTypeProduct, model.SearchHitTypeDatasource:
+			types = append(types, hitType)
+		default:
+			return nil, 0, errory.ParseErrors.Builder().WithPayload("type", value).
+				WithMessage("type parameter has to be one of slo, organization, solution, product, datasource").Create()
+		}
+	}
+
+	limit := defaultSearchLimit
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxSearchLimit {
+			return nil, 0, errory.ParseErrors.Builder().WithPayload("limit", value).
+				WithMessage(fmt.Sprintf("limit parameter has to be between 1 and %d", maxSearchLimit)).Create()
+		}
+	}
+	return types, limit, nil
+}
@@ -0,0 +1,96 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("SearchAPI", func() {
+	var mockController *gomock.Controller
+	var serviceMock *service.MockISearchService
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	logger, _ := logrustest.NewNullLogger()
+	userContext := auth.UserContext{ID: 3, Cookie: "test cookie"}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockISearchService(mockController)
+		searchAPI := &SearchAPI{Service: serviceMock, Log: logger}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.GET("/v1/search", func(c *gin.Context) {
+			c.Set("UserContext", &userContext)
+		}, searchAPI.Search)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	search := func(url string) {
+		w = httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		ginEngine.ServeHTTP(w, req)
+	}
+
+	It("returns 200 code with the ranked hits", func() {
+		serviceMock.EXPECT().Search(&userContext, "checkout", []model.SearchHitType{}, 20).
+			Return([]*model.SearchHit{{Type: model.SearchHitTypeSlo, ID: 7, Name: "checkout", Score: 2, Link: "/v1/slo/7"}}, nil)
+
+		search("/v1/search?q=checkout")
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+		var hits []model.SearchHit
+		Expect(json.Unmarshal(w.Body.Bytes(), &hits)).To(Succeed())
+		Expect(hits).To(HaveLen(1))
+		Expect(hits[0].Link).To(Equal("/v1/slo/7"))
+	})
+
+	It("passes the requested types and limit", func() {
+		serviceMock.EXPECT().Search(&userContext, "es", []model.SearchHitType{model.SearchHitTypeDatasource, model.SearchHitTypeOrganization}, 5).
+			Return([]*model.SearchHit{}, nil)
+
+		search("/v1/search?q=es&type=datasource&type=organization&limit=5")
+
+		Expect(w.Code).To(Equal(http.StatusOK))
+	})
+
+	It("returns 400 code when the type is unknown", func() {
+		search("/v1/search?q=es&type=team")
+
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("returns 400 code when the limit is too large", func() {
+		search("/v1/search?q=es&limit=101")
+
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("returns 400 code when the query is invalid", func() {
+		serviceMock.EXPECT().Search(&userContext, "", []model.SearchHitType{}, 20).
+			Return(nil, errory.ValidationErrors.New("search query has to have 2 to 100 characters"))
+
+		search("/v1/search")
+
+		Expect(w.Code).To(Equal(http.StatusBadRequest))
+	})
+})
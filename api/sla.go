@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultSlaBreachPeriod = 365 * 24 * time.Hour
+
+type SlaAPI struct {
+	Service   service.ISlaService
+	Validator validator.ITranslatedValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Get SLA Breaches
+// @Description Returns the SLA periods of the Organization's SLOs which missed their external SLA, with the credit
+// @Description according to the credit table of their solution. Periods overlapping from to to are returned.
+// @Tags sla
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param from query string false "RFC3339 start, default one year before to"
+// @Param to query string false "RFC3339 end, default now"
+// @Success 200 {array} model.SlaBreach
+// @Router /org/{id}/sla_breach [get]
+func (api *SlaAPI) GetBreaches(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLA breaches").Create(), api.Log)
+		return
+	}
+
+	timeRange, err := extractHistoryRange(c, defaultSlaBreachPeriod)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLA breaches").Create(), api.Log)
+		return
+	}
+
+	breaches, err := api.Service.GetBreaches(orgID, timeRange.From, timeRange.To)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLA breaches").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, breaches)
+}
+
+// @Summary Get SLA Credit Table
+// @Description Returns the SLA credit tiers the Organization agreed on for the solution
+// @Tags sla
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param solutionId path int true "Solution ID"
+// @Success 200 {object} model.SlaCreditTable
+// @Router /org/{id}/sla_credit/{solutionId} [get]
+func (api *SlaAPI) GetCreditTable(c *gin.Context) {
+	orgID, solutionID, err := getSlaCreditParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLA credit table").Create(), api.Log)
+		return
+	}
+
+	table, err := api.Service.GetCreditTable(orgID, solutionID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLA credit table").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+// @Summary Update SLA Credit Table
+// @Description Replaces the SLA credit tiers of the solution, a breach gets the highest credit of the tiers
+// @Description its achieved availability is below of. An empty list removes the table.
+// @Tags sla
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param solutionId path int true "Solution ID"
+// @Param slaCredit body model.SlaCreditTable true "orgId and solutionId are not checked"
+// @Success 200 {object} model.SlaCreditTable
+// @Router /org/{id}/sla_credit/{solutionId} [put]
+func (api *SlaAPI) UpdateCreditTable(c *gin.Context) {
+	orgID, solutionID, err := getSlaCreditParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLA credit table").Create(), api.Log)
+		return
+	}
+
+	var table model.SlaCreditTable
+	if err = c.ShouldBindBodyWith(&table, binding.JSON); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(errory.GetValidationError(err, table)).
+			WithMessage("Cannot update SLA credit table").Create(), api.Log)
+		return
+	}
+	table.OrgID, table.SolutionID = orgID, solutionID
+	if table.Tiers == nil {
+		table.Tiers = []model.SlaCreditTier{}
+	}
+
+	if err = api.Validator.Validate(context.Background(), table); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLA credit table").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.SetCreditTable(&table); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLA credit table").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+func getSlaCreditParams(c *gin.Context) (int64, int64, error) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	solutionID, err := GetInt64Param(c, "solutionId")
+	if err != nil {
+		return 0, 0, err
+	}
+	return orgID, solutionID, nil
+}
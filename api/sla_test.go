@@ -0,0 +1,156 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("SlaAPI", func() {
+	var mockController *gomock.Controller
+	var slaAPI *SlaAPI
+	var serviceMock *service.MockISlaService
+	var validatorMock *validator.MockITranslatedValidator
+	logger, _ := logrustest.NewNullLogger()
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var req *http.Request
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockISlaService(mockController)
+		validatorMock = validator.NewMockITranslatedValidator(mockController)
+		slaAPI = &SlaAPI{
+			Service:   serviceMock,
+			Validator: validatorMock,
+			Log:       logger,
+		}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.GET("/v1/org/:id/sla_breach", slaAPI.GetBreaches)
+		ginEngine.GET("/v1/org/:id/sla_credit/:solutionId", slaAPI.GetCreditTable)
+		ginEngine.PUT("/v1/org/:id/sla_credit/:solutionId", slaAPI.UpdateCreditTable)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetBreaches()", func() {
+		var query string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/sla_breach?"+query, nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				query = "from=2021-03-01T00:00:00Z&to=2021-04-01T00:00:00Z"
+				march, april := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+				credit, solutionID := 25.0, int64(5)
+				serviceMock.EXPECT().GetBreaches(int64(99), march, april).Return([]*model.SlaBreach{{
+					ID: 1, SloID: 7, SloName: "checkout", OrgID: 99, SolutionID: &solutionID, PeriodStart: march, PeriodEnd: april,
+					Start: march.AddDate(0, 0, 3), End: march.AddDate(0, 0, 5), SLA: 99.5, Achieved: 98.9, Closed: true, CreditPercent: &credit,
+				}}, nil)
+			})
+
+			It("returns 200 code with the breaches of the period", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"id":1,"sloId":7,"sloName":"checkout","orgId":99,"solutionId":5,
+					"periodStart":"2021-03-01T00:00:00Z","periodEnd":"2021-04-01T00:00:00Z","start":"2021-03-04T00:00:00Z",
+					"end":"2021-03-06T00:00:00Z","sla":99.5,"achieved":98.9,"closed":true,"creditPercent":25}]`))
+			})
+		})
+
+		Context("when range is invalid", func() {
+			BeforeEach(func() {
+				query = "from=2021-04-01T00:00:00Z&to=2021-03-01T00:00:00Z"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("GetCreditTable()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/sla_credit/5", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().GetCreditTable(int64(99), int64(5)).Return(&model.SlaCreditTable{
+					OrgID: 99, SolutionID: 5, Tiers: []model.SlaCreditTier{{Below: 99.5, CreditPercent: 10}},
+				}, nil)
+			})
+
+			It("returns 200 code with the tiers", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"orgId":99,"solutionId":5,"tiers":[{"below":99.5,"creditPercent":10}]}`))
+			})
+		})
+	})
+
+	Describe("UpdateCreditTable()", func() {
+		var requestBody string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("PUT", "/v1/org/99/sla_credit/5", bytes.NewBufferString(requestBody))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				requestBody = `{"tiers": [{"below": 99, "creditPercent": 25}]}`
+				table := model.SlaCreditTable{OrgID: 99, SolutionID: 5, Tiers: []model.SlaCreditTier{{Below: 99, CreditPercent: 25}}}
+				validatorMock.EXPECT().Validate(context.Background(), table).Return(nil)
+				serviceMock.EXPECT().SetCreditTable(&table).Return(nil)
+			})
+
+			It("returns 200 code with the stored table", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"orgId":99,"solutionId":5,"tiers":[{"below":99,"creditPercent":25}]}`))
+			})
+		})
+
+		Context("when tiers are left out", func() {
+			BeforeEach(func() {
+				requestBody = `{}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(nil)
+				serviceMock.EXPECT().SetCreditTable(gomock.Any()).DoAndReturn(func(table *model.SlaCreditTable) error {
+					Expect(table.Tiers).To(Equal([]model.SlaCreditTier{}))
+					return nil
+				})
+			})
+
+			It("returns 200 code with an empty table", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"orgId":99,"solutionId":5,"tiers":[]}`))
+			})
+		})
+	})
+})
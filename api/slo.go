@@ -3,6 +3,8 @@ package api
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -15,15 +17,19 @@ import (
 
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
 	v "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
 	"github.com/sirupsen/logrus"
 )
 
 type SloAPI struct {
-	SloService service.ISloService
-	Validator  v.ISLOValidator
-	Log        logrus.FieldLogger
+	SloService     service.ISloService
+	BudgetService  service.IErrorBudgetService
+	ChangeRequests service.ISloChangeRequestService
+	Versions       service.IEntityVersionService
+	Validator      v.ISLOValidator
+	Log            logrus.FieldLogger
 }
 
 // @Summary Add SLO
@@ -33,7 +39,10 @@ type SloAPI struct {
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param slo body model.Slo true "neither id nor creation_date is checked"
-// @Success 201 {object} api.ID
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response replayed"
+// @Success 201 {object} api.SavedSlo
+// @Failure 503 {object} model.Problem "Datadog cannot be reached to validate externalId"
+// @Failure 409 {object} model.Problem "Idempotency-Key was used for a different request or is still processed"
 // @Router /slo [post]
 func (api *SloAPI) Create(c *gin.Context) {
 	userContext, err := GetUserContext(c)
@@ -51,18 +60,25 @@ func (api *SloAPI) Create(c *gin.Context) {
 		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create SLO").Create(), api.Log)
 		return
 	}
-	setIDResponse(http.StatusCreated, slo.ID, c)
+	setSavedSloResponse(http.StatusCreated, slo, c)
 }
 
 // @Summary Update SLO
-// @Description Updates SLO
+// @Description Updates SLO. Changes of critical SLOs and of the criticality or external SLA need the approval
+// @Description of an organization Admin unless made by one, a pending change request is returned then.
 // @Tags slos
 // @Accept  json
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param id path int true "Slo ID"
+// @Param If-Match header string true "ETag of the SLO the change is based on, * to overwrite any version"
 // @Param slo body model.Slo true "SLO"
-// @Success 200 {object} api.ID
+// @Success 200 {object} api.SavedSlo
+// @Success 202 {object} model.SloChangeRequest
+// @Header 200 {string} ETag "version of the updated SLO"
+// @Failure 412 {object} model.Problem "the SLO was changed since the version in If-Match"
+// @Failure 428 {object} model.Problem "If-Match is missing"
+// @Failure 503 {object} model.Problem "Datadog cannot be reached to validate externalId"
 // @Router /slo/{id} [put]
 func (api *SloAPI) Update(c *gin.Context) {
 	userContext, err := GetUserContext(c)
@@ -76,22 +92,46 @@ func (api *SloAPI) Update(c *gin.Context) {
 		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO").Create(), api.Log)
 		return
 	}
+	version, err := extractIfMatch(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO").Create(), api.Log)
+		return
+	}
+	if err = api.Versions.Verify(model.VersionedEntitySlo, slo.ID, version); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO").Create(), api.Log)
+		return
+	}
+
+	request, err := api.ChangeRequests.RequestIfRequired(userContext, model.SloChangeActionUpdate, slo.ID, slo, version, false)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO").Create(), api.Log)
+		return
+	}
+	if request != nil {
+		c.JSON(http.StatusAccepted, request)
+		return
+	}
 
-	if err = api.SloService.Update(userContext, slo); err != nil {
+	newVersion, err := api.SloService.Update(userContext, slo, version)
+	if err != nil {
 		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO").Create(), api.Log)
 		return
 	}
 
-	setIDResponse(http.StatusOK, slo.ID, c)
+	setETag(c, newVersion)
+	setSavedSloResponse(http.StatusOK, slo, c)
 }
 
 // @Summary Delete SLO
-// @Description Deletes SLO
+// @Description Deletes SLO. Deleting a critical SLO needs the approval of an organization Admin unless made by one,
+// @Description a pending change request is returned then.
 // @Tags slos
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param id path int true "Slo ID"
+// @Param cascade query bool false "also remove the SLO from the composite SLOs it is a child of, default false"
 // @Success 200 {object} model.Slo
+// @Success 202 {object} model.SloChangeRequest
 // @Router /slo/{id} [delete]
 func (api *SloAPI) Delete(c *gin.Context) {
 	userContext, err := GetUserContext(c)
@@ -106,7 +146,27 @@ func (api *SloAPI) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := api.SloService.Delete(userContext, sloID); err != nil {
+	cascade := false
+	if value := c.Query("cascade"); value != "" {
+		if cascade, err = strconv.ParseBool(value); err != nil {
+			setErrorResponse(c, errory.OnDeleteErrors.Builder().
+				Wrap(errory.ParseErrors.Builder().Wrap(err).WithMessage("cascade parameter cannot be parsed").Create()).
+				WithMessage("Cannot delete SLO").Create(), api.Log)
+			return
+		}
+	}
+
+	request, err := api.ChangeRequests.RequestIfRequired(userContext, model.SloChangeActionDelete, sloID, nil, nil, cascade)
+	if err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete SLO").Create(), api.Log)
+		return
+	}
+	if request != nil {
+		c.JSON(http.StatusAccepted, request)
+		return
+	}
+
+	if err := api.SloService.Delete(userContext, sloID, cascade); err != nil {
 		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete SLO").Create(), api.Log)
 		return
 	}
@@ -115,12 +175,16 @@ func (api *SloAPI) Delete(c *gin.Context) {
 }
 
 // @Summary Delete SLO History
-// @Description Deletes SLO History
+// @Description Starts deletion of SLO History in the given time range or, for a dry run, counts matching documents per index
 // @Tags slos
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param id path int true "Slo ID"
-// @Success 200 {object} model.Slo
+// @Param from query string false "RFC3339 start of the range, default 14 days ago"
+// @Param to query string false "RFC3339 end of the range, default now"
+// @Param dryRun query bool false "only count matching documents, default false"
+// @Success 200 {object} elastic.SloHistoryDeletion
+// @Success 202 {object} elastic.SloHistoryDeletion
 // @Router /slo/{id}/history [delete]
 func (api *SloAPI) DeleteSloHistory(c *gin.Context) {
 	sloID, err := GetIDParam(c)
@@ -129,12 +193,189 @@ func (api *SloAPI) DeleteSloHistory(c *gin.Context) {
 		return
 	}
 
-	if err := api.SloService.DeleteSloHistory(sloID); err != nil {
+	deletion, err := extractSloHistoryDeletion(c, sloID)
+	if err != nil {
 		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete SLO History").Create(), api.Log)
 		return
 	}
 
-	setIDResponse(http.StatusOK, sloID, c)
+	if err := api.SloService.DeleteSloHistory(deletion); err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete SLO History").Create(), api.Log)
+		return
+	}
+
+	if deletion.DryRun {
+		c.JSON(http.StatusOK, deletion)
+		return
+	}
+	c.JSON(http.StatusAccepted, deletion)
+}
+
+// @Summary Get SLO History deletion progress
+// @Description Returns progress of a SLO History deletion task
+// @Tags slos
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Slo ID"
+// @Param taskId path string true "Deletion task ID"
+// @Success 200 {object} elastic.SloHistoryDeletionTask
+// @Router /slo/{id}/history/task/{taskId} [get]
+func (api *SloAPI) GetSloHistoryDeletionTask(c *gin.Context) {
+	sloID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO History deletion task").Create(), api.Log)
+		return
+	}
+
+	task, err := api.SloService.GetSloHistoryDeletionTask(sloID, c.Param("taskId"))
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO History deletion task").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// @Summary Get SLO History
+// @Description Returns compliance and success rate of SLO History aggregated into points of the given interval
+// @Tags slos
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Slo ID"
+// @Param from query string false "RFC3339 start of the range, default start of the current SLO window"
+// @Param to query string false "RFC3339 end of the range, default now"
+// @Param interval query string false "length of a point, e.g. 30m, 1h or 1d, default 1h"
+// @Success 200 {object} elastic.SloHistory
+// @Router /slo/{id}/history [get]
+func (api *SloAPI) GetSloHistory(c *gin.Context) {
+	sloID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO History").Create(), api.Log)
+		return
+	}
+
+	timeRange, err := extractHistoryRange(c, defaultHistoryPeriod)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO History").Create(), api.Log)
+		return
+	}
+
+	if c.Query("from") == "" {
+		slo, err := api.SloService.Get(sloID)
+		if err != nil {
+			setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO History").Create(), api.Log)
+			return
+		}
+		timeRange.From = slo.Window().Start(timeRange.To)
+	}
+
+	interval, err := extractHistoryInterval(c, timeRange)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO History").Create(), api.Log)
+		return
+	}
+
+	history, err := api.SloService.GetSloHistory(sloID, timeRange, interval)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO History").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// @Summary Get SLO Error Budget
+// @Description Returns the error budget of the SLO in its current window, maintenance does not consume budget
+// @Tags slos
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Slo ID"
+// @Success 200 {object} model.ErrorBudget
+// @Router /slo/{id}/budget [get]
+func (api *SloAPI) GetErrorBudget(c *gin.Context) {
+	sloID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO error budget").Create(), api.Log)
+		return
+	}
+
+	budget, err := api.BudgetService.GetErrorBudget(sloID, time.Now())
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO error budget").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// @Summary Get SLO Error Budget Forecast
+// @Description Projects when the SLO runs out of the error budget of its current window, linearly and
+// @Description exponentially weighted from the burn rate of the look-back
+// @Tags slos
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Slo ID"
+// @Param lookback query int false "look-back in days, 1 to 90, the configured default when omitted"
+// @Success 200 {object} model.BudgetForecast
+// @Router /slo/{id}/budget/forecast [get]
+func (api *SloAPI) GetBudgetForecast(c *gin.Context) {
+	sloID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO error budget forecast").Create(), api.Log)
+		return
+	}
+
+	lookback, err := extractForecastLookback(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO error budget forecast").Create(), api.Log)
+		return
+	}
+
+	forecast, err := api.BudgetService.GetForecast(sloID, time.Now(), lookback)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO error budget forecast").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, forecast)
+}
+
+// @Summary Simulate SLO Targets
+// @Description Replays the stored SLO history of a time range with other targets and returns the breached periods,
+// @Description the budget consumed per period and the days on which multi-window burn rate alerts would have fired
+// @Tags slos
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Slo ID"
+// @Param simulation body model.SloSimulation true "targets which are left empty keep the target of the SLO"
+// @Success 200 {object} model.SloSimulationResult
+// @Router /slo/{id}/simulate [post]
+func (api *SloAPI) Simulate(c *gin.Context) {
+	sloID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot simulate SLO").Create(), api.Log)
+		return
+	}
+
+	var simulation model.SloSimulation
+	if err = c.ShouldBindBodyWith(&simulation, binding.JSON); err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(errory.GetValidationError(err, simulation)).
+			WithMessage("Cannot simulate SLO").Create(), api.Log)
+		return
+	}
+	if err = api.Validator.Validate(context.Background(), simulation); err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot simulate SLO").Create(), api.Log)
+		return
+	}
+
+	result, err := api.BudgetService.Simulate(sloID, &simulation)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot simulate SLO").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
 
 // @Summary Get SLO
@@ -144,6 +385,7 @@ func (api *SloAPI) DeleteSloHistory(c *gin.Context) {
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param id path int true "Slo ID"
 // @Success 200 {object} model.Slo
+// @Header 200 {string} ETag "version of the SLO, to be sent as If-Match on update"
 // @Router /slo/{id} [get]
 func (api *SloAPI) Get(c *gin.Context) {
 	sloID, err := GetIDParam(c)
@@ -157,30 +399,53 @@ func (api *SloAPI) Get(c *gin.Context) {
 		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO").Create(), api.Log)
 		return
 	}
+	version, err := api.Versions.GetVersion(model.VersionedEntitySlo, sloID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO").Create(), api.Log)
+		return
+	}
 
+	setETag(c, version)
 	c.JSON(http.StatusOK, slo)
 }
 
 // @Summary Get detailed and filtered SLOs visible to user
-// @Description Returns array of SLOs
+// @Description Returns array of SLOs. Pages are requested with limit, the cursor of the next page is returned
+// @Description in the X-Next-Cursor header as long as more SLOs follow, all SLOs are returned without limit.
 // @Tags slos
 // @Produce  json
 // @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
 // @Param name query string false "filter by SLO name"
+// @Param namePrefix query string false "filter by the beginning of the SLO name"
 // @Param orgName query string false "filter by organization name"
+// @Param critical query bool false "filter by criticality"
+// @Param externalType query string false "filter by external type, empty for SLOs without external SLO" Enums(, metric, monitor, time_slice)
+// @Param datasourceId query int false "filter by datasource"
+// @Param solutionId query int false "filter by solution"
+// @Param label query []string false "filter by label as key:value, repeated labels have to match all" collectionFormat(multi)
+// @Param sort query string false "sort field, name by default" Enums(name, creationDate, critical, org)
+// @Param order query string false "sort order, asc by default" Enums(asc, desc)
+// @Param limit query int false "page size, 1 to 1000"
+// @Param cursor query string false "X-Next-Cursor of the previous page"
 // @Success 200 {array} model.DetailedSlo
+// @Header 200 {integer} X-Total-Count "number of all SLOs matching the filters"
+// @Header 200 {string} X-Next-Cursor "cursor of the next page"
 // @Router /slo [get]
 func (api *SloAPI) GetDetailed(c *gin.Context) {
-	sloNameQuery := c.Query("name")
-	orgNameQuery := c.Query("orgName")
+	query, err := extractSloPageQuery(c)
+	if err != nil {
+		setErrorResponse(c, err, api.Log)
+		return
+	}
 
-	slos, err := api.SloService.GetDetailedSlos(sloNameQuery, orgNameQuery)
+	page, err := api.SloService.GetSloPage(query)
 	if err != nil {
 		setErrorResponse(c, err, api.Log)
 		return
 	}
 
-	c.JSON(http.StatusOK, slos)
+	setSloPageHeaders(c, page)
+	c.JSON(http.StatusOK, page.Slos)
 }
 
 func extractAndValidateSlo(c *gin.Context, create bool, validator v.ISLOValidator) (*model.Slo, error) {
@@ -197,23 +462,51 @@ func extractAndValidateSlo(c *gin.Context, create bool, validator v.ISLOValidato
 		slo.ID = sloID
 	}
 
-	ct := context.WithValue(context.Background(), ctx.Create, create)
-	if err := validator.Validate(ct, slo); err != nil {
+	if err := validateSlo(&slo, create, validator); err != nil {
 		return nil, err
 	}
+	return &slo, nil
+}
 
-	// parsing errors should never occur as fields are already validated
-	complianceDecEncoded, err := decimal.NewFromString(slo.ComplianceExpectedAvailability)
-	if err != nil {
-		return nil, err
+// validateSlo validates the SLO and normalizes its availabilities and latency target. Latency SLOs
+// may leave the availabilities empty, empty values are kept.
+func validateSlo(slo *model.Slo, create bool, validator v.ISLOValidator) error {
+	ct := context.WithValue(context.Background(), ctx.Create, create)
+	if err := validator.Validate(ct, *slo); err != nil {
+		return err
 	}
-	slo.ComplianceExpectedAvailability = complianceDecEncoded.String()
 
-	successRateDecEncoded, err := decimal.NewFromString(slo.SuccessRateExpectedAvailability)
+	for _, value := range []*string{&slo.ComplianceExpectedAvailability, &slo.SuccessRateExpectedAvailability, &slo.LatencyTarget} {
+		if *value == "" {
+			continue
+		}
+		// parsing errors should never occur as fields are already validated
+		decEncoded, err := decimal.NewFromString(*value)
+		if err != nil {
+			return err
+		}
+		*value = decEncoded.String()
+	}
+	return nil
+}
+
+func extractSloHistoryDeletion(c *gin.Context, sloID int64) (*elasticModel.SloHistoryDeletion, error) {
+	timeRange, err := extractHistoryRange(c, defaultHistoryDeletionPeriod)
 	if err != nil {
 		return nil, err
 	}
-	slo.SuccessRateExpectedAvailability = successRateDecEncoded.String()
 
-	return &slo, nil
+	dryRun := false
+	if value := c.Query("dryRun"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage("dryRun parameter cannot be parsed").Create()
+		}
+	}
+
+	return &elasticModel.SloHistoryDeletion{
+		SloID:  sloID,
+		From:   timeRange.From,
+		To:     timeRange.To,
+		DryRun: dryRun,
+	}, nil
 }
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	v "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type SloBatchAPI struct {
+	Service   service.ISloBatchService
+	Validator v.ISLOValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Apply SLO Batch
+// @Description Creates, updates and deletes SLOs across organizations. Every operation is authorized like
+// @Description on the single-item routes, updates send the ETag they are based on as ifMatch. In allOrNothing
+// @Description mode, the default, no operation is applied unless all can be, applied creates and updates are
+// @Description rolled back when an operation fails. In bestEffort mode every operation which can be applied is.
+// @Description A batch with an invalid operation is rejected as a whole.
+// @Tags slos
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param Idempotency-Key header string false "retries with the same key and body get the first response replayed"
+// @Param batch body model.SloBatch true "SLO operations"
+// @Success 200 {object} model.SloBatchResult "all operations succeeded"
+// @Success 207 {object} model.SloBatchResult "some operations failed, see the status of each result"
+// @Router /slo/batch [post]
+func (api *SloBatchAPI) Execute(c *gin.Context) {
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot apply SLO batch").Create(), api.Log)
+		return
+	}
+
+	batch, err := extractAndValidateSloBatch(c, api.Validator)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot apply SLO batch").Create(), api.Log)
+		return
+	}
+	result, err := api.Service.Execute(userContext, batch)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot apply SLO batch").Create(), api.Log)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	c.JSON(status, result)
+}
+
+func extractAndValidateSloBatch(c *gin.Context, validator v.ISLOValidator) (*model.SloBatch, error) {
+	var batch model.SloBatch
+	if err := c.ShouldBindBodyWith(&batch, binding.JSON); err != nil {
+		return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage("batch cannot be parsed").Create()
+	}
+
+	for i, operation := range batch.Operations {
+		if operation == nil {
+			return nil, errory.ValidationErrors.Builder().WithPayload("operation", i).
+				WithMessage(fmt.Sprintf("operation %d is empty", i)).Create()
+		}
+		if operation.Action != model.SloBatchActionCreate && operation.ID <= 0 {
+			return nil, errory.ValidationErrors.Builder().WithPayload("operation", i).
+				WithMessage(fmt.Sprintf("operation %d needs the id of the slo", i)).Create()
+		}
+
+		switch operation.Action {
+		case model.SloBatchActionCreate, model.SloBatchActionUpdate:
+			create := operation.Action == model.SloBatchActionCreate
+			if operation.Slo == nil {
+				return nil, errory.ValidationErrors.Builder().WithPayload("operation", i).
+					WithMessage(fmt.Sprintf("operation %d needs the slo", i)).Create()
+			}
+			if !create {
+				operation.Slo.ID = operation.ID
+				version, err := parseIfMatch(operation.IfMatch, fmt.Sprintf("ifMatch of operation %d", i))
+				if err != nil {
+					return nil, err
+				}
+				operation.Version = version
+			}
+			if err := validateSlo(operation.Slo, create, validator); err != nil {
+				return nil, errory.ValidationErrors.Builder().Wrap(err).WithPayload("operation", i).
+					WithMessage(fmt.Sprintf("slo of operation %d is invalid", i)).Create()
+			}
+		case model.SloBatchActionDelete:
+		default:
+			return nil, errory.ValidationErrors.Builder().WithPayload("operation", i).WithPayload("action", operation.Action).
+				WithMessage(fmt.Sprintf("action of operation %d has to be create, update or delete", i)).Create()
+		}
+	}
+	return &batch, nil
+}
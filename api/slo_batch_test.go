@@ -0,0 +1,141 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/ctx"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("SloBatchAPI", func() {
+	var mockController *gomock.Controller
+	var batchServiceMock *service.MockISloBatchService
+	var validatorMock *validator.MockISLOValidator
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var requestBody string
+	userContext := &auth.UserContext{ID: 3}
+	logger, _ := logrustest.NewNullLogger()
+	createScope := context.WithValue(context.Background(), ctx.Create, true)
+	updateScope := context.WithValue(context.Background(), ctx.Create, false)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		batchServiceMock = service.NewMockISloBatchService(mockController)
+		validatorMock = validator.NewMockISLOValidator(mockController)
+		batchAPI := &SloBatchAPI{Service: batchServiceMock, Validator: validatorMock, Log: logger}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.POST("/v1/slo/batch", func(c *gin.Context) {
+			c.Set("UserContext", userContext)
+		}, batchAPI.Execute)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	JustBeforeEach(func() {
+		w = httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/v1/slo/batch", bytes.NewBufferString(requestBody))
+		ginEngine.ServeHTTP(w, req)
+	})
+
+	Context("when all operations succeed", func() {
+		BeforeEach(func() {
+			requestBody = `{"mode": "bestEffort", "operations": [
+				{"action": "create", "slo": {"orgId": 1, "name": "new", "successRateExpAvailability": "99.90",
+					"complianceExpAvailability": "99"}},
+				{"action": "update", "id": 5, "ifMatch": "\"4\"", "slo": {"orgId": 1, "name": "changed",
+					"successRateExpAvailability": "99", "complianceExpAvailability": "99"}},
+				{"action": "delete", "id": 6, "cascade": true}
+			]}`
+			validatorMock.EXPECT().Validate(createScope, gomock.Any()).Return(nil)
+			validatorMock.EXPECT().Validate(updateScope, gomock.Any()).Return(nil)
+			batchServiceMock.EXPECT().Execute(userContext, gomock.Any()).
+				DoAndReturn(func(_ *auth.UserContext, batch *model.SloBatch) (*model.SloBatchResult, error) {
+					Expect(batch.Mode).To(Equal(model.SloBatchModeBestEffort))
+					Expect(batch.Operations).To(HaveLen(3))
+					Expect(batch.Operations[0].Slo.SuccessRateExpectedAvailability).To(Equal("99.9"))
+					Expect(batch.Operations[1].Slo.ID).To(Equal(int64(5)))
+					Expect(*batch.Operations[1].Version).To(Equal(int64(4)))
+					Expect(batch.Operations[2].Cascade).To(BeTrue())
+					return &model.SloBatchResult{Mode: batch.Mode, Succeeded: 3}, nil
+				})
+		})
+
+		It("returns 200 with the results", func() {
+			Expect(w.Code).To(Equal(http.StatusOK))
+			var result model.SloBatchResult
+			Expect(json.Unmarshal(w.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.Succeeded).To(Equal(3))
+		})
+	})
+
+	Context("when an operation fails", func() {
+		BeforeEach(func() {
+			requestBody = `{"operations": [{"action": "delete", "id": 6}]}`
+			batchServiceMock.EXPECT().Execute(userContext, gomock.Any()).
+				Return(&model.SloBatchResult{Mode: model.SloBatchModeAllOrNothing, Failed: 1}, nil)
+		})
+
+		It("returns 207", func() {
+			Expect(w.Code).To(Equal(http.StatusMultiStatus))
+		})
+	})
+
+	Context("when an update has no ifMatch", func() {
+		BeforeEach(func() {
+			requestBody = `{"operations": [{"action": "update", "id": 5, "slo": {"orgId": 1, "name": "changed"}}]}`
+		})
+
+		It("returns 428 naming the operation", func() {
+			Expect(w.Code).To(Equal(http.StatusPreconditionRequired))
+			Expect(w.Body.String()).To(ContainSubstring("ifMatch of operation 0"))
+			Expect(w.Header().Get("Content-Type")).To(HavePrefix("application/problem+json"))
+			var problem model.Problem
+			Expect(json.Unmarshal(w.Body.Bytes(), &problem)).To(Succeed())
+			Expect(problem.Type).To(HaveSuffix("/precondition-required"))
+			Expect(problem.Instance).To(Equal("/v1/slo/batch"))
+		})
+	})
+
+	Context("when an operation has an unknown action", func() {
+		BeforeEach(func() {
+			requestBody = `{"operations": [{"action": "delete", "id": 6}, {"action": "rename", "id": 5}]}`
+		})
+
+		It("returns 400 naming the operation", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+			Expect(w.Body.String()).To(ContainSubstring("action of operation 1"))
+		})
+	})
+
+	Context("when a delete has no id", func() {
+		BeforeEach(func() {
+			requestBody = `{"operations": [{"action": "delete"}]}`
+		})
+
+		It("returns 400", func() {
+			Expect(w.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
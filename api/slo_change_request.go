@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type SloChangeRequestAPI struct {
+	Service   service.ISloChangeRequestService
+	Validator validator.ITranslatedValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Get SLO Change Requests
+// @Description Returns the change requests of the Organization's critical SLOs, newest first
+// @Tags slo change requests
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param status query string false "pending, approved, rejected, expired or failed, default all"
+// @Success 200 {array} model.SloChangeRequest
+// @Router /org/{id}/slo_change_request [get]
+func (api *SloChangeRequestAPI) GetAll(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO change requests").Create(), api.Log)
+		return
+	}
+
+	status := model.SloChangeStatus(c.Query("status"))
+	switch status {
+	case "", model.SloChangeStatusPending, model.SloChangeStatusApproved, model.SloChangeStatusRejected,
+		model.SloChangeStatusExpired, model.SloChangeStatusFailed:
+	default:
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(errory.ParseErrors.New("status parameter cannot be parsed")).
+			WithMessage("Cannot get SLO change requests").Create(), api.Log)
+		return
+	}
+
+	requests, err := api.Service.GetByOrgID(orgID, status)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO change requests").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// @Summary Approve SLO Change Request
+// @Description Approves a pending change request and applies the change on behalf of the approving Admin.
+// @Description A change which cannot be applied is recorded as failed.
+// @Tags slo change requests
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param requestId path int true "Change Request ID"
+// @Param decision body model.SloChangeDecision false "Decision"
+// @Success 200 {object} model.SloChangeRequest
+// @Router /org/{id}/slo_change_request/{requestId}/approve [post]
+func (api *SloChangeRequestAPI) Approve(c *gin.Context) {
+	api.decide(c, api.Service.Approve, "Cannot approve SLO change request")
+}
+
+// @Summary Reject SLO Change Request
+// @Description Rejects a pending change request, the SLO stays unchanged
+// @Tags slo change requests
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param requestId path int true "Change Request ID"
+// @Param decision body model.SloChangeDecision false "Decision"
+// @Success 200 {object} model.SloChangeRequest
+// @Router /org/{id}/slo_change_request/{requestId}/reject [post]
+func (api *SloChangeRequestAPI) Reject(c *gin.Context) {
+	api.decide(c, api.Service.Reject, "Cannot reject SLO change request")
+}
+
+type sloChangeDecider func(userContext *auth.UserContext, orgID, id int64, decision *model.SloChangeDecision) (*model.SloChangeRequest, error)
+
+func (api *SloChangeRequestAPI) decide(c *gin.Context, decide sloChangeDecider, message string) {
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage(message).Create(), api.Log)
+		return
+	}
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage(message).Create(), api.Log)
+		return
+	}
+	requestID, err := GetInt64Param(c, "requestId")
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage(message).Create(), api.Log)
+		return
+	}
+
+	var decision model.SloChangeDecision
+	if c.Request.ContentLength != 0 {
+		if err = c.ShouldBindBodyWith(&decision, binding.JSON); err != nil {
+			setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(errory.GetValidationError(err, decision)).
+				WithMessage(message).Create(), api.Log)
+			return
+		}
+	}
+	if err = api.Validator.Validate(context.Background(), decision); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage(message).Create(), api.Log)
+		return
+	}
+
+	request, err := decide(userContext, orgID, requestID, &decision)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage(message).Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
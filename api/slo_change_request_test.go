@@ -0,0 +1,148 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("SloChangeRequestAPI", func() {
+	var mockController *gomock.Controller
+	var changeRequestAPI *SloChangeRequestAPI
+	var serviceMock *service.MockISloChangeRequestService
+	var validatorMock *validator.MockITranslatedValidator
+	logger, _ := logrustest.NewNullLogger()
+	userContext := auth.UserContext{ID: 4, Login: "admin", Cookie: "test cookie"}
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var req *http.Request
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockISloChangeRequestService(mockController)
+		validatorMock = validator.NewMockITranslatedValidator(mockController)
+		changeRequestAPI = &SloChangeRequestAPI{
+			Service:   serviceMock,
+			Validator: validatorMock,
+			Log:       logger,
+		}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		userContextMiddleware := func(c *gin.Context) {
+			c.Set("UserContext", &userContext)
+		}
+		ginEngine.GET("/v1/org/:id/slo_change_request", changeRequestAPI.GetAll)
+		ginEngine.POST("/v1/org/:id/slo_change_request/:requestId/approve", userContextMiddleware, changeRequestAPI.Approve)
+		ginEngine.POST("/v1/org/:id/slo_change_request/:requestId/reject", userContextMiddleware, changeRequestAPI.Reject)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetAll()", func() {
+		var status string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/slo_change_request?status="+status, nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				status = "pending"
+				serviceMock.EXPECT().GetByOrgID(int64(99), model.SloChangeStatusPending).Return([]*model.SloChangeRequest{}, nil)
+			})
+
+			It("returns 200 code with the requests of the status", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(Equal("[]"))
+			})
+		})
+
+		Context("when status is unknown", func() {
+			BeforeEach(func() {
+				status = "done"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Approve()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/v1/org/99/slo_change_request/1/approve", bytes.NewBufferString(`{"comment": "looks good"}`))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				decision := model.SloChangeDecision{Comment: "looks good"}
+				validatorMock.EXPECT().Validate(context.Background(), decision).Return(nil)
+				serviceMock.EXPECT().Approve(&userContext, int64(99), int64(1), &decision).
+					Return(&model.SloChangeRequest{ID: 1, Status: model.SloChangeStatusApproved, Comment: "looks good"}, nil)
+			})
+
+			It("returns 200 code with the approved request", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring(`"status":"approved"`))
+			})
+		})
+	})
+
+	Describe("Reject()", func() {
+		var requestID string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/v1/org/99/slo_change_request/"+requestID+"/reject", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request has no body", func() {
+			BeforeEach(func() {
+				requestID = "1"
+				validatorMock.EXPECT().Validate(context.Background(), model.SloChangeDecision{}).Return(nil)
+				serviceMock.EXPECT().Reject(&userContext, int64(99), int64(1), &model.SloChangeDecision{}).
+					Return(&model.SloChangeRequest{ID: 1, Status: model.SloChangeStatusRejected}, nil)
+			})
+
+			It("returns 200 code with the rejected request", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(ContainSubstring(`"status":"rejected"`))
+			})
+		})
+
+		Context("when requestId cannot be parsed", func() {
+			BeforeEach(func() {
+				requestID = "abc"
+			})
+
+			It("returns 400 code without rejecting", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type SloLabelAPI struct {
+	Service   service.ISloLabelService
+	Validator validator.ITranslatedValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Get allowed SLO label keys
+// @Description Returns the label keys SLOs of the Organization may use, any key is allowed while the list is empty
+// @Tags slo labels
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {object} model.SloLabelKeys
+// @Router /org/{id}/slo_label_key [get]
+func (api *SloLabelAPI) GetAllowedKeys(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO label keys").Create(), api.Log)
+		return
+	}
+
+	keys, err := api.Service.GetAllowedKeys(orgID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO label keys").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// @Summary Update allowed SLO label keys
+// @Description Replaces the label keys SLOs of the Organization may use. Labels already on SLOs are kept
+// @Description until the SLOs are updated. An empty list allows any key.
+// @Tags slo labels
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param sloLabelKeys body model.SloLabelKeys true "orgId is not checked"
+// @Success 200 {object} model.SloLabelKeys
+// @Router /org/{id}/slo_label_key [put]
+func (api *SloLabelAPI) UpdateAllowedKeys(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO label keys").Create(), api.Log)
+		return
+	}
+
+	var keys model.SloLabelKeys
+	if err = c.ShouldBindBodyWith(&keys, binding.JSON); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(errory.GetValidationError(err, keys)).
+			WithMessage("Cannot update SLO label keys").Create(), api.Log)
+		return
+	}
+	keys.OrgID = orgID
+	if keys.Keys == nil {
+		keys.Keys = []string{}
+	}
+
+	if err = api.Validator.Validate(context.Background(), keys); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO label keys").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.SetAllowedKeys(&keys); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update SLO label keys").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
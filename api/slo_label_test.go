@@ -0,0 +1,133 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("SloLabelAPI", func() {
+	var mockController *gomock.Controller
+	var sloLabelAPI *SloLabelAPI
+	var providerMock *provider.MockISloLabelProvider
+	var validatorMock *validator.MockITranslatedValidator
+	logger, _ := logrustest.NewNullLogger()
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var req *http.Request
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		providerMock = provider.NewMockISloLabelProvider(mockController)
+		validatorMock = validator.NewMockITranslatedValidator(mockController)
+		sloLabelAPI = &SloLabelAPI{
+			Service:   &service.SloLabelService{Provider: providerMock, Log: logger},
+			Validator: validatorMock,
+			Log:       logger,
+		}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.GET("/v1/org/:id/slo_label_key", sloLabelAPI.GetAllowedKeys)
+		ginEngine.PUT("/v1/org/:id/slo_label_key", sloLabelAPI.UpdateAllowedKeys)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetAllowedKeys()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/slo_label_key", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				providerMock.EXPECT().GetAllowedLabelKeys(int64(99)).Return([]string{"team"}, nil)
+			})
+
+			It("returns 200 code with the keys", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"orgId":99,"keys":["team"]}`))
+			})
+		})
+	})
+
+	Describe("UpdateAllowedKeys()", func() {
+		var requestBody string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("PUT", "/v1/org/99/slo_label_key", bytes.NewBufferString(requestBody))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				requestBody = `{"keys": ["tier", "team", "tier"]}`
+				validatorMock.EXPECT().Validate(context.Background(), model.SloLabelKeys{OrgID: 99, Keys: []string{"tier", "team", "tier"}}).Return(nil)
+				providerMock.EXPECT().SetAllowedLabelKeys(int64(99), []string{"team", "tier"}).Return(nil)
+			})
+
+			It("returns 200 code with the sorted unique keys", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"orgId":99,"keys":["team","tier"]}`))
+			})
+		})
+
+		Context("when a key is not lower case", func() {
+			BeforeEach(func() {
+				requestBody = `{"keys": ["Team"]}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(nil)
+			})
+
+			It("returns 400 code without storing the keys", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(ContainSubstring("label key has to be lower case alphanumeric"))
+			})
+		})
+
+		Context("when a key contains a colon", func() {
+			BeforeEach(func() {
+				requestBody = `{"keys": ["team:name"]}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(nil)
+			})
+
+			It("returns 400 code without storing the keys", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the keys cannot be stored", func() {
+			BeforeEach(func() {
+				requestBody = `{"keys": ["team"]}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(nil)
+				providerMock.EXPECT().SetAllowedLabelKeys(int64(99), []string{"team"}).Return(errory.ProviderErrors.New("db"))
+			})
+
+			It("returns 500 code", func() {
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
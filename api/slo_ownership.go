@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/sirupsen/logrus"
+)
+
+type SloOwnershipAPI struct {
+	Service service.ISloOwnershipService
+	Log     logrus.FieldLogger
+}
+
+// @Summary Get SLOs without an owner
+// @Description Returns the Organization's SLOs whose owner cannot be reached: without owner team, with an owner team
+// @Description no longer existing in Grafana, without contacts or without runbook.
+// @Tags slo ownership
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {array} model.SloOwnershipGap
+// @Router /org/{id}/slo_ownership_gap [get]
+func (api *SloOwnershipAPI) GetGaps(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO ownership gaps").Create(), api.Log)
+		return
+	}
+	userContext, err := GetUserContext(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO ownership gaps").Create(), api.Log)
+		return
+	}
+
+	gaps, err := api.Service.GetGaps(userContext, orgID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get SLO ownership gaps").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, gaps)
+}
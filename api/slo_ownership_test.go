@@ -0,0 +1,77 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("SloOwnershipAPI", func() {
+	var mockController *gomock.Controller
+	var sloOwnershipAPI *SloOwnershipAPI
+	var serviceMock *service.MockISloOwnershipService
+	logger, _ := logrustest.NewNullLogger()
+	userContext := auth.UserContext{ID: 3, Cookie: "test cookie"}
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockISloOwnershipService(mockController)
+		sloOwnershipAPI = &SloOwnershipAPI{
+			Service: serviceMock,
+			Log:     logger,
+		}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		userContextMiddleware := func(c *gin.Context) {
+			c.Set("UserContext", &userContext)
+		}
+		ginEngine.GET("/v1/org/:id/slo_ownership_gap", userContextMiddleware, sloOwnershipAPI.GetGaps)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetGaps()", func() {
+		It("returns 200 code with the gaps", func() {
+			serviceMock.EXPECT().GetGaps(&userContext, int64(99)).Return([]*model.SloOwnershipGap{
+				{SloID: 7, SloName: "checkout", Gaps: []model.SloOwnershipGapType{model.SloOwnershipGapNoOwner}},
+			}, nil)
+
+			w = httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/v1/org/99/slo_ownership_gap", nil)
+			ginEngine.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(MatchJSON(`[{"sloId":7,"sloName":"checkout","gaps":["no_owner"]}]`))
+		})
+
+		It("returns 500 code when grafana cannot be reached", func() {
+			serviceMock.EXPECT().GetGaps(&userContext, int64(99)).Return(nil, errory.GrafanaClientErrors.New("grafana"))
+
+			w = httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/v1/org/99/slo_ownership_gap", nil)
+			ginEngine.ServeHTTP(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/assertions"
@@ -16,10 +17,12 @@ import (
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
 
 	"github.com/gin-gonic/gin"
+	playgroundValidator "github.com/go-playground/validator/v10"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -30,6 +33,9 @@ var _ = Describe("SloAPI", func() {
 	var mockController *gomock.Controller
 	var sloAPI *SloAPI
 	var sloServiceMock *service.MockISloService
+	var budgetServiceMock *service.MockIErrorBudgetService
+	var changeRequestsMock *service.MockISloChangeRequestService
+	var versionsMock *service.MockIEntityVersionService
 	var validatorMock *validator.MockISLOValidator
 	var createScope context.Context
 	var updateScope context.Context
@@ -45,11 +51,17 @@ var _ = Describe("SloAPI", func() {
 	BeforeEach(func() {
 		mockController = gomock.NewController(GinkgoT())
 		sloServiceMock = service.NewMockISloService(mockController)
+		budgetServiceMock = service.NewMockIErrorBudgetService(mockController)
+		changeRequestsMock = service.NewMockISloChangeRequestService(mockController)
+		versionsMock = service.NewMockIEntityVersionService(mockController)
 		validatorMock = validator.NewMockISLOValidator(mockController)
 		sloAPI = &SloAPI{
-			SloService: sloServiceMock,
-			Validator:  validatorMock,
-			Log:        logger,
+			SloService:     sloServiceMock,
+			BudgetService:  budgetServiceMock,
+			ChangeRequests: changeRequestsMock,
+			Versions:       versionsMock,
+			Validator:      validatorMock,
+			Log:            logger,
 		}
 		gin.SetMode(gin.TestMode)
 		ginEngine = gin.New()
@@ -70,7 +82,12 @@ var _ = Describe("SloAPI", func() {
 			sloRoutes.PUT("/:id", userContextMiddleware, sloAPI.Update)
 			sloRoutes.GET("/:id", sloAPI.Get)
 			sloRoutes.DELETE("/:id", userContextMiddleware, sloAPI.Delete)
+			sloRoutes.GET("/:id/history", sloAPI.GetSloHistory)
+			sloRoutes.GET("/:id/budget", sloAPI.GetErrorBudget)
+			sloRoutes.GET("/:id/budget/forecast", sloAPI.GetBudgetForecast)
+			sloRoutes.POST("/:id/simulate", sloAPI.Simulate)
 			sloRoutes.DELETE("/:id/history", userContextMiddleware, sloAPI.DeleteSloHistory)
+			sloRoutes.GET("/:id/history/task/:taskId", sloAPI.GetSloHistoryDeletionTask)
 		}
 		createScope = context.WithValue(context.Background(), ctx.Create, true)
 		updateScope = context.WithValue(context.Background(), ctx.Create, false)
@@ -180,6 +197,62 @@ var _ = Describe("SloAPI", func() {
 					assertions.AssertLogger(logHook, "")
 				})
 			})
+
+			Context("SLO saved with warnings", func() {
+				BeforeEach(func() {
+					requestBody = fmt.Sprintf(`{
+						"orgId": %d,
+						"name": "test",
+						"successRateExpAvailability": "99.9",
+						"complianceExpAvailability": "99.99",
+						"externalId": "abc"
+					}`, orgID)
+
+					err = json.Unmarshal([]byte(requestBody), &slo)
+
+					validatorMock.EXPECT().Validate(createScope, slo).Times(1)
+					sloServiceMock.EXPECT().Create(&userContext, &slo).Times(1).Do(func(userContext *auth.UserContext, slo *model.Slo) {
+						slo.ID = createdSloID
+						slo.Warnings = []string{"datadog cannot be reached to validate externalId"}
+					})
+				})
+
+				It("returns 201 code with id and warnings", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(w.Code).To(Equal(http.StatusCreated))
+					Expect(w.Body.String()).To(MatchJSON(`{"id":9,"warnings":["datadog cannot be reached to validate externalId"]}`))
+				})
+			})
+
+			Context("latency SLO without availabilities", func() {
+				BeforeEach(func() {
+					requestBody = fmt.Sprintf(`{
+						"orgId": %d,
+						"name": "checkout latency",
+						"externalId": "abc",
+						"externalType": "time_slice",
+						"latencyPercentile": "p99",
+						"latencyThresholdMs": 300,
+						"latencyTarget": "99.50"
+					}`, orgID)
+
+					err = json.Unmarshal([]byte(requestBody), &slo)
+
+					validatorMock.EXPECT().Validate(createScope, slo).Times(1)
+					sloServiceMock.EXPECT().Create(&userContext, gomock.Any()).Times(1).Do(func(_ *auth.UserContext, created *model.Slo) {
+						Expect(created.SuccessRateExpectedAvailability).To(BeEmpty())
+						Expect(created.ComplianceExpectedAvailability).To(BeEmpty())
+						Expect(created.LatencyTarget).To(Equal("99.5"))
+						created.ID = createdSloID
+					})
+				})
+
+				It("returns 201 code and id of created SLO", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(w.Code).To(Equal(http.StatusCreated))
+					Expect(w.Body.String()).To(MatchJSON(`{"id":9}`))
+				})
+			})
 		})
 
 		Context("when slo name (required field) is not set", func() {
@@ -275,8 +348,10 @@ var _ = Describe("SloAPI", func() {
 	Describe("Update()", func() {
 		var (
 			requestBody string
+			ifMatch     string
 			err         error
 		)
+		version := int64(4)
 		const (
 			sloID        int64 = 33
 			createdSloID int64 = 9
@@ -285,8 +360,14 @@ var _ = Describe("SloAPI", func() {
 		JustBeforeEach(func() {
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("PUT", fmt.Sprintf("/v1/slo/%d", sloID), bytes.NewBufferString(requestBody))
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
 			ginEngine.ServeHTTP(w, req)
 		})
+		BeforeEach(func() {
+			ifMatch = `"4"`
+		})
 
 		Context("when the request succeeds", func() {
 			BeforeEach(func() {
@@ -305,7 +386,9 @@ var _ = Describe("SloAPI", func() {
 					return nil
 				})
 
-				sloServiceMock.EXPECT().Update(&userContext, gomock.Any()).Times(1).DoAndReturn(func(userContext *auth.UserContext, slo *model.Slo) error {
+				versionsMock.EXPECT().Verify(model.VersionedEntitySlo, sloID, &version).Return(nil)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionUpdate, sloID, gomock.Any(), &version, false).Return(nil, nil)
+				sloServiceMock.EXPECT().Update(&userContext, gomock.Any(), &version).Times(1).DoAndReturn(func(userContext *auth.UserContext, slo *model.Slo, version *int64) (int64, error) {
 					Expect(slo.OrgID).To(Equal(int64(1)))
 					Expect(slo.Name).To(Equal("test"))
 					Expect(slo.SuccessRateExpectedAvailability).To(Equal("99.9"))
@@ -313,7 +396,7 @@ var _ = Describe("SloAPI", func() {
 					Expect(userContext.ID).To(Equal(int64(3)))
 					Expect(userContext.Cookie).To(Equal("test cookie"))
 					Expect(slo.ComplianceExpectedAvailability).To(Equal("99.99"))
-					return nil
+					return int64(5), nil
 				})
 
 				expErr = nil
@@ -323,6 +406,7 @@ var _ = Describe("SloAPI", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(w.Code).To(Equal(http.StatusOK))
 				Expect(w.Body.String()).To(Equal(fmt.Sprintf(`{"id":%d}`, sloID)))
+				Expect(w.Header().Get("ETag")).To(Equal(`"5"`))
 				assertions.AssertAPIResponse(w.Body.String(), "", expErr)
 				assertions.AssertLogger(logHook, "")
 			})
@@ -345,14 +429,16 @@ var _ = Describe("SloAPI", func() {
 					return nil
 				})
 
-				sloServiceMock.EXPECT().Update(&userContext, gomock.Any()).Times(1).DoAndReturn(func(userContext *auth.UserContext, slo *model.Slo) error {
+				versionsMock.EXPECT().Verify(model.VersionedEntitySlo, sloID, &version).Return(nil)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionUpdate, sloID, gomock.Any(), &version, false).Return(nil, nil)
+				sloServiceMock.EXPECT().Update(&userContext, gomock.Any(), &version).Times(1).DoAndReturn(func(userContext *auth.UserContext, slo *model.Slo, version *int64) (int64, error) {
 					Expect(slo.OrgID).To(Equal(int64(1)))
 					Expect(slo.Name).To(Equal("test"))
 					Expect(slo.SuccessRateExpectedAvailability).To(Equal("10"))
 					Expect(slo.ComplianceExpectedAvailability).To(Equal("94.99"))
 					Expect(userContext.ID).To(Equal(int64(3)))
 					Expect(userContext.Cookie).To(Equal("test cookie"))
-					return nil
+					return int64(5), nil
 				})
 				expErr = nil
 			})
@@ -406,14 +492,16 @@ var _ = Describe("SloAPI", func() {
 					Expect(slo.ComplianceExpectedAvailability).To(Equal("99.99"))
 					return nil
 				})
-				sloServiceMock.EXPECT().Update(&userContext, gomock.Any()).Times(1).DoAndReturn(func(userContext *auth.UserContext, slo *model.Slo) error {
+				versionsMock.EXPECT().Verify(model.VersionedEntitySlo, sloID, &version).Return(nil)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionUpdate, sloID, gomock.Any(), &version, false).Return(nil, nil)
+				sloServiceMock.EXPECT().Update(&userContext, gomock.Any(), &version).Times(1).DoAndReturn(func(userContext *auth.UserContext, slo *model.Slo, version *int64) (int64, error) {
 					Expect(slo.OrgID).To(Equal(int64(1)))
 					Expect(slo.Name).To(Equal("test"))
 					Expect(slo.SuccessRateExpectedAvailability).To(Equal("99.9"))
 					Expect(slo.ComplianceExpectedAvailability).To(Equal("99.99"))
 					Expect(userContext.ID).To(Equal(int64(3)))
 					Expect(userContext.Cookie).To(Equal("test cookie"))
-					return someErr
+					return 0, someErr
 				})
 			})
 
@@ -424,19 +512,105 @@ var _ = Describe("SloAPI", func() {
 			})
 		})
 
+		Context("when the change needs approval", func() {
+			BeforeEach(func() {
+				requestBody = `{
+					"orgId": 1,
+					"name": "test",
+					"successRateExpAvailability": "99.9",
+					"complianceExpAvailability": "99.99",
+					"critical": true,
+					"externalSla": "99.5"
+				}`
+				validatorMock.EXPECT().Validate(updateScope, gomock.Any()).Return(nil)
+				versionsMock.EXPECT().Verify(model.VersionedEntitySlo, sloID, &version).Return(nil)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionUpdate, sloID, gomock.Any(), &version, false).
+					Return(&model.SloChangeRequest{ID: 4, SloID: sloID, Action: model.SloChangeActionUpdate, Status: model.SloChangeStatusPending}, nil)
+			})
+
+			It("returns 202 code with the pending change request without updating the SLO", func() {
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(w.Body.String()).To(ContainSubstring(`"id":4`))
+				Expect(w.Body.String()).To(ContainSubstring(`"status":"pending"`))
+			})
+		})
+
+		Context("when If-Match is missing", func() {
+			BeforeEach(func() {
+				requestBody = `{
+					"orgId": 1,
+					"name": "test",
+					"successRateExpAvailability": "99.9",
+					"complianceExpAvailability": "99.99"
+				}`
+				ifMatch = ""
+				validatorMock.EXPECT().Validate(updateScope, gomock.Any()).Return(nil)
+			})
+
+			It("returns 428 code", func() {
+				Expect(w.Code).To(Equal(http.StatusPreconditionRequired))
+			})
+		})
+
+		Context("when the SLO was changed since the version in If-Match", func() {
+			BeforeEach(func() {
+				requestBody = `{
+					"orgId": 1,
+					"name": "test",
+					"successRateExpAvailability": "99.9",
+					"complianceExpAvailability": "99.99"
+				}`
+				ifMatch = `W/"3"`
+				stale := int64(3)
+				validatorMock.EXPECT().Validate(updateScope, gomock.Any()).Return(nil)
+				versionsMock.EXPECT().Verify(model.VersionedEntitySlo, sloID, &stale).
+					Return(errory.PreconditionFailedErrors.New("slo 33 was changed meanwhile"))
+			})
+
+			It("returns 412 code without updating the SLO", func() {
+				Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+			})
+		})
+
+		Context("when If-Match is *", func() {
+			BeforeEach(func() {
+				requestBody = `{
+					"orgId": 1,
+					"name": "test",
+					"successRateExpAvailability": "99.9",
+					"complianceExpAvailability": "99.99"
+				}`
+				ifMatch = "*"
+				validatorMock.EXPECT().Validate(updateScope, gomock.Any()).Return(nil)
+				versionsMock.EXPECT().Verify(model.VersionedEntitySlo, sloID, nil).Return(nil)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionUpdate, sloID, gomock.Any(), nil, false).Return(nil, nil)
+				sloServiceMock.EXPECT().Update(&userContext, gomock.Any(), nil).Return(int64(9), nil)
+			})
+
+			It("returns 200 code with the new version", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("ETag")).To(Equal(`"9"`))
+			})
+		})
+
 	})
 
 	Describe("Delete()", func() {
 		const sloID int64 = 33
+		var url string
+		BeforeEach(func() {
+			url = fmt.Sprintf("/v1/slo/%d", sloID)
+		})
 		JustBeforeEach(func() {
 			w = httptest.NewRecorder()
-			req, _ = http.NewRequest("DELETE", fmt.Sprintf("/v1/slo/%d", sloID), nil)
+			req, _ = http.NewRequest("DELETE", url, nil)
 			ginEngine.ServeHTTP(w, req)
 		})
 
 		Context("when the request succeeds", func() {
 			BeforeEach(func() {
-				sloServiceMock.EXPECT().Delete(&userContext, sloID).Times(1)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionDelete, sloID, nil, nil, false).Return(nil, nil)
+				sloServiceMock.EXPECT().Delete(&userContext, sloID, false).Times(1)
 				expErr = nil
 			})
 
@@ -448,10 +622,33 @@ var _ = Describe("SloAPI", func() {
 			})
 		})
 
+		Context("when the slo is deleted with cascade", func() {
+			BeforeEach(func() {
+				url = fmt.Sprintf("/v1/slo/%d?cascade=true", sloID)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionDelete, sloID, nil, nil, true).Return(nil, nil)
+				sloServiceMock.EXPECT().Delete(&userContext, sloID, true).Times(1)
+			})
+
+			It("returns 200 code", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when cascade cannot be parsed", func() {
+			BeforeEach(func() {
+				url = fmt.Sprintf("/v1/slo/%d?cascade=maybe", sloID)
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		Context("when slo service returns 'NotFound' error", func() {
 			BeforeEach(func() {
 				expErr = errory.NotFoundErrors.Builder().WithMessage("slo not found").WithPayload("Slo", 1).Create()
-				sloServiceMock.EXPECT().Delete(&userContext, sloID).Times(1).Return(expErr)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionDelete, sloID, nil, nil, false).Return(nil, nil)
+				sloServiceMock.EXPECT().Delete(&userContext, sloID, false).Times(1).Return(expErr)
 			})
 
 			It("returns 404 code with proper message", func() {
@@ -465,7 +662,8 @@ var _ = Describe("SloAPI", func() {
 			BeforeEach(func() {
 				someErr := errory.ProviderErrors.New("test slo")
 				expErr = errory.OnDeleteErrors.Builder().Wrap(someErr).Create()
-				sloServiceMock.EXPECT().Delete(&userContext, sloID).Times(1).Return(someErr)
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionDelete, sloID, nil, nil, false).Return(nil, nil)
+				sloServiceMock.EXPECT().Delete(&userContext, sloID, false).Times(1).Return(someErr)
 			})
 
 			It("returns 500 code with proper message", func() {
@@ -474,35 +672,113 @@ var _ = Describe("SloAPI", func() {
 				assertions.AssertLogger(logHook, "ebt.api_error.on_delete_error: Cannot delete SLO, cause: ebt.provider_error: test slo")
 			})
 		})
+
+		Context("when the deletion needs approval", func() {
+			BeforeEach(func() {
+				changeRequestsMock.EXPECT().RequestIfRequired(&userContext, model.SloChangeActionDelete, sloID, nil, nil, false).
+					Return(&model.SloChangeRequest{ID: 5, SloID: sloID, Action: model.SloChangeActionDelete, Status: model.SloChangeStatusPending}, nil)
+			})
+
+			It("returns 202 code with the pending change request", func() {
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(w.Body.String()).To(ContainSubstring(`"action":"delete"`))
+			})
+		})
 	})
 
 	Describe("DeleteSloHistory()", func() {
 		const sloID int64 = 33
+		var query string
 		JustBeforeEach(func() {
 			w = httptest.NewRecorder()
-			req, _ = http.NewRequest("DELETE", fmt.Sprintf("/v1/slo/%d/history", sloID), nil)
+			req, _ = http.NewRequest("DELETE", fmt.Sprintf("/v1/slo/%d/history%s", sloID, query), nil)
 			ginEngine.ServeHTTP(w, req)
 		})
+		BeforeEach(func() {
+			query = "?from=2021-03-01T00:00:00Z&to=2021-03-15T00:00:00Z"
+		})
 
 		Context("when the request succeeds", func() {
 			BeforeEach(func() {
-				sloServiceMock.EXPECT().DeleteSloHistory(sloID).Times(1)
+				sloServiceMock.EXPECT().DeleteSloHistory(gomock.Any()).Times(1).
+					DoAndReturn(func(deletion *elasticModel.SloHistoryDeletion) error {
+						Expect(deletion.SloID).To(Equal(sloID))
+						Expect(deletion.From).To(Equal(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)))
+						Expect(deletion.To).To(Equal(time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)))
+						Expect(deletion.DryRun).To(BeFalse())
+						deletion.TaskID = "node:1"
+						return nil
+					})
 				expErr = nil
 			})
 
-			It("returns 200 code with proper message", func() {
-				Expect(w.Code).To(Equal(http.StatusOK))
-				Expect(w.Body.String()).To(Equal(fmt.Sprintf(`{"id":%d}`, sloID)))
-				assertions.AssertAPIResponse(w.Body.String(), "", expErr)
+			It("returns 202 code with deletion task", func() {
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+				Expect(w.Body.String()).To(MatchJSON(fmt.Sprintf(`{"sloId":%d,"from":"2021-03-01T00:00:00Z","to":"2021-03-15T00:00:00Z","dryRun":false,"taskId":"node:1"}`, sloID)))
 				assertions.AssertLogger(logHook, "")
 			})
 		})
 
+		Context("when the request is a dry run", func() {
+			BeforeEach(func() {
+				query += "&dryRun=true"
+				sloServiceMock.EXPECT().DeleteSloHistory(gomock.Any()).Times(1).
+					DoAndReturn(func(deletion *elasticModel.SloHistoryDeletion) error {
+						Expect(deletion.DryRun).To(BeTrue())
+						deletion.Matches = []*elasticModel.SloHistoryIndexCount{{Index: "oma-datadog-slo-2021.03", Count: 7}}
+						return nil
+					})
+			})
+
+			It("returns 200 code with matching document counts", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(fmt.Sprintf(`{"sloId":%d,"from":"2021-03-01T00:00:00Z","to":"2021-03-15T00:00:00Z","dryRun":true,
+					"matches":[{"index":"oma-datadog-slo-2021.03","count":7}]}`, sloID)))
+			})
+		})
+
+		Context("when range is omitted", func() {
+			BeforeEach(func() {
+				query = ""
+				sloServiceMock.EXPECT().DeleteSloHistory(gomock.Any()).Times(1).
+					DoAndReturn(func(deletion *elasticModel.SloHistoryDeletion) error {
+						Expect(deletion.To.Sub(deletion.From)).To(Equal(14 * 24 * time.Hour))
+						Expect(deletion.To).To(BeTemporally("~", time.Now(), time.Minute))
+						return nil
+					})
+			})
+
+			It("deletes the last 14 days", func() {
+				Expect(w.Code).To(Equal(http.StatusAccepted))
+			})
+		})
+
+		Context("when from is not before to", func() {
+			BeforeEach(func() {
+				query = "?from=2021-03-15T00:00:00Z&to=2021-03-01T00:00:00Z"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				assertions.AssertLogger(logHook, "ebt.api_error.on_delete_error: Cannot delete SLO History, cause: ebt.validation_error: from parameter has to be before to parameter")
+			})
+		})
+
+		Context("when from cannot be parsed", func() {
+			BeforeEach(func() {
+				query = "?from=yesterday"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
 		Context("when slo service returns not 'special' error", func() {
 			BeforeEach(func() {
 				someErr := errory.ProviderErrors.New("test slo")
 				expErr = errory.OnDeleteErrors.Builder().Wrap(someErr).Create()
-				sloServiceMock.EXPECT().DeleteSloHistory(sloID).Times(1).Return(someErr)
+				sloServiceMock.EXPECT().DeleteSloHistory(gomock.Any()).Times(1).Return(someErr)
 			})
 
 			It("returns 500 code with proper message", func() {
@@ -513,6 +789,264 @@ var _ = Describe("SloAPI", func() {
 		})
 	})
 
+	Describe("GetSloHistory()", func() {
+		const sloID int64 = 33
+		var query string
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", fmt.Sprintf("/v1/slo/%d/history%s", sloID, query), nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+		BeforeEach(func() {
+			query = "?from=2021-03-01T00:00:00Z&to=2021-03-15T00:00:00Z&interval=7d"
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				compliance, successRate := 99.5, 98.25
+				timeRange := &elasticModel.SloHistoryRange{
+					From: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+					To:   time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+				}
+				history := &elasticModel.SloHistory{
+					SloID:    sloID,
+					From:     timeRange.From,
+					To:       timeRange.To,
+					Interval: "7d",
+					Points: []*elasticModel.SloHistoryPoint{
+						{Time: timeRange.From, Compliance: &compliance, SuccessRate: &successRate},
+						{Time: time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)},
+					},
+				}
+				sloServiceMock.EXPECT().GetSloHistory(sloID, timeRange, "7d").Times(1).Return(history, nil)
+			})
+
+			It("returns 200 code with history points", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(fmt.Sprintf(`{"sloId":%d,"from":"2021-03-01T00:00:00Z","to":"2021-03-15T00:00:00Z","interval":"7d","points":[
+					{"time":"2021-03-01T00:00:00Z","compliance":99.5,"successRate":98.25,"goodWindows":null},
+					{"time":"2021-03-08T00:00:00Z","compliance":null,"successRate":null,"goodWindows":null}]}`, sloID)))
+			})
+		})
+
+		Context("when range and interval are omitted", func() {
+			BeforeEach(func() {
+				query = ""
+				sloServiceMock.EXPECT().Get(sloID).Times(1).Return(&model.Slo{ID: sloID, WindowType: model.SloWindowTypeRolling, WindowDays: 14}, nil)
+				sloServiceMock.EXPECT().GetSloHistory(sloID, gomock.Any(), "1h").Times(1).
+					DoAndReturn(func(sloID int64, timeRange *elasticModel.SloHistoryRange, interval string) (*elasticModel.SloHistory, error) {
+						Expect(timeRange.To.Sub(timeRange.From)).To(Equal(14 * 24 * time.Hour))
+						return &elasticModel.SloHistory{}, nil
+					})
+			})
+
+			It("returns hourly points of the current slo window", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when interval cannot be parsed", func() {
+			BeforeEach(func() {
+				query = "?from=2021-03-01T00:00:00Z&interval=1w"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when interval results in too many points", func() {
+			BeforeEach(func() {
+				query = "?from=2021-03-01T00:00:00Z&to=2021-03-15T00:00:00Z&interval=1m"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				assertions.AssertLogger(logHook, "ebt.api_error.on_get_error: Cannot get SLO History, cause: ebt.validation_error: "+
+					"interval parameter is too small for the given range, at most 2000 points are returned")
+			})
+		})
+
+		Context("when slo does not exist", func() {
+			BeforeEach(func() {
+				sloServiceMock.EXPECT().GetSloHistory(sloID, gomock.Any(), "7d").Times(1).Return(nil, errory.NotFoundErrors.New("slo"))
+			})
+
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GetErrorBudget()", func() {
+		const sloID int64 = 33
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", fmt.Sprintf("/v1/slo/%d/budget", sloID), nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				actual, remaining := 99.95, 0.5
+				budgetServiceMock.EXPECT().GetErrorBudget(sloID, gomock.Any()).Times(1).Return(&model.ErrorBudget{
+					SloID:       sloID,
+					Window:      model.SloWindow{Type: model.SloWindowTypeCalendar, Period: model.CalendarPeriodMonth},
+					From:        time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+					To:          time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+					MetricType:  model.MetricTypeSuccessRate,
+					Target:      99.9,
+					Actual:      &actual,
+					Remaining:   &remaining,
+					Maintenance: []model.MaintenancePeriod{},
+				}, nil)
+			})
+
+			It("returns 200 code with the budget", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(fmt.Sprintf(`{"sloId":%d,"window":{"type":"calendar","period":"month"},
+					"from":"2021-03-01T00:00:00Z","to":"2021-03-15T00:00:00Z","metricType":"successrate","target":99.9,
+					"actual":99.95,"remaining":0.5,"excludedDays":0,"maintenance":[]}`, sloID)))
+			})
+		})
+
+		Context("when slo does not exist", func() {
+			BeforeEach(func() {
+				budgetServiceMock.EXPECT().GetErrorBudget(sloID, gomock.Any()).Times(1).Return(nil, errory.NotFoundErrors.New("slo"))
+			})
+
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GetBudgetForecast()", func() {
+		const sloID int64 = 33
+		var lookback string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", fmt.Sprintf("/v1/slo/%d/budget/forecast?lookback=%s", sloID, lookback), nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				lookback = "14"
+				remaining, daysLeft := 0.4, 2.0
+				exhaustedAt := time.Date(2021, 3, 17, 0, 0, 0, 0, time.UTC)
+				budgetServiceMock.EXPECT().GetForecast(sloID, gomock.Any(), 14).Times(1).Return(&model.BudgetForecast{
+					SloID:        sloID,
+					SloName:      "checkout",
+					Window:       model.SloWindow{Type: model.SloWindowTypeCalendar, Period: model.CalendarPeriodMonth},
+					Horizon:      time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+					LookbackDays: 14,
+					Remaining:    &remaining,
+					Linear:       &model.BudgetProjection{BurnRate: 6, DaysLeft: &daysLeft, ExhaustedAt: &exhaustedAt, BeforeHorizon: true},
+				}, nil)
+			})
+
+			It("returns 200 code with the forecast for the look-back", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(fmt.Sprintf(`{"sloId":%d,"sloName":"checkout","critical":false,
+					"window":{"type":"calendar","period":"month"},"horizon":"2021-04-01T00:00:00Z","lookbackDays":14,"remaining":0.4,
+					"linear":{"burnRate":6,"daysLeft":2,"exhaustedAt":"2021-03-17T00:00:00Z","beforeHorizon":true},"ewma":null}`, sloID)))
+			})
+		})
+
+		Context("when lookback is out of range", func() {
+			BeforeEach(func() {
+				lookback = "365"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Simulate()", func() {
+		const sloID int64 = 33
+		from, to := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+		var requestBody string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", fmt.Sprintf("/v1/slo/%d/simulate", sloID), bytes.NewBufferString(requestBody))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				requestBody = `{"successRateExpAvailability": "99.95", "from": "2021-01-01T00:00:00Z", "to": "2021-03-01T00:00:00Z"}`
+				simulation := model.SloSimulation{SuccessRateExpectedAvailability: "99.95", From: from, To: to}
+				actual, consumed := 99.9, 2.0
+				validatorMock.EXPECT().Validate(context.Background(), simulation).Times(1).Return(nil)
+				budgetServiceMock.EXPECT().Simulate(sloID, &simulation).Times(1).Return(&model.SloSimulationResult{
+					SloID:           sloID,
+					MetricType:      model.MetricTypeSuccessRate,
+					CurrentTarget:   99.9,
+					Target:          99.95,
+					From:            from,
+					To:              to,
+					BreachedPeriods: 1,
+					Periods:         []model.SimulatedPeriod{{From: from, To: to, Actual: &actual, Consumed: &consumed, Breached: true}},
+					Alerts:          []model.SimulatedAlert{{Day: from, Alert: "ticket", LongBurnRate: 2, ShortBurnRate: 2}},
+				}, nil)
+			})
+
+			It("returns 200 code with the simulation result", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(fmt.Sprintf(`{"sloId":%d,"metricType":"successrate","currentTarget":99.9,"target":99.95,
+					"from":"2021-01-01T00:00:00Z","to":"2021-03-01T00:00:00Z","breachedPeriods":1,
+					"periods":[{"from":"2021-01-01T00:00:00Z","to":"2021-03-01T00:00:00Z","actual":99.9,"consumed":2,"breached":true}],
+					"alerts":[{"day":"2021-01-01T00:00:00Z","alert":"ticket","longBurnRate":2,"shortBurnRate":2}]}`, sloID)))
+			})
+		})
+
+		Context("when validator returns an error", func() {
+			BeforeEach(func() {
+				requestBody = `{"from": "2021-03-01T00:00:00Z", "to": "2021-01-01T00:00:00Z"}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Times(1).Return(errory.ValidationErrors.New("to must be greater than from"))
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("GetSloHistoryDeletionTask()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/slo/33/history/task/node:1", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				task := &elasticModel.SloHistoryDeletionTask{TaskID: "node:1", Total: 10, Deleted: 4}
+				sloServiceMock.EXPECT().GetSloHistoryDeletionTask(int64(33), "node:1").Times(1).Return(task, nil)
+			})
+
+			It("returns 200 code with task progress", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"taskId":"node:1","completed":false,"total":10,"deleted":4,"versionConflicts":0,"failures":0}`))
+			})
+		})
+
+		Context("when task is not found", func() {
+			BeforeEach(func() {
+				sloServiceMock.EXPECT().GetSloHistoryDeletionTask(int64(33), "node:1").Times(1).Return(nil, errory.NotFoundErrors.New("task"))
+			})
+
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
 	Describe("Get()", func() {
 		const sloID int64 = 33
 		JustBeforeEach(func() {
@@ -530,6 +1064,7 @@ var _ = Describe("SloAPI", func() {
 			}
 			BeforeEach(func() {
 				sloServiceMock.EXPECT().Get(sloID).Times(1).Return(&foundSLO, nil)
+				versionsMock.EXPECT().GetVersion(model.VersionedEntitySlo, sloID).Return(int64(4), nil)
 				expErr = nil
 			})
 
@@ -538,6 +1073,7 @@ var _ = Describe("SloAPI", func() {
 				err := json.Unmarshal(w.Body.Bytes(), &slo)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("ETag")).To(Equal(`"4"`))
 				Expect(slo.ID).To(Equal(sloID))
 				Expect(slo.Name).To(Equal("test2"))
 				Expect(slo.ComplianceExpectedAvailability).To(Equal("88.8"))
@@ -607,7 +1143,8 @@ var _ = Describe("SloAPI", func() {
 						OrgName: "test-org2",
 					},
 				}
-				sloServiceMock.EXPECT().GetDetailedSlos("", "").Times(1).Return(foundSLOs, nil)
+				sloServiceMock.EXPECT().GetSloPage(&model.SloPageQuery{Labels: map[string]string{}, Sort: model.SloSortFieldName}).
+					Times(1).Return(&model.SloPage{Slos: foundSLOs, Total: 2}, nil)
 			})
 
 			It("returns 200 code with proper message", func() {
@@ -644,7 +1181,8 @@ var _ = Describe("SloAPI", func() {
 						OrgName: "test-org",
 					},
 				}
-				sloServiceMock.EXPECT().GetDetailedSlos("test2", "test-org").Times(1).Return(foundSLOs, nil)
+				sloServiceMock.EXPECT().GetSloPage(&model.SloPageQuery{Name: "test2", OrgName: "test-org", Labels: map[string]string{},
+					Sort: model.SloSortFieldName}).Times(1).Return(&model.SloPage{Slos: foundSLOs, Total: 1}, nil)
 			})
 
 			It("returns 200 code with proper message", func() {
@@ -665,11 +1203,130 @@ var _ = Describe("SloAPI", func() {
 			var expErr error
 			BeforeEach(func() {
 				expErr = errory.ProviderErrors.Builder().WithMessage("database error").Create()
-				sloServiceMock.EXPECT().GetDetailedSlos("", "").Times(1).Return(nil, expErr)
+				sloServiceMock.EXPECT().GetSloPage(gomock.Any()).Times(1).Return(nil, expErr)
 			})
 			It("returns 500 code with proper message", func() {
 				Expect(w.Code).To(Equal(http.StatusInternalServerError))
 			})
 		})
+
+		Context("when labels are given", func() {
+			BeforeEach(func() {
+				path = "/v1/slo?label=team:checkout&label=tier:1"
+				sloServiceMock.EXPECT().GetSloPage(&model.SloPageQuery{Labels: map[string]string{"team": "checkout", "tier": "1"},
+					Sort: model.SloSortFieldName}).Times(1).Return(&model.SloPage{Slos: []*model.DetailedSlo{}}, nil)
+			})
+			It("returns 200 code", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+
+		Context("when a label is malformed", func() {
+			BeforeEach(func() {
+				path = "/v1/slo?label=team"
+			})
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when a page is requested", func() {
+			cursor := &model.SloCursor{Sort: model.SloSortFieldCreationDate, Descending: true, Value: "2022-01-02T00:00:00Z", ID: 12}
+			next := &model.SloCursor{Sort: model.SloSortFieldCreationDate, Descending: true, Value: "2022-01-01T00:00:00Z", ID: 45}
+
+			BeforeEach(func() {
+				path = "/v1/slo?sort=creationDate&order=desc&critical=true&externalType=&datasourceId=3&solutionId=8&namePrefix=ch&limit=1&cursor=" +
+					cursor.Encode()
+				critical, noType, datasourceID, solutionID := true, model.ExternalSloTypeNoType, int64(3), int64(8)
+				sloServiceMock.EXPECT().GetSloPage(&model.SloPageQuery{
+					NamePrefix:   "ch",
+					Critical:     &critical,
+					ExternalType: &noType,
+					DatasourceID: &datasourceID,
+					SolutionID:   &solutionID,
+					Labels:       map[string]string{},
+					Sort:         model.SloSortFieldCreationDate,
+					Descending:   true,
+					Limit:        1,
+					After:        cursor,
+				}).Times(1).Return(&model.SloPage{Slos: []*model.DetailedSlo{{Slo: model.Slo{ID: 45}}}, Total: 9, NextCursor: next}, nil)
+			})
+
+			It("returns 200 code with the total and the next cursor in the headers", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("X-Total-Count")).To(Equal("9"))
+				Expect(model.ParseSloCursor(w.Header().Get("X-Next-Cursor"))).To(Equal(next))
+			})
+		})
+
+		Context("when the sort field is unknown", func() {
+			BeforeEach(func() {
+				path = "/v1/slo?sort=owner"
+			})
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the limit is too large", func() {
+			BeforeEach(func() {
+				path = "/v1/slo?limit=1001"
+			})
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the cursor belongs to another sort order", func() {
+			BeforeEach(func() {
+				path = "/v1/slo?sort=name&cursor=" + (&model.SloCursor{Sort: model.SloSortFieldOrg, Value: "org", ID: 1}).Encode()
+			})
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
+
+// The window fields are checked by the validation rules of the Slo model, the custom rules of the
+// other fields are not needed for them.
+var _ = Describe("Slo window validation", func() {
+	var validate *playgroundValidator.Validate
+
+	BeforeEach(func() {
+		validate = playgroundValidator.New()
+		for _, rule := range []string{"required_for_update", "float_string", "gte_val", "lte_val", "max_precision"} {
+			Expect(validate.RegisterValidation(rule, func(playgroundValidator.FieldLevel) bool { return true })).To(Succeed())
+		}
+	})
+
+	It("rejects window fields which do not fit the window type", func() {
+		for _, test := range []struct {
+			name   string
+			slo    model.Slo
+			failed string
+		}{
+			{"rolling without days", model.Slo{WindowType: model.SloWindowTypeRolling}, "WindowDays"},
+			{"rolling with period", model.Slo{WindowType: model.SloWindowTypeRolling, WindowDays: 7, WindowPeriod: model.CalendarPeriodWeek}, "WindowDays"},
+			{"calendar without period", model.Slo{WindowType: model.SloWindowTypeCalendar}, "WindowPeriod"},
+			{"calendar with days", model.Slo{WindowType: model.SloWindowTypeCalendar, WindowDays: 7, WindowPeriod: model.CalendarPeriodWeek}, "WindowDays"},
+			{"period without window type", model.Slo{WindowPeriod: model.CalendarPeriodMonth}, "WindowPeriod"},
+		} {
+			err := validate.StructPartial(test.slo, "WindowType", "WindowDays", "WindowPeriod")
+
+			Expect(err).To(HaveOccurred(), test.name)
+			Expect(err.(playgroundValidator.ValidationErrors)[0].Field()).To(Equal(test.failed), test.name)
+		}
+	})
+
+	It("accepts window fields which fit the window type", func() {
+		for _, slo := range []model.Slo{
+			{},
+			{WindowDays: 7},
+			{WindowType: model.SloWindowTypeRolling, WindowDays: 7},
+			{WindowType: model.SloWindowTypeCalendar, WindowPeriod: model.CalendarPeriodQuarter},
+		} {
+			Expect(validate.StructPartial(slo, "WindowType", "WindowDays", "WindowPeriod")).To(Succeed())
+		}
 	})
 })
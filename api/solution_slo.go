@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/middleware"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
 	"github.com/sirupsen/logrus"
 )
@@ -25,14 +26,13 @@ type SolutionSloAPI struct {
 func (api *SolutionSloAPI) GetSolutionSlo(c *gin.Context) {
 	orgNameQuery := c.Query("orgName")
 	if orgNameQuery == "" {
-		c.String(http.StatusBadRequest, "Param 'orgName' is manadatory!")
+		middleware.WriteProblem(c, middleware.NewProblem(c, errory.ValidationErrors.New("Param 'orgName' is manadatory!")))
 		return
 	}
 	solutionSlo, err := api.SloService.GetSolutionSlo(orgNameQuery)
 	if err != nil {
-		details := errory.GetErrorDetails(err)
-		if details.Status == http.StatusNotFound {
-			c.JSON(details.Status, Message{Message: details.Message})
+		if errory.GetErrorDetails(err).Status == http.StatusNotFound {
+			middleware.WriteProblem(c, middleware.NewProblem(c, err))
 		} else {
 			setErrorResponse(c, err, api.Log)
 		}
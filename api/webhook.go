@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookAPI struct {
+	Service   service.IWebhookService
+	Validator validator.ITranslatedValidator
+	Log       logrus.FieldLogger
+}
+
+// @Summary Get Webhooks
+// @Description Returns all webhooks of the Organization without their secrets
+// @Tags webhooks
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Success 200 {array} model.Webhook
+// @Router /org/{id}/webhook [get]
+func (api *WebhookAPI) GetAll(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get webhooks").Create(), api.Log)
+		return
+	}
+
+	webhooks, err := api.Service.GetByOrgID(orgID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get webhooks").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// @Summary Get Webhook
+// @Description Returns Webhook without its secret
+// @Tags webhooks
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param webhookId path int true "Webhook ID"
+// @Success 200 {object} model.Webhook
+// @Router /org/{id}/webhook/{webhookId} [get]
+func (api *WebhookAPI) Get(c *gin.Context) {
+	orgID, webhookID, err := getWebhookParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get webhook").Create(), api.Log)
+		return
+	}
+
+	webhook, err := api.Service.Get(orgID, webhookID)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get webhook").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// @Summary Add Webhook
+// @Description Registers a webhook which is notified of the subscribed events of the Organization: slo.created,
+// @Description slo.updated, slo.deleted, plugin.enabled, budget.threshold_crossed and budget.threshold_recovered.
+// @Description Deliveries are signed with the secret, a secret is generated when none is given. The response is
+// @Description the only one containing the secret.
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param webhook body model.Webhook true "id and orgId are not checked"
+// @Success 201 {object} model.Webhook
+// @Router /org/{id}/webhook [post]
+func (api *WebhookAPI) Create(c *gin.Context) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create webhook").Create(), api.Log)
+		return
+	}
+
+	webhook, err := api.extractAndValidateWebhook(c, orgID, 0)
+	if err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create webhook").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Create(webhook); err != nil {
+		setErrorResponse(c, errory.OnCreateErrors.Builder().Wrap(err).WithMessage("Cannot create webhook").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusCreated, webhook)
+}
+
+// @Summary Update Webhook
+// @Description Updates Webhook, the secret is kept when none is given
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param webhookId path int true "Webhook ID"
+// @Param webhook body model.Webhook true "id and orgId are not checked"
+// @Success 200 {object} api.ID
+// @Router /org/{id}/webhook/{webhookId} [put]
+func (api *WebhookAPI) Update(c *gin.Context) {
+	orgID, webhookID, err := getWebhookParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update webhook").Create(), api.Log)
+		return
+	}
+
+	webhook, err := api.extractAndValidateWebhook(c, orgID, webhookID)
+	if err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update webhook").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Update(webhook); err != nil {
+		setErrorResponse(c, errory.OnUpdateErrors.Builder().Wrap(err).WithMessage("Cannot update webhook").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusOK, webhook.ID, c)
+}
+
+// @Summary Delete Webhook
+// @Description Deletes Webhook with its deliveries
+// @Tags webhooks
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param webhookId path int true "Webhook ID"
+// @Success 200 {object} api.ID
+// @Router /org/{id}/webhook/{webhookId} [delete]
+func (api *WebhookAPI) Delete(c *gin.Context) {
+	orgID, webhookID, err := getWebhookParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete webhook").Create(), api.Log)
+		return
+	}
+
+	if err = api.Service.Delete(orgID, webhookID); err != nil {
+		setErrorResponse(c, errory.OnDeleteErrors.Builder().Wrap(err).WithMessage("Cannot delete webhook").Create(), api.Log)
+		return
+	}
+
+	setIDResponse(http.StatusOK, webhookID, c)
+}
+
+// @Summary Get Webhook Deliveries
+// @Description Returns the latest 100 deliveries of the Webhook, newest first, with the payload sent and
+// @Description the outcome of the last attempt
+// @Tags webhooks
+// @Produce  json
+// @Param Authorization header string true "Bearer token | Basic auth | Cookie grafana_session"
+// @Param id path int true "Organization ID"
+// @Param webhookId path int true "Webhook ID"
+// @Param status query string false "pending, delivered or failed, default all"
+// @Success 200 {array} model.WebhookDelivery
+// @Router /org/{id}/webhook/{webhookId}/delivery [get]
+func (api *WebhookAPI) GetDeliveries(c *gin.Context) {
+	orgID, webhookID, err := getWebhookParams(c)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get webhook deliveries").Create(), api.Log)
+		return
+	}
+
+	status := model.WebhookDeliveryStatus(c.Query("status"))
+	switch status {
+	case "", model.WebhookDeliveryStatusPending, model.WebhookDeliveryStatusDelivered, model.WebhookDeliveryStatusFailed:
+	default:
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(errory.ParseErrors.New("status parameter cannot be parsed")).
+			WithMessage("Cannot get webhook deliveries").Create(), api.Log)
+		return
+	}
+
+	deliveries, err := api.Service.GetDeliveries(orgID, webhookID, status)
+	if err != nil {
+		setErrorResponse(c, errory.OnGetErrors.Builder().Wrap(err).WithMessage("Cannot get webhook deliveries").Create(), api.Log)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+func getWebhookParams(c *gin.Context) (int64, int64, error) {
+	orgID, err := GetIDParam(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	webhookID, err := GetInt64Param(c, "webhookId")
+	if err != nil {
+		return 0, 0, err
+	}
+	return orgID, webhookID, nil
+}
+
+func (api *WebhookAPI) extractAndValidateWebhook(c *gin.Context, orgID, webhookID int64) (*model.Webhook, error) {
+	var webhook model.Webhook
+	if err := c.ShouldBindBodyWith(&webhook, binding.JSON); err != nil {
+		return nil, errory.GetValidationError(err, webhook)
+	}
+	webhook.ID = webhookID
+	webhook.OrgID = orgID
+
+	if err := api.Validator.Validate(context.Background(), webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
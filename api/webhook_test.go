@@ -0,0 +1,209 @@
+//go:build unitTests
+// +build unitTests
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("WebhookAPI", func() {
+	var mockController *gomock.Controller
+	var webhookAPI *WebhookAPI
+	var serviceMock *service.MockIWebhookService
+	var validatorMock *validator.MockITranslatedValidator
+	logger, _ := logrustest.NewNullLogger()
+
+	var ginEngine *gin.Engine
+	var w *httptest.ResponseRecorder
+	var req *http.Request
+
+	webhook := model.Webhook{
+		OrgID:   99,
+		Name:    "chat",
+		URL:     "https://chat.example.com/hooks/slo",
+		Events:  []model.WebhookEventType{model.WebhookEventSloCreated, model.WebhookEventBudgetThresholdCrossed},
+		Enabled: true,
+	}
+	const body = `{"name": "chat", "url": "https://chat.example.com/hooks/slo", "events": ["slo.created", "budget.threshold_crossed"],
+		"enabled": true}`
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		serviceMock = service.NewMockIWebhookService(mockController)
+		validatorMock = validator.NewMockITranslatedValidator(mockController)
+		webhookAPI = &WebhookAPI{
+			Service:   serviceMock,
+			Validator: validatorMock,
+			Log:       logger,
+		}
+
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.GET("/v1/org/:id/webhook", webhookAPI.GetAll)
+		ginEngine.POST("/v1/org/:id/webhook", webhookAPI.Create)
+		ginEngine.GET("/v1/org/:id/webhook/:webhookId", webhookAPI.Get)
+		ginEngine.PUT("/v1/org/:id/webhook/:webhookId", webhookAPI.Update)
+		ginEngine.DELETE("/v1/org/:id/webhook/:webhookId", webhookAPI.Delete)
+		ginEngine.GET("/v1/org/:id/webhook/:webhookId/delivery", webhookAPI.GetDeliveries)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetAll()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/webhook", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				listed := webhook
+				listed.ID = 3
+				serviceMock.EXPECT().GetByOrgID(int64(99)).Return([]*model.Webhook{&listed}, nil)
+			})
+
+			It("returns 200 code with webhooks of the organization", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"id":3,"orgId":99,"name":"chat","url":"https://chat.example.com/hooks/slo",
+					"events":["slo.created","budget.threshold_crossed"],"enabled":true}]`))
+			})
+		})
+	})
+
+	Describe("Create()", func() {
+		var requestBody string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("POST", "/v1/org/99/webhook", bytes.NewBufferString(requestBody))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				requestBody = body
+				validatorMock.EXPECT().Validate(context.Background(), webhook).Return(nil)
+				serviceMock.EXPECT().Create(&webhook).DoAndReturn(func(created *model.Webhook) error {
+					created.ID = 3
+					created.Secret = "0123456789abcdef"
+					return nil
+				})
+			})
+
+			It("returns 201 code with the webhook and its generated secret", func() {
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				var created model.Webhook
+				Expect(json.Unmarshal(w.Body.Bytes(), &created)).To(Succeed())
+				Expect(created.ID).To(Equal(int64(3)))
+				Expect(created.Secret).To(Equal("0123456789abcdef"))
+			})
+		})
+
+		Context("when validator returns an error", func() {
+			BeforeEach(func() {
+				requestBody = `{"name": "no events", "url": "https://chat.example.com"}`
+				validatorMock.EXPECT().Validate(context.Background(), gomock.Any()).Return(errory.ValidationErrors.New("events is required"))
+			})
+
+			It("returns 400 code without creating the webhook", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Update()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("PUT", "/v1/org/99/webhook/3", bytes.NewBufferString(body))
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when webhook belongs to another organization", func() {
+			BeforeEach(func() {
+				updated := webhook
+				updated.ID = 3
+				validatorMock.EXPECT().Validate(context.Background(), updated).Return(nil)
+				serviceMock.EXPECT().Update(&updated).Return(errory.NotFoundErrors.New("webhook"))
+			})
+
+			It("returns 404 code", func() {
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("Delete()", func() {
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("DELETE", "/v1/org/99/webhook/3", nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				serviceMock.EXPECT().Delete(int64(99), int64(3)).Return(nil)
+			})
+
+			It("returns 200 code with the id", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`{"id":3}`))
+			})
+		})
+	})
+
+	Describe("GetDeliveries()", func() {
+		var status string
+
+		JustBeforeEach(func() {
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/v1/org/99/webhook/3/delivery?status="+status, nil)
+			ginEngine.ServeHTTP(w, req)
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				status = "failed"
+				serviceMock.EXPECT().GetDeliveries(int64(99), int64(3), model.WebhookDeliveryStatusFailed).Return([]*model.WebhookDelivery{
+					{ID: 12, WebhookID: 3, OrgID: 99, Event: model.WebhookEventSloCreated, Payload: json.RawMessage(`{"event":"slo.created"}`),
+						Status: model.WebhookDeliveryStatusFailed, Attempts: 8, Error: "connection refused"},
+				}, nil)
+			})
+
+			It("returns 200 code with the deliveries of the status", func() {
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body.String()).To(MatchJSON(`[{"id":12,"webhookId":3,"orgId":99,"event":"slo.created","payload":{"event":"slo.created"},
+					"status":"failed","attempts":8,"url":"","createdAt":"0001-01-01T00:00:00Z","error":"connection refused"}]`))
+			})
+		})
+
+		Context("when status is unknown", func() {
+			BeforeEach(func() {
+				status = "lost"
+			})
+
+			It("returns 400 code", func() {
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
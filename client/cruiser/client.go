@@ -0,0 +1,337 @@
+package cruiser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+)
+
+const (
+	healthAPIPath        = "/api/health"
+	configureUserAPIPath = "/v1/configure_user"
+
+	idempotencyKeyHeader = "Idempotency-Key"
+	ifMatchHeader        = "If-Match"
+	etagHeader           = "ETag"
+	sessionCookie        = "grafana_session"
+)
+
+// IClient covers the routes of the controller API. Every call takes the context of the request
+// it is made for, failed calls return an *Error unless the API could not be reached.
+type IClient interface {
+	Health(ctx context.Context) error
+	ConfigureUser(ctx context.Context) (*grafanaModel.UserInfo, error)
+
+	ListSlos(ctx context.Context, options *SloListOptions) (*DetailedSloPage, error)
+	AllSlos(ctx context.Context, options *SloListOptions) ([]*model.DetailedSlo, error)
+	GetSlo(ctx context.Context, sloID int64) (*model.Slo, string, error)
+	CreateSlo(ctx context.Context, slo *model.Slo, idempotencyKey string) (int64, error)
+	UpdateSlo(ctx context.Context, slo *model.Slo, ifMatch string) (*UpdateResult, error)
+	DeleteSlo(ctx context.Context, sloID int64, cascade bool) (*UpdateResult, error)
+	ExecuteSloBatch(ctx context.Context, batch *model.SloBatch, idempotencyKey string) (*model.SloBatchResult, error)
+	GetSloHistory(ctx context.Context, sloID int64, options *SloHistoryOptions) (*elasticModel.SloHistory, error)
+	DeleteSloHistory(ctx context.Context, sloID int64, options *SloHistoryDeletionOptions) (*elasticModel.SloHistoryDeletion, error)
+	GetSloHistoryDeletionTask(ctx context.Context, sloID int64, taskID string) (*elasticModel.SloHistoryDeletionTask, error)
+	GetErrorBudget(ctx context.Context, sloID int64) (*model.ErrorBudget, error)
+	GetBudgetForecast(ctx context.Context, sloID int64, lookbackDays int) (*model.BudgetForecast, error)
+	SimulateSlo(ctx context.Context, sloID int64, simulation *model.SloSimulation) (*model.SloSimulationResult, error)
+
+	GetOrg(ctx context.Context, orgID int64) (*grafanaModel.Organization, error)
+	ListOrgSlos(ctx context.Context, orgID int64, options *SloListOptions) (*SloPage, error)
+	AllOrgSlos(ctx context.Context, orgID int64, options *SloListOptions) ([]*model.Slo, error)
+	FindOrgSlos(ctx context.Context, orgID int64, params *model.SloQueryParams) ([]*model.Slo, error)
+	GetAtRiskSlos(ctx context.Context, orgID int64, lookbackDays int) ([]*model.BudgetForecast, error)
+	GetOrgDatasources(ctx context.Context, orgID int64) ([]*grafanaModel.Datasource, error)
+	DiscoverDatadogSlos(ctx context.Context, orgID, datasourceID int64) ([]*datadogModel.SLO, error)
+	ImportDatadogSlos(ctx context.Context, orgID, datasourceID int64, sloImport *model.SloImport) ([]*model.SloImportResult, error)
+	ListMaintenanceWindows(ctx context.Context, orgID int64) ([]*model.MaintenanceWindow, error)
+	GetMaintenanceWindow(ctx context.Context, orgID, windowID int64) (*model.MaintenanceWindow, error)
+	CreateMaintenanceWindow(ctx context.Context, orgID int64, window *model.MaintenanceWindow) (int64, error)
+	UpdateMaintenanceWindow(ctx context.Context, orgID int64, window *model.MaintenanceWindow) error
+	DeleteMaintenanceWindow(ctx context.Context, orgID, windowID int64) error
+	ListBudgetPolicies(ctx context.Context, orgID int64) ([]*model.BudgetPolicy, error)
+	GetBudgetPolicy(ctx context.Context, orgID, policyID int64) (*model.BudgetPolicy, error)
+	CreateBudgetPolicy(ctx context.Context, orgID int64, policy *model.BudgetPolicy) (int64, error)
+	UpdateBudgetPolicy(ctx context.Context, orgID int64, policy *model.BudgetPolicy) error
+	DeleteBudgetPolicy(ctx context.Context, orgID, policyID int64) error
+	GetDeployGate(ctx context.Context, orgID int64) (*model.DeployGate, error)
+	ListDeployFreezes(ctx context.Context, orgID int64) ([]*model.DeployFreeze, error)
+	ListComplianceReports(ctx context.Context, orgID int64) ([]*model.ComplianceReport, error)
+	CreateComplianceReport(ctx context.Context, orgID int64, request *model.ComplianceReportRequest) (*model.ComplianceReport, error)
+	DownloadComplianceReport(ctx context.Context, orgID, reportID int64) ([]byte, error)
+	ListSlaBreaches(ctx context.Context, orgID int64, from, to time.Time) ([]*model.SlaBreach, error)
+	GetSlaCreditTable(ctx context.Context, orgID, solutionID int64) (*model.SlaCreditTable, error)
+	UpdateSlaCreditTable(ctx context.Context, orgID, solutionID int64, table *model.SlaCreditTable) (*model.SlaCreditTable, error)
+	GetSloLabelKeys(ctx context.Context, orgID int64) (*model.SloLabelKeys, error)
+	UpdateSloLabelKeys(ctx context.Context, orgID int64, keys *model.SloLabelKeys) (*model.SloLabelKeys, error)
+	ListSloOwnershipGaps(ctx context.Context, orgID int64) ([]*model.SloOwnershipGap, error)
+	ListSloChangeRequests(ctx context.Context, orgID int64, status model.SloChangeStatus) ([]*model.SloChangeRequest, error)
+	ApproveSloChangeRequest(ctx context.Context, orgID, requestID int64, decision *model.SloChangeDecision) (*model.SloChangeRequest, error)
+	RejectSloChangeRequest(ctx context.Context, orgID, requestID int64, decision *model.SloChangeDecision) (*model.SloChangeRequest, error)
+	ListWebhooks(ctx context.Context, orgID int64) ([]*model.Webhook, error)
+	GetWebhook(ctx context.Context, orgID, webhookID int64) (*model.Webhook, error)
+	CreateWebhook(ctx context.Context, orgID int64, webhook *model.Webhook) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, orgID int64, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, orgID, webhookID int64) error
+	ListWebhookDeliveries(ctx context.Context, orgID, webhookID int64, status model.WebhookDeliveryStatus) ([]*model.WebhookDelivery, error)
+	EnablePlugin(ctx context.Context, orgID int64, skipDatasources bool) error
+
+	GetUserHappiness(ctx context.Context, orgID int64) ([]*model.HappinessMetric, error)
+	GetTeamHappiness(ctx context.Context, orgID int64) ([]*model.HappinessMetric, error)
+	SaveTeamHappinessAverage(ctx context.Context, orgID int64) (int64, error)
+	GetUsersMissingInput(ctx context.Context, orgID int64) ([]*model.UserMissingInput, error)
+	GetHappinessMetric(ctx context.Context, metricID int64) (*model.HappinessMetric, string, error)
+	CreateHappinessMetric(ctx context.Context, metric *model.HappinessMetric, idempotencyKey string) (int64, error)
+	UpdateHappinessMetric(ctx context.Context, metric *model.HappinessMetric, ifMatch string) (*UpdateResult, error)
+	DeleteHappinessMetric(ctx context.Context, metricID int64) error
+
+	ListFeedback(ctx context.Context, orgID int64) ([]*model.Feedback, error)
+	CreateFeedback(ctx context.Context, feedback *model.Feedback, idempotencyKey string) (int64, error)
+
+	ListRecommendationVotes(ctx context.Context, orgID *int64) ([]*model.RecommendationVote, error)
+	CreateRecommendationVote(ctx context.Context, vote *model.RecommendationVote) (int64, error)
+	DeleteRecommendationVote(ctx context.Context, vote *model.RecommendationVote) error
+
+	GetDatasource(ctx context.Context, datasourceID int64) (*grafanaModel.Datasource, error)
+	GetSolutions(ctx context.Context, options *SolutionsOptions) ([]*model.Solution, error)
+	GetSolutionSlo(ctx context.Context, orgName string) (*model.SolutionSlo, error)
+	GetProductsStatus(ctx context.Context) ([]*model.ProductStatus, error)
+	GetSDAConfig(ctx context.Context) ([]map[string]interface{}, error)
+	Search(ctx context.Context, query string, types []model.SearchHitType, limit int) ([]*model.SearchHit, error)
+}
+
+// Auth adds the credentials of the caller to a request.
+type Auth func(req *http.Request)
+
+// BearerAuth authenticates with an IDAM token.
+func BearerAuth(token string) Auth {
+	return func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+}
+
+// BasicAuth authenticates with the Grafana login of the user.
+func BasicAuth(username, password string) Auth {
+	return func(req *http.Request) {
+		req.SetBasicAuth(username, password)
+	}
+}
+
+// CookieAuth authenticates with the Grafana session of the user.
+func CookieAuth(session string) Auth {
+	return func(req *http.Request) {
+		req.AddCookie(&http.Cookie{Name: sessionCookie, Value: session})
+	}
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with the given client instead of one with a 30s timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.Client = httpClient
+	}
+}
+
+type Client struct {
+	baseURL string
+	auth    Auth
+	*http.Client
+}
+
+// New returns a client of the API at baseURL, e.g. https://oma.metro.digital/ebt, authenticating
+// every request with auth.
+func New(baseURL string, auth Auth, options ...Option) (*Client, error) {
+	_, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+
+	c := &Client{
+		baseURL,
+		auth,
+		&http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c, nil
+}
+
+// UpdateResult is the outcome of a change. ETag is the version of the changed entity, to be sent
+// as If-Match on the next update. A change waiting for the approval of an organization Admin is
+// not applied yet, ChangeRequest is set instead.
+type UpdateResult struct {
+	ID            int64
+	ETag          string
+	ChangeRequest *model.SloChangeRequest
+}
+
+// Pending tells whether the change waits for approval.
+func (r *UpdateResult) Pending() bool {
+	return r.ChangeRequest != nil
+}
+
+// Health returns nil when the API is up, it does not need to be authenticated.
+func (c *Client) Health(ctx context.Context) error {
+	_, err := c.send(ctx, &request{method: http.MethodGet, path: healthAPIPath}, nil)
+	return err
+}
+
+// ConfigureUser returns the user the client is authenticated as, the user is set up in Grafana
+// on first use.
+func (c *Client) ConfigureUser(ctx context.Context) (*grafanaModel.UserInfo, error) {
+	var userInfo grafanaModel.UserInfo
+	if _, err := c.send(ctx, &request{method: http.MethodGet, path: configureUserAPIPath}, &userInfo); err != nil {
+		return nil, err
+	}
+	return &userInfo, nil
+}
+
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   interface{}
+}
+
+type response struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// send makes the request and decodes the JSON body of a successful response into result, when given.
+// Error responses are returned as *Error.
+func (c *Client) send(ctx context.Context, r *request, result interface{}) (*response, error) {
+	req, err := c.newRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errory.FetchResourceErrors.Builder().Wrap(err).WithPayload("path", r.path).Create()
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Builder().Wrap(err).WithPayload("path", r.path).Create()
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, newError(resp.StatusCode, data)
+	}
+
+	decoded := &response{status: resp.StatusCode, header: resp.Header, body: data}
+	if result != nil {
+		if err := decode(decoded, result); err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
+
+func decode(r *response, result interface{}) error {
+	if len(r.body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.body, result); err != nil {
+		return errory.FetchResourceErrors.Builder().Wrap(err).WithMessage("response cannot be parsed").Create()
+	}
+	return nil
+}
+
+func (c *Client) newRequest(ctx context.Context, r *request) (*http.Request, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+	u.Path = path.Join(u.Path, r.path)
+	u.RawQuery = r.query.Encode()
+
+	var body io.Reader
+	if r.body != nil {
+		data, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, errory.ParseErrors.Wrap(err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u.String(), body)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+	for key, values := range r.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.auth != nil {
+		c.auth(req)
+	}
+	return req, nil
+}
+
+func (c *Client) get(ctx context.Context, apiPath string, query url.Values, result interface{}) error {
+	_, err := c.send(ctx, &request{method: http.MethodGet, path: apiPath, query: query}, result)
+	return err
+}
+
+// create sends the entity and returns the ID it was created with.
+func (c *Client) create(ctx context.Context, apiPath string, header http.Header, entity interface{}) (int64, error) {
+	var created idResponse
+	if _, err := c.send(ctx, &request{method: http.MethodPost, path: apiPath, header: header, body: entity}, &created); err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+func idempotencyKeyHeaders(key string) http.Header {
+	header := http.Header{}
+	if key != "" {
+		header.Set(idempotencyKeyHeader, key)
+	}
+	return header
+}
+
+// ifMatchHeaders sends the ETag an update is based on, * overwrites any version.
+func ifMatchHeaders(ifMatch string) http.Header {
+	header := http.Header{}
+	if ifMatch != "" {
+		header.Set(ifMatchHeader, ifMatch)
+	}
+	return header
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+type idResponse struct {
+	ID int64 `json:"id"`
+}
@@ -0,0 +1,330 @@
+//go:build unitTests
+// +build unitTests
+
+package cruiser_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/cruiser"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+var _ = Describe("Client", func() {
+	var server *ghttp.Server
+	var client *Client
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		var err error
+		client, err = New(server.URL()+"/ebt", BearerAuth("token"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("New(baseURL, auth, options...)", func() {
+		Context("when baseURL is unparseable", func() {
+			It("should return error", func() {
+				newClient, err := New(`ś://nourl`, BearerAuth("token"))
+				Expect(newClient).To(BeNil())
+				Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+			})
+		})
+
+		Context("when an http client is given", func() {
+			It("should send the requests with it", func() {
+				transport := &countingTransport{}
+				client, err := New(server.URL(), nil, WithHTTPClient(&http.Client{Transport: transport}))
+				Expect(err).NotTo(HaveOccurred())
+				server.AppendHandlers(ghttp.RespondWith(http.StatusNoContent, ""))
+
+				Expect(client.Health(ctx)).To(Succeed())
+				Expect(transport.requests).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("authentication", func() {
+		It("should send the bearer token", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/ebt/v1/configure_user"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer token"),
+				ghttp.RespondWith(http.StatusOK, `{"login": "jane"}`),
+			))
+
+			userInfo, err := client.ConfigureUser(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userInfo.Login).To(Equal("jane"))
+		})
+
+		It("should send the basic auth", func() {
+			client, err := New(server.URL()+"/ebt", BasicAuth("jane", "secret"))
+			Expect(err).NotTo(HaveOccurred())
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyBasicAuth("jane", "secret"),
+				ghttp.RespondWith(http.StatusOK, `{"login": "jane"}`),
+			))
+
+			_, err = client.ConfigureUser(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should send the grafana session cookie", func() {
+			client, err := New(server.URL()+"/ebt", CookieAuth("session"))
+			Expect(err).NotTo(HaveOccurred())
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyHeaderKV("Cookie", "grafana_session=session"),
+				ghttp.RespondWith(http.StatusOK, `{"login": "jane"}`),
+			))
+
+			_, err = client.ConfigureUser(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("errors", func() {
+		Context("when the api responds with a problem", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{
+					"type": "https://oma.metro.digital/ebt/problems/not-found",
+					"title": "Not Found",
+					"status": 404,
+					"detail": "Cannot get SLO",
+					"correlationId": "abc",
+					"retryable": false,
+					"message": "Cannot get SLO"
+				}`, http.Header{"Content-Type": []string{"application/problem+json"}}))
+			})
+
+			It("should return the problem as error", func() {
+				_, _, err := client.GetSlo(ctx, 7)
+
+				apiErr, ok := AsError(err)
+				Expect(ok).To(BeTrue())
+				Expect(apiErr.Status).To(Equal(http.StatusNotFound))
+				Expect(apiErr.Problem.Detail).To(Equal("Cannot get SLO"))
+				Expect(apiErr.Problem.CorrelationID).To(Equal("abc"))
+				Expect(apiErr.Error()).To(Equal("cruiser api responded 404 Not Found: Cannot get SLO (correlation id abc)"))
+				Expect(IsNotFound(err)).To(BeTrue())
+				Expect(IsConflict(err)).To(BeFalse())
+				Expect(IsRetryable(err)).To(BeFalse())
+				Expect(IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+
+		Context("when the problem type is a subtype", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, `{
+					"type": "https://oma.metro.digital/ebt/problems/delete-last-metric-forbidden",
+					"title": "Forbidden",
+					"status": 403
+				}`))
+			})
+
+			It("should map it to the errory type", func() {
+				err := client.DeleteHappinessMetric(ctx, 3)
+
+				apiErr, _ := AsError(err)
+				Expect(apiErr.Type()).To(Equal(errory.DeleteLastMetricForbiddenErrors))
+				Expect(IsOfType(err, errory.OnDeleteErrors)).To(BeTrue())
+				Expect(IsForbidden(err)).To(BeTrue())
+			})
+		})
+
+		Context("when the api responds without a problem", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusServiceUnavailable, "Timeout Exceeded"))
+			})
+
+			It("should return the status and body as error", func() {
+				_, err := client.GetProductsStatus(ctx)
+
+				apiErr, ok := AsError(err)
+				Expect(ok).To(BeTrue())
+				Expect(apiErr.Problem.Detail).To(Equal("Timeout Exceeded"))
+				Expect(apiErr.Type()).To(Equal(errory.APIErrors))
+				Expect(IsRetryable(err)).To(BeTrue())
+			})
+		})
+
+		Context("when the context is canceled", func() {
+			It("should return the context error", func() {
+				canceled, cancel := context.WithCancel(ctx)
+				cancel()
+
+				_, err := client.GetProductsStatus(canceled)
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+	})
+
+	Describe("UpdateSlo(ctx, slo, ifMatch)", func() {
+		var statusCode int
+		var returnString string
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPut, "/ebt/v1/slo/7"),
+				ghttp.VerifyHeaderKV("If-Match", `"3"`),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyJSONRepresenting(&model.Slo{ID: 7, OrgID: 2, Name: "availability"}),
+				ghttp.RespondWithPtr(&statusCode, &returnString, http.Header{"ETag": []string{`"4"`}}),
+			))
+		})
+
+		Context("when the update is applied", func() {
+			BeforeEach(func() {
+				statusCode, returnString = http.StatusOK, `{"id": 7}`
+			})
+
+			It("should return the new ETag", func() {
+				result, err := client.UpdateSlo(ctx, &model.Slo{ID: 7, OrgID: 2, Name: "availability"}, `"3"`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.ID).To(Equal(int64(7)))
+				Expect(result.ETag).To(Equal(`"4"`))
+				Expect(result.Pending()).To(BeFalse())
+			})
+		})
+
+		Context("when the update needs approval", func() {
+			BeforeEach(func() {
+				statusCode, returnString = http.StatusAccepted, `{"id": 11, "sloId": 7, "status": "pending"}`
+			})
+
+			It("should return the change request", func() {
+				result, err := client.UpdateSlo(ctx, &model.Slo{ID: 7, OrgID: 2, Name: "availability"}, `"3"`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Pending()).To(BeTrue())
+				Expect(result.ChangeRequest.ID).To(Equal(int64(11)))
+			})
+		})
+	})
+
+	Describe("CreateSlo(ctx, slo, idempotencyKey)", func() {
+		It("should send the idempotency key", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, "/ebt/v1/slo"),
+				ghttp.VerifyHeaderKV("Idempotency-Key", "key-1"),
+				ghttp.RespondWith(http.StatusCreated, `{"id": 5}`),
+			))
+
+			id, err := client.CreateSlo(ctx, &model.Slo{OrgID: 2, Name: "availability"}, "key-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(int64(5)))
+		})
+	})
+
+	Describe("AllSlos(ctx, options)", func() {
+		critical := true
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/ebt/v1/slo", "critical=true&label=team%3Aa&label=tier%3A1&limit=1000&sort=creationDate"),
+					ghttp.RespondWith(http.StatusOK, `[{"id": 1}, {"id": 2}]`, http.Header{
+						"X-Total-Count": []string{"3"},
+						"X-Next-Cursor": []string{"next"},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest(http.MethodGet, "/ebt/v1/slo",
+						"critical=true&cursor=next&label=team%3Aa&label=tier%3A1&limit=1000&sort=creationDate"),
+					ghttp.RespondWith(http.StatusOK, `[{"id": 3}]`, http.Header{"X-Total-Count": []string{"3"}}),
+				),
+			)
+		})
+
+		It("should read all pages", func() {
+			slos, err := client.AllSlos(ctx, &SloListOptions{
+				Critical: &critical,
+				Labels:   map[string]string{"tier": "1", "team": "a"},
+				Sort:     model.SloSortFieldCreationDate,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slos).To(HaveLen(3))
+			Expect(slos[2].ID).To(Equal(int64(3)))
+		})
+	})
+
+	Describe("ListOrgSlos(ctx, orgID, options)", func() {
+		It("should return the page info", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/ebt/v1/org/2/slo", "limit=1&order=desc"),
+				ghttp.RespondWith(http.StatusOK, `[{"id": 1}]`, http.Header{
+					"X-Total-Count": []string{"2"},
+					"X-Next-Cursor": []string{"next"},
+				}),
+			))
+
+			page, err := client.ListOrgSlos(ctx, 2, &SloListOptions{OrgName: "ignored", Descending: true, Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Slos).To(HaveLen(1))
+			Expect(page.Total).To(Equal(int64(2)))
+			Expect(page.HasNext()).To(BeTrue())
+		})
+	})
+
+	Describe("ListSlaBreaches(ctx, orgID, from, to)", func() {
+		It("should send the range as RFC3339", func() {
+			from := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/ebt/v1/org/2/sla_breach", "from=2021-03-01T00%3A00%3A00Z"),
+				ghttp.RespondWith(http.StatusOK, `[]`),
+			))
+
+			breaches, err := client.ListSlaBreaches(ctx, 2, from, time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(breaches).To(BeEmpty())
+		})
+	})
+
+	Describe("CreateWebhook(ctx, orgID, webhook)", func() {
+		It("should return the webhook with its secret", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, "/ebt/v1/org/2/webhook"),
+				ghttp.VerifyJSONRepresenting(&model.Webhook{Name: "chat", URL: "https://chat.example.com",
+					Events: []model.WebhookEventType{model.WebhookEventSloCreated}, Enabled: true}),
+				ghttp.RespondWith(http.StatusCreated, `{"id":3,"orgId":2,"name":"chat","secret":"s3cr3t"}`),
+			))
+
+			created, err := client.CreateWebhook(ctx, 2, &model.Webhook{Name: "chat", URL: "https://chat.example.com",
+				Events: []model.WebhookEventType{model.WebhookEventSloCreated}, Enabled: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.ID).To(Equal(int64(3)))
+			Expect(created.Secret).To(Equal("s3cr3t"))
+		})
+	})
+
+	Describe("ListWebhookDeliveries(ctx, orgID, webhookID, status)", func() {
+		It("should send the status", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/ebt/v1/org/2/webhook/3/delivery", "status=failed"),
+				ghttp.RespondWith(http.StatusOK, `[{"id":12,"webhookId":3,"status":"failed","error":"status 503"}]`),
+			))
+
+			deliveries, err := client.ListWebhookDeliveries(ctx, 2, 3, model.WebhookDeliveryStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].Status).To(Equal(model.WebhookDeliveryStatusFailed))
+		})
+	})
+})
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,16 @@
+//go:build unitTests
+// +build unitTests
+
+package cruiser_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCruiser(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cruiser Client Suite")
+}
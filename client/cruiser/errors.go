@@ -0,0 +1,146 @@
+package cruiser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+// problemTypeBase prefixes the type URI of the problems reported by the API.
+const problemTypeBase = "https://oma.metro.digital/ebt/problems/"
+
+// problemTypes maps the problem categories of the API to the errory types they are reported for.
+var problemTypes = map[string]*errory.ErrorType{
+	"precondition-failed":          errory.PreconditionFailedErrors,
+	"precondition-required":        errory.PreconditionRequiredErrors,
+	"validation-error":             errory.ValidationErrors,
+	"parse-error":                  errory.ParseErrors,
+	"not-found":                    errory.NotFoundErrors,
+	"not-unique":                   errory.NotUniqueErrors,
+	"delete-last-metric-forbidden": errory.DeleteLastMetricForbiddenErrors,
+	"wrong-datasource-forbidden":   errory.CreateExternalSLOWithWrongDSForbiddenErrors,
+	"grafana-unauthorized":         errory.GrafanaClientAuthErrors,
+	"unauthorized":                 errory.AuthErrors,
+	"grafana-error":                errory.GrafanaClientErrors,
+	"elastic-error":                errory.ElasticClientErrors,
+	"fetch-resource-error":         errory.FetchResourceErrors,
+	"database-error":               errory.ProviderErrors,
+	"processing-error":             errory.ProcessingErrors,
+}
+
+// statusTypes are the errory types of problems without category, by status.
+var statusTypes = map[int]*errory.ErrorType{
+	http.StatusBadRequest:           errory.ValidationErrors,
+	http.StatusUnauthorized:         errory.AuthErrors,
+	http.StatusNotFound:             errory.NotFoundErrors,
+	http.StatusConflict:             errory.NotUniqueErrors,
+	http.StatusPreconditionFailed:   errory.PreconditionFailedErrors,
+	http.StatusPreconditionRequired: errory.PreconditionRequiredErrors,
+}
+
+// Error is an error response of the API. Problem is the RFC 7807 body, for responses without one
+// it only holds the status and the body as detail.
+type Error struct {
+	Status  int
+	Problem *model.Problem
+}
+
+func newError(status int, body []byte) *Error {
+	var problem model.Problem
+	if err := json.Unmarshal(body, &problem); err != nil || problem.Status == 0 {
+		problem = model.Problem{
+			Type:      "about:blank",
+			Title:     http.StatusText(status),
+			Status:    status,
+			Detail:    strings.TrimSpace(string(body)),
+			Retryable: status >= http.StatusInternalServerError || status == http.StatusTooManyRequests,
+		}
+	}
+	return &Error{Status: status, Problem: &problem}
+}
+
+func (e *Error) Error() string {
+	message := fmt.Sprintf("cruiser api responded %d %s", e.Status, e.Problem.Title)
+	if e.Problem.Detail != "" {
+		message += ": " + e.Problem.Detail
+	}
+	if e.Problem.CorrelationID != "" {
+		message += " (correlation id " + e.Problem.CorrelationID + ")"
+	}
+	return message
+}
+
+// Type returns the errory type the API reported the error with, APIErrors when it is not known.
+func (e *Error) Type() *errory.ErrorType {
+	if errorType, ok := problemTypes[strings.TrimPrefix(e.Problem.Type, problemTypeBase)]; ok {
+		return errorType
+	}
+	if errorType, ok := statusTypes[e.Status]; ok {
+		return errorType
+	}
+	return errory.APIErrors
+}
+
+// Retryable tells whether repeating the same request may succeed.
+func (e *Error) Retryable() bool {
+	return e.Problem.Retryable
+}
+
+// AsError returns the error response of the API, if err is one.
+func AsError(err error) (*Error, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsOfType tells whether the API reported err with the errory type or one of its subtypes.
+func IsOfType(err error, errorType *errory.ErrorType) bool {
+	apiErr, ok := AsError(err)
+	return ok && errory.IsOfType(apiErr.Type().Builder().Create(), errorType)
+}
+
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+func IsPreconditionRequired(err error) bool {
+	return hasStatus(err, http.StatusPreconditionRequired)
+}
+
+// IsRetryable tells whether repeating the failed request may succeed.
+func IsRetryable(err error) bool {
+	apiErr, ok := AsError(err)
+	return ok && apiErr.Retryable()
+}
+
+func hasStatus(err error, status int) bool {
+	apiErr, ok := AsError(err)
+	return ok && apiErr.Status == status
+}
@@ -0,0 +1,27 @@
+package cruiser
+
+import (
+	"context"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+const (
+	feedbackAPIPath     = "/v1/feedback"
+	feedbackByIDAPIPath = feedbackAPIPath + "/"
+)
+
+// ListFeedback returns the feedback given in the organization.
+func (c *Client) ListFeedback(ctx context.Context, orgID int64) ([]*model.Feedback, error) {
+	var feedback []*model.Feedback
+	if err := c.get(ctx, feedbackByIDAPIPath+formatID(orgID), nil, &feedback); err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
+
+// CreateFeedback creates the feedback and returns its ID. Retries with the same idempotency key get
+// the response of the first request, no key is sent when it is empty.
+func (c *Client) CreateFeedback(ctx context.Context, feedback *model.Feedback, idempotencyKey string) (int64, error) {
+	return c.create(ctx, feedbackAPIPath, idempotencyKeyHeaders(idempotencyKey), feedback)
+}
@@ -0,0 +1,79 @@
+package cruiser
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+const (
+	happinessMetricAPIPath     = "/v1/happiness_metric"
+	happinessMetricByIDAPIPath = happinessMetricAPIPath + "/"
+)
+
+// GetUserHappiness returns the happiness metrics the user entered in the organization.
+func (c *Client) GetUserHappiness(ctx context.Context, orgID int64) ([]*model.HappinessMetric, error) {
+	var metrics []*model.HappinessMetric
+	if err := c.get(ctx, orgPath(orgID)+"/user_happiness", nil, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// GetTeamHappiness returns the happiness metrics entered by the team of the organization.
+func (c *Client) GetTeamHappiness(ctx context.Context, orgID int64) ([]*model.HappinessMetric, error) {
+	var metrics []*model.HappinessMetric
+	if err := c.get(ctx, orgPath(orgID)+"/team_happiness", nil, &metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// SaveTeamHappinessAverage stores the average happiness of the team and returns the ID of the average.
+func (c *Client) SaveTeamHappinessAverage(ctx context.Context, orgID int64) (int64, error) {
+	return c.create(ctx, orgPath(orgID)+"/team_happiness/average", nil, nil)
+}
+
+func (c *Client) GetUsersMissingInput(ctx context.Context, orgID int64) ([]*model.UserMissingInput, error) {
+	var missingInput []*model.UserMissingInput
+	if err := c.get(ctx, orgPath(orgID)+"/team_happiness/missing", nil, &missingInput); err != nil {
+		return nil, err
+	}
+	return missingInput, nil
+}
+
+// GetHappinessMetric returns the happiness metric and its ETag, to be sent as If-Match on update.
+func (c *Client) GetHappinessMetric(ctx context.Context, metricID int64) (*model.HappinessMetric, string, error) {
+	var metric model.HappinessMetric
+	r, err := c.send(ctx, &request{method: http.MethodGet, path: happinessMetricPath(metricID)}, &metric)
+	if err != nil {
+		return nil, "", err
+	}
+	return &metric, r.header.Get(etagHeader), nil
+}
+
+// CreateHappinessMetric creates the happiness metric and returns its ID. Retries with the same
+// idempotency key get the response of the first request, no key is sent when it is empty.
+func (c *Client) CreateHappinessMetric(ctx context.Context, metric *model.HappinessMetric, idempotencyKey string) (int64, error) {
+	return c.create(ctx, happinessMetricAPIPath, idempotencyKeyHeaders(idempotencyKey), metric)
+}
+
+// UpdateHappinessMetric updates the happiness metric based on the version ifMatch, * overwrites any version.
+func (c *Client) UpdateHappinessMetric(ctx context.Context, metric *model.HappinessMetric, ifMatch string) (*UpdateResult, error) {
+	return c.sendChange(ctx, &request{
+		method: http.MethodPut,
+		path:   happinessMetricPath(metric.ID),
+		header: ifMatchHeaders(ifMatch),
+		body:   metric,
+	})
+}
+
+func (c *Client) DeleteHappinessMetric(ctx context.Context, metricID int64) error {
+	_, err := c.send(ctx, &request{method: http.MethodDelete, path: happinessMetricPath(metricID)}, nil)
+	return err
+}
+
+func happinessMetricPath(metricID int64) string {
+	return happinessMetricByIDAPIPath + formatID(metricID)
+}
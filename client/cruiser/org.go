@@ -0,0 +1,386 @@
+package cruiser
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+)
+
+const (
+	orgByIDAPIPath                = "/v1/org/"
+	orgSloAPIPathSuffix           = "/slo"
+	orgAtRiskAPIPathSuffix        = "/slo/at-risk"
+	orgDatasourceAPIPathSuffix    = "/datasource"
+	orgMaintenanceAPIPathSuffix   = "/maintenance_window"
+	orgBudgetPolicyAPIPathSuffix  = "/budget_policy"
+	orgDeployGateAPIPathSuffix    = "/deploy-gate"
+	orgDeployFreezeAPIPathSuffix  = "/deploy_freeze"
+	orgComplianceAPIPathSuffix    = "/compliance_report"
+	orgSlaBreachAPIPathSuffix     = "/sla_breach"
+	orgSlaCreditAPIPathSuffix     = "/sla_credit"
+	orgLabelKeyAPIPathSuffix      = "/slo_label_key"
+	orgOwnershipGapAPIPathSuffix  = "/slo_ownership_gap"
+	orgChangeRequestAPIPathSuffix = "/slo_change_request"
+	orgWebhookAPIPathSuffix       = "/webhook"
+	pluginAPIPath                 = "/v1/plugin/"
+)
+
+func (c *Client) GetOrg(ctx context.Context, orgID int64) (*grafanaModel.Organization, error) {
+	var org grafanaModel.Organization
+	if err := c.get(ctx, orgPath(orgID), nil, &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// ListOrgSlos returns a page of the SLOs of the organization, OrgName of the options is not applied.
+func (c *Client) ListOrgSlos(ctx context.Context, orgID int64, options *SloListOptions) (*SloPage, error) {
+	query := options.values()
+	query.Del("orgName")
+
+	page := &SloPage{}
+	r, err := c.send(ctx, &request{method: http.MethodGet, path: orgPath(orgID) + orgSloAPIPathSuffix, query: query}, &page.Slos)
+	if err != nil {
+		return nil, err
+	}
+	page.PageInfo = newPageInfo(r)
+	return page, nil
+}
+
+func (c *Client) FindOrgSlos(ctx context.Context, orgID int64, params *model.SloQueryParams) ([]*model.Slo, error) {
+	var slos []*model.Slo
+	_, err := c.send(ctx, &request{method: http.MethodPost, path: orgPath(orgID) + orgSloAPIPathSuffix, body: params}, &slos)
+	if err != nil {
+		return nil, err
+	}
+	return slos, nil
+}
+
+// GetAtRiskSlos returns the forecasts of the SLOs whose error budget runs out within their window, a
+// look-back of 0 days takes the configured default.
+func (c *Client) GetAtRiskSlos(ctx context.Context, orgID int64, lookbackDays int) ([]*model.BudgetForecast, error) {
+	var forecasts []*model.BudgetForecast
+	if err := c.get(ctx, orgPath(orgID)+orgAtRiskAPIPathSuffix, lookbackQuery(lookbackDays), &forecasts); err != nil {
+		return nil, err
+	}
+	return forecasts, nil
+}
+
+func (c *Client) GetOrgDatasources(ctx context.Context, orgID int64) ([]*grafanaModel.Datasource, error) {
+	var datasources []*grafanaModel.Datasource
+	if err := c.get(ctx, orgPath(orgID)+orgDatasourceAPIPathSuffix, nil, &datasources); err != nil {
+		return nil, err
+	}
+	return datasources, nil
+}
+
+func (c *Client) DiscoverDatadogSlos(ctx context.Context, orgID, datasourceID int64) ([]*datadogModel.SLO, error) {
+	var slos []*datadogModel.SLO
+	if err := c.get(ctx, datadogPath(orgID, datasourceID)+"/discover", nil, &slos); err != nil {
+		return nil, err
+	}
+	return slos, nil
+}
+
+func (c *Client) ImportDatadogSlos(ctx context.Context, orgID, datasourceID int64,
+	sloImport *model.SloImport) ([]*model.SloImportResult, error) {
+	var results []*model.SloImportResult
+	_, err := c.send(ctx, &request{method: http.MethodPost, path: datadogPath(orgID, datasourceID) + "/import", body: sloImport}, &results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (c *Client) ListMaintenanceWindows(ctx context.Context, orgID int64) ([]*model.MaintenanceWindow, error) {
+	var windows []*model.MaintenanceWindow
+	if err := c.get(ctx, orgPath(orgID)+orgMaintenanceAPIPathSuffix, nil, &windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+func (c *Client) GetMaintenanceWindow(ctx context.Context, orgID, windowID int64) (*model.MaintenanceWindow, error) {
+	var window model.MaintenanceWindow
+	if err := c.get(ctx, maintenanceWindowPath(orgID, windowID), nil, &window); err != nil {
+		return nil, err
+	}
+	return &window, nil
+}
+
+func (c *Client) CreateMaintenanceWindow(ctx context.Context, orgID int64, window *model.MaintenanceWindow) (int64, error) {
+	return c.create(ctx, orgPath(orgID)+orgMaintenanceAPIPathSuffix, nil, window)
+}
+
+func (c *Client) UpdateMaintenanceWindow(ctx context.Context, orgID int64, window *model.MaintenanceWindow) error {
+	_, err := c.send(ctx, &request{method: http.MethodPut, path: maintenanceWindowPath(orgID, window.ID), body: window}, nil)
+	return err
+}
+
+func (c *Client) DeleteMaintenanceWindow(ctx context.Context, orgID, windowID int64) error {
+	_, err := c.send(ctx, &request{method: http.MethodDelete, path: maintenanceWindowPath(orgID, windowID)}, nil)
+	return err
+}
+
+func (c *Client) ListBudgetPolicies(ctx context.Context, orgID int64) ([]*model.BudgetPolicy, error) {
+	var policies []*model.BudgetPolicy
+	if err := c.get(ctx, orgPath(orgID)+orgBudgetPolicyAPIPathSuffix, nil, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (c *Client) GetBudgetPolicy(ctx context.Context, orgID, policyID int64) (*model.BudgetPolicy, error) {
+	var policy model.BudgetPolicy
+	if err := c.get(ctx, budgetPolicyPath(orgID, policyID), nil, &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (c *Client) CreateBudgetPolicy(ctx context.Context, orgID int64, policy *model.BudgetPolicy) (int64, error) {
+	return c.create(ctx, orgPath(orgID)+orgBudgetPolicyAPIPathSuffix, nil, policy)
+}
+
+func (c *Client) UpdateBudgetPolicy(ctx context.Context, orgID int64, policy *model.BudgetPolicy) error {
+	_, err := c.send(ctx, &request{method: http.MethodPut, path: budgetPolicyPath(orgID, policy.ID), body: policy}, nil)
+	return err
+}
+
+func (c *Client) DeleteBudgetPolicy(ctx context.Context, orgID, policyID int64) error {
+	_, err := c.send(ctx, &request{method: http.MethodDelete, path: budgetPolicyPath(orgID, policyID)}, nil)
+	return err
+}
+
+func (c *Client) GetDeployGate(ctx context.Context, orgID int64) (*model.DeployGate, error) {
+	var gate model.DeployGate
+	if err := c.get(ctx, orgPath(orgID)+orgDeployGateAPIPathSuffix, nil, &gate); err != nil {
+		return nil, err
+	}
+	return &gate, nil
+}
+
+func (c *Client) ListDeployFreezes(ctx context.Context, orgID int64) ([]*model.DeployFreeze, error) {
+	var freezes []*model.DeployFreeze
+	if err := c.get(ctx, orgPath(orgID)+orgDeployFreezeAPIPathSuffix, nil, &freezes); err != nil {
+		return nil, err
+	}
+	return freezes, nil
+}
+
+func (c *Client) ListComplianceReports(ctx context.Context, orgID int64) ([]*model.ComplianceReport, error) {
+	var reports []*model.ComplianceReport
+	if err := c.get(ctx, orgPath(orgID)+orgComplianceAPIPathSuffix, nil, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+func (c *Client) CreateComplianceReport(ctx context.Context, orgID int64,
+	complianceRequest *model.ComplianceReportRequest) (*model.ComplianceReport, error) {
+	var report model.ComplianceReport
+	_, err := c.send(ctx, &request{method: http.MethodPost, path: orgPath(orgID) + orgComplianceAPIPathSuffix, body: complianceRequest}, &report)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// DownloadComplianceReport returns the content of the report file, CSV or PDF as requested on creation.
+func (c *Client) DownloadComplianceReport(ctx context.Context, orgID, reportID int64) ([]byte, error) {
+	r, err := c.send(ctx, &request{
+		method: http.MethodGet,
+		path:   orgPath(orgID) + orgComplianceAPIPathSuffix + "/" + formatID(reportID) + "/download",
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return r.body, nil
+}
+
+// ListSlaBreaches returns the SLA breaches between from and to, zero times take the defaults of
+// the API: the year until now.
+func (c *Client) ListSlaBreaches(ctx context.Context, orgID int64, from, to time.Time) ([]*model.SlaBreach, error) {
+	query := url.Values{}
+	setTimeQuery(query, "from", from)
+	setTimeQuery(query, "to", to)
+
+	var breaches []*model.SlaBreach
+	if err := c.get(ctx, orgPath(orgID)+orgSlaBreachAPIPathSuffix, query, &breaches); err != nil {
+		return nil, err
+	}
+	return breaches, nil
+}
+
+func (c *Client) GetSlaCreditTable(ctx context.Context, orgID, solutionID int64) (*model.SlaCreditTable, error) {
+	var table model.SlaCreditTable
+	if err := c.get(ctx, slaCreditPath(orgID, solutionID), nil, &table); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+func (c *Client) UpdateSlaCreditTable(ctx context.Context, orgID, solutionID int64,
+	table *model.SlaCreditTable) (*model.SlaCreditTable, error) {
+	var updated model.SlaCreditTable
+	_, err := c.send(ctx, &request{method: http.MethodPut, path: slaCreditPath(orgID, solutionID), body: table}, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *Client) GetSloLabelKeys(ctx context.Context, orgID int64) (*model.SloLabelKeys, error) {
+	var keys model.SloLabelKeys
+	if err := c.get(ctx, orgPath(orgID)+orgLabelKeyAPIPathSuffix, nil, &keys); err != nil {
+		return nil, err
+	}
+	return &keys, nil
+}
+
+func (c *Client) UpdateSloLabelKeys(ctx context.Context, orgID int64, keys *model.SloLabelKeys) (*model.SloLabelKeys, error) {
+	var updated model.SloLabelKeys
+	_, err := c.send(ctx, &request{method: http.MethodPut, path: orgPath(orgID) + orgLabelKeyAPIPathSuffix, body: keys}, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *Client) ListSloOwnershipGaps(ctx context.Context, orgID int64) ([]*model.SloOwnershipGap, error) {
+	var gaps []*model.SloOwnershipGap
+	if err := c.get(ctx, orgPath(orgID)+orgOwnershipGapAPIPathSuffix, nil, &gaps); err != nil {
+		return nil, err
+	}
+	return gaps, nil
+}
+
+// ListSloChangeRequests returns the change requests of the organization, all of them for an empty status.
+func (c *Client) ListSloChangeRequests(ctx context.Context, orgID int64, status model.SloChangeStatus) ([]*model.SloChangeRequest, error) {
+	query := url.Values{}
+	setQuery(query, "status", string(status))
+
+	var requests []*model.SloChangeRequest
+	if err := c.get(ctx, orgPath(orgID)+orgChangeRequestAPIPathSuffix, query, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (c *Client) ApproveSloChangeRequest(ctx context.Context, orgID, requestID int64,
+	decision *model.SloChangeDecision) (*model.SloChangeRequest, error) {
+	return c.decideSloChangeRequest(ctx, orgID, requestID, "/approve", decision)
+}
+
+func (c *Client) RejectSloChangeRequest(ctx context.Context, orgID, requestID int64,
+	decision *model.SloChangeDecision) (*model.SloChangeRequest, error) {
+	return c.decideSloChangeRequest(ctx, orgID, requestID, "/reject", decision)
+}
+
+func (c *Client) decideSloChangeRequest(ctx context.Context, orgID, requestID int64, action string,
+	decision *model.SloChangeDecision) (*model.SloChangeRequest, error) {
+	req := &request{
+		method: http.MethodPost,
+		path:   orgPath(orgID) + orgChangeRequestAPIPathSuffix + "/" + formatID(requestID) + action,
+	}
+	if decision != nil {
+		req.body = decision
+	}
+
+	var changeRequest model.SloChangeRequest
+	if _, err := c.send(ctx, req, &changeRequest); err != nil {
+		return nil, err
+	}
+	return &changeRequest, nil
+}
+
+func (c *Client) ListWebhooks(ctx context.Context, orgID int64) ([]*model.Webhook, error) {
+	var webhooks []*model.Webhook
+	if err := c.get(ctx, orgPath(orgID)+orgWebhookAPIPathSuffix, nil, &webhooks); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (c *Client) GetWebhook(ctx context.Context, orgID, webhookID int64) (*model.Webhook, error) {
+	var webhook model.Webhook
+	if err := c.get(ctx, webhookPath(orgID, webhookID), nil, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// CreateWebhook returns the created webhook with its secret, which is generated when none is given.
+// The secret is not returned again.
+func (c *Client) CreateWebhook(ctx context.Context, orgID int64, webhook *model.Webhook) (*model.Webhook, error) {
+	var created model.Webhook
+	_, err := c.send(ctx, &request{method: http.MethodPost, path: orgPath(orgID) + orgWebhookAPIPathSuffix, body: webhook}, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateWebhook keeps the secret of the webhook when none is given.
+func (c *Client) UpdateWebhook(ctx context.Context, orgID int64, webhook *model.Webhook) error {
+	_, err := c.send(ctx, &request{method: http.MethodPut, path: webhookPath(orgID, webhook.ID), body: webhook}, nil)
+	return err
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, orgID, webhookID int64) error {
+	_, err := c.send(ctx, &request{method: http.MethodDelete, path: webhookPath(orgID, webhookID)}, nil)
+	return err
+}
+
+// ListWebhookDeliveries returns the latest deliveries of the webhook, of all statuses for an empty status.
+func (c *Client) ListWebhookDeliveries(ctx context.Context, orgID, webhookID int64,
+	status model.WebhookDeliveryStatus) ([]*model.WebhookDelivery, error) {
+	query := url.Values{}
+	setQuery(query, "status", string(status))
+
+	var deliveries []*model.WebhookDelivery
+	if err := c.get(ctx, webhookPath(orgID, webhookID)+"/delivery", query, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// EnablePlugin enables the OMA plugin in the organization, creating its datasources unless skipped.
+func (c *Client) EnablePlugin(ctx context.Context, orgID int64, skipDatasources bool) error {
+	query := url.Values{}
+	if skipDatasources {
+		query.Set("skipds", strconv.FormatBool(skipDatasources))
+	}
+	_, err := c.send(ctx, &request{method: http.MethodPost, path: pluginAPIPath + formatID(orgID), query: query}, nil)
+	return err
+}
+
+func orgPath(orgID int64) string {
+	return orgByIDAPIPath + formatID(orgID)
+}
+
+func datadogPath(orgID, datasourceID int64) string {
+	return orgPath(orgID) + orgDatasourceAPIPathSuffix + "/" + formatID(datasourceID)
+}
+
+func maintenanceWindowPath(orgID, windowID int64) string {
+	return orgPath(orgID) + orgMaintenanceAPIPathSuffix + "/" + formatID(windowID)
+}
+
+func budgetPolicyPath(orgID, policyID int64) string {
+	return orgPath(orgID) + orgBudgetPolicyAPIPathSuffix + "/" + formatID(policyID)
+}
+
+func slaCreditPath(orgID, solutionID int64) string {
+	return orgPath(orgID) + orgSlaCreditAPIPathSuffix + "/" + formatID(solutionID)
+}
+
+func webhookPath(orgID, webhookID int64) string {
+	return orgPath(orgID) + orgWebhookAPIPathSuffix + "/" + formatID(webhookID)
+}
@@ -0,0 +1,158 @@
+package cruiser
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+const (
+	totalCountHeader = "X-Total-Count"
+	nextCursorHeader = "X-Next-Cursor"
+)
+
+// SloListOptions filters, sorts and pages the SLO lists. Nil and empty fields are not sent, OrgName
+// only applies to ListSlos. Cursor is the NextCursor of the previous page of the same sort order.
+type SloListOptions struct {
+	Name         string
+	NamePrefix   string
+	OrgName      string
+	Critical     *bool
+	ExternalType *model.ExternalSloType
+	DatasourceID *int64
+	SolutionID   *int64
+	Labels       map[string]string
+	Sort         model.SloSortField
+	Descending   bool
+	Limit        int
+	Cursor       string
+}
+
+func (o *SloListOptions) values() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	setQuery(query, "name", o.Name)
+	setQuery(query, "namePrefix", o.NamePrefix)
+	setQuery(query, "orgName", o.OrgName)
+	if o.Critical != nil {
+		query.Set("critical", strconv.FormatBool(*o.Critical))
+	}
+	if o.ExternalType != nil {
+		query.Set("externalType", string(*o.ExternalType))
+	}
+	if o.DatasourceID != nil {
+		query.Set("datasourceId", formatID(*o.DatasourceID))
+	}
+	if o.SolutionID != nil {
+		query.Set("solutionId", formatID(*o.SolutionID))
+	}
+	keys := make([]string, 0, len(o.Labels))
+	for key := range o.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query.Add("label", key+":"+o.Labels[key])
+	}
+	setQuery(query, "sort", string(o.Sort))
+	if o.Descending {
+		query.Set("order", "desc")
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	setQuery(query, "cursor", o.Cursor)
+	return query
+}
+
+// nextPage returns the options of the page after the cursor, reading pages of the largest size
+// when no limit is set.
+func (o *SloListOptions) nextPage(cursor string) *SloListOptions {
+	next := SloListOptions{}
+	if o != nil {
+		next = *o
+	}
+	if next.Limit == 0 {
+		next.Limit = model.MaxSloPageLimit
+	}
+	next.Cursor = cursor
+	return &next
+}
+
+// PageInfo tells the number of all SLOs matching the filters and where the next page starts,
+// NextCursor is empty on the last page.
+type PageInfo struct {
+	Total      int64
+	NextCursor string
+}
+
+// HasNext tells whether more SLOs follow the page.
+func (p PageInfo) HasNext() bool {
+	return p.NextCursor != ""
+}
+
+type SloPage struct {
+	PageInfo
+	Slos []*model.Slo
+}
+
+type DetailedSloPage struct {
+	PageInfo
+	Slos []*model.DetailedSlo
+}
+
+func newPageInfo(r *response) PageInfo {
+	total, _ := strconv.ParseInt(r.header.Get(totalCountHeader), 10, 64)
+	return PageInfo{Total: total, NextCursor: r.header.Get(nextCursorHeader)}
+}
+
+// AllSlos reads ListSlos page by page until the last one, starting at the cursor of the options.
+func (c *Client) AllSlos(ctx context.Context, options *SloListOptions) ([]*model.DetailedSlo, error) {
+	slos := []*model.DetailedSlo{}
+	cursor := ""
+	if options != nil {
+		cursor = options.Cursor
+	}
+	for {
+		page, err := c.ListSlos(ctx, options.nextPage(cursor))
+		if err != nil {
+			return nil, err
+		}
+		slos = append(slos, page.Slos...)
+		if !page.HasNext() {
+			return slos, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// AllOrgSlos reads ListOrgSlos page by page until the last one, starting at the cursor of the options.
+func (c *Client) AllOrgSlos(ctx context.Context, orgID int64, options *SloListOptions) ([]*model.Slo, error) {
+	slos := []*model.Slo{}
+	cursor := ""
+	if options != nil {
+		cursor = options.Cursor
+	}
+	for {
+		page, err := c.ListOrgSlos(ctx, orgID, options.nextPage(cursor))
+		if err != nil {
+			return nil, err
+		}
+		slos = append(slos, page.Slos...)
+		if !page.HasNext() {
+			return slos, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
@@ -0,0 +1,34 @@
+package cruiser
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+const recommendationVoteAPIPath = "/v1/recommendation_vote"
+
+// ListRecommendationVotes returns the votes of the user, of all organizations when orgID is nil.
+func (c *Client) ListRecommendationVotes(ctx context.Context, orgID *int64) ([]*model.RecommendationVote, error) {
+	query := url.Values{}
+	if orgID != nil {
+		query.Set("orgId", formatID(*orgID))
+	}
+
+	var votes []*model.RecommendationVote
+	if err := c.get(ctx, recommendationVoteAPIPath, query, &votes); err != nil {
+		return nil, err
+	}
+	return votes, nil
+}
+
+func (c *Client) CreateRecommendationVote(ctx context.Context, vote *model.RecommendationVote) (int64, error) {
+	return c.create(ctx, recommendationVoteAPIPath, nil, vote)
+}
+
+func (c *Client) DeleteRecommendationVote(ctx context.Context, vote *model.RecommendationVote) error {
+	_, err := c.send(ctx, &request{method: http.MethodDelete, path: recommendationVoteAPIPath, body: vote}, nil)
+	return err
+}
@@ -0,0 +1,222 @@
+package cruiser
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+)
+
+const (
+	sloAPIPath                     = "/v1/slo"
+	sloByIDAPIPath                 = sloAPIPath + "/"
+	sloBatchAPIPath                = sloAPIPath + "/batch"
+	sloHistoryAPIPathSuffix        = "/history"
+	sloHistoryTaskAPIPathSuffix    = "/history/task/"
+	sloBudgetAPIPathSuffix         = "/budget"
+	sloBudgetForecastAPIPathSuffix = "/budget/forecast"
+	sloSimulationAPIPathSuffix     = "/simulate"
+)
+
+// SloHistoryOptions selects the range and resolution of the SLO history. Zero values take the
+// defaults of the API: the current SLO window until now, in points of 1h.
+type SloHistoryOptions struct {
+	From     time.Time
+	To       time.Time
+	Interval string
+}
+
+// SloHistoryDeletionOptions selects the range of the SLO history to delete, zero values take the
+// defaults of the API: the last 14 days. A dry run only counts the matching documents.
+type SloHistoryDeletionOptions struct {
+	From   time.Time
+	To     time.Time
+	DryRun bool
+}
+
+// ListSlos returns a page of SLOs of all organizations the user can see. Without limit all SLOs after
+// the cursor are returned at once.
+func (c *Client) ListSlos(ctx context.Context, options *SloListOptions) (*DetailedSloPage, error) {
+	page := &DetailedSloPage{}
+	r, err := c.send(ctx, &request{method: http.MethodGet, path: sloAPIPath, query: options.values()}, &page.Slos)
+	if err != nil {
+		return nil, err
+	}
+	page.PageInfo = newPageInfo(r)
+	return page, nil
+}
+
+// GetSlo returns the SLO and its ETag, to be sent as If-Match on update.
+func (c *Client) GetSlo(ctx context.Context, sloID int64) (*model.Slo, string, error) {
+	var slo model.Slo
+	r, err := c.send(ctx, &request{method: http.MethodGet, path: sloPath(sloID)}, &slo)
+	if err != nil {
+		return nil, "", err
+	}
+	return &slo, r.header.Get(etagHeader), nil
+}
+
+// CreateSlo creates the SLO and returns its ID. Retries with the same idempotency key get the
+// response of the first request, no key is sent when it is empty.
+func (c *Client) CreateSlo(ctx context.Context, slo *model.Slo, idempotencyKey string) (int64, error) {
+	return c.create(ctx, sloAPIPath, idempotencyKeyHeaders(idempotencyKey), slo)
+}
+
+// UpdateSlo updates the SLO based on the version ifMatch, * overwrites any version. Changes which
+// need the approval of an organization Admin are returned as pending change request.
+func (c *Client) UpdateSlo(ctx context.Context, slo *model.Slo, ifMatch string) (*UpdateResult, error) {
+	return c.sendChange(ctx, &request{
+		method: http.MethodPut,
+		path:   sloPath(slo.ID),
+		header: ifMatchHeaders(ifMatch),
+		body:   slo,
+	})
+}
+
+// DeleteSlo deletes the SLO, with cascade it is also removed from the composite SLOs it is a child
+// of. Deletions which need the approval of an organization Admin are returned as pending change request.
+func (c *Client) DeleteSlo(ctx context.Context, sloID int64, cascade bool) (*UpdateResult, error) {
+	query := url.Values{}
+	if cascade {
+		query.Set("cascade", "true")
+	}
+	return c.sendChange(ctx, &request{method: http.MethodDelete, path: sloPath(sloID), query: query})
+}
+
+// ExecuteSloBatch applies the SLO operations. A batch in which some operations failed is no
+// error, the status of each operation is in its result.
+func (c *Client) ExecuteSloBatch(ctx context.Context, batch *model.SloBatch, idempotencyKey string) (*model.SloBatchResult, error) {
+	var result model.SloBatchResult
+	_, err := c.send(ctx, &request{
+		method: http.MethodPost,
+		path:   sloBatchAPIPath,
+		header: idempotencyKeyHeaders(idempotencyKey),
+		body:   batch,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) GetSloHistory(ctx context.Context, sloID int64, options *SloHistoryOptions) (*elasticModel.SloHistory, error) {
+	query := url.Values{}
+	if options != nil {
+		setTimeQuery(query, "from", options.From)
+		setTimeQuery(query, "to", options.To)
+		setQuery(query, "interval", options.Interval)
+	}
+
+	var history elasticModel.SloHistory
+	if err := c.get(ctx, sloPath(sloID)+sloHistoryAPIPathSuffix, query, &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// DeleteSloHistory starts the deletion of the SLO history, its progress is returned by
+// GetSloHistoryDeletionTask.
+func (c *Client) DeleteSloHistory(ctx context.Context, sloID int64,
+	options *SloHistoryDeletionOptions) (*elasticModel.SloHistoryDeletion, error) {
+	query := url.Values{}
+	if options != nil {
+		setTimeQuery(query, "from", options.From)
+		setTimeQuery(query, "to", options.To)
+		if options.DryRun {
+			query.Set("dryRun", "true")
+		}
+	}
+
+	var deletion elasticModel.SloHistoryDeletion
+	_, err := c.send(ctx, &request{method: http.MethodDelete, path: sloPath(sloID) + sloHistoryAPIPathSuffix, query: query}, &deletion)
+	if err != nil {
+		return nil, err
+	}
+	return &deletion, nil
+}
+
+func (c *Client) GetSloHistoryDeletionTask(ctx context.Context, sloID int64, taskID string) (*elasticModel.SloHistoryDeletionTask, error) {
+	var task elasticModel.SloHistoryDeletionTask
+	if err := c.get(ctx, sloPath(sloID)+sloHistoryTaskAPIPathSuffix+taskID, nil, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (c *Client) GetErrorBudget(ctx context.Context, sloID int64) (*model.ErrorBudget, error) {
+	var budget model.ErrorBudget
+	if err := c.get(ctx, sloPath(sloID)+sloBudgetAPIPathSuffix, nil, &budget); err != nil {
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// GetBudgetForecast returns the forecast of the error budget, a look-back of 0 days takes the
+// configured default.
+func (c *Client) GetBudgetForecast(ctx context.Context, sloID int64, lookbackDays int) (*model.BudgetForecast, error) {
+	var forecast model.BudgetForecast
+	if err := c.get(ctx, sloPath(sloID)+sloBudgetForecastAPIPathSuffix, lookbackQuery(lookbackDays), &forecast); err != nil {
+		return nil, err
+	}
+	return &forecast, nil
+}
+
+func (c *Client) SimulateSlo(ctx context.Context, sloID int64, simulation *model.SloSimulation) (*model.SloSimulationResult, error) {
+	var result model.SloSimulationResult
+	_, err := c.send(ctx, &request{
+		method: http.MethodPost,
+		path:   sloPath(sloID) + sloSimulationAPIPathSuffix,
+		body:   simulation,
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// sendChange sends an update which is answered with the ID and ETag of the changed entity, or with a
+// pending change request.
+func (c *Client) sendChange(ctx context.Context, req *request) (*UpdateResult, error) {
+	r, err := c.send(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &UpdateResult{ETag: r.header.Get(etagHeader)}
+	if r.status == http.StatusAccepted {
+		result.ChangeRequest = &model.SloChangeRequest{}
+		if err := decode(r, result.ChangeRequest); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	var changed idResponse
+	if err := decode(r, &changed); err != nil {
+		return nil, err
+	}
+	result.ID = changed.ID
+	return result, nil
+}
+
+func sloPath(sloID int64) string {
+	return sloByIDAPIPath + formatID(sloID)
+}
+
+func lookbackQuery(lookbackDays int) url.Values {
+	query := url.Values{}
+	if lookbackDays > 0 {
+		query.Set("lookback", strconv.Itoa(lookbackDays))
+	}
+	return query
+}
+
+func setTimeQuery(query url.Values, key string, value time.Time) {
+	if !value.IsZero() {
+		query.Set(key, value.Format(time.RFC3339))
+	}
+}
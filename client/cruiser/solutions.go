@@ -0,0 +1,101 @@
+package cruiser
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+)
+
+const (
+	datasourceByIDAPIPath = "/v1/datasource/"
+	solutionsAPIPath      = "/v1/solutions"
+	solutionSloAPIPath    = "/v1/solutionSlo"
+	productsStatusAPIPath = "/v1/products_status"
+	sdaAPIPath            = "/v1/sda"
+	searchAPIPath         = "/v1/search"
+)
+
+// SolutionsOptions selects the solutions. Long includes their products, AllowedOnly only returns
+// products which allow sharing DevOps metrics and Scope filters by solution scope when set.
+type SolutionsOptions struct {
+	Long        bool
+	AllowedOnly bool
+	Scope       model.ProductScopeType
+}
+
+func (c *Client) GetDatasource(ctx context.Context, datasourceID int64) (*grafanaModel.Datasource, error) {
+	var datasource grafanaModel.Datasource
+	if err := c.get(ctx, datasourceByIDAPIPath+formatID(datasourceID), nil, &datasource); err != nil {
+		return nil, err
+	}
+	return &datasource, nil
+}
+
+func (c *Client) GetSolutions(ctx context.Context, options *SolutionsOptions) ([]*model.Solution, error) {
+	query := url.Values{}
+	if options != nil {
+		if options.Long {
+			query.Set("long", strconv.FormatBool(options.Long))
+		}
+		if options.AllowedOnly {
+			query.Set("allowedOnly", strconv.FormatBool(options.AllowedOnly))
+		}
+		setQuery(query, "solutionScope", string(options.Scope))
+	}
+
+	var solutions []*model.Solution
+	if err := c.get(ctx, solutionsAPIPath, query, &solutions); err != nil {
+		return nil, err
+	}
+	return solutions, nil
+}
+
+// GetSolutionSlo returns the SLOs by solution, of all organizations for an empty orgName.
+func (c *Client) GetSolutionSlo(ctx context.Context, orgName string) (*model.SolutionSlo, error) {
+	query := url.Values{}
+	setQuery(query, "orgName", orgName)
+
+	var solutionSlo model.SolutionSlo
+	if err := c.get(ctx, solutionSloAPIPath, query, &solutionSlo); err != nil {
+		return nil, err
+	}
+	return &solutionSlo, nil
+}
+
+func (c *Client) GetProductsStatus(ctx context.Context) ([]*model.ProductStatus, error) {
+	var productsStatus []*model.ProductStatus
+	if err := c.get(ctx, productsStatusAPIPath, nil, &productsStatus); err != nil {
+		return nil, err
+	}
+	return productsStatus, nil
+}
+
+func (c *Client) GetSDAConfig(ctx context.Context) ([]map[string]interface{}, error) {
+	var config []map[string]interface{}
+	if err := c.get(ctx, sdaAPIPath, nil, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Search returns the SLOs, organizations, solutions, products and datasources matching the query,
+// restricted to the given hit types when any. A limit of 0 takes the default of the API.
+func (c *Client) Search(ctx context.Context, searchQuery string, types []model.SearchHitType, limit int) ([]*model.SearchHit, error) {
+	query := url.Values{}
+	query.Set("q", searchQuery)
+	for _, hitType := range types {
+		query.Add("type", string(hitType))
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var hits []*model.SearchHit
+	if err := c.get(ctx, searchAPIPath, query, &hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
@@ -0,0 +1,197 @@
+package datadog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	model "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+)
+
+const (
+	sloAPIPath        = "/api/v1/slo"
+	sloByIDAPIPath    = sloAPIPath + "/%s"
+	sloHistoryAPIPath = sloAPIPath + "/%s/history"
+	sloPageSize       = 1000
+)
+
+type IClient interface {
+	GetSLOHistory(sloID string, from, to time.Time) (*model.SLOHistory, error)
+	GetSLO(sloID string) (*model.SLO, error)
+	ListSLOs() ([]*model.SLO, error)
+}
+
+type Client struct {
+	baseURL string
+	apiKey  string
+	appKey  string
+	*http.Client
+}
+
+func New(baseURL, apiKey, appKey string) (*Client, error) {
+	_, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+
+	return &Client{
+		baseURL,
+		apiKey,
+		appKey,
+		&http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// GetSLOHistory returns the SLI value of the Datadog SLO over the given time range,
+// the value is nil when Datadog has no data for the range.
+func (c *Client) GetSLOHistory(sloID string, from, to time.Time) (*model.SLOHistory, error) {
+	data, err := c.httpGet(fmt.Sprintf(sloHistoryAPIPath, url.PathEscape(sloID)), map[string]string{
+		"from_ts": strconv.FormatInt(from.Unix(), 10),
+		"to_ts":   strconv.FormatInt(to.Unix(), 10),
+	})
+	if err != nil {
+		return nil, errory.Decorate(err, "get slo history")
+	}
+
+	var result historyResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+
+	return &model.SLOHistory{
+		Type:     result.Data.Type,
+		SLIValue: result.Data.Overall.SLIValue,
+	}, nil
+}
+
+// GetSLO returns the Datadog SLO with the given ID, or a NotFoundErrors error when Datadog does not know it.
+func (c *Client) GetSLO(sloID string) (*model.SLO, error) {
+	data, err := c.httpGet(fmt.Sprintf(sloByIDAPIPath, url.PathEscape(sloID)), nil)
+	if err != nil {
+		return nil, errory.Decorate(err, "get slo")
+	}
+
+	var result getResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+
+	return result.Data.toModel(), nil
+}
+
+// ListSLOs returns all SLOs of the Datadog account, reading the pages until a page is not full.
+func (c *Client) ListSLOs() ([]*model.SLO, error) {
+	slos := []*model.SLO{}
+	for offset := 0; ; offset += sloPageSize {
+		data, err := c.httpGet(sloAPIPath, map[string]string{
+			"limit":  strconv.Itoa(sloPageSize),
+			"offset": strconv.Itoa(offset),
+		})
+		if err != nil {
+			return nil, errory.Decorate(err, "list slos")
+		}
+
+		var result listResponse
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, errory.FetchResourceErrors.Wrap(err)
+		}
+
+		for _, slo := range result.Data {
+			slos = append(slos, slo.toModel())
+		}
+		if len(result.Data) < sloPageSize {
+			return slos, nil
+		}
+	}
+}
+
+func (c *Client) httpGet(apiPath string, query map[string]string) ([]byte, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+	u.Path = path.Join(u.Path, apiPath)
+
+	q := u.Query()
+	for key := range query {
+		q.Add(key, query[key])
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("DD-API-KEY", c.apiKey)
+	req.Header.Add("DD-APPLICATION-KEY", c.appKey)
+
+	r, err := c.Do(req)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+	defer r.Body.Close()
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errory.FetchResourceErrors.Wrap(err)
+	}
+
+	if r.StatusCode == http.StatusNotFound {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("path", apiPath).Create()
+	}
+	if r.StatusCode != http.StatusOK {
+		return nil, errory.FetchResourceErrors.Builder().
+			WithPayload("code", r.StatusCode).WithPayload("path", apiPath).
+			WithMessage(string(data)).
+			Create()
+	}
+	return data, nil
+}
+
+type sloResponse struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Type        string               `json:"type"`
+	Description string               `json:"description"`
+	Thresholds  []model.SLOThreshold `json:"thresholds"`
+	MonitorIDs  []int64              `json:"monitor_ids"`
+	Tags        []string             `json:"tags"`
+}
+
+func (r *sloResponse) toModel() *model.SLO {
+	return &model.SLO{
+		ID:          r.ID,
+		Name:        r.Name,
+		Type:        r.Type,
+		Description: r.Description,
+		Thresholds:  r.Thresholds,
+		MonitorIDs:  r.MonitorIDs,
+		Tags:        r.Tags,
+	}
+}
+
+type getResponse struct {
+	Data sloResponse `json:"data"`
+}
+
+type listResponse struct {
+	Data []sloResponse `json:"data"`
+}
+
+type historyResponse struct {
+	Data struct {
+		Type    string `json:"type"`
+		Overall struct {
+			SLIValue *float64 `json:"sli_value"`
+		} `json:"overall"`
+	} `json:"data"`
+}
@@ -0,0 +1,191 @@
+//go:build unitTests
+// +build unitTests
+
+package datadog_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
+	model "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+)
+
+var _ = Describe("Client", func() {
+	Describe("New(baseURL, apiKey, appKey string)", func() {
+		Context("when baseURL is unparseable", func() {
+			It("should return error", func() {
+				newClient, err := New(`ś://nourl`, "api", "app")
+				Expect(newClient).To(BeNil())
+				Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetSLOHistory(sloID, from, to)", func() {
+		var server *ghttp.Server
+		var client *Client
+		var statusCode int
+		var returnString string
+		from := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			var err error
+			client, err = New(server.URL(), "api-key", "app-key")
+			Expect(err).NotTo(HaveOccurred())
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/slo/abc123/history", "from_ts=1614556800&to_ts=1614643200"),
+				ghttp.VerifyHeaderKV("DD-API-KEY", "api-key"),
+				ghttp.VerifyHeaderKV("DD-APPLICATION-KEY", "app-key"),
+				ghttp.RespondWithPtr(&statusCode, &returnString),
+			))
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		Context("when response returns status ok", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusOK
+				returnString = `{"data": {"type": "monitor", "overall": {"sli_value": 99.95, "span_precision": 2}}}`
+			})
+			It("returns the sli value", func() {
+				history, err := client.GetSLOHistory("abc123", from, to)
+				Expect(err).NotTo(HaveOccurred())
+				sli := 99.95
+				Expect(*history).To(Equal(model.SLOHistory{Type: "monitor", SLIValue: &sli}))
+			})
+		})
+		Context("when datadog has no data for the range", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusOK
+				returnString = `{"data": {"type": "metric", "overall": {}}, "errors": [{"error": "no data"}]}`
+			})
+			It("returns history without sli value", func() {
+				history, err := client.GetSLOHistory("abc123", from, to)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(history.SLIValue).To(BeNil())
+			})
+		})
+		Context("when slo does not exist", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusNotFound
+				returnString = `{"errors": ["Not found"]}`
+			})
+			It("returns not found error", func() {
+				_, err := client.GetSLOHistory("abc123", from, to)
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+		Context("when response status code is different than status ok", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusForbidden
+				returnString = `{"errors": ["Forbidden"]}`
+			})
+			It("returns fetch resource error", func() {
+				history, err := client.GetSLOHistory("abc123", from, to)
+				Expect(history).To(BeNil())
+				Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetSLO(sloID)", func() {
+		var server *ghttp.Server
+		var client *Client
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			var err error
+			client, err = New(server.URL(), "api-key", "app-key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		Context("when response returns status ok", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/slo/abc123"),
+					ghttp.VerifyHeaderKV("DD-APPLICATION-KEY", "app-key"),
+					ghttp.RespondWith(http.StatusOK, `{"data": {"id": "abc123", "name": "Checkout availability", "type": "monitor",
+						"thresholds": [{"timeframe": "30d", "target": 99.9}], "monitor_ids": [42]}}`),
+				))
+			})
+			It("returns the slo", func() {
+				slo, err := client.GetSLO("abc123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slo).To(Equal(&model.SLO{ID: "abc123", Name: "Checkout availability", Type: "monitor",
+					Thresholds: []model.SLOThreshold{{Timeframe: "30d", Target: 99.9}}, MonitorIDs: []int64{42}}))
+			})
+		})
+		Context("when slo does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"errors": ["Not found"]}`))
+			})
+			It("returns not found error", func() {
+				slo, err := client.GetSLO("abc123")
+				Expect(slo).To(BeNil())
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("ListSLOs()", func() {
+		var server *ghttp.Server
+		var client *Client
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			var err error
+			client, err = New(server.URL(), "api-key", "app-key")
+			Expect(err).NotTo(HaveOccurred())
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		Context("when response returns status ok", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/slo", "limit=1000&offset=0"),
+					ghttp.VerifyHeaderKV("DD-API-KEY", "api-key"),
+					ghttp.RespondWith(http.StatusOK, `{"data": [
+						{"id": "abc123", "name": "Checkout availability", "type": "monitor", "description": null,
+							"thresholds": [{"timeframe": "30d", "target": 99.9}], "monitor_ids": [42], "tags": ["team:oma"]},
+						{"id": "def456", "name": "Checkout success rate", "type": "metric", "description": "good requests",
+							"thresholds": [{"timeframe": "7d", "target": 99.5}]}
+					]}`),
+				))
+			})
+			It("returns all slos", func() {
+				slos, err := client.ListSLOs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slos).To(Equal([]*model.SLO{
+					{ID: "abc123", Name: "Checkout availability", Type: "monitor",
+						Thresholds: []model.SLOThreshold{{Timeframe: "30d", Target: 99.9}}, MonitorIDs: []int64{42}, Tags: []string{"team:oma"}},
+					{ID: "def456", Name: "Checkout success rate", Type: "metric", Description: "good requests",
+						Thresholds: []model.SLOThreshold{{Timeframe: "7d", Target: 99.5}}},
+				}))
+			})
+		})
+		Context("when response status code is different than status ok", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, `{"errors": ["Forbidden"]}`))
+			})
+			It("returns fetch resource error", func() {
+				slos, err := client.ListSLOs()
+				Expect(slos).To(BeNil())
+				Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -16,16 +16,33 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	DefaultSloHistoryIndex = "oma-datadog-slo-*"
+	deleteByQueryAction    = "indices:data/write/delete/byquery"
+	sloHistoryIDsPageSize  = 1000
+
+	ComplianceField  = "compliance"
+	SuccessRateField = "success_rate"
+	GoodWindowsField = "good_windows"
+)
+
 type IClient interface {
 	GetIndices() ([]*model.Index, error)
 	CreateIndex(name, mapping string) error
-	DeleteSloHistory(id int64) error
+	CountSloHistory(id int64, timeRange *model.SloHistoryRange) ([]*model.SloHistoryIndexCount, error)
+	DeleteSloHistory(id int64, timeRange *model.SloHistoryRange) (string, error)
+	GetDeleteTask(id int64, taskID string) (*model.SloHistoryDeletionTask, error)
+	GetSloHistory(id int64, timeRange *model.SloHistoryRange, interval string) ([]*model.SloHistoryPoint, error)
+	GetLatestSloHistoryDate(id int64) (*time.Time, error)
+	GetSloHistoryIDs() ([]int64, error)
+	IndexSloHistory(documents []*model.SloHistoryDocument) error
 }
 
 type Client struct {
 	baseURL string
 	*http.Client
-	Log logrus.FieldLogger
+	Log          logrus.FieldLogger
+	HistoryIndex string
 }
 
 func New(baseURL string, log logrus.FieldLogger) (*Client, error) {
@@ -40,6 +57,7 @@ func New(baseURL string, log logrus.FieldLogger) (*Client, error) {
 			Timeout: 30 * time.Second,
 		},
 		log,
+		DefaultSloHistoryIndex,
 	}, nil
 }
 
@@ -93,36 +111,280 @@ func (c *Client) CreateIndex(name, mapping string) error {
 	return nil
 }
 
-func (c *Client) DeleteSloHistory(id int64) error {
+func (c *Client) CountSloHistory(id int64, timeRange *model.SloHistoryRange) ([]*model.SloHistoryIndexCount, error) {
 	query := fmt.Sprintf(`{
-		"query": {
-			"bool": {
-				"filter": [
-					{
-						"term": {
-							"id": %d
+		"size": 0,
+		"query": %s,
+		"aggs": {
+			"indices": {
+				"terms": {
+					"field": "_index",
+					"size": 10000
+				}
+			}
+		}
+	}`, sloHistoryQuery(id, timeRange))
+
+	data, err := c.postJSON(fmt.Sprintf("%s/%s/_search", c.baseURL, c.HistoryIndex), query)
+	if err != nil {
+		return nil, errory.Decorate(err, "count slo history")
+	}
+
+	var result countResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+
+	counts := []*model.SloHistoryIndexCount{}
+	for _, bucket := range result.Aggregations.Indices.Buckets {
+		counts = append(counts, &model.SloHistoryIndexCount{Index: bucket.Key, Count: bucket.DocCount})
+	}
+	return counts, nil
+}
+
+// DeleteSloHistory starts _delete_by_query as a background task and returns its ID,
+// so large deletions are not bound by the client timeout. Use GetDeleteTask to poll it.
+func (c *Client) DeleteSloHistory(id int64, timeRange *model.SloHistoryRange) (string, error) {
+	query := fmt.Sprintf(`{
+		"query": %s
+	}`, sloHistoryQuery(id, timeRange))
+
+	data, err := c.postJSON(fmt.Sprintf("%s/%s/_delete_by_query?wait_for_completion=false&conflicts=proceed", c.baseURL, c.HistoryIndex), query)
+	if err != nil {
+		return "", errory.Decorate(err, "delete slo history")
+	}
+
+	var result struct {
+		Task string `json:"task"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", errory.ElasticClientErrors.Wrap(err)
+	}
+	if result.Task == "" {
+		return "", errory.ElasticClientErrors.Builder().WithPayload("id", id).WithMessage("no task returned").Create()
+	}
+	return result.Task, nil
+}
+
+// GetDeleteTask returns the progress of a delete by query task started by DeleteSloHistory.
+// Tasks whose query does not target the SLO with the given id are reported as not found.
+func (c *Client) GetDeleteTask(id int64, taskID string) (*model.SloHistoryDeletionTask, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/_tasks/%s", c.baseURL, url.PathEscape(taskID)), nil)
+	if err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+
+	r, err := c.Do(req)
+	if err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode == http.StatusNotFound {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("task", taskID).Create()
+	}
+	if r.StatusCode != http.StatusOK {
+		return nil, errory.ElasticClientErrors.Builder().WithPayload("code", r.StatusCode).WithPayload("task", taskID).Create()
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+
+	var result taskResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+	if result.Task.Action != deleteByQueryAction || !taskTargetsSlo(result.Task.Description, id) {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("task", taskID).Create()
+	}
+
+	status := result.Task.Status
+	if result.Completed && result.Response != nil {
+		status = result.Response.taskStatus
+	}
+	failures := 0
+	if result.Response != nil {
+		failures = len(result.Response.Failures)
+	}
+
+	return &model.SloHistoryDeletionTask{
+		TaskID:    taskID,
+		Completed: result.Completed,
+		Total:     status.Total,
+		Deleted:   status.Deleted,
+		Conflicts: status.VersionConflicts,
+		Failures:  failures,
+	}, nil
+}
+
+// GetSloHistory averages compliance, success rate and good windows of the SLO history documents
+// into date histogram buckets of the given fixed interval, e.g. "1h" or "1d".
+// Buckets without documents are returned with nil values.
+func (c *Client) GetSloHistory(id int64, timeRange *model.SloHistoryRange, interval string) ([]*model.SloHistoryPoint, error) {
+	query := fmt.Sprintf(`{
+		"size": 0,
+		"query": %s,
+		"aggs": {
+			"history": {
+				"date_histogram": {
+					"field": "date",
+					"fixed_interval": %q,
+					"min_doc_count": 0,
+					"extended_bounds": {
+						"min": %d,
+						"max": %d
+					}
+				},
+				"aggs": {
+					"compliance": {
+						"avg": {
+							"field": %q
 						}
 					},
-					{
-						"range": {
-						  "date": {
-							"gte": "now-14d/d",
-							"lte": "now/d"
-						  }
+					"success_rate": {
+						"avg": {
+							"field": %q
+						}
+					},
+					"good_windows": {
+						"avg": {
+							"field": %q
 						}
 					}
-				]
+				}
+			}
+		}
+	}`, sloHistoryQuery(id, timeRange), interval, timeRange.From.UnixMilli(), timeRange.To.UnixMilli(), ComplianceField, SuccessRateField, GoodWindowsField)
+
+	data, err := c.postJSON(fmt.Sprintf("%s/%s/_search", c.baseURL, c.HistoryIndex), query)
+	if err != nil {
+		return nil, errory.Decorate(err, "get slo history")
+	}
+
+	var result historyResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+
+	points := []*model.SloHistoryPoint{}
+	for _, bucket := range result.Aggregations.History.Buckets {
+		points = append(points, &model.SloHistoryPoint{
+			Time:        time.UnixMilli(bucket.Key).UTC(),
+			Compliance:  bucket.Compliance.Value,
+			SuccessRate: bucket.SuccessRate.Value,
+			GoodWindows: bucket.GoodWindows.Value,
+		})
+	}
+	return points, nil
+}
+
+func (c *Client) GetLatestSloHistoryDate(id int64) (*time.Time, error) {
+	query := fmt.Sprintf(`{
+		"size": 0,
+		"query": {
+			"term": {
+				"id": %d
+			}
+		},
+		"aggs": {
+			"latest": {
+				"max": {
+					"field": "date"
+				}
 			}
 		}
 	}`, id)
 
-	req, err := http.NewRequest(http.MethodPost, c.baseURL+"/oma-datadog-slo-*/_delete_by_query", bytes.NewBuffer([]byte(query)))
+	data, err := c.postJSON(fmt.Sprintf("%s/%s/_search", c.baseURL, c.HistoryIndex), query)
 	if err != nil {
-		c.Log.WithError(err).Error("could not send request")
-		return err
+		return nil, errory.Decorate(err, "get latest slo history date")
 	}
 
-	req.Header.Add("Content-Type", "application/json")
+	var result latestResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+	if result.Aggregations.Latest.Value == nil {
+		return nil, nil
+	}
+
+	latest := time.UnixMilli(int64(*result.Aggregations.Latest.Value)).UTC()
+	return &latest, nil
+}
+
+// GetSloHistoryIDs returns the IDs of all SLOs with history documents, read page by page with a
+// composite aggregation so the number of SLOs is not limited by the bucket size.
+func (c *Client) GetSloHistoryIDs() ([]int64, error) {
+	ids := []int64{}
+	after := ""
+	for {
+		query := fmt.Sprintf(`{
+			"size": 0,
+			"aggs": {
+				"ids": {
+					"composite": {
+						"size": %d,
+						"sources": [
+							{
+								"id": {
+									"terms": {
+										"field": "id"
+									}
+								}
+							}
+						]%s
+					}
+				}
+			}
+		}`, sloHistoryIDsPageSize, after)
+
+		data, err := c.postJSON(fmt.Sprintf("%s/%s/_search", c.baseURL, c.HistoryIndex), query)
+		if err != nil {
+			return nil, errory.Decorate(err, "get slo history ids")
+		}
+
+		var result idsResponse
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, errory.ElasticClientErrors.Wrap(err)
+		}
+		for _, bucket := range result.Aggregations.IDs.Buckets {
+			ids = append(ids, bucket.Key.ID)
+		}
+		if result.Aggregations.IDs.AfterKey == nil || len(result.Aggregations.IDs.Buckets) < sloHistoryIDsPageSize {
+			return ids, nil
+		}
+		after = fmt.Sprintf(`, "after": {"id": %d}`, result.Aggregations.IDs.AfterKey.ID)
+	}
+}
+
+// IndexSloHistory writes the documents with the bulk API into monthly indices matching
+// HistoryIndex, e.g. oma-datadog-slo-2021.03. Documents with the same SLO and date are overwritten.
+func (c *Client) IndexSloHistory(documents []*model.SloHistoryDocument) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, document := range documents {
+		action := bulkAction{}
+		action.Index.Index = c.historyWriteIndex(document.Date)
+		action.Index.ID = document.DocumentID()
+		if err := encoder.Encode(action); err != nil {
+			return errory.ElasticClientErrors.Wrap(err)
+		}
+		if err := encoder.Encode(document); err != nil {
+			return errory.ElasticClientErrors.Wrap(err)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/_bulk", c.baseURL), &body)
+	if err != nil {
+		return errory.ElasticClientErrors.Wrap(err)
+	}
+	req.Header.Add("Content-Type", "application/x-ndjson")
 
 	r, err := c.Do(req)
 	if err != nil {
@@ -131,11 +393,213 @@ func (c *Client) DeleteSloHistory(id int64) error {
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		c.Log.Errorf("unexpected request status code:%v", r.StatusCode)
+		return errory.ElasticClientErrors.Builder().WithPayload("code", r.StatusCode).Create()
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return errory.ElasticClientErrors.Wrap(err)
+	}
+
+	var result bulkResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return errory.ElasticClientErrors.Wrap(err)
+	}
+	if result.Errors {
+		failed := 0
+		for _, item := range result.Items {
+			if item.Index.Error != nil {
+				failed++
+			}
+		}
 		return errory.ElasticClientErrors.Builder().
-			WithPayload("code", r.StatusCode).WithPayload("id", id).
+			WithPayload("failed", failed).WithPayload("total", len(documents)).
+			WithMessage("bulk indexing of slo history failed").
 			Create()
 	}
-
 	return nil
 }
+
+func (c *Client) historyWriteIndex(date time.Time) string {
+	prefix := strings.TrimSuffix(c.HistoryIndex, "*")
+	if prefix == c.HistoryIndex {
+		return c.HistoryIndex
+	}
+	return prefix + date.UTC().Format("2006.01")
+}
+
+func (c *Client) postJSON(reqURL, body string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, reqURL, bytes.NewBufferString(body))
+	if err != nil {
+		c.Log.WithError(err).Error("could not send request")
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	r, err := c.Do(req)
+	if err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		c.Log.Errorf("unexpected request status code:%v", r.StatusCode)
+		return nil, errory.ElasticClientErrors.Builder().WithPayload("code", r.StatusCode).Create()
+	}
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errory.ElasticClientErrors.Wrap(err)
+	}
+	return data, nil
+}
+
+func sloHistoryQuery(id int64, timeRange *model.SloHistoryRange) string {
+	return fmt.Sprintf(`{
+			"bool": {
+				"filter": [
+					{
+						"term": {
+							"id": %d
+						}
+					},
+					{
+						"range": {
+						  "date": {
+							"gte": %q,
+							"lte": %q
+						  }
+						}
+					}
+				]
+			}
+		}`, id, timeRange.From.UTC().Format(time.RFC3339), timeRange.To.UTC().Format(time.RFC3339))
+}
+
+type countResponse struct {
+	Aggregations struct {
+		Indices struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount int64  `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"indices"`
+	} `json:"aggregations"`
+}
+
+type valueAggregation struct {
+	Value *float64 `json:"value"`
+}
+
+type historyResponse struct {
+	Aggregations struct {
+		History struct {
+			Buckets []struct {
+				Key         int64            `json:"key"`
+				Compliance  valueAggregation `json:"compliance"`
+				SuccessRate valueAggregation `json:"success_rate"`
+				GoodWindows valueAggregation `json:"good_windows"`
+			} `json:"buckets"`
+		} `json:"history"`
+	} `json:"aggregations"`
+}
+
+type latestResponse struct {
+	Aggregations struct {
+		Latest valueAggregation `json:"latest"`
+	} `json:"aggregations"`
+}
+
+type idsResponse struct {
+	Aggregations struct {
+		IDs struct {
+			AfterKey *idsKey `json:"after_key"`
+			Buckets  []struct {
+				Key idsKey `json:"key"`
+			} `json:"buckets"`
+		} `json:"ids"`
+	} `json:"aggregations"`
+}
+
+type idsKey struct {
+	ID int64 `json:"id"`
+}
+
+type bulkAction struct {
+	Index struct {
+		Index string `json:"_index"`
+		ID    string `json:"_id"`
+	} `json:"index"`
+}
+
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []struct {
+		Index struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"index"`
+	} `json:"items"`
+}
+
+type taskStatus struct {
+	Total            int64 `json:"total"`
+	Deleted          int64 `json:"deleted"`
+	VersionConflicts int64 `json:"version_conflicts"`
+}
+
+// taskSearch is the search source Elasticsearch appends to the description of a delete by query task,
+// e.g. `delete-by-query [index]{"query":{"bool":{"filter":[{"term":{"id":{"value":1}}}]}}}`.
+type taskSearch struct {
+	Query struct {
+		Bool struct {
+			Filter []struct {
+				Term struct {
+					ID json.RawMessage `json:"id"`
+				} `json:"term"`
+			} `json:"filter"`
+		} `json:"bool"`
+	} `json:"query"`
+}
+
+// taskTargetsSlo reports whether the query in a task description filters on the SLO id,
+// which sloHistoryQuery always does first.
+func taskTargetsSlo(description string, id int64) bool {
+	start := strings.Index(description, "{")
+	if start < 0 {
+		return false
+	}
+	var search taskSearch
+	if err := json.Unmarshal([]byte(description[start:]), &search); err != nil {
+		return false
+	}
+	for _, filter := range search.Query.Bool.Filter {
+		if len(filter.Term.ID) == 0 {
+			continue
+		}
+		var term struct {
+			Value int64 `json:"value"`
+		}
+		if err := json.Unmarshal(filter.Term.ID, &term); err != nil {
+			if err := json.Unmarshal(filter.Term.ID, &term.Value); err != nil {
+				return false
+			}
+		}
+		return term.Value == id
+	}
+	return false
+}
+
+type taskResponse struct {
+	Completed bool `json:"completed"`
+	Task      struct {
+		Action      string     `json:"action"`
+		Description string     `json:"description"`
+		Status      taskStatus `json:"status"`
+	} `json:"task"`
+	Response *struct {
+		taskStatus
+		Failures []json.RawMessage `json:"failures"`
+	} `json:"response"`
+}
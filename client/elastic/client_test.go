@@ -5,6 +5,8 @@ package elastic_test
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 	. "github.com/onsi/ginkgo"
@@ -12,6 +14,7 @@ import (
 	"github.com/onsi/gomega/ghttp"
 
 	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	model "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
 	logrustest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -212,15 +215,18 @@ var _ = Describe("Client", func() {
 			})
 		})
 
-		Describe("DeleteSloHistory(id)", func() {
+		Describe("history operations", func() {
 			var server *ghttp.Server
 			var client *Client
 			var statusCode int
 			var returnString string
 			var clientErr error
 			id := int64(11)
+			timeRange := &model.SloHistoryRange{
+				From: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+			}
 			query := fmt.Sprintf(`{
-				"query": {
 					"bool": {
 						"filter": [
 							{
@@ -231,54 +237,383 @@ var _ = Describe("Client", func() {
 							{
 								"range": {
 								  "date": {
-									"gte": "now-14d/d",
-									"lte": "now/d"
+									"gte": "2021-03-01T00:00:00Z",
+									"lte": "2021-03-15T00:00:00Z"
 								  }
 								}
 							}
 						]
 					}
-				}
-			}`, id)
+				}`, id)
 
 			BeforeEach(func() {
 				server = ghttp.NewServer()
 				client, clientErr = New(server.URL(), logger)
 				Expect(clientErr).NotTo(HaveOccurred())
-				server.AppendHandlers(ghttp.CombineHandlers(
-					ghttp.VerifyRequest("POST", fmt.Sprintf("/%s", "oma-datadog-slo-*/_delete_by_query")),
-					ghttp.VerifyJSON(query),
-					ghttp.VerifyContentType("application/json"),
-					ghttp.RespondWithPtr(&statusCode, &returnString),
-				))
 			})
 			AfterEach(func() {
 				server.Close()
 			})
 
-			Context("successful scenario", func() {
+			Describe("CountSloHistory(id, timeRange)", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/oma-datadog-slo-*/_search"),
+						ghttp.VerifyJSON(fmt.Sprintf(`{
+							"size": 0,
+							"query": %s,
+							"aggs": {"indices": {"terms": {"field": "_index", "size": 10000}}}
+						}`, query)),
+						ghttp.VerifyContentType("application/json"),
+						ghttp.RespondWithPtr(&statusCode, &returnString),
+					))
+				})
+
 				Context("when response returns status ok", func() {
 					BeforeEach(func() {
 						statusCode = http.StatusOK
+						returnString = `{"aggregations": {"indices": {"buckets": [
+							{"key": "oma-datadog-slo-2021.03", "doc_count": 42},
+							{"key": "oma-datadog-slo-2021.02", "doc_count": 0}
+						]}}}`
 					})
-					It("returns does not return an error", func() {
-						err := client.DeleteSloHistory(id)
-						Expect(err).To(BeNil())
+					It("returns matching document counts per index", func() {
+						counts, err := client.CountSloHistory(id, timeRange)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(counts).To(Equal([]*model.SloHistoryIndexCount{
+							{Index: "oma-datadog-slo-2021.03", Count: 42},
+							{Index: "oma-datadog-slo-2021.02", Count: 0},
+						}))
+					})
+				})
+				Context("when response status code is different than status ok", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusBadRequest
+						returnString = `{}`
+					})
+					It("returns elasticClientError", func() {
+						counts, err := client.CountSloHistory(id, timeRange)
+						Expect(counts).To(BeNil())
+						Expect(errory.IsOfType(err, errory.ElasticClientErrors)).To(BeTrue())
 					})
 				})
 			})
-			Context("unsuccessful scenarios", func() {
+
+			Describe("DeleteSloHistory(id, timeRange)", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/oma-datadog-slo-*/_delete_by_query", "wait_for_completion=false&conflicts=proceed"),
+						ghttp.VerifyJSON(fmt.Sprintf(`{"query": %s}`, query)),
+						ghttp.VerifyContentType("application/json"),
+						ghttp.RespondWithPtr(&statusCode, &returnString),
+					))
+				})
+
+				Context("when response returns status ok", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"task": "oTUltX4IQMOUUVeiohTt8A:12345"}`
+					})
+					It("returns the id of the started task", func() {
+						taskID, err := client.DeleteSloHistory(id, timeRange)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(taskID).To(Equal("oTUltX4IQMOUUVeiohTt8A:12345"))
+					})
+				})
+				Context("when history index is configured", func() {
+					BeforeEach(func() {
+						client.HistoryIndex = "custom-slo-*"
+						server.SetHandler(0, ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", "/custom-slo-*/_delete_by_query"),
+							ghttp.RespondWith(http.StatusOK, `{"task": "node:1"}`),
+						))
+					})
+					It("deletes from the configured indices", func() {
+						taskID, err := client.DeleteSloHistory(id, timeRange)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(taskID).To(Equal("node:1"))
+					})
+				})
+				Context("when response does not contain a task", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{}`
+					})
+					It("returns elasticClientError", func() {
+						_, err := client.DeleteSloHistory(id, timeRange)
+						Expect(errory.IsOfType(err, errory.ElasticClientErrors)).To(BeTrue())
+					})
+				})
 				Context("when response status code is different than status ok", func() {
 					BeforeEach(func() {
 						statusCode = http.StatusNotFound
+						returnString = `{}`
 					})
 					It("returns elasticClientError", func() {
-						err := client.DeleteSloHistory(id)
+						_, err := client.DeleteSloHistory(id, timeRange)
 						Expect(err).To(HaveOccurred())
 						Expect(errory.IsOfType(err, errory.ElasticClientErrors)).To(BeTrue())
 					})
 				})
 			})
+
+			Describe("GetDeleteTask(id, taskID)", func() {
+				const taskID = "node:12345"
+				BeforeEach(func() {
+					server.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/_tasks/node:12345"),
+						ghttp.RespondWithPtr(&statusCode, &returnString),
+					))
+				})
+
+				Context("when task is still running", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"completed": false, "task": {"action": "indices:data/write/delete/byquery",
+							"description": "delete-by-query [oma-datadog-slo-*]{\"size\":1000,\"query\":{\"bool\":{\"filter\":[{\"term\":{\"id\":{\"value\":5,\"boost\":1.0}}}]}}}",
+							"status": {"total": 100, "deleted": 40, "version_conflicts": 1}}}`
+					})
+					It("returns task progress", func() {
+						task, err := client.GetDeleteTask(5, taskID)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(*task).To(Equal(model.SloHistoryDeletionTask{TaskID: taskID, Total: 100, Deleted: 40, Conflicts: 1}))
+					})
+				})
+				Context("when task is completed", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"completed": true, "task": {"action": "indices:data/write/delete/byquery",
+							"description": "delete-by-query [oma-datadog-slo-*]{\"query\":{\"bool\":{\"filter\":[{\"term\":{\"id\":5}}]}}}",
+							"status": {"total": 100, "deleted": 90}},
+							"response": {"total": 100, "deleted": 99, "failures": [{"index": "oma-datadog-slo-2021.03"}]}}`
+					})
+					It("returns the final result", func() {
+						task, err := client.GetDeleteTask(5, taskID)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(*task).To(Equal(model.SloHistoryDeletionTask{TaskID: taskID, Completed: true, Total: 100, Deleted: 99, Failures: 1}))
+					})
+				})
+				Context("when task is not a delete by query", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"completed": true, "task": {"action": "indices:data/write/reindex"}}`
+					})
+					It("returns not found error", func() {
+						_, err := client.GetDeleteTask(5, taskID)
+						Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+					})
+				})
+				Context("when task deletes history of another SLO", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"completed": true, "task": {"action": "indices:data/write/delete/byquery",
+							"description": "delete-by-query [oma-datadog-slo-*]{\"query\":{\"bool\":{\"filter\":[{\"term\":{\"id\":{\"value\":6}}}]}}}"}}`
+					})
+					It("returns not found error", func() {
+						_, err := client.GetDeleteTask(5, taskID)
+						Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+					})
+				})
+				Context("when task does not exist", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusNotFound
+						returnString = `{}`
+					})
+					It("returns not found error", func() {
+						_, err := client.GetDeleteTask(5, taskID)
+						Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+					})
+				})
+			})
+
+			Describe("GetSloHistory(id, timeRange, interval)", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/oma-datadog-slo-*/_search"),
+						ghttp.VerifyJSON(fmt.Sprintf(`{
+							"size": 0,
+							"query": %s,
+							"aggs": {"history": {
+								"date_histogram": {"field": "date", "fixed_interval": "7d", "min_doc_count": 0,
+									"extended_bounds": {"min": 1614556800000, "max": 1615766400000}},
+								"aggs": {
+									"compliance": {"avg": {"field": "compliance"}},
+									"success_rate": {"avg": {"field": "success_rate"}},
+									"good_windows": {"avg": {"field": "good_windows"}}
+								}
+							}}
+						}`, query)),
+						ghttp.VerifyContentType("application/json"),
+						ghttp.RespondWithPtr(&statusCode, &returnString),
+					))
+				})
+
+				Context("when response returns status ok", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"aggregations": {"history": {"buckets": [
+							{"key": 1614556800000, "doc_count": 2, "compliance": {"value": 99.5}, "success_rate": {"value": 98.25},
+								"good_windows": {"value": null}},
+							{"key": 1615161600000, "doc_count": 0, "compliance": {"value": null}, "success_rate": {"value": null},
+								"good_windows": {"value": null}}
+						]}}}`
+					})
+					It("returns a point per bucket", func() {
+						points, err := client.GetSloHistory(id, timeRange, "7d")
+						Expect(err).NotTo(HaveOccurred())
+						compliance, successRate := 99.5, 98.25
+						Expect(points).To(Equal([]*model.SloHistoryPoint{
+							{Time: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), Compliance: &compliance, SuccessRate: &successRate},
+							{Time: time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)},
+						}))
+					})
+				})
+				Context("when response status code is different than status ok", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusBadRequest
+						returnString = `{}`
+					})
+					It("returns elasticClientError", func() {
+						points, err := client.GetSloHistory(id, timeRange, "7d")
+						Expect(points).To(BeNil())
+						Expect(errory.IsOfType(err, errory.ElasticClientErrors)).To(BeTrue())
+					})
+				})
+			})
+
+			Describe("GetLatestSloHistoryDate(id)", func() {
+				BeforeEach(func() {
+					server.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/oma-datadog-slo-*/_search"),
+						ghttp.VerifyJSON(fmt.Sprintf(`{
+							"size": 0,
+							"query": {"term": {"id": %d}},
+							"aggs": {"latest": {"max": {"field": "date"}}}
+						}`, id)),
+						ghttp.RespondWithPtr(&statusCode, &returnString),
+					))
+				})
+
+				Context("when history exists", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"aggregations": {"latest": {"value": 1615766400000, "value_as_string": "2021-03-15T00:00:00.000Z"}}}`
+					})
+					It("returns the date of the latest document", func() {
+						latest, err := client.GetLatestSloHistoryDate(id)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(*latest).To(Equal(timeRange.To))
+					})
+				})
+				Context("when there is no history", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"aggregations": {"latest": {"value": null}}}`
+					})
+					It("returns nil", func() {
+						latest, err := client.GetLatestSloHistoryDate(id)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(latest).To(BeNil())
+					})
+				})
+			})
+
+			Describe("GetSloHistoryIDs()", func() {
+				idsQuery := func(after string) string {
+					return fmt.Sprintf(`{
+						"size": 0,
+						"aggs": {"ids": {"composite": {"size": 1000, "sources": [{"id": {"terms": {"field": "id"}}}]%s}}}
+					}`, after)
+				}
+
+				Context("when the ids fill more than one page", func() {
+					BeforeEach(func() {
+						buckets := make([]string, 1000)
+						for i := range buckets {
+							buckets[i] = fmt.Sprintf(`{"key": {"id": %d}, "doc_count": 1}`, i+1)
+						}
+						server.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("POST", "/oma-datadog-slo-*/_search"),
+								ghttp.VerifyJSON(idsQuery("")),
+								ghttp.RespondWith(http.StatusOK, `{"aggregations": {"ids": {"after_key": {"id": 1000}, "buckets": [`+
+									strings.Join(buckets, ",")+`]}}}`),
+							),
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("POST", "/oma-datadog-slo-*/_search"),
+								ghttp.VerifyJSON(idsQuery(`, "after": {"id": 1000}`)),
+								ghttp.RespondWith(http.StatusOK, `{"aggregations": {"ids": {"after_key": {"id": 1001}, "buckets": [
+									{"key": {"id": 1001}, "doc_count": 3}
+								]}}}`),
+							),
+						)
+					})
+					It("returns the ids of all pages", func() {
+						ids, err := client.GetSloHistoryIDs()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(ids).To(HaveLen(1001))
+						Expect(ids[0]).To(Equal(int64(1)))
+						Expect(ids[1000]).To(Equal(int64(1001)))
+					})
+				})
+				Context("when response status code is different than status ok", func() {
+					BeforeEach(func() {
+						server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, `{}`))
+					})
+					It("returns elasticClientError", func() {
+						ids, err := client.GetSloHistoryIDs()
+						Expect(ids).To(BeNil())
+						Expect(errory.IsOfType(err, errory.ElasticClientErrors)).To(BeTrue())
+					})
+				})
+			})
+
+			Describe("IndexSloHistory(documents)", func() {
+				compliance := 99.5
+				documents := []*model.SloHistoryDocument{
+					{ID: id, ExternalID: "abc", Date: timeRange.From, Compliance: &compliance},
+					{ID: id, ExternalID: "abc", Date: timeRange.To},
+				}
+				bulkBody := `{"index":{"_index":"oma-datadog-slo-2021.03","_id":"11-20210301"}}
+{"id":11,"external_id":"abc","date":"2021-03-01T00:00:00Z","compliance":99.5}
+{"index":{"_index":"oma-datadog-slo-2021.03","_id":"11-20210315"}}
+{"id":11,"external_id":"abc","date":"2021-03-15T00:00:00Z"}
+`
+				BeforeEach(func() {
+					server.AppendHandlers(ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/_bulk"),
+						ghttp.VerifyContentType("application/x-ndjson"),
+						ghttp.VerifyBody([]byte(bulkBody)),
+						ghttp.RespondWithPtr(&statusCode, &returnString),
+					))
+				})
+
+				Context("when all documents are indexed", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"errors": false, "items": [{"index": {"status": 201}}, {"index": {"status": 200}}]}`
+					})
+					It("does not return an error", func() {
+						Expect(client.IndexSloHistory(documents)).To(Succeed())
+					})
+				})
+				Context("when some documents fail", func() {
+					BeforeEach(func() {
+						statusCode = http.StatusOK
+						returnString = `{"errors": true, "items": [{"index": {"status": 201}}, {"index": {"status": 400, "error": {"type": "mapper_parsing_exception"}}}]}`
+					})
+					It("returns elasticClientError", func() {
+						err := client.IndexSloHistory(documents)
+						Expect(errory.IsOfType(err, errory.ElasticClientErrors)).To(BeTrue())
+					})
+				})
+				Context("when there are no documents", func() {
+					It("does not send a request", func() {
+						Expect(client.IndexSloHistory(nil)).To(Succeed())
+						Expect(server.ReceivedRequests()).To(BeEmpty())
+					})
+				})
+			})
 		})
 	})
 })
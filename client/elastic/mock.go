@@ -6,6 +6,7 @@ package elastic
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	elastic "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
@@ -34,6 +35,21 @@ func (m *MockIClient) EXPECT() *MockIClientMockRecorder {
 	return m.recorder
 }
 
+// CountSloHistory mocks base method.
+func (m *MockIClient) CountSloHistory(arg0 int64, arg1 *elastic.SloHistoryRange) ([]*elastic.SloHistoryIndexCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSloHistory", arg0, arg1)
+	ret0, _ := ret[0].([]*elastic.SloHistoryIndexCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSloHistory indicates an expected call of CountSloHistory.
+func (mr *MockIClientMockRecorder) CountSloHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSloHistory", reflect.TypeOf((*MockIClient)(nil).CountSloHistory), arg0, arg1)
+}
+
 // CreateIndex mocks base method.
 func (m *MockIClient) CreateIndex(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -49,17 +65,33 @@ func (mr *MockIClientMockRecorder) CreateIndex(arg0, arg1 interface{}) *gomock.C
 }
 
 // DeleteSloHistory mocks base method.
-func (m *MockIClient) DeleteSloHistory(arg0 int64) error {
+func (m *MockIClient) DeleteSloHistory(arg0 int64, arg1 *elastic.SloHistoryRange) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSloHistory", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteSloHistory", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteSloHistory indicates an expected call of DeleteSloHistory.
-func (mr *MockIClientMockRecorder) DeleteSloHistory(arg0 interface{}) *gomock.Call {
+func (mr *MockIClientMockRecorder) DeleteSloHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSloHistory", reflect.TypeOf((*MockIClient)(nil).DeleteSloHistory), arg0, arg1)
+}
+
+// GetDeleteTask mocks base method.
+func (m *MockIClient) GetDeleteTask(arg0 int64, arg1 string) (*elastic.SloHistoryDeletionTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleteTask", arg0, arg1)
+	ret0, _ := ret[0].(*elastic.SloHistoryDeletionTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleteTask indicates an expected call of GetDeleteTask.
+func (mr *MockIClientMockRecorder) GetDeleteTask(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSloHistory", reflect.TypeOf((*MockIClient)(nil).DeleteSloHistory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleteTask", reflect.TypeOf((*MockIClient)(nil).GetDeleteTask), arg0, arg1)
 }
 
 // GetIndices mocks base method.
@@ -76,3 +108,62 @@ func (mr *MockIClientMockRecorder) GetIndices() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndices", reflect.TypeOf((*MockIClient)(nil).GetIndices))
 }
+
+// GetLatestSloHistoryDate mocks base method.
+func (m *MockIClient) GetLatestSloHistoryDate(arg0 int64) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSloHistoryDate", arg0)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSloHistoryDate indicates an expected call of GetLatestSloHistoryDate.
+func (mr *MockIClientMockRecorder) GetLatestSloHistoryDate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSloHistoryDate", reflect.TypeOf((*MockIClient)(nil).GetLatestSloHistoryDate), arg0)
+}
+
+// GetSloHistory mocks base method.
+func (m *MockIClient) GetSloHistory(arg0 int64, arg1 *elastic.SloHistoryRange, arg2 string) ([]*elastic.SloHistoryPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*elastic.SloHistoryPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloHistory indicates an expected call of GetSloHistory.
+func (mr *MockIClientMockRecorder) GetSloHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloHistory", reflect.TypeOf((*MockIClient)(nil).GetSloHistory), arg0, arg1, arg2)
+}
+
+// GetSloHistoryIDs mocks base method.
+func (m *MockIClient) GetSloHistoryIDs() ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloHistoryIDs")
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloHistoryIDs indicates an expected call of GetSloHistoryIDs.
+func (mr *MockIClientMockRecorder) GetSloHistoryIDs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloHistoryIDs", reflect.TypeOf((*MockIClient)(nil).GetSloHistoryIDs))
+}
+
+// IndexSloHistory mocks base method.
+func (m *MockIClient) IndexSloHistory(arg0 []*elastic.SloHistoryDocument) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IndexSloHistory", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IndexSloHistory indicates an expected call of IndexSloHistory.
+func (mr *MockIClientMockRecorder) IndexSloHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexSloHistory", reflect.TypeOf((*MockIClient)(nil).IndexSloHistory), arg0)
+}
@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+)
+
+const (
+	// DeliveryTimeout is the longest a delivery may take.
+	DeliveryTimeout = 10 * time.Second
+	// maxDrainLength limits the part of the response body read to reuse the connection.
+	maxDrainLength = 4096
+	lookupTimeout  = 5 * time.Second
+)
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, not covered by net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+type IClient interface {
+	VerifyURL(url string) error
+	Post(url string, headers map[string]string, body []byte) (int, error)
+}
+
+// Client posts webhook deliveries. Redirects are not followed, they fail the delivery like
+// any other status than 2xx. Loopback, private, link-local and other internal addresses are
+// refused when a webhook is registered and again when a delivery connects, unless
+// AllowInternalTargets is set.
+type Client struct {
+	*http.Client
+	AllowInternalTargets bool
+}
+
+func New(allowInternalTargets bool) *Client {
+	c := &Client{AllowInternalTargets: allowInternalTargets}
+	dialer := &net.Dialer{Timeout: DeliveryTimeout, Control: c.checkDialedAddress}
+	c.Client = &http.Client{
+		Timeout: DeliveryTimeout,
+		Transport: &http.Transport{
+			// deliveries are not sent through a proxy, it would connect to the target instead of the checked dialer
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: DeliveryTimeout,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return c
+}
+
+// VerifyURL checks that the URL is http or https and that its host resolves to public addresses only.
+func (c *Client) VerifyURL(rawURL string) error {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return errory.ValidationErrors.Builder().Wrap(err).WithPayload("url", rawURL).WithMessage("url cannot be parsed").Create()
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return errory.ValidationErrors.Builder().WithPayload("url", rawURL).WithMessage("url has to be http or https").Create()
+	}
+	if c.AllowInternalTargets {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, target.Hostname())
+	if err != nil {
+		return errory.ValidationErrors.Builder().Wrap(err).WithPayload("url", rawURL).WithMessage("url host cannot be resolved").Create()
+	}
+	for _, address := range addresses {
+		if isInternal(address.IP) {
+			return errory.ValidationErrors.Builder().WithPayload("url", rawURL).
+				WithMessage("url must not point to a loopback, private or link-local address").Create()
+		}
+	}
+	return nil
+}
+
+// checkDialedAddress refuses connections to internal addresses, the host may resolve to another
+// address than when the webhook was registered.
+func (c *Client) checkDialedAddress(network, address string, _ syscall.RawConn) error {
+	if c.AllowInternalTargets {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isInternal(ip) {
+		return fmt.Errorf("connection to internal address %s refused", host)
+	}
+	return nil
+}
+
+func isInternal(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// Post sends the JSON body with the headers and returns the response status, which is 0 when the
+// endpoint could not be reached. The response body is never returned, only the status.
+func (c *Client) Post(url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, errory.FetchResourceErrors.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	r, err := c.Do(req)
+	if err != nil {
+		return 0, errory.FetchResourceErrors.Wrap(err)
+	}
+	defer r.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(r.Body, maxDrainLength)) //nolint:errcheck
+
+	if r.StatusCode < http.StatusOK || r.StatusCode >= http.StatusMultipleChoices {
+		return r.StatusCode, errory.FetchResourceErrors.Builder().
+			WithPayload("code", r.StatusCode).
+			WithMessage(fmt.Sprintf("endpoint answered with status %d", r.StatusCode)).
+			Create()
+	}
+	return r.StatusCode, nil
+}
@@ -0,0 +1,129 @@
+//go:build unitTests
+// +build unitTests
+
+package webhook_test
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/webhook"
+)
+
+var _ = Describe("Client", func() {
+	Describe("Post(url, headers, body)", func() {
+		var server *ghttp.Server
+		var client *Client
+		var statusCode int
+		var returnString string
+		const body = `{"event":"slo.created","orgId":2}`
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			client = New(true)
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/hooks/cruiser"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyHeaderKV("X-Cruiser-Event", "slo.created"),
+				ghttp.VerifyBody([]byte(body)),
+				ghttp.RespondWithPtr(&statusCode, &returnString),
+			))
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		post := func() (int, error) {
+			return client.Post(server.URL()+"/hooks/cruiser", map[string]string{"X-Cruiser-Event": "slo.created"}, []byte(body))
+		}
+
+		Context("when the endpoint answers with 2xx", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusAccepted
+				returnString = ""
+			})
+			It("returns the status", func() {
+				status, err := post()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal(http.StatusAccepted))
+			})
+		})
+
+		Context("when the endpoint answers with an error", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusServiceUnavailable
+				returnString = "maintenance " + strings.Repeat("x", 1000)
+			})
+			It("returns the status without the response as error", func() {
+				status, err := post()
+				Expect(status).To(Equal(http.StatusServiceUnavailable))
+				Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+				Expect(errory.GetErrorDetails(err).Message).To(HaveSuffix("endpoint answered with status 503"))
+				Expect(errory.GetErrorDetails(err).Message).NotTo(ContainSubstring("maintenance"))
+			})
+		})
+
+		Context("when the endpoint redirects", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusFound
+				returnString = ""
+			})
+			It("does not follow the redirect", func() {
+				status, err := post()
+				Expect(status).To(Equal(http.StatusFound))
+				Expect(err).To(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
+	Context("when the endpoint cannot be reached", func() {
+		It("returns status 0", func() {
+			server := ghttp.NewServer()
+			url := server.URL()
+			server.Close()
+
+			status, err := New(true).Post(url, nil, []byte("{}"))
+			Expect(status).To(Equal(0))
+			Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+		})
+	})
+
+	Context("when the endpoint is internal", func() {
+		It("refuses to connect", func() {
+			server := ghttp.NewServer()
+			defer server.Close()
+
+			status, err := New(false).Post(server.URL(), nil, []byte("{}"))
+			Expect(status).To(Equal(0))
+			Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Describe("VerifyURL(url)", func() {
+		It("rejects loopback, private and link-local addresses", func() {
+			for _, url := range []string{"http://127.0.0.1:8080", "http://localhost", "https://10.1.2.3", "http://192.168.0.1",
+				"http://169.254.169.254/latest/meta-data", "http://[::1]", "http://[fd00::1]", "http://100.64.0.1", "http://0.0.0.0"} {
+				Expect(errory.IsOfType(New(false).VerifyURL(url), errory.ValidationErrors)).To(BeTrue(), url)
+			}
+		})
+
+		It("accepts public addresses", func() {
+			Expect(New(false).VerifyURL("https://93.184.216.34/hooks")).To(Succeed())
+		})
+
+		It("rejects other schemes than http", func() {
+			Expect(errory.IsOfType(New(true).VerifyURL("file:///etc/passwd"), errory.ValidationErrors)).To(BeTrue())
+		})
+
+		It("accepts internal addresses when allowed", func() {
+			Expect(New(true).VerifyURL("http://127.0.0.1:8080")).To(Succeed())
+		})
+	})
+})
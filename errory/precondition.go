@@ -0,0 +1,10 @@
+package errory
+
+import "net/http"
+
+var (
+	// PreconditionFailedErrors reject changes based on an outdated version of the entity.
+	PreconditionFailedErrors = NewType("ebt.precondition_failed", http.StatusPreconditionFailed)
+	// PreconditionRequiredErrors reject changes which do not tell the version they are based on.
+	PreconditionRequiredErrors = NewType("ebt.precondition_required", http.StatusPreconditionRequired)
+)
@@ -0,0 +1,6 @@
+package errory
+
+import "net/http"
+
+// ServiceUnavailableErrors reject requests which depend on an external service that cannot be reached.
+var ServiceUnavailableErrors = NewType("ebt.service_unavailable", http.StatusServiceUnavailable)
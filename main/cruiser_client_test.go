@@ -0,0 +1,449 @@
+//go:build unitTests
+// +build unitTests
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/cruiser"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/middleware"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	authModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+)
+
+// The client is tested against the router of the API, only the services behind it are mocked.
+var _ = Describe("cruiser client against the CruiserServer router", func() {
+	var mockController *gomock.Controller
+	var authenticatorMock *auth.MockIAuthenticator
+	var authorizerMock *middleware.MockIAuthorizer
+	var paramExistMock *service.MockIParamExistCheckService
+	var idempotencyMock *service.MockIIdempotencyService
+	var sloServiceMock *service.MockISloService
+	var changeRequestsMock *service.MockISloChangeRequestService
+	var versionsMock *service.MockIEntityVersionService
+	var orgServiceMock *service.MockIOrganizationService
+	var datasourceServiceMock *service.MockIDatasourceService
+	var happinessServiceMock *service.MockIHappinessMetricService
+	var feedbackServiceMock *service.MockIFeedbackService
+	var voteServiceMock *service.MockIRecommendationVoteService
+	var solutionsServiceMock *service.MockISolutionsService
+	var productsStatusServiceMock *service.MockIProductsStatusService
+	var sloBatchServiceMock *service.MockISloBatchService
+
+	var server *httptest.Server
+	var client *cruiser.Client
+	user := &authModel.UserContext{ID: 3, Cookie: "session"}
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		authenticatorMock = auth.NewMockIAuthenticator(mockController)
+		authorizerMock = middleware.NewMockIAuthorizer(mockController)
+		paramExistMock = service.NewMockIParamExistCheckService(mockController)
+		idempotencyMock = service.NewMockIIdempotencyService(mockController)
+		sloServiceMock = service.NewMockISloService(mockController)
+		changeRequestsMock = service.NewMockISloChangeRequestService(mockController)
+		versionsMock = service.NewMockIEntityVersionService(mockController)
+		orgServiceMock = service.NewMockIOrganizationService(mockController)
+		datasourceServiceMock = service.NewMockIDatasourceService(mockController)
+		happinessServiceMock = service.NewMockIHappinessMetricService(mockController)
+		feedbackServiceMock = service.NewMockIFeedbackService(mockController)
+		voteServiceMock = service.NewMockIRecommendationVoteService(mockController)
+		solutionsServiceMock = service.NewMockISolutionsService(mockController)
+		productsStatusServiceMock = service.NewMockIProductsStatusService(mockController)
+		sloBatchServiceMock = service.NewMockISloBatchService(mockController)
+
+		sloValidatorMock := validator.NewMockISLOValidator(mockController)
+		sloValidatorMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		happinessValidatorMock := validator.NewMockIHappinessMetricValidator(mockController)
+		happinessValidatorMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		feedbackValidatorMock := validator.NewMockIFeedbackValidator(mockController)
+		feedbackValidatorMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		translatedValidatorMock := validator.NewMockITranslatedValidator(mockController)
+		translatedValidatorMock.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+		logger, _ := logrustest.NewNullLogger()
+		s := &CruiserServer{
+			OrgAPI: &api.OrgAPI{SloService: sloServiceMock, OrgService: orgServiceMock, DatasourceService: datasourceServiceMock,
+				HappinessService: happinessServiceMock, Validator: translatedValidatorMock, Log: logger},
+			SloAPI: &api.SloAPI{SloService: sloServiceMock, ChangeRequests: changeRequestsMock, Versions: versionsMock,
+				Validator: sloValidatorMock, Log: logger},
+			FeedbackAPI:            &api.FeedbackAPI{Service: feedbackServiceMock, Validator: feedbackValidatorMock, Log: logger},
+			HappinessMetricAPI:     &api.HappinessMetricAPI{Service: happinessServiceMock, Versions: versionsMock, Validator: happinessValidatorMock, Log: logger},
+			RecommendationVoteAPI:  &api.RecommendationVoteAPI{Service: voteServiceMock, Validator: translatedValidatorMock, Log: logger},
+			HealthAPI:              &api.HealthAPI{},
+			ConfigureUserAPI:       &api.ConfigureUserAPI{Log: logger},
+			Log:                    logger,
+			Authenticator:          authenticatorMock,
+			DatasourceAPI:          &api.DatasourceAPI{DatasourceService: datasourceServiceMock, Log: logger},
+			PluginAPI:              &api.PluginAPI{Log: logger},
+			SDAAPI:                 &api.SDAAPI{Log: logger},
+			SolutionsAPI:           &api.SolutionsAPI{Service: solutionsServiceMock, Log: logger},
+			SolutionSloAPI:         &api.SolutionSloAPI{Log: logger},
+			ProductsStatusAPI:      &api.ProductsStatusAPI{Service: productsStatusServiceMock, Log: logger},
+			Authorizer:             authorizerMock,
+			Cors:                   Cors(func(c *gin.Context) { c.Next() }),
+			ParamExistCheckService: paramExistMock,
+			MaintenanceWindowAPI:   &api.MaintenanceWindowAPI{Log: logger},
+			BudgetPolicyAPI:        &api.BudgetPolicyAPI{Log: logger},
+			ComplianceReportAPI:    &api.ComplianceReportAPI{Log: logger},
+			SlaAPI:                 &api.SlaAPI{Log: logger},
+			SloLabelAPI:            &api.SloLabelAPI{Log: logger},
+			SloOwnershipAPI:        &api.SloOwnershipAPI{Log: logger},
+			SloChangeRequestAPI:    &api.SloChangeRequestAPI{Log: logger},
+			SearchAPI:              &api.SearchAPI{Log: logger},
+			Idempotency:            idempotencyMock,
+			SloBatchAPI:            &api.SloBatchAPI{Service: sloBatchServiceMock, Validator: sloValidatorMock, Log: logger},
+		}
+		server = httptest.NewServer(s.Router())
+
+		var err error
+		client, err = cruiser.New(server.URL, cruiser.BearerAuth("token"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockController.Finish()
+	})
+
+	authenticate := func() {
+		authenticatorMock.EXPECT().Authenticate(gomock.Any()).Return(user, nil)
+	}
+
+	Describe("authentication", func() {
+		var authorization, cookie string
+
+		BeforeEach(func() {
+			authenticatorMock.EXPECT().Authenticate(gomock.Any()).DoAndReturn(func(c *gin.Context) (*authModel.UserContext, error) {
+				authorization = c.GetHeader("Authorization")
+				cookie, _ = c.Cookie("grafana_session")
+				return user, nil
+			})
+			productsStatusServiceMock.EXPECT().GetProductsStatus().Return([]*model.ProductStatus{{ProductName: "shop"}}, nil)
+		})
+
+		It("should send the bearer token", func() {
+			productsStatus, err := client.GetProductsStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(productsStatus[0].ProductName).To(Equal("shop"))
+			Expect(authorization).To(Equal("Bearer token"))
+		})
+
+		It("should send the basic auth", func() {
+			client, err := cruiser.New(server.URL, cruiser.BasicAuth("jane", "secret"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.GetProductsStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorization).To(Equal("Basic amFuZTpzZWNyZXQ="))
+		})
+
+		It("should send the grafana session", func() {
+			client, err := cruiser.New(server.URL, cruiser.CookieAuth("session"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = client.GetProductsStatus(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cookie).To(Equal("session"))
+		})
+	})
+
+	Describe("errors", func() {
+		It("should return unauthorized when authentication fails", func() {
+			authenticatorMock.EXPECT().Authenticate(gomock.Any()).Return(nil, errory.AuthErrors.New("bearer token incorrect"))
+
+			_, err := client.GetProductsStatus(ctx)
+			Expect(cruiser.IsUnauthorized(err)).To(BeTrue())
+			Expect(cruiser.IsOfType(err, errory.AuthErrors)).To(BeTrue())
+		})
+
+		It("should return not found with the errory type of the service error", func() {
+			authenticate()
+			sloServiceMock.EXPECT().Get(int64(7)).Return(nil, errory.NotFoundErrors.New("slo 7 not found"))
+
+			_, _, err := client.GetSlo(ctx, 7)
+			Expect(cruiser.IsNotFound(err)).To(BeTrue())
+			Expect(cruiser.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			apiErr, _ := cruiser.AsError(err)
+			Expect(apiErr.Problem.Instance).To(Equal("/v1/slo/7"))
+		})
+
+		It("should return precondition required for an update without ETag", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForSLO(int64(7), user.ID, authModel.Editor).Return(true, nil)
+
+			_, err := client.UpdateSlo(ctx, newSlo(7), "")
+			Expect(cruiser.IsPreconditionRequired(err)).To(BeTrue())
+		})
+	})
+
+	Describe("SLOs", func() {
+		It("should update the SLO with the ETag of the read", func() {
+			authenticate()
+			sloServiceMock.EXPECT().Get(int64(7)).Return(newSlo(7), nil)
+			versionsMock.EXPECT().GetVersion(model.VersionedEntitySlo, int64(7)).Return(int64(3), nil)
+
+			slo, etag, err := client.GetSlo(ctx, 7)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(etag).To(Equal(`"3"`))
+
+			version := int64(3)
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForSLO(int64(7), user.ID, authModel.Editor).Return(true, nil)
+			versionsMock.EXPECT().Verify(model.VersionedEntitySlo, int64(7), &version).Return(nil)
+			changeRequestsMock.EXPECT().RequestIfRequired(user, model.SloChangeActionUpdate, int64(7), gomock.Any(), &version, false).Return(nil, nil)
+			sloServiceMock.EXPECT().Update(user, gomock.Any(), &version).Return(int64(4), nil)
+
+			result, err := client.UpdateSlo(ctx, slo, etag)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Pending()).To(BeFalse())
+			Expect(result.ID).To(Equal(int64(7)))
+			Expect(result.ETag).To(Equal(`"4"`))
+		})
+
+		It("should return the change request of a deletion which needs approval", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForSLO(int64(7), user.ID, authModel.Editor).Return(true, nil)
+			changeRequestsMock.EXPECT().RequestIfRequired(user, model.SloChangeActionDelete, int64(7), nil, nil, true).
+				Return(&model.SloChangeRequest{ID: 11, SloID: 7}, nil)
+
+			result, err := client.DeleteSlo(ctx, 7, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Pending()).To(BeTrue())
+			Expect(result.ChangeRequest.ID).To(Equal(int64(11)))
+		})
+
+		It("should create the SLO", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForOrganization(int64(2), user.ID, authModel.Editor).Return(true, nil)
+			sloServiceMock.EXPECT().Create(user, gomock.Any()).DoAndReturn(func(_ *authModel.UserContext, slo *model.Slo) error {
+				slo.ID = 9
+				return nil
+			})
+
+			id, err := client.CreateSlo(ctx, newSlo(0), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(int64(9)))
+		})
+
+		It("should read all pages of SLOs", func() {
+			cursor := &model.SloCursor{Sort: model.SloSortFieldName, Value: "b", ID: 2}
+			authenticate()
+			sloServiceMock.EXPECT().GetSloPage(gomock.Any()).DoAndReturn(func(query *model.SloPageQuery) (*model.SloPage, error) {
+				Expect(query.Limit).To(Equal(model.MaxSloPageLimit))
+				Expect(query.After).To(BeNil())
+				return &model.SloPage{Slos: []*model.DetailedSlo{{Slo: *newSlo(1)}, {Slo: *newSlo(2)}}, Total: 3, NextCursor: cursor}, nil
+			})
+			authenticate()
+			sloServiceMock.EXPECT().GetSloPage(gomock.Any()).DoAndReturn(func(query *model.SloPageQuery) (*model.SloPage, error) {
+				Expect(query.After).To(Equal(cursor))
+				return &model.SloPage{Slos: []*model.DetailedSlo{{Slo: *newSlo(3)}}, Total: 3}, nil
+			})
+
+			slos, err := client.AllSlos(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slos).To(HaveLen(3))
+			Expect(slos[2].ID).To(Equal(int64(3)))
+		})
+
+		It("should return unauthorized for the history of an SLO the user may not see", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForSLO(int64(7), user.ID, authModel.Viewer).Return(false, nil)
+
+			_, err := client.GetSloHistory(ctx, 7, nil)
+			Expect(cruiser.IsUnauthorized(err)).To(BeTrue())
+		})
+
+		It("should return unauthorized for the error budget and its forecast of an SLO the user may not see", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForSLO(int64(7), user.ID, authModel.Viewer).Return(false, nil)
+
+			_, err := client.GetErrorBudget(ctx, 7)
+			Expect(cruiser.IsUnauthorized(err)).To(BeTrue())
+
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForSLO(int64(7), user.ID, authModel.Viewer).Return(false, nil)
+
+			_, err = client.GetBudgetForecast(ctx, 7, 14)
+			Expect(cruiser.IsUnauthorized(err)).To(BeTrue())
+		})
+
+		It("should return the batch result when operations failed", func() {
+			authenticate()
+			sloBatchServiceMock.EXPECT().Execute(user, gomock.Any()).Return(&model.SloBatchResult{
+				Mode:    model.SloBatchModeBestEffort,
+				Failed:  1,
+				Results: []*model.SloBatchItemResult{{Index: 0, Action: model.SloBatchActionDelete, ID: 7, Status: http.StatusNotFound}},
+			}, nil)
+
+			result, err := client.ExecuteSloBatch(ctx, &model.SloBatch{
+				Mode:       model.SloBatchModeBestEffort,
+				Operations: []*model.SloBatchOperation{{Action: model.SloBatchActionDelete, ID: 7}},
+			}, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Failed).To(Equal(1))
+			Expect(result.Results[0].Status).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("organizations", func() {
+		It("should return the organization", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForOrganization(int64(2), user.ID, authModel.Viewer).Return(true, nil)
+			orgServiceMock.EXPECT().GetOrganizationByID(int64(2)).Return(&grafanaModel.Organization{ID: 2, Name: "shop"}, nil)
+
+			org, err := client.GetOrg(ctx, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(org.Name).To(Equal("shop"))
+		})
+
+		It("should return a page of the SLOs of the organization", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForOrganization(int64(2), user.ID, authModel.Viewer).Return(true, nil)
+			paramExistMock.EXPECT().CheckOrgByID(int64(2)).Return(nil)
+			sloServiceMock.EXPECT().GetSloPage(gomock.Any()).DoAndReturn(func(query *model.SloPageQuery) (*model.SloPage, error) {
+				Expect(*query.OrgID).To(Equal(int64(2)))
+				Expect(query.Limit).To(Equal(1))
+				return &model.SloPage{
+					Slos:       []*model.DetailedSlo{{Slo: *newSlo(1)}},
+					Total:      2,
+					NextCursor: &model.SloCursor{Sort: model.SloSortFieldName, Value: "a", ID: 1},
+				}, nil
+			})
+
+			page, err := client.ListOrgSlos(ctx, 2, &cruiser.SloListOptions{Limit: 1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Slos).To(HaveLen(1))
+			Expect(page.Total).To(Equal(int64(2)))
+			Expect(page.HasNext()).To(BeTrue())
+		})
+
+		It("should return unauthorized when the user may not see the organization", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForOrganization(int64(2), user.ID, authModel.Viewer).Return(false, nil)
+
+			_, err := client.GetOrgDatasources(ctx, 2)
+			Expect(cruiser.IsUnauthorized(err)).To(BeTrue())
+		})
+	})
+
+	Describe("datasources", func() {
+		It("should return the datasource", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForDatasource(int64(4), user.ID, authModel.Viewer).Return(true, nil)
+			datasourceServiceMock.EXPECT().GetDatasourceByID(int64(4)).Return(&grafanaModel.Datasource{ID: 4, Type: "datadog"}, nil)
+
+			datasource, err := client.GetDatasource(ctx, 4)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(datasource.Type).To(Equal("datadog"))
+		})
+	})
+
+	Describe("happiness metrics", func() {
+		It("should create the happiness metric with an idempotency key", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForTeam(int64(2), user.ID, authModel.Editor).Return(true, nil)
+			response := &model.IdempotentResponse{UserID: user.ID, Key: "key-1"}
+			idempotencyMock.EXPECT().Begin(user.ID, "key-1", http.MethodPost, "/v1/happiness_metric", gomock.Any()).Return(response, nil)
+			happinessServiceMock.EXPECT().Create(user, gomock.Any()).DoAndReturn(func(_ *authModel.UserContext, metric *model.HappinessMetric) error {
+				metric.ID = 6
+				return nil
+			})
+			idempotencyMock.EXPECT().Complete(response).Return(nil)
+
+			id, err := client.CreateHappinessMetric(ctx, &model.HappinessMetric{OrgID: 2, Happiness: 8}, "key-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal(int64(6)))
+		})
+
+		It("should return precondition failed for an outdated ETag", func() {
+			version := int64(1)
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForHappinessMetric(int64(6), user.ID, authModel.Editor).Return(true, nil)
+			happinessServiceMock.EXPECT().Update(user, gomock.Any(), &version).
+				Return(int64(0), errory.PreconditionFailedErrors.New("happiness metric 6 was changed"))
+
+			_, err := client.UpdateHappinessMetric(ctx, &model.HappinessMetric{ID: 6, OrgID: 2, Happiness: 8}, `"1"`)
+			Expect(cruiser.IsPreconditionFailed(err)).To(BeTrue())
+		})
+
+		It("should return the team happiness", func() {
+			authenticate()
+			happinessServiceMock.EXPECT().GetAllHappinessMetricsForTeam(int64(2)).Return([]*model.HappinessMetric{{ID: 6}}, nil)
+
+			metrics, err := client.GetTeamHappiness(ctx, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(metrics).To(HaveLen(1))
+		})
+	})
+
+	Describe("feedback", func() {
+		It("should return the feedback of the organization", func() {
+			authenticate()
+			feedbackServiceMock.EXPECT().GetByOrgID(int64(2)).Return([]*model.Feedback{{ID: 1, OrgID: 2}}, nil)
+
+			feedback, err := client.ListFeedback(ctx, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(feedback[0].ID).To(Equal(int64(1)))
+		})
+	})
+
+	Describe("recommendation votes", func() {
+		It("should return the votes of the organization", func() {
+			orgID := int64(2)
+			authenticate()
+			voteServiceMock.EXPECT().Get(user.ID, &orgID).Return([]*model.RecommendationVote{{ID: 1, OrgID: 2}}, nil)
+
+			votes, err := client.ListRecommendationVotes(ctx, &orgID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(votes).To(HaveLen(1))
+		})
+
+		It("should delete the vote", func() {
+			authenticate()
+			authorizerMock.EXPECT().AuthorizeForOrganization(int64(2), user.ID, authModel.Viewer).Return(true, nil)
+			voteServiceMock.EXPECT().Delete(gomock.Any()).Return(nil)
+
+			err := client.DeleteRecommendationVote(ctx, &model.RecommendationVote{OrgID: 2, RecommendationType: "slo", Vote: "like"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("solutions", func() {
+		It("should send the options", func() {
+			authenticate()
+			solutionsServiceMock.EXPECT().GetSolutions(true, false, model.ProductScopeTypeElementary).
+				Return([]*model.Solution{{OrgName: "shop"}}, nil)
+
+			solutions, err := client.GetSolutions(ctx, &cruiser.SolutionsOptions{Long: true, Scope: model.ProductScopeTypeElementary})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(solutions[0].OrgName).To(Equal("shop"))
+		})
+	})
+})
+
+func newSlo(id int64) *model.Slo {
+	return &model.Slo{
+		ID:                              id,
+		OrgID:                           2,
+		Name:                            "availability",
+		ComplianceExpectedAvailability:  "99.9",
+		SuccessRateExpectedAvailability: "99.5",
+	}
+}
@@ -2,6 +2,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -37,8 +38,28 @@ type CruiserServer struct {
 	Authorizer             middleware.IAuthorizer
 	Cors                   Cors
 	ParamExistCheckService service.IParamExistCheckService
+	SloHistoryIngestion    service.ISloHistoryIngestionService
+	MaintenanceWindowAPI   *api.MaintenanceWindowAPI
+	BudgetPolicyAPI        *api.BudgetPolicyAPI
+	BudgetPolicies         service.IBudgetPolicyService
+	ComplianceReportAPI    *api.ComplianceReportAPI
+	ComplianceReports      service.IComplianceReportService
+	SlaAPI                 *api.SlaAPI
+	SlaTracking            service.ISlaService
+	SloLabelAPI            *api.SloLabelAPI
+	SloOwnershipAPI        *api.SloOwnershipAPI
+	SloChangeRequestAPI    *api.SloChangeRequestAPI
+	SloChangeRequests      service.ISloChangeRequestService
+	SearchAPI              *api.SearchAPI
+	Idempotency            service.IIdempotencyService
+	SloBatchAPI            *api.SloBatchAPI
+	WebhookAPI             *api.WebhookAPI
+	Webhooks               service.IWebhookService
 }
 
+// requestTimeout is the time after which requests are answered with 503.
+const requestTimeout = 120 * time.Second
+
 var prometheus *middleware.Prometheus
 
 type Group struct {
@@ -84,13 +105,37 @@ func (group *Group) DELETE(relativePath string, handlers ...gin.HandlerFunc) gin
 
 // @BasePath /ebt/v1
 func (s *CruiserServer) Run() error {
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	s.SloHistoryIngestion.Start(ctx)
+	s.ComplianceReports.Start(ctx)
+	s.SlaTracking.Start(ctx)
+	s.SloChangeRequests.Start(ctx)
+	s.Idempotency.Start(ctx)
+	s.BudgetPolicies.Start(ctx)
+	s.Webhooks.Start(ctx)
+
+	srv := &http.Server{
+		Addr:              ":8000",
+		Handler:           middleware.TimeoutLogHandler(http.TimeoutHandler(s.Router(), requestTimeout, "Timeout Exceeded"), s.Log, requestTimeout),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       20 * time.Second,
+		WriteTimeout:      130 * time.Second,
+	}
+
+	return srv.ListenAndServe()
+}
+
+// Router registers the middlewares and routes of the API.
+func (s *CruiserServer) Router() *gin.Engine {
 	authenticate := middleware.Authenticate(s.Authenticator, s.Log)
 	checkContentType := middleware.ContentType()
-	timeout := 120 * time.Second
 
 	authorize := middleware.Authorize
 	idParam := api.GetIDParam
 	paramExistChecker := middleware.CheckIfExist
+	idempotent := middleware.Idempotency(s.Idempotency, s.Log)
 
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
@@ -107,7 +152,7 @@ func (s *CruiserServer) Run() error {
 	health.Use(middleware.Recovery(s.Log))
 	health.GET("/health", s.HealthAPI.HealthCheck)
 
-	r.Use(middleware.LoggerMiddleware(s.Log, timeout))
+	r.Use(middleware.LoggerMiddleware(s.Log, requestTimeout))
 
 	r.StaticFile("/grafana_source", "/doc/oma_grafana_source.zip")
 
@@ -146,6 +191,11 @@ func (s *CruiserServer) Run() error {
 		psRoutes.GET("", s.ProductsStatusAPI.GetProductsStatus)
 	}
 
+	searchRoutes := Group{ar.Group("/v1/search")}
+	{
+		searchRoutes.GET("", s.SearchAPI.Search)
+	}
+
 	dsRoutes := Group{ar.Group("/v1/datasource")}
 	{
 		dsRoutes.GET("/:id", authorize(idParam, s.Authorizer.AuthorizeForDatasource, authModel.Viewer, s.Log), s.DatasourceAPI.Get)
@@ -156,10 +206,76 @@ func (s *CruiserServer) Run() error {
 		organizationRoutes.GET("/:id", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log), s.OrgAPI.GetOrg)
 		organizationRoutes.GET("/:id/slo", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
 			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.OrgAPI.GetSlos)
+		organizationRoutes.GET("/:id/slo/at-risk", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.OrgAPI.GetAtRiskSlos)
 		organizationRoutes.POST("/:id/slo", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
 			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.OrgAPI.FindSlos)
 		organizationRoutes.GET("/:id/datasource", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
 			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.OrgAPI.GetDatasources)
+		organizationRoutes.GET("/:id/datasource/:dsId/discover", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.OrgAPI.DiscoverDatadogSlos)
+		organizationRoutes.POST("/:id/datasource/:dsId/import", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.OrgAPI.ImportDatadogSlos)
+		organizationRoutes.GET("/:id/maintenance_window", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.MaintenanceWindowAPI.GetAll)
+		organizationRoutes.POST("/:id/maintenance_window", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.MaintenanceWindowAPI.Create)
+		organizationRoutes.GET("/:id/maintenance_window/:windowId", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			s.MaintenanceWindowAPI.Get)
+		organizationRoutes.PUT("/:id/maintenance_window/:windowId", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			s.MaintenanceWindowAPI.Update)
+		organizationRoutes.DELETE("/:id/maintenance_window/:windowId", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			s.MaintenanceWindowAPI.Delete)
+		organizationRoutes.GET("/:id/budget_policy", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.BudgetPolicyAPI.GetAll)
+		organizationRoutes.POST("/:id/budget_policy", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.BudgetPolicyAPI.Create)
+		organizationRoutes.GET("/:id/budget_policy/:policyId", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			s.BudgetPolicyAPI.Get)
+		organizationRoutes.PUT("/:id/budget_policy/:policyId", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			s.BudgetPolicyAPI.Update)
+		organizationRoutes.DELETE("/:id/budget_policy/:policyId", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			s.BudgetPolicyAPI.Delete)
+		organizationRoutes.GET("/:id/deploy-gate", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.BudgetPolicyAPI.GetDeployGate)
+		organizationRoutes.GET("/:id/deploy_freeze", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.BudgetPolicyAPI.GetDeployFreezes)
+		organizationRoutes.GET("/:id/compliance_report", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.ComplianceReportAPI.GetAll)
+		organizationRoutes.POST("/:id/compliance_report", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.ComplianceReportAPI.Create)
+		organizationRoutes.GET("/:id/compliance_report/:reportId/download", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			s.ComplianceReportAPI.Download)
+		organizationRoutes.GET("/:id/sla_breach", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SlaAPI.GetBreaches)
+		organizationRoutes.GET("/:id/sla_credit/:solutionId", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SlaAPI.GetCreditTable)
+		organizationRoutes.PUT("/:id/sla_credit/:solutionId", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SlaAPI.UpdateCreditTable)
+		organizationRoutes.GET("/:id/slo_label_key", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SloLabelAPI.GetAllowedKeys)
+		organizationRoutes.PUT("/:id/slo_label_key", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SloLabelAPI.UpdateAllowedKeys)
+		organizationRoutes.GET("/:id/slo_ownership_gap", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SloOwnershipAPI.GetGaps)
+		organizationRoutes.GET("/:id/slo_change_request", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SloChangeRequestAPI.GetAll)
+		organizationRoutes.POST("/:id/slo_change_request/:requestId/approve", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SloChangeRequestAPI.Approve)
+		organizationRoutes.POST("/:id/slo_change_request/:requestId/reject", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.SloChangeRequestAPI.Reject)
+		organizationRoutes.GET("/:id/webhook", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.WebhookAPI.GetAll)
+		organizationRoutes.POST("/:id/webhook", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			paramExistChecker(idParam, s.ParamExistCheckService, service.Organization), s.WebhookAPI.Create)
+		organizationRoutes.GET("/:id/webhook/:webhookId", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			s.WebhookAPI.Get)
+		organizationRoutes.PUT("/:id/webhook/:webhookId", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			s.WebhookAPI.Update)
+		organizationRoutes.DELETE("/:id/webhook/:webhookId", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			s.WebhookAPI.Delete)
+		organizationRoutes.GET("/:id/webhook/:webhookId/delivery", authorize(idParam, s.Authorizer.AuthorizeForOrganization, authModel.Admin, s.Log),
+			s.WebhookAPI.GetDeliveries)
 
 		organizationRoutes.GET("/:id/user_happiness", s.OrgAPI.GetAllHappinessMetricsForUser)
 		organizationRoutes.GET("/:id/team_happiness", s.OrgAPI.GetAllHappinessMetricsForTeam)
@@ -170,24 +286,30 @@ func (s *CruiserServer) Run() error {
 	sloRoutes := Group{ar.Group("/v1/slo")}
 	{
 		sloRoutes.GET("", s.SloAPI.GetDetailed)
-		sloRoutes.POST("", checkContentType, authorize(middleware.OrgIDInStruct, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log), s.SloAPI.Create)
+		sloRoutes.POST("", checkContentType, authorize(middleware.OrgIDInStruct, s.Authorizer.AuthorizeForOrganization, authModel.Editor, s.Log), idempotent, s.SloAPI.Create)
+		sloRoutes.POST("/batch", checkContentType, idempotent, s.SloBatchAPI.Execute)
 		sloRoutes.PUT("/:id", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Editor, s.Log), s.SloAPI.Update)
 		sloRoutes.GET("/:id", s.SloAPI.Get)
 		sloRoutes.DELETE("/:id", authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Editor, s.Log), s.SloAPI.Delete)
+		sloRoutes.GET("/:id/history", authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Viewer, s.Log), s.SloAPI.GetSloHistory)
+		sloRoutes.GET("/:id/budget", authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Viewer, s.Log), s.SloAPI.GetErrorBudget)
+		sloRoutes.GET("/:id/budget/forecast", authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Viewer, s.Log), s.SloAPI.GetBudgetForecast)
+		sloRoutes.POST("/:id/simulate", checkContentType, authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Viewer, s.Log), s.SloAPI.Simulate)
 		sloRoutes.DELETE("/:id/history", authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Editor, s.Log), s.SloAPI.DeleteSloHistory)
+		sloRoutes.GET("/:id/history/task/:taskId", authorize(idParam, s.Authorizer.AuthorizeForSLO, authModel.Editor, s.Log), s.SloAPI.GetSloHistoryDeletionTask)
 	}
 
 	feedbackRoutes := Group{ar.Group("/v1/feedback")}
 	{
 		feedbackRoutes.POST("", checkContentType,
-			authorize(middleware.OrgIDInStruct, s.Authorizer.AuthorizeForTeam, authModel.Editor, s.Log), s.FeedbackAPI.Create)
+			authorize(middleware.OrgIDInStruct, s.Authorizer.AuthorizeForTeam, authModel.Editor, s.Log), idempotent, s.FeedbackAPI.Create)
 		feedbackRoutes.GET("/:id", s.FeedbackAPI.Get)
 	}
 
 	happinessMetricRoutes := Group{ar.Group("/v1/happiness_metric")}
 	{
 		happinessMetricRoutes.POST("", checkContentType,
-			authorize(middleware.OrgIDInStruct, s.Authorizer.AuthorizeForTeam, authModel.Editor, s.Log), s.HappinessMetricAPI.Create)
+			authorize(middleware.OrgIDInStruct, s.Authorizer.AuthorizeForTeam, authModel.Editor, s.Log), idempotent, s.HappinessMetricAPI.Create)
 		happinessMetricRoutes.PUT("/:id", checkContentType,
 			authorize(idParam, s.Authorizer.AuthorizeForHappinessMetric, authModel.Editor, s.Log), s.HappinessMetricAPI.Update)
 		happinessMetricRoutes.DELETE("/:id",
@@ -204,13 +326,5 @@ func (s *CruiserServer) Run() error {
 			authorize(middleware.OrgIDInStruct, s.Authorizer.AuthorizeForOrganization, authModel.Viewer, s.Log), s.RecommendationVoteAPI.Delete)
 	}
 
-	srv := &http.Server{
-		Addr:              ":8000",
-		Handler:           middleware.TimeoutLogHandler(http.TimeoutHandler(r, timeout, "Timeout Exceeded"), s.Log, timeout),
-		ReadHeaderTimeout: 10 * time.Second,
-		ReadTimeout:       20 * time.Second,
-		WriteTimeout:      130 * time.Second,
-	}
-
-	return srv.ListenAndServe()
+	return r
 }
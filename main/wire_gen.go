@@ -10,41 +10,58 @@ import (
 	"github.com/google/wire"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/api"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/auth"
-	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
-	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/grafana"
-	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/idam"
-	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/middleware"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
-	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service/plugin"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
-)
-
-import (
-	_ "github.com/lib/pq"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/wiring"
 )
 
 // Injectors from wire.go:
 
 func initApp() (*CruiserServer, error) {
-	fieldLogger := createLoggerWithStandardFields()
-	db := newDBConnection(fieldLogger)
+	fieldLogger := wiring.CreateLoggerWithStandardFields()
+	db := wiring.NewDBConnection(fieldLogger)
 	sql := &provider.SQL{
 		DB:  db,
 		Log: fieldLogger,
 	}
-	authProvider := newAuthProvider(db, fieldLogger)
-	client := newGrafanaClient()
-	dashboardService := newDashboardService(fieldLogger, sql, authProvider, client)
-	pluginConfig := readInPluginConfig()
-	elasticClient := newSDAElasticClient(pluginConfig, fieldLogger)
-	sloService := &service.SloService{
+	authProvider := wiring.NewAuthProvider(db, fieldLogger)
+	client := wiring.NewGrafanaClient()
+	maintenancePeriodService := &service.MaintenancePeriodService{
+		Provider: sql,
+	}
+	dashboardService := wiring.NewDashboardService(fieldLogger, sql, authProvider, client, maintenancePeriodService, sql, sql, sql, sql)
+	pluginConfig := wiring.ReadInPluginConfig()
+	elasticClient := wiring.NewSDAElasticClient(pluginConfig, fieldLogger)
+	datasourceParser := wiring.NewDSParser()
+	datadogClient := wiring.NewDatadogClient(datasourceParser)
+	datadogCredentialsProvider := wiring.NewDatadogCredentialsProvider(db, fieldLogger)
+	datadogClientFactory := wiring.NewDatadogClientFactory(datadogCredentialsProvider)
+	compositeSloService := &service.CompositeSloService{
+		Provider:         sql,
 		SloProvider:      sql,
-		DSProvider:       sql,
+		Authorizer:       authProvider,
+		ElasticClient:    elasticClient,
 		DashboardService: dashboardService,
 		Log:              fieldLogger,
-		ElasticClient:    elasticClient,
 	}
+	sloLabelService := &service.SloLabelService{
+		Provider: sql,
+		Log:      fieldLogger,
+	}
+	sloOwnershipService := &service.SloOwnershipService{
+		Provider:    sql,
+		SloProvider: sql,
+		Grafana:     client,
+		Log:         fieldLogger,
+	}
+	webhookClient := wiring.NewWebhookClient()
+	webhookService := wiring.NewWebhookService(fieldLogger, sql, sql, webhookClient)
+	entityVersionService := &service.EntityVersionService{
+		Provider: sql,
+		Log:      fieldLogger,
+	}
+	sloService := wiring.NewSloService(fieldLogger, sql, sql, sql, sql, dashboardService, elasticClient, datadogClientFactory, maintenancePeriodService, compositeSloService, sloLabelService, sloOwnershipService, entityVersionService)
 	organizationService := &service.OrganizationService{
 		Provider: sql,
 		Log:      fieldLogger,
@@ -54,18 +71,30 @@ func initApp() (*CruiserServer, error) {
 		Log:      fieldLogger,
 	}
 	happinessMetricService := &service.HappinessMetricService{
-		Provider: sql,
-		Log:      fieldLogger,
+		Provider:        sql,
+		VersionProvider: sql,
+		Versions:        entityVersionService,
+		Log:             fieldLogger,
 	}
 	translatedValidator, err := validator.NewValidator(fieldLogger)
 	if err != nil {
 		return nil, err
 	}
+	datadogImportService := &service.DatadogImportService{
+		SloService:     sloService,
+		SloProvider:    sql,
+		DSProvider:     sql,
+		DatadogClients: datadogClientFactory,
+		Log:            fieldLogger,
+	}
+	errorBudgetService := wiring.NewErrorBudgetService(fieldLogger, sql, elasticClient, maintenancePeriodService, compositeSloService)
 	orgAPI := &api.OrgAPI{
 		SloService:        sloService,
 		OrgService:        organizationService,
 		DatasourceService: datasourceService,
 		HappinessService:  happinessMetricService,
+		ImportService:     datadogImportService,
+		BudgetService:     errorBudgetService,
 		Validator:         translatedValidator,
 		Log:               fieldLogger,
 	}
@@ -73,10 +102,21 @@ func initApp() (*CruiserServer, error) {
 	if err != nil {
 		return nil, err
 	}
+	idempotencyService := wiring.NewIdempotencyService(fieldLogger, sql, sql)
+	sloChangeRequestService := wiring.NewSloChangeRequestService(fieldLogger, sql, sql, sql, sloService, authProvider)
+	sloBatchService := wiring.NewSloBatchService(fieldLogger, sloService, sloChangeRequestService, entityVersionService, authProvider)
+	sloBatchAPI := &api.SloBatchAPI{
+		Service:   sloBatchService,
+		Validator: sloValidator,
+		Log:       fieldLogger,
+	}
 	sloAPI := &api.SloAPI{
-		SloService: sloService,
-		Validator:  sloValidator,
-		Log:        fieldLogger,
+		SloService:     sloService,
+		BudgetService:  errorBudgetService,
+		ChangeRequests: sloChangeRequestService,
+		Versions:       entityVersionService,
+		Validator:      sloValidator,
+		Log:            fieldLogger,
 	}
 	feedbackService := &service.FeedbackService{
 		Provider: sql,
@@ -97,6 +137,7 @@ func initApp() (*CruiserServer, error) {
 	}
 	happinessMetricAPI := &api.HappinessMetricAPI{
 		Service:   happinessMetricService,
+		Versions:  entityVersionService,
 		Validator: happinessMetricValidator,
 		Log:       fieldLogger,
 	}
@@ -124,17 +165,17 @@ func initApp() (*CruiserServer, error) {
 		UserInfoService: userInfoService,
 		Log:             fieldLogger,
 	}
-	idamRestClient := newIDAMClient(fieldLogger)
+	idamRestClient := wiring.NewIDAMClient(fieldLogger)
 	authenticator := newAuthenticator(idamRestClient, authProvider, client, fieldLogger)
 	datasourceAPI := &api.DatasourceAPI{
 		DatasourceService: datasourceService,
 		Log:               fieldLogger,
 	}
-	datasourceParser := newDSParser()
-	plugin := newPluginService(fieldLogger, client, elasticClient, sql, sql, authProvider, pluginConfig, datasourceParser)
+	plugin := wiring.NewPluginService(fieldLogger, client, elasticClient, sql, sql, authProvider, pluginConfig, datasourceParser)
 	pluginAPI := &api.PluginAPI{
 		Plugin:              plugin,
 		OrganizationService: organizationService,
+		Webhooks:            webhookService,
 		Log:                 fieldLogger,
 	}
 	sdaService := &service.SDAService{
@@ -168,6 +209,63 @@ func initApp() (*CruiserServer, error) {
 		Service: productsStatusService,
 		Log:     fieldLogger,
 	}
+	maintenanceWindowService := &service.MaintenanceWindowService{
+		Provider:         sql,
+		SloProvider:      sql,
+		DashboardService: dashboardService,
+		Log:              fieldLogger,
+	}
+	maintenanceWindowAPI := &api.MaintenanceWindowAPI{
+		Service:   maintenanceWindowService,
+		Validator: translatedValidator,
+		Log:       fieldLogger,
+	}
+	budgetPolicyService := wiring.NewBudgetPolicyService(fieldLogger, sql, sql, sql, errorBudgetService)
+	budgetPolicyAPI := &api.BudgetPolicyAPI{
+		Service:   budgetPolicyService,
+		Validator: translatedValidator,
+		Log:       fieldLogger,
+	}
+	complianceReportService := wiring.NewComplianceReportService(fieldLogger, sql, sql, sql, sql, errorBudgetService)
+	complianceReportAPI := &api.ComplianceReportAPI{
+		Service:   complianceReportService,
+		Validator: translatedValidator,
+		Log:       fieldLogger,
+	}
+	slaService := wiring.NewSlaService(fieldLogger, sql, sql, sql, sql, errorBudgetService)
+	slaAPI := &api.SlaAPI{
+		Service:   slaService,
+		Validator: translatedValidator,
+		Log:       fieldLogger,
+	}
+	sloLabelAPI := &api.SloLabelAPI{
+		Service:   sloLabelService,
+		Validator: translatedValidator,
+		Log:       fieldLogger,
+	}
+	sloOwnershipAPI := &api.SloOwnershipAPI{
+		Service: sloOwnershipService,
+		Log:     fieldLogger,
+	}
+	sloChangeRequestAPI := &api.SloChangeRequestAPI{
+		Service:   sloChangeRequestService,
+		Validator: translatedValidator,
+		Log:       fieldLogger,
+	}
+	searchService := &service.SearchService{
+		Provider: sql,
+		Log:      fieldLogger,
+	}
+	searchAPI := &api.SearchAPI{
+		Service: searchService,
+		Log:     fieldLogger,
+	}
+	webhookAPI := &api.WebhookAPI{
+		Service:   webhookService,
+		Validator: translatedValidator,
+		Log:       fieldLogger,
+	}
+	sloHistoryIngestionService := wiring.NewSloHistoryIngestionService(fieldLogger, sql, sql, datadogClient, elasticClient)
 	cors := createCors(fieldLogger)
 	paramExistCheckService := &service.ParamExistCheckService{
 		OrgProvider: sql,
@@ -193,31 +291,31 @@ func initApp() (*CruiserServer, error) {
 		Authorizer:             authProvider,
 		Cors:                   cors,
 		ParamExistCheckService: paramExistCheckService,
+		SloHistoryIngestion:    sloHistoryIngestionService,
+		MaintenanceWindowAPI:   maintenanceWindowAPI,
+		BudgetPolicyAPI:        budgetPolicyAPI,
+		BudgetPolicies:         budgetPolicyService,
+		ComplianceReportAPI:    complianceReportAPI,
+		ComplianceReports:      complianceReportService,
+		SlaAPI:                 slaAPI,
+		SlaTracking:            slaService,
+		SloLabelAPI:            sloLabelAPI,
+		SloOwnershipAPI:        sloOwnershipAPI,
+		SloChangeRequestAPI:    sloChangeRequestAPI,
+		SloChangeRequests:      sloChangeRequestService,
+		SearchAPI:              searchAPI,
+		Idempotency:            idempotencyService,
+		SloBatchAPI:            sloBatchAPI,
+		WebhookAPI:             webhookAPI,
+		Webhooks:               webhookService,
 	}
 	return cruiserServer, nil
 }
 
 // wire.go:
 
-var configsSet = wire.NewSet(
-	readInPluginConfig,
-)
-
-var clientsSet = wire.NewSet(
-	newGrafanaClient, wire.Bind(new(grafana.IClient), new(*grafana.Client)), newSDAElasticClient, wire.Bind(new(elastic.IClient), new(*elastic.Client)), newIDAMClient, wire.Bind(new(idam.IIDAMClient), new(*idam.IDAMRestClient)),
-)
-
-var servicesSet = wire.NewSet(wire.Struct(new(service.SloService), "*"), wire.Bind(new(service.ISloService), new(*service.SloService)), wire.Struct(new(service.DatasourceService), "*"), wire.Bind(new(service.IDatasourceService), new(*service.DatasourceService)), wire.Struct(new(service.OrganizationService), "*"), wire.Bind(new(service.IOrganizationService), new(*service.OrganizationService)), newDashboardService, wire.Bind(new(service.IDashboardService), new(*service.DashboardService)), wire.Struct(new(service.ParamExistCheckService), "*"), wire.Bind(new(service.IParamExistCheckService), new(*service.ParamExistCheckService)), newPluginService, wire.Bind(new(plugin.IPluginService), new(*plugin.Plugin)), newDSParser, wire.Bind(new(plugin.IDatasourceParser), new(*plugin.DatasourceParser)), wire.Struct(new(service.SDAService), "*"), wire.Bind(new(service.ISDAService), new(*service.SDAService)), wire.Struct(new(service.HealthService), "*"), wire.Bind(new(service.IHealthService), new(*service.HealthService)), wire.Struct(new(service.FeedbackService), "*"), wire.Bind(new(service.IFeedbackService), new(*service.FeedbackService)), wire.Struct(new(service.HappinessMetricService), "*"), wire.Bind(new(service.IHappinessMetricService), new(*service.HappinessMetricService)), wire.Struct(new(service.UserInfoService), "*"), wire.Bind(new(service.IUserInfoService), new(*service.UserInfoService)), wire.Struct(new(service.SolutionsService), "*"), wire.Bind(new(service.ISolutionsService), new(*service.SolutionsService)), wire.Struct(new(service.SolutionSloService), "*"), wire.Bind(new(service.ISolutionSloService), new(*service.SolutionSloService)), wire.Struct(new(service.RecommendationVoteService), "*"), wire.Bind(new(service.IRecommendationVoteService), new(*service.RecommendationVoteService)), wire.Struct(new(service.ProductsStatusService), "*"), wire.Bind(new(service.IProductsStatusService), new(*service.ProductsStatusService)))
-
-var validatorsSet = wire.NewSet(validator.NewSLOValidator, wire.Bind(new(validator.ISLOValidator), new(*validator.SLOValidator)), validator.NewFeedbackValidator, wire.Bind(new(validator.IFeedbackValidator), new(*validator.FeedbackValidator)), validator.NewHappinessMetricValidator, wire.Bind(new(validator.IHappinessMetricValidator), new(*validator.HappinessMetricValidator)), validator.NewValidator, wire.Bind(new(validator.ITranslatedValidator), new(*validator.TranslatedValidator)))
-
-var apisSet = wire.NewSet(wire.Struct(new(api.OrgAPI), "*"), wire.Struct(new(api.SloAPI), "*"), wire.Struct(new(api.HealthAPI), "*"), wire.Struct(new(api.ConfigureUserAPI), "*"), wire.Struct(new(api.DatasourceAPI), "*"), wire.Struct(new(api.PluginAPI), "*"), wire.Struct(new(api.SDAAPI), "*"), wire.Struct(new(api.SolutionsAPI), "*"), wire.Struct(new(api.FeedbackAPI), "*"), wire.Struct(new(api.HappinessMetricAPI), "*"), wire.Struct(new(api.SolutionSloAPI), "*"), wire.Struct(new(api.RecommendationVoteAPI), "*"), wire.Struct(new(api.ProductsStatusAPI), "*"))
-
-var providerSet = wire.NewSet(
-	newDBConnection, wire.Struct(new(provider.SQL), "*"), wire.Bind(new(provider.IOrganizationProvider), new(*provider.SQL)), wire.Bind(new(provider.ISLOProvider), new(*provider.SQL)), wire.Bind(new(provider.IDatasourceProvider), new(*provider.SQL)), wire.Bind(new(provider.ISDAProvider), new(*provider.SQL)), wire.Bind(new(provider.ISolutionsProvider), new(*provider.SQL)), wire.Bind(new(provider.IHealthProvider), new(*provider.SQL)), wire.Bind(new(provider.IFeedbackProvider), new(*provider.SQL)), wire.Bind(new(provider.IRecommendationVoteProvider), new(*provider.SQL)), wire.Bind(new(provider.IHappinessMetricProvider), new(*provider.SQL)), wire.Bind(new(provider.IUserInfoProvider), new(*provider.SQL)), newAuthProvider, wire.Bind(new(provider.IAuthProvider), new(*provider.AuthProvider)), wire.Bind(new(middleware.IAuthorizer), new(*provider.AuthProvider)), wire.Bind(new(provider.ISolutionSloProvider), new(*provider.SQL)), wire.Bind(new(provider.IProductsStatusProvider), new(*provider.SQL)),
-)
+var apisSet = wire.NewSet(wire.Struct(new(api.OrgAPI), "*"), wire.Struct(new(api.SloAPI), "*"), wire.Struct(new(api.HealthAPI), "*"), wire.Struct(new(api.ConfigureUserAPI), "*"), wire.Struct(new(api.DatasourceAPI), "*"), wire.Struct(new(api.PluginAPI), "*"), wire.Struct(new(api.SDAAPI), "*"), wire.Struct(new(api.SolutionsAPI), "*"), wire.Struct(new(api.FeedbackAPI), "*"), wire.Struct(new(api.HappinessMetricAPI), "*"), wire.Struct(new(api.SolutionSloAPI), "*"), wire.Struct(new(api.RecommendationVoteAPI), "*"), wire.Struct(new(api.ProductsStatusAPI), "*"), wire.Struct(new(api.MaintenanceWindowAPI), "*"), wire.Struct(new(api.BudgetPolicyAPI), "*"), wire.Struct(new(api.ComplianceReportAPI), "*"), wire.Struct(new(api.SlaAPI), "*"), wire.Struct(new(api.SloLabelAPI), "*"), wire.Struct(new(api.SloOwnershipAPI), "*"), wire.Struct(new(api.SloChangeRequestAPI), "*"), wire.Struct(new(api.SearchAPI), "*"), wire.Struct(new(api.SloBatchAPI), "*"), wire.Struct(new(api.WebhookAPI), "*"))
 
 var othersSet = wire.NewSet(
 	newAuthenticator, wire.Bind(new(auth.IAuthenticator), new(*auth.Authenticator)), createCors,
-	createLoggerWithStandardFields,
 )
@@ -14,11 +14,8 @@ func Authenticate(authenticator auth.IAuthenticator, log logrus.FieldLogger) gin
 	return func(c *gin.Context) {
 		user, err := authenticator.Authenticate(c)
 		if err != nil {
-			details := errory.GetErrorDetails(errory.AuthErrors.New("Not authorized"))
 			log.Errorf("Not authorized: %s", err.Error())
-			c.AbortWithStatusJSON(details.Status, gin.H{
-				"message": details.Message,
-			})
+			AbortWithProblem(c, errory.AuthErrors.New("Not authorized"))
 			return
 		}
 		log.Debugf("Authenticated user: %d", user.ID)
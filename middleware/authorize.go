@@ -3,8 +3,6 @@ package middleware
 import (
 	"net/http"
 
-	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
-
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
 
 	"github.com/gin-gonic/gin"
@@ -34,11 +32,8 @@ func Authorize(idSelector IDSelector, authorizer Authorizer, requiredPermission
 
 		id, err := idSelector(c)
 		if err != nil {
-			details := errory.GetErrorDetails(err)
 			log.Errorf("ID param error: %s", err)
-			c.AbortWithStatusJSON(details.Status, gin.H{
-				"message": details.Message,
-			})
+			AbortWithProblem(c, err)
 			return
 		}
 
@@ -50,9 +45,7 @@ func Authorize(idSelector IDSelector, authorizer Authorizer, requiredPermission
 		}
 		if !authorized {
 			log.Errorf("not authorized: %s", err)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"message": "Not authorized",
-			})
+			AbortWithStatusProblem(c, http.StatusUnauthorized, "Not authorized")
 			return
 		}
 
@@ -61,7 +54,5 @@ func Authorize(idSelector IDSelector, authorizer Authorizer, requiredPermission
 }
 
 func authorizationError(c *gin.Context) {
-	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-		"message": "Authorization error",
-	})
+	AbortWithStatusProblem(c, http.StatusInternalServerError, "Authorization error")
 }
@@ -15,7 +15,7 @@ func ContentType() gin.HandlerFunc {
 		if hasContentType(c.ContentType(), "application/json") {
 			c.Next()
 		} else {
-			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{"message": msgUnssuportedContentType})
+			AbortWithStatusProblem(c, http.StatusUnsupportedMediaType, msgUnssuportedContentType)
 		}
 	}
 }
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	defaultReplayContentType = "application/json; charset=utf-8"
+)
+
+// bodyRecorder keeps a copy of the response body written by the handlers.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *bodyRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// Idempotency replays the stored response to requests repeating the Idempotency-Key and body of
+// an earlier request of the user. Requests without the header are processed as usual, server
+// errors are not stored so that the request can be retried.
+func Idempotency(idempotencyService service.IIdempotencyService, log logrus.FieldLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		u, exists := c.Get(UserContextContextKey)
+		if !exists {
+			log.Errorf("no userID found")
+			authorizationError(c)
+			return
+		}
+		user := u.(*auth.UserContext)
+
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			AbortWithProblem(c, errory.ParseErrors.Builder().Wrap(err).WithMessage("request body cannot be read").Create())
+			return
+		}
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		response, err := idempotencyService.Begin(user.ID, key, c.Request.Method, c.FullPath(), body)
+		if err != nil {
+			AbortWithProblem(c, err)
+			return
+		}
+		if response.Status != 0 {
+			contentType := response.ContentType
+			if contentType == "" {
+				contentType = defaultReplayContentType
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(response.Status, contentType, response.Body)
+			c.Abort()
+			return
+		}
+
+		processed := false
+		defer func() {
+			// a panicking handler leaves no response worth replaying
+			if !processed {
+				abortIdempotentRequest(idempotencyService, response, log)
+			}
+		}()
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+		processed = true
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			abortIdempotentRequest(idempotencyService, response, log)
+			return
+		}
+		response.Status = recorder.Status()
+		response.ContentType = recorder.Header().Get("Content-Type")
+		response.Body = recorder.body.Bytes()
+		if err = idempotencyService.Complete(response); err != nil {
+			log.WithError(err).Error("could not store idempotent response")
+		}
+	}
+}
+
+func abortIdempotentRequest(idempotencyService service.IIdempotencyService, response *model.IdempotentResponse,
+	log logrus.FieldLogger) {
+	if err := idempotencyService.Abort(response); err != nil {
+		log.WithError(err).Error("could not release idempotency key")
+	}
+}
@@ -0,0 +1,118 @@
+// +build unitTests
+
+package middleware_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/middleware"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Idempotency middleware", func() {
+	var ginEngine *gin.Engine
+	var responseRecorder *httptest.ResponseRecorder
+	var mockController *gomock.Controller
+	var mockIdempotencyService *service.MockIIdempotencyService
+	var handlerStatus int
+	var handlerBody []byte
+	var handlerCalls int
+	logger, _ := logrustest.NewNullLogger()
+	body := `{"name":"slo"}`
+
+	send := func(key string) {
+		req, _ := http.NewRequest(http.MethodPost, "/test", bytes.NewBufferString(body))
+		req.Header.Add("Content-Type", "application/json")
+		if key != "" {
+			req.Header.Add(middleware.IdempotencyKeyHeader, key)
+		}
+		responseRecorder = httptest.NewRecorder()
+		ginEngine.ServeHTTP(responseRecorder, req)
+	}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockIdempotencyService = service.NewMockIIdempotencyService(mockController)
+		handlerStatus, handlerCalls = http.StatusCreated, 0
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.Use(func(c *gin.Context) {
+			c.Set(middleware.UserContextContextKey, &auth.UserContext{ID: 3})
+		})
+		ginEngine.Use(middleware.Idempotency(mockIdempotencyService, logger))
+		ginEngine.POST("/test", func(c *gin.Context) {
+			handlerCalls++
+			handlerBody, _ = ioutil.ReadAll(c.Request.Body)
+			c.JSON(handlerStatus, gin.H{"id": 1})
+		})
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("Should pass requests without Idempotency-Key", func() {
+		send("")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusCreated))
+		Expect(handlerCalls).To(Equal(1))
+	})
+
+	It("Should store the response of the first request", func() {
+		reservation := &model.IdempotentResponse{UserID: 3, Key: "key"}
+		mockIdempotencyService.EXPECT().Begin(int64(3), "key", http.MethodPost, "/test", []byte(body)).Return(reservation, nil)
+		mockIdempotencyService.EXPECT().Complete(reservation).Return(nil)
+
+		send("key")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusCreated))
+		Expect(handlerBody).To(Equal([]byte(body)))
+		Expect(reservation.Status).To(Equal(http.StatusCreated))
+		Expect(string(reservation.Body)).To(Equal(`{"id":1}`))
+		Expect(reservation.ContentType).To(ContainSubstring("application/json"))
+	})
+
+	It("Should replay the stored response of a retry", func() {
+		stored := &model.IdempotentResponse{Status: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"id":1}`)}
+		mockIdempotencyService.EXPECT().Begin(int64(3), "key", http.MethodPost, "/test", []byte(body)).Return(stored, nil)
+
+		send("key")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusCreated))
+		Expect(responseRecorder.Body.String()).To(Equal(`{"id":1}`))
+		Expect(responseRecorder.Header().Get(middleware.IdempotentReplayedHeader)).To(Equal("true"))
+		Expect(handlerCalls).To(Equal(0))
+	})
+
+	It("Should return conflict for a reused key", func() {
+		mockIdempotencyService.EXPECT().Begin(int64(3), "key", http.MethodPost, "/test", []byte(body)).
+			Return(nil, errory.NotUniqueErrors.New("different request"))
+
+		send("key")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+		Expect(handlerCalls).To(Equal(0))
+	})
+
+	It("Should release the key on server errors", func() {
+		handlerStatus = http.StatusInternalServerError
+		reservation := &model.IdempotentResponse{UserID: 3, Key: "key"}
+		mockIdempotencyService.EXPECT().Begin(int64(3), "key", http.MethodPost, "/test", []byte(body)).Return(reservation, nil)
+		mockIdempotencyService.EXPECT().Abort(reservation).Return(nil)
+
+		send("key")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -3,8 +3,6 @@ package middleware
 import (
 	"net/http"
 
-	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
-
 	"github.com/gin-gonic/gin"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
 )
@@ -13,9 +11,7 @@ func CheckIfExist(idSelector IDSelector, paramExistCheckService service.IParamEx
 	return func(c *gin.Context) {
 		id, err := idSelector(c)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"message": err.Error(),
-			})
+			AbortWithStatusProblem(c, http.StatusUnauthorized, err.Error())
 			return
 		}
 		switch objectType {
@@ -25,10 +21,7 @@ func CheckIfExist(idSelector IDSelector, paramExistCheckService service.IParamEx
 			err = paramExistCheckService.CheckSloByID(id)
 		}
 		if err != nil {
-			details := errory.GetErrorDetails(err)
-			c.AbortWithStatusJSON(details.Status, gin.H{
-				"message": details.Message,
-			})
+			AbortWithProblem(c, err)
 			return
 		}
 		c.Next()
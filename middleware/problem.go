@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	// ProblemTypeBase prefixes the type URI of every problem category.
+	ProblemTypeBase = "https://oma.metro.digital/ebt/problems/"
+)
+
+// problemCategory is the type of problem reported for errors of an errory type. Retryable tells
+// clients whether repeating the same request may succeed.
+type problemCategory struct {
+	errorType *errory.ErrorType
+	name      string
+	title     string
+	retryable bool
+}
+
+// problemCategories are matched in order, subtypes have to come before their parents.
+var problemCategories = []problemCategory{
+	{errory.PreconditionFailedErrors, "precondition-failed", "Precondition Failed", false},
+	{errory.PreconditionRequiredErrors, "precondition-required", "Precondition Required", false},
+	{errory.ValidationErrors, "validation-error", "Validation Failed", false},
+	{errory.ParseErrors, "parse-error", "Malformed Request", false},
+	{errory.NotFoundErrors, "not-found", "Not Found", false},
+	{errory.NotUniqueErrors, "not-unique", "Conflict", false},
+	{errory.DeleteLastMetricForbiddenErrors, "delete-last-metric-forbidden", "Forbidden", false},
+	{errory.CreateExternalSLOWithWrongDSForbiddenErrors, "wrong-datasource-forbidden", "Forbidden", false},
+	{errory.GrafanaClientAuthErrors, "grafana-unauthorized", "Not Authorized in Grafana", false},
+	{errory.AuthErrors, "unauthorized", "Not Authorized", false},
+	{errory.GrafanaClientErrors, "grafana-error", "Grafana Request Failed", true},
+	{errory.ElasticClientErrors, "elastic-error", "Elasticsearch Request Failed", true},
+	{errory.FetchResourceErrors, "fetch-resource-error", "Fetching Resource Failed", true},
+	{errory.ProviderErrors, "database-error", "Database Request Failed", true},
+	{errory.ProcessingErrors, "processing-error", "Processing Failed", false},
+}
+
+// NewProblem describes the error as RFC 7807 problem. The category is the first errory type
+// found from the error down its causes, field problems are taken from validator errors.
+func NewProblem(c *gin.Context, err error) *model.Problem {
+	details := errory.GetErrorDetails(err)
+	problem := newStatusProblem(c, details.Status, details.Message)
+
+	for _, cause := range causes(err) {
+		category, ok := findProblemCategory(cause)
+		if ok {
+			problem.Type = ProblemTypeBase + category.name
+			problem.Title, problem.Retryable = category.title, category.retryable
+			break
+		}
+	}
+	for _, cause := range causes(err) {
+		if fieldErrors, ok := cause.(validator.ValidationErrors); ok {
+			problem.Errors = fieldProblems(fieldErrors)
+			break
+		}
+	}
+	return problem
+}
+
+// AbortWithProblem aborts the request with the problem describing the error.
+func AbortWithProblem(c *gin.Context, err error) {
+	c.Abort()
+	WriteProblem(c, NewProblem(c, err))
+}
+
+// AbortWithStatusProblem aborts the request with a problem of the status without an error
+// category, its type is about:blank as of RFC 7807.
+func AbortWithStatusProblem(c *gin.Context, status int, message string) {
+	c.Abort()
+	WriteProblem(c, newStatusProblem(c, status, message))
+}
+
+func WriteProblem(c *gin.Context, problem *model.Problem) {
+	c.Header("Content-Type", ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+func newStatusProblem(c *gin.Context, status int, message string) *model.Problem {
+	return &model.Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        message,
+		Instance:      c.Request.URL.Path,
+		CorrelationID: c.GetString(CorrelationIDHeader),
+		Retryable:     status >= http.StatusInternalServerError || status == http.StatusTooManyRequests,
+		Message:       message,
+	}
+}
+
+func findProblemCategory(err error) (problemCategory, bool) {
+	for _, category := range problemCategories {
+		if errory.IsOfType(err, category.errorType) {
+			return category, true
+		}
+	}
+	return problemCategory{}, false
+}
+
+// causes returns the error followed by the errors it wraps.
+func causes(err error) []error {
+	chain := []error{}
+	for err != nil {
+		chain = append(chain, err)
+		if cause, ok := err.(interface{ Cause() error }); ok && cause.Cause() != nil {
+			err = cause.Cause()
+		} else {
+			err = errors.Unwrap(err)
+		}
+	}
+	return chain
+}
+
+func fieldProblems(fieldErrors validator.ValidationErrors) []model.FieldProblem {
+	problems := make([]model.FieldProblem, 0, len(fieldErrors))
+	for _, fieldError := range fieldErrors {
+		field := fieldError.Namespace()
+		if i := strings.Index(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		message := field + " failed on the " + fieldError.Tag() + " rule"
+		if fieldError.Param() != "" {
+			message += " with " + fieldError.Param()
+		}
+		problems = append(problems, model.FieldProblem{
+			Field:   field,
+			Rule:    fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: message,
+		})
+	}
+	return problems
+}
@@ -0,0 +1,113 @@
+// +build unitTests
+
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/middleware"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type problemTestRequest struct {
+	Name  string `validate:"required"`
+	Limit int    `validate:"lte=10"`
+}
+
+var _ = Describe("Problem responses", func() {
+	var ginEngine *gin.Engine
+	var responseRecorder *httptest.ResponseRecorder
+	var handlerErr error
+	var problem model.Problem
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		ginEngine = gin.New()
+		ginEngine.Use(middleware.Tracing)
+		ginEngine.GET("/test", func(c *gin.Context) {
+			middleware.AbortWithProblem(c, handlerErr)
+		})
+		ginEngine.GET("/status", func(c *gin.Context) {
+			middleware.AbortWithStatusProblem(c, http.StatusServiceUnavailable, "try later")
+		})
+	})
+
+	send := func(path string) {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Add(middleware.CorrelationIDHeader, "correlation")
+		responseRecorder = httptest.NewRecorder()
+		ginEngine.ServeHTTP(responseRecorder, req)
+		problem = model.Problem{}
+		Expect(json.Unmarshal(responseRecorder.Body.Bytes(), &problem)).To(Succeed())
+	}
+
+	It("should describe the errory category of the cause", func() {
+		handlerErr = errory.OnUpdateErrors.Builder().
+			Wrap(errory.NotUniqueErrors.Builder().WithMessage("name is taken").Create()).
+			WithMessage("Cannot update SLO").Create()
+
+		send("/test")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusConflict))
+		Expect(responseRecorder.Header().Get("Content-Type")).To(HavePrefix(middleware.ProblemContentType))
+		Expect(problem.Type).To(Equal(middleware.ProblemTypeBase + "not-unique"))
+		Expect(problem.Title).To(Equal("Conflict"))
+		Expect(problem.Status).To(Equal(http.StatusConflict))
+		Expect(problem.Instance).To(Equal("/test"))
+		Expect(problem.CorrelationID).To(Equal("correlation"))
+		Expect(problem.Retryable).To(BeFalse())
+		Expect(problem.Message).To(Equal(problem.Detail))
+		Expect(problem.Message).NotTo(BeEmpty())
+	})
+
+	It("should mark failures of dependencies as retryable", func() {
+		handlerErr = errory.OnGetErrors.Builder().Wrap(errory.GrafanaClientErrors.New("grafana down")).Create()
+
+		send("/test")
+
+		Expect(problem.Type).To(Equal(middleware.ProblemTypeBase + "grafana-error"))
+		Expect(problem.Retryable).To(BeTrue())
+	})
+
+	It("should list the fields failing validation", func() {
+		validationErr := validator.New().Struct(problemTestRequest{Limit: 11})
+		handlerErr = errory.ValidationErrors.Builder().Wrap(validationErr).WithMessage("request is invalid").Create()
+
+		send("/test")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(problem.Type).To(Equal(middleware.ProblemTypeBase + "validation-error"))
+		Expect(problem.Errors).To(ConsistOf(
+			model.FieldProblem{Field: "Name", Rule: "required", Message: "Name failed on the required rule"},
+			model.FieldProblem{Field: "Limit", Rule: "lte", Param: "10", Message: "Limit failed on the lte rule with 10"},
+		))
+	})
+
+	It("should describe errors without category by their status", func() {
+		handlerErr = errors.New("unexpected")
+
+		send("/test")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusInternalServerError))
+		Expect(problem.Type).To(Equal("about:blank"))
+		Expect(problem.Title).To(Equal("Internal Server Error"))
+		Expect(problem.Retryable).To(BeTrue())
+	})
+
+	It("should describe a status with message", func() {
+		send("/status")
+
+		Expect(responseRecorder.Code).To(Equal(http.StatusServiceUnavailable))
+		Expect(problem.Type).To(Equal("about:blank"))
+		Expect(problem.Message).To(Equal("try later"))
+		Expect(problem.Retryable).To(BeTrue())
+	})
+})
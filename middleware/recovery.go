@@ -17,7 +17,7 @@ func Recovery(logger logrus.FieldLogger) gin.HandlerFunc {
 					r := c.Request
 					logger.Errorf("[Recovery] panic recovered:\n%s %s\n%s\n\n%+v\n", r.Method, r.URL.String(), r.UserAgent(), err)
 				}
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": AbortMessage})
+				AbortWithStatusProblem(c, http.StatusInternalServerError, AbortMessage)
 			}
 		}()
 		c.Next()
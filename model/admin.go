@@ -0,0 +1,40 @@
+package model
+
+type AdminEntity string
+
+const (
+	AdminEntitySlo          AdminEntity = "slo"
+	AdminEntityDashboard    AdminEntity = "dashboard"
+	AdminEntityOrganization AdminEntity = "organization"
+	AdminEntitySloHistory   AdminEntity = "sloHistory"
+	AdminEntityUser         AdminEntity = "user"
+)
+
+type AdminTaskStatus string
+
+const (
+	// AdminTaskStatusDone means the change was applied.
+	AdminTaskStatusDone AdminTaskStatus = "done"
+	// AdminTaskStatusPlanned means the change would have been applied without dry run.
+	AdminTaskStatusPlanned AdminTaskStatus = "planned"
+	// AdminTaskStatusFailed means the change could not be applied, the task went on with the next entity.
+	AdminTaskStatusFailed AdminTaskStatus = "failed"
+)
+
+// AdminTaskResult is the outcome of an admin task for a single entity.
+type AdminTaskResult struct {
+	Entity  AdminEntity     `json:"entity"`
+	ID      int64           `json:"id,omitempty"`
+	OrgID   int64           `json:"orgId,omitempty"`
+	Name    string          `json:"name,omitempty"`
+	Action  string          `json:"action"`
+	Status  AdminTaskStatus `json:"status"`
+	Message string          `json:"message,omitempty"`
+}
+
+// GrafanaUser is a user of Grafana, server admins are Admin in all organizations.
+type GrafanaUser struct {
+	ID      int64  `db:"id" json:"id"`
+	Login   string `db:"login" json:"login"`
+	IsAdmin bool   `db:"is_admin" json:"isAdmin"`
+}
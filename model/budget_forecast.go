@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+)
+
+// BudgetForecast projects when the SLO runs out of the error budget of its current window
+// at the burn rate of the last LookbackDays days. Remaining is the share of the budget of the
+// whole window which is left, unlike ErrorBudget.Remaining which relates to the days so far.
+// Horizon is the end of a calendar window, rolling windows look one window length ahead.
+type BudgetForecast struct {
+	SloID        int64             `json:"sloId"`
+	SloName      string            `json:"sloName"`
+	Critical     bool              `json:"critical"`
+	Window       SloWindow         `json:"window"`
+	Horizon      time.Time         `json:"horizon"`
+	LookbackDays int               `json:"lookbackDays"`
+	Remaining    *float64          `json:"remaining"`
+	Linear       *BudgetProjection `json:"linear"`
+	Ewma         *BudgetProjection `json:"ewma"`
+}
+
+// BudgetProjection is the exhaustion at a burn rate, 1 consuming exactly the budget over the
+// window. DaysLeft and ExhaustedAt are nil when the budget is not consumed within ten years.
+type BudgetProjection struct {
+	BurnRate      float64    `json:"burnRate"`
+	DaysLeft      *float64   `json:"daysLeft"`
+	ExhaustedAt   *time.Time `json:"exhaustedAt"`
+	BeforeHorizon bool       `json:"beforeHorizon"`
+}
+
+// AtRisk tells whether one of the projections runs out of budget before the horizon.
+func (f *BudgetForecast) AtRisk() bool {
+	return (f.Linear != nil && f.Linear.BeforeHorizon) || (f.Ewma != nil && f.Ewma.BeforeHorizon)
+}
+
+// Exhaustion returns the earliest projected exhaustion, nil without any.
+func (f *BudgetForecast) Exhaustion() *time.Time {
+	var earliest *time.Time
+	for _, projection := range []*BudgetProjection{f.Linear, f.Ewma} {
+		if projection != nil && projection.ExhaustedAt != nil && (earliest == nil || projection.ExhaustedAt.Before(*earliest)) {
+			earliest = projection.ExhaustedAt
+		}
+	}
+	return earliest
+}
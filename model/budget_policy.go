@@ -0,0 +1,69 @@
+package model
+
+import (
+	"time"
+)
+
+type BudgetPolicyAction string
+
+const (
+	// BudgetPolicyActionFreeze denies deploys while the policy is triggered.
+	BudgetPolicyActionFreeze BudgetPolicyAction = "freeze"
+	// BudgetPolicyActionWarn only reports the triggered policy, deploys are still allowed.
+	BudgetPolicyActionWarn BudgetPolicyAction = "warn"
+)
+
+// BudgetPolicy is triggered by an SLO of the organization whose remaining error budget is at
+// or below Threshold, 1 being the full budget and 0 an exhausted one. With CriticalOnly only
+// critical SLOs are considered.
+//
+//nolint:lll
+type BudgetPolicy struct {
+	ID           int64              `db:"id" json:"id" binding:"-"`
+	OrgID        int64              `db:"org_id" json:"orgId" binding:"-" validate:"required"`
+	Name         string             `db:"name" json:"name" binding:"-" validate:"required,max=256"`
+	CriticalOnly bool               `db:"critical_only" json:"criticalOnly" binding:"-"`
+	Threshold    float64            `db:"threshold" json:"threshold" binding:"-" validate:"gte=-1,lte=1"`
+	Action       BudgetPolicyAction `db:"action" json:"action" binding:"-" validate:"required,oneof=freeze warn"`
+}
+
+// DeployGate tells CI pipelines whether the organization may deploy, Reasons lists every
+// triggered policy.
+type DeployGate struct {
+	OrgID       int64              `json:"orgId"`
+	Allowed     bool               `json:"allowed"`
+	Reasons     []DeployGateReason `json:"reasons"`
+	FrozenSince *time.Time         `json:"frozenSince"`
+}
+
+// DeployGateReason is a policy triggered by an SLO. BudgetUnknown is set when the budget of the
+// SLO could not be calculated, Remaining is 0 then.
+type DeployGateReason struct {
+	PolicyID      int64              `json:"policyId"`
+	Policy        string             `json:"policy"`
+	Action        BudgetPolicyAction `json:"action"`
+	SloID         int64              `json:"sloId"`
+	SloName       string             `json:"sloName"`
+	Remaining     float64            `json:"remaining"`
+	Threshold     float64            `json:"threshold"`
+	BudgetUnknown bool               `json:"budgetUnknown,omitempty"`
+}
+
+// DeployFreeze is a period in which the deploy gate of the organization denied deploys,
+// End is nil while the freeze lasts.
+type DeployFreeze struct {
+	ID      int64      `db:"id" json:"id"`
+	OrgID   int64      `db:"org_id" json:"orgId"`
+	Start   time.Time  `db:"start_date" json:"start"`
+	End     *time.Time `db:"end_date" json:"end"`
+	Reasons string     `db:"reasons" json:"reasons"`
+}
+
+// BudgetPolicyTrigger records that the remaining budget of an SLO is at or below the threshold
+// of a policy, so that crossing the threshold is only notified once.
+type BudgetPolicyTrigger struct {
+	PolicyID int64     `db:"policy_id"`
+	SloID    int64     `db:"slo_id"`
+	OrgID    int64     `db:"org_id"`
+	Since    time.Time `db:"since"`
+}
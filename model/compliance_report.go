@@ -0,0 +1,64 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+type ComplianceReportFormat string
+
+const (
+	ComplianceReportFormatCSV ComplianceReportFormat = "csv"
+	ComplianceReportFormatPDF ComplianceReportFormat = "pdf"
+)
+
+// ComplianceReport is a stored report of the SLOs of an organization, or of one of its solutions
+// when SolutionID is set, over the month from PeriodStart to PeriodEnd. Content is only loaded
+// for the download.
+type ComplianceReport struct {
+	ID          int64                  `db:"id" json:"id"`
+	OrgID       int64                  `db:"org_id" json:"orgId"`
+	SolutionID  *int64                 `db:"solution_id" json:"solutionId"`
+	PeriodStart time.Time              `db:"period_start" json:"periodStart"`
+	PeriodEnd   time.Time              `db:"period_end" json:"periodEnd"`
+	Format      ComplianceReportFormat `db:"format" json:"format"`
+	Scheduled   bool                   `db:"scheduled" json:"scheduled"`
+	CreatedAt   time.Time              `db:"created_at" json:"createdAt"`
+	Content     []byte                 `db:"content" json:"-"`
+}
+
+// FileName is the name the report is downloaded as.
+func (r *ComplianceReport) FileName() string {
+	name := "compliance-org-" + strconv.FormatInt(r.OrgID, 10)
+	if r.SolutionID != nil {
+		name += "-solution-" + strconv.FormatInt(*r.SolutionID, 10)
+	}
+	return name + "-" + r.PeriodStart.Format("2006-01") + "." + string(r.Format)
+}
+
+// ComplianceReportRequest requests the report of Month, formatted as 2006-01.
+//
+//nolint:lll
+type ComplianceReportRequest struct {
+	SolutionID *int64                 `json:"solutionId" binding:"-" validate:"omitempty,gt=0"`
+	Month      string                 `json:"month" binding:"-" validate:"required"`
+	Format     ComplianceReportFormat `json:"format" binding:"-" validate:"required,oneof=csv pdf"`
+}
+
+// SloCompliance is the outcome of an SLO over a reporting period. Days overlapping maintenance are
+// left out. BudgetConsumed is the share of the error budget of the whole period which was used,
+// SlaMet is nil for SLOs without an external SLA or without history in the period.
+type SloCompliance struct {
+	SloID          int64      `json:"sloId"`
+	SloName        string     `json:"sloName"`
+	SolutionName   string     `json:"solutionName"`
+	Critical       bool       `json:"critical"`
+	MetricType     MetricType `json:"metricType"`
+	Target         float64    `json:"target"`
+	Achieved       *float64   `json:"achieved"`
+	BudgetConsumed *float64   `json:"budgetConsumed"`
+	BreachedDays   int        `json:"breachedDays"`
+	Breached       bool       `json:"breached"`
+	ExternalSLA    string     `json:"externalSla"`
+	SlaMet         *bool      `json:"slaMet"`
+}
@@ -0,0 +1,25 @@
+package model
+
+type CompositeAggregation string
+
+const (
+	CompositeAggregationNone CompositeAggregation = ""
+	// CompositeAggregationWeightedAverage averages the values of the children by their weights.
+	CompositeAggregationWeightedAverage CompositeAggregation = "weighted_average"
+	// CompositeAggregationAllMustPass is good only while every child meets its own target.
+	CompositeAggregationAllMustPass CompositeAggregation = "all_must_pass"
+
+	MaxSloChildren = 50
+)
+
+// SloChild is an SLO aggregated into the composite SLO ParentID.
+type SloChild struct {
+	ParentID int64   `db:"parent_id" json:"-"`
+	ChildID  int64   `db:"child_id" json:"sloId" validate:"required,gt=0"`
+	Weight   float64 `db:"weight" json:"weight" validate:"gte=0"`
+}
+
+// IsComposite tells whether the SLO is computed from child SLOs instead of its own history.
+func (s *Slo) IsComposite() bool {
+	return s.Composite != CompositeAggregationNone
+}
@@ -0,0 +1,7 @@
+package datadog
+
+// Credentials are the API and application key a Datadog datasource calls Datadog with.
+type Credentials struct {
+	APIKey string
+	AppKey string
+}
@@ -0,0 +1,41 @@
+package datadog
+
+const (
+	SLOTypeMetric    = "metric"
+	SLOTypeMonitor   = "monitor"
+	SLOTypeTimeSlice = "time_slice"
+)
+
+type SLOHistory struct {
+	Type     string   `json:"type"`
+	SLIValue *float64 `json:"sliValue"`
+}
+
+type SLOThreshold struct {
+	Timeframe string  `json:"timeframe"`
+	Target    float64 `json:"target"`
+}
+
+type SLO struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	Description string         `json:"description"`
+	Thresholds  []SLOThreshold `json:"thresholds"`
+	MonitorIDs  []int64        `json:"monitorIds,omitempty"`
+	Tags        []string       `json:"tags"`
+}
+
+// Threshold returns the threshold of the given timeframe, or the first threshold when the
+// SLO has none for it. It returns nil for an SLO without thresholds.
+func (s *SLO) Threshold(timeframe string) *SLOThreshold {
+	if len(s.Thresholds) == 0 {
+		return nil
+	}
+	for i := range s.Thresholds {
+		if s.Thresholds[i].Timeframe == timeframe {
+			return &s.Thresholds[i]
+		}
+	}
+	return &s.Thresholds[0]
+}
@@ -0,0 +1,67 @@
+package elastic
+
+import (
+	"fmt"
+	"time"
+)
+
+type SloHistoryRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type SloHistoryIndexCount struct {
+	Index string `json:"index"`
+	Count int64  `json:"count"`
+}
+
+type SloHistoryDeletion struct {
+	SloID   int64                   `json:"sloId"`
+	From    time.Time               `json:"from"`
+	To      time.Time               `json:"to"`
+	DryRun  bool                    `json:"dryRun"`
+	Matches []*SloHistoryIndexCount `json:"matches,omitempty"`
+	TaskID  string                  `json:"taskId,omitempty"`
+}
+
+type SloHistoryDeletionTask struct {
+	TaskID    string `json:"taskId"`
+	Completed bool   `json:"completed"`
+	Total     int64  `json:"total"`
+	Deleted   int64  `json:"deleted"`
+	Conflicts int64  `json:"versionConflicts"`
+	Failures  int    `json:"failures"`
+}
+
+// SloHistoryPoint has no values when it overlaps maintenance of the SLO. GoodWindows is the
+// percentage of time windows in which a latency SLO met its threshold.
+type SloHistoryPoint struct {
+	Time        time.Time `json:"time"`
+	Compliance  *float64  `json:"compliance"`
+	SuccessRate *float64  `json:"successRate"`
+	GoodWindows *float64  `json:"goodWindows"`
+	Maintenance bool      `json:"maintenance,omitempty"`
+}
+
+type SloHistory struct {
+	SloID    int64              `json:"sloId"`
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Interval string             `json:"interval"`
+	Points   []*SloHistoryPoint `json:"points"`
+}
+
+// SloHistoryDocument is a daily SLO history entry as stored in the SLO history indices.
+type SloHistoryDocument struct {
+	ID          int64     `json:"id"`
+	ExternalID  string    `json:"external_id"`
+	Date        time.Time `json:"date"`
+	Compliance  *float64  `json:"compliance,omitempty"`
+	SuccessRate *float64  `json:"success_rate,omitempty"`
+	GoodWindows *float64  `json:"good_windows,omitempty"`
+}
+
+// DocumentID is derived from SLO and date so that ingesting a day again overwrites it.
+func (d *SloHistoryDocument) DocumentID() string {
+	return fmt.Sprintf("%d-%s", d.ID, d.Date.UTC().Format("20060102"))
+}
@@ -0,0 +1,10 @@
+package model
+
+// VersionedEntity is an entity whose version is increased on every change, clients send the
+// version they read as If-Match to not overwrite changes made meanwhile.
+type VersionedEntity string
+
+const (
+	VersionedEntitySlo             VersionedEntity = "slo"
+	VersionedEntityHappinessMetric VersionedEntity = "happiness_metric"
+)
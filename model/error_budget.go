@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+// ErrorBudget is the state of the error budget of an SLO in its current window.
+// Days overlapping maintenance are left out of Actual and so do not consume budget.
+type ErrorBudget struct {
+	SloID        int64               `json:"sloId"`
+	Window       SloWindow           `json:"window"`
+	From         time.Time           `json:"from"`
+	To           time.Time           `json:"to"`
+	MetricType   MetricType          `json:"metricType"`
+	Target       float64             `json:"target"`
+	Actual       *float64            `json:"actual"`
+	Remaining    *float64            `json:"remaining"`
+	ExcludedDays int                 `json:"excludedDays"`
+	Maintenance  []MaintenancePeriod `json:"maintenance"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// IdempotentResponse is the response stored for an Idempotency-Key of a user. A Status of 0
+// marks a request which is still being processed.
+type IdempotentResponse struct {
+	UserID      int64     `db:"user_id"`
+	Key         string    `db:"key"`
+	RequestHash string    `db:"request_hash"`
+	Status      int       `db:"status"`
+	ContentType string    `db:"content_type"`
+	Body        []byte    `db:"body"`
+	CreatedAt   time.Time `db:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"time"
+)
+
+type MaintenanceRecurrence string
+
+const (
+	MaintenanceRecurrenceNone    MaintenanceRecurrence = ""
+	MaintenanceRecurrenceDaily   MaintenanceRecurrence = "daily"
+	MaintenanceRecurrenceWeekly  MaintenanceRecurrence = "weekly"
+	MaintenanceRecurrenceMonthly MaintenanceRecurrence = "monthly"
+)
+
+// MaintenanceWindow is planned downtime of a single SLO or of all SLOs of a product.
+// A recurring window repeats from Start/End until RecurrenceEnd, or forever without it.
+//
+//nolint:lll
+type MaintenanceWindow struct {
+	ID            int64                 `db:"id" json:"id" binding:"-"`
+	OrgID         int64                 `db:"org_id" json:"orgId" binding:"-" validate:"required"`
+	SloID         *int64                `db:"slo_id" json:"sloId" binding:"-" validate:"required_without=ProductID,excluded_with=ProductID,omitempty,gt=0"`
+	ProductID     *int64                `db:"product_id" json:"productId" binding:"-" validate:"required_without=SloID,omitempty,gt=0"`
+	Description   string                `db:"description" json:"description" binding:"-" validate:"max=256"`
+	Start         time.Time             `db:"start_date" json:"start" binding:"-" validate:"required"`
+	End           time.Time             `db:"end_date" json:"end" binding:"-" validate:"required,gtfield=Start"`
+	Recurrence    MaintenanceRecurrence `db:"recurrence" json:"recurrence" binding:"-" validate:"omitempty,oneof=daily weekly monthly"`
+	RecurrenceEnd *time.Time            `db:"recurrence_end" json:"recurrenceEnd" binding:"-" validate:"omitempty,gtfield=End"`
+}
+
+type MaintenancePeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	Text string    `json:"text,omitempty"`
+}
+
+// Occurrence returns the nth occurrence of the window, the first one being Start/End.
+func (m *MaintenanceWindow) Occurrence(n int) MaintenancePeriod {
+	period := MaintenancePeriod{From: m.Start, To: m.End, Text: m.Description}
+	switch m.Recurrence {
+	case MaintenanceRecurrenceDaily:
+		period.From, period.To = m.Start.AddDate(0, 0, n), m.End.AddDate(0, 0, n)
+	case MaintenanceRecurrenceWeekly:
+		period.From, period.To = m.Start.AddDate(0, 0, 7*n), m.End.AddDate(0, 0, 7*n)
+	case MaintenanceRecurrenceMonthly:
+		period.From, period.To = m.Start.AddDate(0, n, 0), m.End.AddDate(0, n, 0)
+	}
+	return period
+}
+
+// Periods returns the occurrences of the window which overlap the range from - to.
+func (m *MaintenanceWindow) Periods(from, to time.Time) []MaintenancePeriod {
+	periods := []MaintenancePeriod{}
+	if m.Recurrence == MaintenanceRecurrenceNone {
+		if m.Start.Before(to) && m.End.After(from) {
+			periods = append(periods, m.Occurrence(0))
+		}
+		return periods
+	}
+
+	// skip the daily and weekly occurrences ending before the range instead of walking through them
+	n := 0
+	step := map[MaintenanceRecurrence]time.Duration{
+		MaintenanceRecurrenceDaily:  24 * time.Hour,
+		MaintenanceRecurrenceWeekly: 7 * 24 * time.Hour,
+	}[m.Recurrence]
+	if step > 0 && from.After(m.End) {
+		n = int(from.Sub(m.End)/step) - 1
+		if n < 0 {
+			n = 0
+		}
+	}
+
+	for ; ; n++ {
+		period := m.Occurrence(n)
+		if !period.From.Before(to) || (m.RecurrenceEnd != nil && !period.From.Before(*m.RecurrenceEnd)) {
+			return periods
+		}
+		if period.To.After(from) {
+			periods = append(periods, period)
+		}
+	}
+}
+
+// Overlaps tells whether any of the periods overlaps the range from - to.
+func Overlaps(periods []MaintenancePeriod, from, to time.Time) bool {
+	for _, period := range periods {
+		if period.From.Before(to) && period.To.After(from) {
+			return true
+		}
+	}
+	return false
+}
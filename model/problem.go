@@ -0,0 +1,23 @@
+package model
+
+// Problem is an RFC 7807 problem details error response. Type is a stable URI per error
+// category, Message repeats Detail for clients of the former {"message": ...} responses.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	CorrelationID string         `json:"correlationId,omitempty"`
+	Retryable     bool           `json:"retryable"`
+	Errors        []FieldProblem `json:"errors,omitempty"`
+	Message       string         `json:"message"`
+}
+
+// FieldProblem tells which field of the request failed which validation rule.
+type FieldProblem struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,26 @@
+package model
+
+type SearchHitType string
+
+const (
+	SearchHitTypeSlo          SearchHitType = "slo"
+	SearchHitTypeOrganization SearchHitType = "organization"
+	SearchHitTypeSolution     SearchHitType = "solution"
+	SearchHitTypeProduct      SearchHitType = "product"
+	SearchHitTypeDatasource   SearchHitType = "datasource"
+)
+
+// SearchHitTypes are all types searched when no type is requested.
+var SearchHitTypes = []SearchHitType{SearchHitTypeSlo, SearchHitTypeOrganization, SearchHitTypeSolution,
+	SearchHitTypeProduct, SearchHitTypeDatasource}
+
+// SearchHit is an entity whose name matches the search, Score ranks it against the other hits.
+// OrgID is nil for products, which are shared by the solutions of several organizations.
+type SearchHit struct {
+	Type  SearchHitType `db:"type" json:"type"`
+	ID    int64         `db:"id" json:"id"`
+	OrgID *int64        `db:"org_id" json:"orgId"`
+	Name  string        `db:"name" json:"name"`
+	Score float64       `db:"score" json:"score"`
+	Link  string        `db:"-" json:"link"`
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+)
+
+// SlaBreach is an SLA period in which the achieved availability of an SLO stayed below its
+// external SLA. Start is the beginning of the first and End the end of the last day below
+// the SLA. Breaches of periods which are not Closed yet may still heal and disappear.
+// CreditPercent is nil when the solution of the SLO has no credit table.
+type SlaBreach struct {
+	ID            int64     `db:"id" json:"id"`
+	SloID         int64     `db:"slo_id" json:"sloId"`
+	SloName       string    `db:"slo_name" json:"sloName"`
+	OrgID         int64     `db:"org_id" json:"orgId"`
+	SolutionID    *int64    `db:"solution_id" json:"solutionId"`
+	PeriodStart   time.Time `db:"period_start" json:"periodStart"`
+	PeriodEnd     time.Time `db:"period_end" json:"periodEnd"`
+	Start         time.Time `db:"start_date" json:"start"`
+	End           time.Time `db:"end_date" json:"end"`
+	SLA           float64   `db:"sla" json:"sla"`
+	Achieved      float64   `db:"achieved" json:"achieved"`
+	Closed        bool      `db:"closed" json:"closed"`
+	CreditPercent *float64  `db:"-" json:"creditPercent"`
+}
+
+// SlaCreditTier grants CreditPercent of the fee of an SLA period whose achieved availability
+// is below Below. Of several matching tiers the highest credit applies.
+//
+//nolint:lll
+type SlaCreditTier struct {
+	OrgID         int64   `db:"org_id" json:"-"`
+	SolutionID    int64   `db:"solution_id" json:"-"`
+	Below         float64 `db:"below" json:"below" binding:"-" validate:"gt=0,lte=100"`
+	CreditPercent float64 `db:"credit_percent" json:"creditPercent" binding:"-" validate:"gte=0,lte=100"`
+}
+
+// SlaCreditTable holds the credit tiers an organization agreed on for a solution.
+type SlaCreditTable struct {
+	OrgID      int64           `json:"orgId" binding:"-"`
+	SolutionID int64           `json:"solutionId" binding:"-"`
+	Tiers      []SlaCreditTier `json:"tiers" binding:"-" validate:"max=20,dive"`
+}
+
+// Credit returns the credit of the highest tier the achieved availability falls into, 0 when
+// it falls into none.
+func (t *SlaCreditTable) Credit(achieved float64) float64 {
+	credit := 0.0
+	for _, tier := range t.Tiers {
+		if achieved < tier.Below && tier.CreditPercent > credit {
+			credit = tier.CreditPercent
+		}
+	}
+	return credit
+}
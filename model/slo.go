@@ -10,22 +10,49 @@ const (
 	ExternalSloTypeNoType  ExternalSloType = ""
 	ExternalSloTypeMetric  ExternalSloType = "metric"
 	ExternalSloTypeMonitor ExternalSloType = "monitor"
+	// ExternalSloTypeTimeSlice is a Datadog time slice SLO, which counts the time windows in which
+	// a latency percentile stays below the threshold.
+	ExternalSloTypeTimeSlice ExternalSloType = "time_slice"
+)
+
+type LatencyPercentile string
+
+const (
+	LatencyPercentileP50  LatencyPercentile = "p50"
+	LatencyPercentileP75  LatencyPercentile = "p75"
+	LatencyPercentileP90  LatencyPercentile = "p90"
+	LatencyPercentileP95  LatencyPercentile = "p95"
+	LatencyPercentileP99  LatencyPercentile = "p99"
+	LatencyPercentileP999 LatencyPercentile = "p99.9"
 )
 
 //nolint:lll
 type Slo struct {
-	ID                              int64           `db:"id" json:"id" binding:"-" validate:"required_for_update"`
-	OrgID                           int64           `db:"org_id" json:"orgId" binding:"-" validate:"required"`
-	Name                            string          `db:"name" json:"name" binding:"-" validate:"required,max=188"`
-	SuccessRateExpectedAvailability string          `db:"success_rate_exp_availability" json:"successRateExpAvailability" binding:"-" validate:"required,float_string,gte_val=0,lte_val=100,max_precision=3"`
-	ComplianceExpectedAvailability  string          `db:"compliance_exp_availability" json:"complianceExpAvailability" binding:"-" validate:"required,float_string,gte_val=0,lte_val=100,max_precision=3"`
-	Autogen                         bool            `db:"autogen" json:"autogen"`
-	CreationDate                    time.Time       `db:"creation_date" json:"creationDate" binding:"-"`
-	Critical                        bool            `db:"critical" json:"critical" binding:"-" validate:"required_with=ExternalSLA"`
-	DatasourceID                    int64           `db:"ds_id" json:"datasourceId" binding:"-" validate:"gte=0"`
-	ExternalID                      string          `db:"external_id" json:"externalId" binding:"-" validate:"omitempty,max=32"`
-	ExternalSLA                     string          `db:"external_sla" json:"externalSla" binding:"-" validate:"omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
-	ExternalType                    ExternalSloType `db:"external_type" json:"externalType" binding:"-" validate:"omitempty,oneof=metric monitor"`
+	ID                              int64                `db:"id" json:"id" binding:"-" validate:"required_for_update"`
+	OrgID                           int64                `db:"org_id" json:"orgId" binding:"-" validate:"required"`
+	Name                            string               `db:"name" json:"name" binding:"-" validate:"required,max=188"`
+	SuccessRateExpectedAvailability string               `db:"success_rate_exp_availability" json:"successRateExpAvailability" binding:"-" validate:"required_unless=ExternalType time_slice,omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
+	ComplianceExpectedAvailability  string               `db:"compliance_exp_availability" json:"complianceExpAvailability" binding:"-" validate:"required_unless=ExternalType time_slice,omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
+	Autogen                         bool                 `db:"autogen" json:"autogen"`
+	CreationDate                    time.Time            `db:"creation_date" json:"creationDate" binding:"-"`
+	Critical                        bool                 `db:"critical" json:"critical" binding:"-" validate:"required_with=ExternalSLA"`
+	DatasourceID                    int64                `db:"ds_id" json:"datasourceId" binding:"-" validate:"gte=0"`
+	ExternalID                      string               `db:"external_id" json:"externalId" binding:"-" validate:"omitempty,max=32"`
+	ExternalSLA                     string               `db:"external_sla" json:"externalSla" binding:"-" validate:"omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
+	ExternalType                    ExternalSloType      `db:"external_type" json:"externalType" binding:"-" validate:"omitempty,oneof=metric monitor time_slice"`
+	WindowType                      SloWindowType        `db:"window_type" json:"windowType" binding:"-" validate:"omitempty,oneof=rolling calendar"`
+	WindowDays                      int64                `db:"window_days" json:"windowDays" binding:"-" validate:"required_if=WindowType rolling,excluded_with=WindowPeriod,gte=0,lte=365"`
+	WindowPeriod                    CalendarPeriod       `db:"window_period" json:"windowPeriod" binding:"-" validate:"required_if=WindowType calendar,excluded_without=WindowType,omitempty,oneof=week month quarter"`
+	LatencyPercentile               LatencyPercentile    `db:"latency_percentile" json:"latencyPercentile" binding:"-" validate:"required_if=ExternalType time_slice,omitempty,oneof=p50 p75 p90 p95 p99 p99.9"`
+	LatencyThresholdMs              int64                `db:"latency_threshold_ms" json:"latencyThresholdMs" binding:"-" validate:"required_if=ExternalType time_slice,gte=0"`
+	LatencyTarget                   string               `db:"latency_target" json:"latencyTarget" binding:"-" validate:"required_if=ExternalType time_slice,omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
+	Composite                       CompositeAggregation `db:"composite" json:"composite" binding:"-" validate:"omitempty,oneof=weighted_average all_must_pass"`
+	Children                        []*SloChild          `db:"-" json:"children,omitempty" binding:"-" validate:"required_with=Composite,omitempty,max=50,dive"`
+	// Labels and Ownership are left unchanged by an update without them, an empty value removes them.
+	Labels    map[string]string `db:"-" json:"labels,omitempty" binding:"-"`
+	Ownership *SloOwnership     `db:"-" json:"ownership,omitempty" binding:"-" validate:"omitempty"`
+	// Warnings lists problems found while saving which did not prevent it.
+	Warnings []string `db:"-" json:"-"`
 }
 
 type DetailedSlo struct {
@@ -40,11 +67,25 @@ type DatasourceType string
 const (
 	MetricTypeSuccessRate  MetricType = "successrate"
 	MetricTypeCompliance   MetricType = "compliance"
+	MetricTypeLatency      MetricType = "latency"
 	MetricTypeNoMetricType MetricType = ""
 )
 
 type SloQueryParams struct {
-	OrgID          int64          `json:"-" validate:"required"`
-	MetricType     MetricType     `json:"metricType" validate:"omitempty,oneof=successrate compliance"`
-	DatasourceType DatasourceType `json:"datasourceType" validate:"omitempty,oneof=elasticsearch prometheus datadog"`
+	OrgID          int64             `json:"-" validate:"required"`
+	MetricType     MetricType        `json:"metricType" validate:"omitempty,oneof=successrate compliance latency"`
+	DatasourceType DatasourceType    `json:"datasourceType" validate:"omitempty,oneof=elasticsearch prometheus datadog"`
+	Labels         map[string]string `json:"labels"`
+}
+
+// MetricType is the metric the error budget of the SLO is calculated from.
+func (s *Slo) MetricType() MetricType {
+	switch s.ExternalType {
+	case ExternalSloTypeMonitor:
+		return MetricTypeCompliance
+	case ExternalSloTypeTimeSlice:
+		return MetricTypeLatency
+	default:
+		return MetricTypeSuccessRate
+	}
 }
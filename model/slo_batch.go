@@ -0,0 +1,59 @@
+package model
+
+type SloBatchAction string
+
+const (
+	SloBatchActionCreate SloBatchAction = "create"
+	SloBatchActionUpdate SloBatchAction = "update"
+	SloBatchActionDelete SloBatchAction = "delete"
+)
+
+type SloBatchMode string
+
+const (
+	// SloBatchModeAllOrNothing applies the operations only if all of them can be applied.
+	SloBatchModeAllOrNothing SloBatchMode = "allOrNothing"
+	// SloBatchModeBestEffort applies every operation which can be applied.
+	SloBatchModeBestEffort SloBatchMode = "bestEffort"
+)
+
+// MaxSloBatchSize is the largest number of operations in one batch.
+const MaxSloBatchSize = 1000
+
+// SloBatch is a list of SLO operations across organizations. The default mode is all or nothing.
+type SloBatch struct {
+	Mode       SloBatchMode         `json:"mode"`
+	Operations []*SloBatchOperation `json:"operations"`
+}
+
+// SloBatchOperation creates the Slo, or updates or deletes the SLO with ID. Updates send the
+// ETag they are based on as IfMatch like the If-Match header of single updates.
+type SloBatchOperation struct {
+	Action  SloBatchAction `json:"action"`
+	ID      int64          `json:"id,omitempty"`
+	IfMatch string         `json:"ifMatch,omitempty"`
+	Cascade bool           `json:"cascade,omitempty"`
+	Slo     *Slo           `json:"slo,omitempty"`
+	Version *int64         `json:"-"`
+}
+
+// SloBatchItemResult is the outcome of the operation at Index, Status is the status code the
+// single-item route would have returned.
+type SloBatchItemResult struct {
+	Index         int               `json:"index"`
+	Action        SloBatchAction    `json:"action"`
+	ID            int64             `json:"id,omitempty"`
+	Status        int               `json:"status"`
+	Version       *int64            `json:"version,omitempty"`
+	ChangeRequest *SloChangeRequest `json:"changeRequest,omitempty"`
+	Message       string            `json:"message,omitempty"`
+	Warnings      []string          `json:"warnings,omitempty"`
+}
+
+type SloBatchResult struct {
+	Mode       SloBatchMode          `json:"mode"`
+	Succeeded  int                   `json:"succeeded"`
+	Failed     int                   `json:"failed"`
+	RolledBack bool                  `json:"rolledBack"`
+	Results    []*SloBatchItemResult `json:"results"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+type SloChangeAction string
+
+const (
+	SloChangeActionUpdate SloChangeAction = "update"
+	SloChangeActionDelete SloChangeAction = "delete"
+)
+
+type SloChangeStatus string
+
+const (
+	SloChangeStatusPending  SloChangeStatus = "pending"
+	SloChangeStatusApproved SloChangeStatus = "approved"
+	SloChangeStatusRejected SloChangeStatus = "rejected"
+	SloChangeStatusExpired  SloChangeStatus = "expired"
+	SloChangeStatusFailed   SloChangeStatus = "failed"
+)
+
+// SloChangeRequest is a change of a critical SLO waiting for an organization Admin. Requests are
+// never removed, the decision, its author and an error applying the change stay on record.
+// Version is the version of the SLO the change is based on, nil overwrites any version.
+type SloChangeRequest struct {
+	ID               int64           `db:"id" json:"id"`
+	SloID            int64           `db:"slo_id" json:"sloId"`
+	OrgID            int64           `db:"org_id" json:"orgId"`
+	Action           SloChangeAction `db:"action" json:"action"`
+	Slo              *Slo            `db:"-" json:"slo,omitempty"`
+	Version          *int64          `db:"version" json:"version,omitempty"`
+	Cascade          bool            `db:"cascade" json:"cascade"`
+	Status           SloChangeStatus `db:"status" json:"status"`
+	RequestedBy      int64           `db:"requested_by" json:"requestedBy"`
+	RequestedByLogin string          `db:"requested_by_login" json:"requestedByLogin"`
+	RequestedAt      time.Time       `db:"requested_at" json:"requestedAt"`
+	ExpiresAt        time.Time       `db:"expires_at" json:"expiresAt"`
+	DecidedBy        *int64          `db:"decided_by" json:"decidedBy,omitempty"`
+	DecidedByLogin   string          `db:"decided_by_login" json:"decidedByLogin,omitempty"`
+	DecidedAt        *time.Time      `db:"decided_at" json:"decidedAt,omitempty"`
+	Comment          string          `db:"comment" json:"comment,omitempty"`
+	Error            string          `db:"error" json:"error,omitempty"`
+}
+
+type SloChangeDecision struct {
+	Comment string `json:"comment" validate:"max=1000"`
+}
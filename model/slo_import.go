@@ -0,0 +1,12 @@
+package model
+
+type SloImport struct {
+	ExternalIDs []string `json:"externalIds" validate:"required,min=1,max=100,dive,required,max=32"`
+	Critical    bool     `json:"critical"`
+}
+
+type SloImportResult struct {
+	ExternalID string `json:"externalId"`
+	ID         int64  `json:"id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
@@ -0,0 +1,20 @@
+package model
+
+// SloLabelKeys are the label keys SLOs of the organization may use, any key is allowed while
+// the organization has none.
+//
+//nolint:lll
+type SloLabelKeys struct {
+	OrgID int64    `json:"orgId" binding:"-"`
+	Keys  []string `json:"keys" binding:"-" validate:"max=50,dive,required,max=63"`
+}
+
+// HasLabels tells whether the SLO carries every label of the selector with the same value.
+func (s *Slo) HasLabels(selector map[string]string) bool {
+	for key, value := range selector {
+		if labelValue, ok := s.Labels[key]; !ok || labelValue != value {
+			return false
+		}
+	}
+	return true
+}
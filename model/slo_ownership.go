@@ -0,0 +1,50 @@
+package model
+
+type SloContactType string
+
+const (
+	SloContactTypeEmail     SloContactType = "email"
+	SloContactTypeSlack     SloContactType = "slack"
+	SloContactTypePagerDuty SloContactType = "pagerduty"
+	SloContactTypeURL       SloContactType = "url"
+)
+
+// SloContact is a channel to reach the owner of an SLO, url contacts are linked on the dashboard.
+type SloContact struct {
+	Type  SloContactType `db:"type" json:"type" validate:"required,oneof=email slack pagerduty url"`
+	Value string         `db:"value" json:"value" validate:"required,max=256"`
+}
+
+// SloOwnership tells who to contact when the SLO burns its error budget. The owner team is a
+// Grafana team of the organization, its id is resolved when the ownership is saved.
+//
+//nolint:lll
+type SloOwnership struct {
+	OwnerTeam       string       `db:"owner_team" json:"ownerTeam" validate:"max=256"`
+	OwnerTeamID     *int64       `db:"owner_team_id" json:"ownerTeamId,omitempty" binding:"-"`
+	Contacts        []SloContact `db:"-" json:"contacts" validate:"max=10,dive"`
+	RunbookURL      string       `db:"runbook_url" json:"runbookUrl" validate:"omitempty,url,max=512"`
+	EscalationNotes string       `db:"escalation_notes" json:"escalationNotes" validate:"max=2000"`
+}
+
+// IsEmpty tells whether nothing is set, saving an empty ownership removes the stored one.
+func (o *SloOwnership) IsEmpty() bool {
+	return o.OwnerTeam == "" && len(o.Contacts) == 0 && o.RunbookURL == "" && o.EscalationNotes == ""
+}
+
+type SloOwnershipGapType string
+
+const (
+	SloOwnershipGapNoOwner      SloOwnershipGapType = "no_owner"
+	SloOwnershipGapUnknownOwner SloOwnershipGapType = "unknown_owner"
+	SloOwnershipGapNoContacts   SloOwnershipGapType = "no_contacts"
+	SloOwnershipGapNoRunbook    SloOwnershipGapType = "no_runbook"
+)
+
+// SloOwnershipGap lists what is missing to reach the owner of an SLO.
+type SloOwnershipGap struct {
+	SloID     int64                 `json:"sloId"`
+	SloName   string                `json:"sloName"`
+	OwnerTeam string                `json:"ownerTeam,omitempty"`
+	Gaps      []SloOwnershipGapType `json:"gaps"`
+}
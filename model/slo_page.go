@@ -0,0 +1,87 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+type SloSortField string
+
+const (
+	SloSortFieldName         SloSortField = "name"
+	SloSortFieldCreationDate SloSortField = "creationDate"
+	SloSortFieldCritical     SloSortField = "critical"
+	SloSortFieldOrg          SloSortField = "org"
+)
+
+// MaxSloPageLimit is the largest page of SLOs which can be requested at once.
+const MaxSloPageLimit = 1000
+
+// SloPageQuery selects a page of SLOs. Nil and empty filters are not applied, a Limit of 0
+// returns all SLOs after the cursor.
+type SloPageQuery struct {
+	OrgID        *int64
+	Name         string
+	NamePrefix   string
+	OrgName      string
+	Critical     *bool
+	ExternalType *ExternalSloType
+	DatasourceID *int64
+	SolutionID   *int64
+	Labels       map[string]string
+	Sort         SloSortField
+	Descending   bool
+	Limit        int
+	After        *SloCursor
+}
+
+// SloCursor points behind the last SLO of a page. It is only valid for the sort order it was
+// created for.
+type SloCursor struct {
+	Sort       SloSortField `json:"s"`
+	Descending bool         `json:"d"`
+	Value      string       `json:"v"`
+	ID         int64        `json:"i"`
+}
+
+type SloPage struct {
+	Slos       []*DetailedSlo
+	Total      int64
+	NextCursor *SloCursor
+}
+
+// NewSloCursor returns the cursor pointing behind the SLO in the given sort order.
+func NewSloCursor(slo *DetailedSlo, sort SloSortField, descending bool) *SloCursor {
+	cursor := &SloCursor{Sort: sort, Descending: descending, ID: slo.ID}
+	switch sort {
+	case SloSortFieldCreationDate:
+		cursor.Value = slo.CreationDate.Format(time.RFC3339Nano)
+	case SloSortFieldCritical:
+		cursor.Value = strconv.FormatBool(slo.Critical)
+	case SloSortFieldOrg:
+		cursor.Value = slo.OrgName
+	default:
+		cursor.Value = slo.Name
+	}
+	return cursor
+}
+
+// Encode returns the opaque representation of the cursor handed out to clients.
+func (c *SloCursor) Encode() string {
+	value, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+func ParseSloCursor(value string) (*SloCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var cursor SloCursor
+	if err = json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+)
+
+// SloSimulation replays the history of an SLO from From to To with other targets, targets
+// which are left empty keep the target of the SLO.
+//
+//nolint:lll
+type SloSimulation struct {
+	SuccessRateExpectedAvailability string    `json:"successRateExpAvailability" binding:"-" validate:"omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
+	ComplianceExpectedAvailability  string    `json:"complianceExpAvailability" binding:"-" validate:"omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
+	LatencyTarget                   string    `json:"latencyTarget" binding:"-" validate:"omitempty,float_string,gte_val=0,lte_val=100,max_precision=3"`
+	From                            time.Time `json:"from" binding:"-" validate:"required"`
+	To                              time.Time `json:"to" binding:"-" validate:"required,gtfield=From"`
+}
+
+// SloSimulationResult splits the simulated range into the windows of the SLO. A period has
+// breached when its average missed the target, Consumed is the share of the budget of the
+// whole period which was used within the range.
+type SloSimulationResult struct {
+	SloID           int64             `json:"sloId"`
+	MetricType      MetricType        `json:"metricType"`
+	CurrentTarget   float64           `json:"currentTarget"`
+	Target          float64           `json:"target"`
+	From            time.Time         `json:"from"`
+	To              time.Time         `json:"to"`
+	BreachedPeriods int               `json:"breachedPeriods"`
+	Periods         []SimulatedPeriod `json:"periods"`
+	Alerts          []SimulatedAlert  `json:"alerts"`
+}
+
+type SimulatedPeriod struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Actual   *float64  `json:"actual"`
+	Consumed *float64  `json:"consumed"`
+	Breached bool      `json:"breached"`
+}
+
+// SimulatedAlert is a day on which the burn rate over both the long and the short window of
+// the multi-window alert reached its threshold.
+type SimulatedAlert struct {
+	Day           time.Time `json:"day"`
+	Alert         string    `json:"alert"`
+	LongBurnRate  float64   `json:"longBurnRate"`
+	ShortBurnRate float64   `json:"shortBurnRate"`
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+)
+
+type SloWindowType string
+type CalendarPeriod string
+
+const (
+	SloWindowTypeRolling  SloWindowType = "rolling"
+	SloWindowTypeCalendar SloWindowType = "calendar"
+
+	CalendarPeriodWeek    CalendarPeriod = "week"
+	CalendarPeriodMonth   CalendarPeriod = "month"
+	CalendarPeriodQuarter CalendarPeriod = "quarter"
+
+	DefaultSloWindowDays = 30
+)
+
+// SloWindow is the time window an SLO is evaluated over, either the last Days days
+// or the current calendar Period.
+type SloWindow struct {
+	Type   SloWindowType  `json:"type"`
+	Days   int64          `json:"days,omitempty"`
+	Period CalendarPeriod `json:"period,omitempty"`
+}
+
+// Window returns the window of the SLO, SLOs without one are evaluated over
+// the last 30 days and calendar SLOs without a period over the current month.
+func (s *Slo) Window() SloWindow {
+	if s.WindowType == SloWindowTypeCalendar {
+		period := s.WindowPeriod
+		if period == "" {
+			period = CalendarPeriodMonth
+		}
+		return SloWindow{Type: SloWindowTypeCalendar, Period: period}
+	}
+
+	days := s.WindowDays
+	if days <= 0 {
+		days = DefaultSloWindowDays
+	}
+	return SloWindow{Type: SloWindowTypeRolling, Days: days}
+}
+
+// Start returns the beginning of the window which contains now. Calendar windows
+// start at midnight UTC, weeks on Monday.
+func (w SloWindow) Start(now time.Time) time.Time {
+	now = now.UTC()
+	if w.Type == SloWindowTypeRolling {
+		return now.AddDate(0, 0, -int(w.Days))
+	}
+
+	year, month, day := now.Date()
+	switch w.Period {
+	case CalendarPeriodWeek:
+		daysSinceMonday := (int(now.Weekday()) + 6) % 7
+		return time.Date(year, month, day-daysSinceMonday, 0, 0, 0, 0, time.UTC)
+	case CalendarPeriodQuarter:
+		return time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// End returns the end of the window which contains now, which is now for rolling windows.
+func (w SloWindow) End(now time.Time) time.Time {
+	start := w.Start(now)
+	switch {
+	case w.Type == SloWindowTypeRolling:
+		return now.UTC()
+	case w.Period == CalendarPeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case w.Period == CalendarPeriodQuarter:
+		return start.AddDate(0, 3, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
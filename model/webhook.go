@@ -0,0 +1,95 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookEventType string
+
+const (
+	WebhookEventSloCreated    WebhookEventType = "slo.created"
+	WebhookEventSloUpdated    WebhookEventType = "slo.updated"
+	WebhookEventSloDeleted    WebhookEventType = "slo.deleted"
+	WebhookEventPluginEnabled WebhookEventType = "plugin.enabled"
+	// WebhookEventBudgetThresholdCrossed is sent when the remaining budget of an SLO drops to or
+	// below the threshold of a budget policy of its organization.
+	WebhookEventBudgetThresholdCrossed WebhookEventType = "budget.threshold_crossed"
+	// WebhookEventBudgetThresholdRecovered is sent when the remaining budget of an SLO is above
+	// the threshold of the policy again.
+	WebhookEventBudgetThresholdRecovered WebhookEventType = "budget.threshold_recovered"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending deliveries are sent, or sent again after a failed attempt.
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryStatusDelivered deliveries were answered with a 2xx status.
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryStatusFailed deliveries are not sent again, all attempts failed.
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "failed"
+)
+
+// Webhook is an endpoint of an organization which is notified of the events in Events. The
+// deliveries are signed with Secret, which is only returned when the webhook is created.
+//
+//nolint:lll
+type Webhook struct {
+	ID      int64              `db:"id" json:"id" binding:"-"`
+	OrgID   int64              `db:"org_id" json:"orgId" binding:"-" validate:"required"`
+	Name    string             `db:"name" json:"name" binding:"-" validate:"required,max=256"`
+	URL     string             `db:"url" json:"url" binding:"-" validate:"required,url,startswith=http,max=2048"`
+	Secret  string             `db:"secret" json:"secret,omitempty" binding:"-" validate:"omitempty,min=16,max=256"`
+	Events  []WebhookEventType `db:"-" json:"events" binding:"-" validate:"required,min=1,dive,oneof=slo.created slo.updated slo.deleted plugin.enabled budget.threshold_crossed budget.threshold_recovered"`
+	Enabled bool               `db:"enabled" json:"enabled" binding:"-"`
+}
+
+// Subscribed tells whether the webhook is notified of the event.
+func (w *Webhook) Subscribed(event WebhookEventType) bool {
+	for _, subscribed := range w.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent is the body of a delivery, Data depends on Event.
+type WebhookEvent struct {
+	Event      WebhookEventType `json:"event"`
+	OrgID      int64            `json:"orgId"`
+	OccurredAt time.Time        `json:"occurredAt"`
+	Data       interface{}      `json:"data"`
+}
+
+// NewWebhookEvent returns the event occurring now.
+func NewWebhookEvent(orgID int64, event WebhookEventType, data interface{}) *WebhookEvent {
+	return &WebhookEvent{Event: event, OrgID: orgID, OccurredAt: time.Now().UTC(), Data: data}
+}
+
+// WebhookDelivery is an event in the outbox of a webhook. Pending deliveries are attempted at
+// NextAttemptAt, the last attempt leaves its response status or error.
+type WebhookDelivery struct {
+	ID             int64                 `db:"id" json:"id"`
+	WebhookID      int64                 `db:"webhook_id" json:"webhookId"`
+	OrgID          int64                 `db:"org_id" json:"orgId"`
+	Event          WebhookEventType      `db:"event" json:"event"`
+	Payload        json.RawMessage       `db:"-" json:"payload"`
+	Status         WebhookDeliveryStatus `db:"status" json:"status"`
+	Attempts       int                   `db:"attempts" json:"attempts"`
+	URL            string                `db:"url" json:"url"`
+	CreatedAt      time.Time             `db:"created_at" json:"createdAt"`
+	NextAttemptAt  *time.Time            `db:"next_attempt_at" json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *time.Time            `db:"last_attempt_at" json:"lastAttemptAt,omitempty"`
+	ResponseStatus *int                  `db:"response_status" json:"responseStatus,omitempty"`
+	Error          string                `db:"error" json:"error,omitempty"`
+	DeliveredAt    *time.Time            `db:"delivered_at" json:"deliveredAt,omitempty"`
+}
+
+// WebhookPluginEnabled is the data of plugin.enabled events.
+type WebhookPluginEnabled struct {
+	OrgID              int64  `json:"orgId"`
+	OrgName            string `json:"orgName"`
+	DatasourcesSkipped bool   `json:"datasourcesSkipped"`
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+)
+
+// IAdminProvider lists entities across all organizations for the admin tasks, it does no
+// authorization and must not be used by the API.
+type IAdminProvider interface {
+	GetOrganizations() ([]*grafana.Organization, error)
+	GetUsers() ([]*model.GrafanaUser, error)
+	GetSloIDs() ([]int64, error)
+}
+
+func (s *SQL) GetOrganizations() ([]*grafana.Organization, error) {
+	orgs := []*grafana.Organization{}
+	if err := s.DB.Select(&orgs, `SELECT id, name FROM org ORDER BY id`); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return orgs, nil
+}
+
+func (s *SQL) GetUsers() ([]*model.GrafanaUser, error) {
+	users := []*model.GrafanaUser{}
+	if err := s.DB.Select(&users, `SELECT id, login, is_admin FROM "user" ORDER BY id`); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return users, nil
+}
+
+func (s *SQL) GetSloIDs() ([]int64, error) {
+	ids := []int64{}
+	if err := s.DB.Select(&ids, `SELECT id FROM slo ORDER BY id`); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return ids, nil
+}
@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type IBudgetPolicyProvider interface {
+	CreateBudgetPolicy(policy *model.BudgetPolicy) error
+	UpdateBudgetPolicy(policy *model.BudgetPolicy) error
+	DeleteBudgetPolicy(id int64) error
+	GetBudgetPolicy(id int64) (*model.BudgetPolicy, error)
+	GetBudgetPoliciesByOrgID(orgID int64) ([]*model.BudgetPolicy, error)
+	StartDeployFreeze(freeze *model.DeployFreeze) (bool, error)
+	EndDeployFreeze(id int64, end time.Time) error
+	GetActiveDeployFreeze(orgID int64) (*model.DeployFreeze, error)
+	GetDeployFreezesByOrgID(orgID int64) ([]*model.DeployFreeze, error)
+	GetBudgetPolicyOrgIDs() ([]int64, error)
+	GetBudgetPolicyTriggers(orgID int64) ([]*model.BudgetPolicyTrigger, error)
+	CreateBudgetPolicyTrigger(trigger *model.BudgetPolicyTrigger, event *model.WebhookEvent) (bool, error)
+	DeleteBudgetPolicyTrigger(policyID, sloID int64, event *model.WebhookEvent) (bool, error)
+}
+
+const budgetPolicyColumns = `id, org_id, name, critical_only, threshold, action`
+
+const deployFreezeColumns = `id, org_id, start_date, end_date, reasons`
+
+const budgetPolicyTriggerColumns = `policy_id, slo_id, org_id, since`
+
+func (s *SQL) CreateBudgetPolicy(policy *model.BudgetPolicy) error {
+	rows, err := s.DB.NamedQuery(`INSERT INTO budget_policy (org_id, name, critical_only, threshold, action)
+		VALUES (:org_id, :name, :critical_only, :threshold, :action)
+		RETURNING id`, policy)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&policy.ID); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *SQL) UpdateBudgetPolicy(policy *model.BudgetPolicy) error {
+	result, err := s.DB.NamedExec(`UPDATE budget_policy SET
+		name = :name, critical_only = :critical_only, threshold = :threshold, action = :action
+		WHERE id = :id AND org_id = :org_id`, policy)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return checkBudgetPolicyAffected(result, "budgetPolicy", policy.ID)
+}
+
+func (s *SQL) DeleteBudgetPolicy(id int64) error {
+	result, err := s.DB.Exec(`DELETE FROM budget_policy WHERE id = $1`, id)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return checkBudgetPolicyAffected(result, "budgetPolicy", id)
+}
+
+func (s *SQL) GetBudgetPolicy(id int64) (*model.BudgetPolicy, error) {
+	policy := &model.BudgetPolicy{}
+	err := s.DB.Get(policy, `SELECT `+budgetPolicyColumns+` FROM budget_policy WHERE id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("budgetPolicy", id).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return policy, nil
+}
+
+func (s *SQL) GetBudgetPoliciesByOrgID(orgID int64) ([]*model.BudgetPolicy, error) {
+	policies := []*model.BudgetPolicy{}
+	err := s.DB.Select(&policies, `SELECT `+budgetPolicyColumns+` FROM budget_policy WHERE org_id = $1 ORDER BY id`, orgID)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return policies, nil
+}
+
+// StartDeployFreeze creates the freeze unless the organization has an active one and returns
+// whether it did. The check and the insert run under a lock on the organization, so instances
+// evaluating the same policies do not both start a freeze.
+func (s *SQL) StartDeployFreeze(freeze *model.DeployFreeze) (bool, error) {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('deploy_freeze'), hashtext($1::text))`, freeze.OrgID); err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	err = tx.Get(&freeze.ID, `INSERT INTO deploy_freeze (org_id, start_date, end_date, reasons)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM deploy_freeze WHERE org_id = $1 AND end_date IS NULL)
+		RETURNING id`, freeze.OrgID, freeze.Start, freeze.End, freeze.Reasons)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return true, nil
+}
+
+func (s *SQL) EndDeployFreeze(id int64, end time.Time) error {
+	result, err := s.DB.Exec(`UPDATE deploy_freeze SET end_date = $2 WHERE id = $1 AND end_date IS NULL`, id, end)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return checkBudgetPolicyAffected(result, "deployFreeze", id)
+}
+
+// GetActiveDeployFreeze returns the freeze of the organization which has not ended yet.
+func (s *SQL) GetActiveDeployFreeze(orgID int64) (*model.DeployFreeze, error) {
+	freeze := &model.DeployFreeze{}
+	err := s.DB.Get(freeze, `SELECT `+deployFreezeColumns+` FROM deploy_freeze
+		WHERE org_id = $1 AND end_date IS NULL ORDER BY start_date DESC LIMIT 1`, orgID)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("deployFreeze", orgID).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return freeze, nil
+}
+
+func (s *SQL) GetDeployFreezesByOrgID(orgID int64) ([]*model.DeployFreeze, error) {
+	freezes := []*model.DeployFreeze{}
+	err := s.DB.Select(&freezes, `SELECT `+deployFreezeColumns+` FROM deploy_freeze
+		WHERE org_id = $1 ORDER BY start_date DESC`, orgID)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return freezes, nil
+}
+
+// GetBudgetPolicyOrgIDs returns the organizations which have budget policies or an active freeze,
+// which has to be ended when their last policy was deleted.
+func (s *SQL) GetBudgetPolicyOrgIDs() ([]int64, error) {
+	orgIDs := []int64{}
+	if err := s.DB.Select(&orgIDs, `SELECT org_id FROM budget_policy
+		UNION SELECT org_id FROM deploy_freeze WHERE end_date IS NULL
+		ORDER BY org_id`); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return orgIDs, nil
+}
+
+func (s *SQL) GetBudgetPolicyTriggers(orgID int64) ([]*model.BudgetPolicyTrigger, error) {
+	triggers := []*model.BudgetPolicyTrigger{}
+	err := s.DB.Select(&triggers, `SELECT `+budgetPolicyTriggerColumns+` FROM budget_policy_trigger
+		WHERE org_id = $1 ORDER BY policy_id, slo_id`, orgID)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return triggers, nil
+}
+
+// CreateBudgetPolicyTrigger returns false when the trigger was already recorded, e.g. by another
+// instance of the controller evaluating the same policies. The event is only published with a
+// newly recorded trigger, in the same transaction.
+func (s *SQL) CreateBudgetPolicyTrigger(trigger *model.BudgetPolicyTrigger, event *model.WebhookEvent) (bool, error) {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	result, err := tx.NamedExec(`INSERT INTO budget_policy_trigger (`+budgetPolicyTriggerColumns+`)
+		VALUES (:policy_id, :slo_id, :org_id, :since)
+		ON CONFLICT (policy_id, slo_id) DO NOTHING`, trigger)
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return commitTriggerChange(tx, result, event)
+}
+
+// DeleteBudgetPolicyTrigger returns false when the trigger was already deleted. The event is only
+// published when the trigger is deleted, in the same transaction.
+func (s *SQL) DeleteBudgetPolicyTrigger(policyID, sloID int64, event *model.WebhookEvent) (bool, error) {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	result, err := tx.Exec(`DELETE FROM budget_policy_trigger WHERE policy_id = $1 AND slo_id = $2`, policyID, sloID)
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return commitTriggerChange(tx, result, event)
+}
+
+// commitTriggerChange publishes the event when the statement changed a trigger and commits.
+func commitTriggerChange(tx *sqlx.Tx, result sql.Result, event *model.WebhookEvent) (bool, error) {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	if err = insertWebhookDeliveries(tx, event); err != nil {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return true, nil
+}
+
+func checkBudgetPolicyAffected(result sql.Result, entity string, id int64) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	if affected == 0 {
+		return errory.NotFoundErrors.Builder().WithPayload(entity, id).Create()
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type IComplianceReportProvider interface {
+	CreateComplianceReport(report *model.ComplianceReport) error
+	CreateScheduledComplianceReport(report *model.ComplianceReport) (bool, error)
+	GetComplianceReport(id int64) (*model.ComplianceReport, error)
+	GetComplianceReportsByOrgID(orgID int64) ([]*model.ComplianceReport, error)
+	ComplianceReportExists(orgID int64, solutionID *int64, periodStart time.Time, format model.ComplianceReportFormat) (bool, error)
+}
+
+// complianceReportColumns leaves out the content, which is only selected for the download.
+const complianceReportColumns = `id, org_id, solution_id, period_start, period_end, format, scheduled, created_at`
+
+func (s *SQL) CreateComplianceReport(report *model.ComplianceReport) error {
+	rows, err := s.DB.NamedQuery(`INSERT INTO compliance_report
+		(org_id, solution_id, period_start, period_end, format, scheduled, created_at, content)
+		VALUES (:org_id, :solution_id, :period_start, :period_end, :format, :scheduled, :created_at, :content)
+		RETURNING id`, report)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&report.ID); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// CreateScheduledComplianceReport stores the report unless one of the same organization, solution,
+// period and format exists and returns whether it did. The check and the insert run under a lock
+// on the organization, so instances generating the same reports do not store them twice.
+func (s *SQL) CreateScheduledComplianceReport(report *model.ComplianceReport) (bool, error) {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('compliance_report'), hashtext($1::text))`, report.OrgID); err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	err = tx.Get(&report.ID, `INSERT INTO compliance_report
+		(org_id, solution_id, period_start, period_end, format, scheduled, created_at, content)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		WHERE NOT EXISTS (SELECT 1 FROM compliance_report
+			WHERE org_id = $1 AND solution_id IS NOT DISTINCT FROM $2 AND period_start = $3 AND format = $5)
+		RETURNING id`, report.OrgID, report.SolutionID, report.PeriodStart, report.PeriodEnd, report.Format,
+		report.Scheduled, report.CreatedAt, report.Content)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return true, nil
+}
+
+func (s *SQL) GetComplianceReport(id int64) (*model.ComplianceReport, error) {
+	report := &model.ComplianceReport{}
+	err := s.DB.Get(report, `SELECT `+complianceReportColumns+`, content FROM compliance_report WHERE id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("complianceReport", id).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return report, nil
+}
+
+func (s *SQL) GetComplianceReportsByOrgID(orgID int64) ([]*model.ComplianceReport, error) {
+	reports := []*model.ComplianceReport{}
+	err := s.DB.Select(&reports, `SELECT `+complianceReportColumns+` FROM compliance_report
+		WHERE org_id = $1 ORDER BY period_start DESC, id DESC`, orgID)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return reports, nil
+}
+
+// ComplianceReportExists tells whether a report of the organization, or of the solution when
+// solutionID is set, was already stored for the period.
+func (s *SQL) ComplianceReportExists(orgID int64, solutionID *int64, periodStart time.Time,
+	format model.ComplianceReportFormat) (bool, error) {
+	var exists bool
+	err := s.DB.Get(&exists, `SELECT EXISTS (SELECT 1 FROM compliance_report
+		WHERE org_id = $1 AND solution_id IS NOT DISTINCT FROM $2 AND period_start = $3 AND format = $4)`,
+		orgID, solutionID, periodStart, format)
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return exists, nil
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type ICompositeSloProvider interface {
+	GetSloChildren(parentID int64) ([]*model.SloChild, error)
+	GetSloParents(childID int64) ([]*model.SloChild, error)
+	GetAllSloChildren() ([]*model.SloChild, error)
+}
+
+func (s *SQL) GetSloChildren(parentID int64) ([]*model.SloChild, error) {
+	return s.selectSloChildren(`SELECT parent_id, child_id, weight FROM slo_child WHERE parent_id = $1 ORDER BY child_id`, parentID)
+}
+
+func (s *SQL) GetSloParents(childID int64) ([]*model.SloChild, error) {
+	return s.selectSloChildren(`SELECT parent_id, child_id, weight FROM slo_child WHERE child_id = $1 ORDER BY parent_id`, childID)
+}
+
+func (s *SQL) GetAllSloChildren() ([]*model.SloChild, error) {
+	return s.selectSloChildren(`SELECT parent_id, child_id, weight FROM slo_child`)
+}
+
+func setSloChildren(tx *sqlx.Tx, parentID int64, children []*model.SloChild) error {
+	if _, err := tx.Exec(`DELETE FROM slo_child WHERE parent_id = $1`, parentID); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	for _, child := range children {
+		if _, err := tx.Exec(`INSERT INTO slo_child (parent_id, child_id, weight) VALUES ($1, $2, $3)`,
+			parentID, child.ChildID, child.Weight); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *SQL) selectSloChildren(query string, args ...interface{}) ([]*model.SloChild, error) {
+	children := []*model.SloChild{}
+	if err := s.DB.Select(&children, query, args...); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return children, nil
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	datadogAPIKeyField = "apiKey"
+	datadogAppKeyField = "appKey"
+
+	// the layout of values Grafana encrypts with its secret key: salt, IV and AES-CFB cipher text,
+	// optionally preceded by the algorithm as *<base64 name>*
+	secretSaltLength      = 8
+	secretKeyIterations   = 10000
+	secretKeyLength       = 32
+	secretAlgorithmMarker = '*'
+	secretAlgorithmAESCFB = "aes-cfb"
+)
+
+type IDatadogCredentialsProvider interface {
+	GetDatadogCredentials(datasourceID int64) (*datadogModel.Credentials, error)
+}
+
+// DatadogCredentialsProvider reads the keys of Datadog datasources from the secure JSON data Grafana
+// stores encrypted with its secret key.
+type DatadogCredentialsProvider struct {
+	DB     *sqlx.DB
+	Log    logrus.FieldLogger
+	secret string
+}
+
+func NewDatadogCredentialsProvider(db *sqlx.DB, log logrus.FieldLogger, secret string) *DatadogCredentialsProvider {
+	return &DatadogCredentialsProvider{DB: db, Log: log, secret: secret}
+}
+
+// GetDatadogCredentials returns the keys of the Datadog datasource, or a NotFoundErrors error when
+// there is no Datadog datasource with the ID.
+func (p *DatadogCredentialsProvider) GetDatadogCredentials(datasourceID int64) (*datadogModel.Credentials, error) {
+	var secureJSONData sql.NullString
+	err := p.DB.Get(&secureJSONData, `SELECT secure_json_data FROM data_source WHERE id = $1 AND type = $2`,
+		datasourceID, grafana.DatasourceTypeDatadog)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("datasource", datasourceID).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+
+	encrypted := map[string]string{}
+	if secureJSONData.Valid && secureJSONData.String != "" {
+		if err := json.Unmarshal([]byte(secureJSONData.String), &encrypted); err != nil {
+			return nil, errory.ProviderErrors.Wrap(err)
+		}
+	}
+	apiKey, err := p.decrypt(encrypted[datadogAPIKeyField])
+	if err != nil {
+		return nil, errory.ProviderErrors.Builder().Wrap(err).WithPayload("datasource", datasourceID).Create()
+	}
+	appKey, err := p.decrypt(encrypted[datadogAppKeyField])
+	if err != nil {
+		return nil, errory.ProviderErrors.Builder().Wrap(err).WithPayload("datasource", datasourceID).Create()
+	}
+	if apiKey == "" || appKey == "" {
+		return nil, errory.ValidationErrors.Builder().WithPayload("datasource", datasourceID).
+			WithMessage("datadog datasource has no api or application key").Create()
+	}
+	return &datadogModel.Credentials{APIKey: apiKey, AppKey: appKey}, nil
+}
+
+func (p *DatadogCredentialsProvider) decrypt(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	payload, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+
+	if len(payload) > 0 && payload[0] == secretAlgorithmMarker {
+		end := bytes.IndexByte(payload[1:], secretAlgorithmMarker)
+		if end < 0 {
+			return "", errors.New("secret has no valid algorithm")
+		}
+		algorithm, err := base64.RawStdEncoding.DecodeString(string(payload[1 : end+1]))
+		if err != nil {
+			return "", err
+		}
+		if string(algorithm) != secretAlgorithmAESCFB {
+			return "", errors.New("secret is encrypted with unsupported algorithm " + string(algorithm))
+		}
+		payload = payload[end+2:]
+	}
+
+	if len(payload) < secretSaltLength+aes.BlockSize {
+		return "", errors.New("secret is too short")
+	}
+	salt := payload[:secretSaltLength]
+	key := pbkdf2.Key([]byte(p.secret), salt, secretKeyIterations, secretKeyLength, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+	iv := payload[secretSaltLength : secretSaltLength+aes.BlockSize]
+	plain := make([]byte, len(payload)-secretSaltLength-aes.BlockSize)
+	cipher.NewCFBDecrypter(block, iv).XORKeyStream(plain, payload[secretSaltLength+aes.BlockSize:])
+	return string(plain), nil
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"database/sql"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type IEntityVersionProvider interface {
+	GetVersion(entity model.VersionedEntity, id int64) (int64, error)
+	UpdateHappinessMetricVersioned(metric *model.HappinessMetric, version *int64) (int64, bool, error)
+}
+
+var versionStatements = map[model.VersionedEntity]string{
+	model.VersionedEntitySlo:             `SELECT version FROM slo WHERE id = $1`,
+	model.VersionedEntityHappinessMetric: `SELECT version FROM happiness_metric WHERE id = $1`,
+}
+
+func (s *SQL) GetVersion(entity model.VersionedEntity, id int64) (int64, error) {
+	var version int64
+	if err := s.DB.Get(&version, versionStatements[entity], id); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errory.NotFoundErrors.Builder().Wrap(err).WithPayload(string(entity), id).Create()
+		}
+		return 0, errory.ProviderErrors.Wrap(err)
+	}
+	return version, nil
+}
+
+// UpdateHappinessMetricVersioned updates the metric and increases its version if it still has the
+// given version, any version matches when version is nil. Returns the new version, false when the
+// version did not match or the metric does not exist.
+func (s *SQL) UpdateHappinessMetricVersioned(metric *model.HappinessMetric, version *int64) (int64, bool, error) {
+	var updated int64
+	err := s.DB.Get(&updated, `UPDATE happiness_metric SET happiness = $1, safety = $2, date = $3, version = version + 1
+		WHERE id = $4 AND ($5::bigint IS NULL OR version = $5) RETURNING version`,
+		metric.Happiness, metric.Safety, metric.Date, metric.ID, version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, errory.ProviderErrors.Wrap(err)
+	}
+	return updated, true, nil
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type IIdempotencyProvider interface {
+	ReserveIdempotencyKey(response *model.IdempotentResponse) (bool, error)
+	GetIdempotentResponse(userID int64, key string) (*model.IdempotentResponse, error)
+	CompleteIdempotencyKey(response *model.IdempotentResponse) error
+	DeleteIdempotencyKey(userID int64, key string) error
+	DeleteExpiredIdempotencyKeys(now time.Time) (int64, error)
+}
+
+const idempotencyKeyColumns = `user_id, key, request_hash, status, content_type, body, created_at, expires_at`
+
+// ReserveIdempotencyKey stores the key as in progress. Returns false when the user already used
+// the key and it did not expire yet, an expired key is taken over.
+func (s *SQL) ReserveIdempotencyKey(response *model.IdempotentResponse) (bool, error) {
+	result, err := s.DB.NamedExec(`INSERT INTO idempotency_key (`+idempotencyKeyColumns+`)
+		VALUES (:user_id, :key, :request_hash, :status, :content_type, :body, :created_at, :expires_at)
+		ON CONFLICT (user_id, key) DO UPDATE SET request_hash = EXCLUDED.request_hash, status = EXCLUDED.status,
+		content_type = EXCLUDED.content_type, body = EXCLUDED.body, created_at = EXCLUDED.created_at,
+		expires_at = EXCLUDED.expires_at
+		WHERE idempotency_key.expires_at <= EXCLUDED.created_at`, response)
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return affected > 0, nil
+}
+
+func (s *SQL) GetIdempotentResponse(userID int64, key string) (*model.IdempotentResponse, error) {
+	response := &model.IdempotentResponse{}
+	err := s.DB.Get(response, `SELECT `+idempotencyKeyColumns+` FROM idempotency_key WHERE user_id = $1 AND key = $2`,
+		userID, key)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("idempotencyKey", key).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return response, nil
+}
+
+func (s *SQL) CompleteIdempotencyKey(response *model.IdempotentResponse) error {
+	_, err := s.DB.NamedExec(`UPDATE idempotency_key SET status = :status, content_type = :content_type, body = :body
+		WHERE user_id = :user_id AND key = :key AND request_hash = :request_hash`, response)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+func (s *SQL) DeleteIdempotencyKey(userID int64, key string) error {
+	if _, err := s.DB.Exec(`DELETE FROM idempotency_key WHERE user_id = $1 AND key = $2`, userID, key); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+func (s *SQL) DeleteExpiredIdempotencyKeys(now time.Time) (int64, error) {
+	result, err := s.DB.Exec(`DELETE FROM idempotency_key WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, errory.ProviderErrors.Wrap(err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errory.ProviderErrors.Wrap(err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+)
+
+// IJobLockProvider locks background jobs across the instances sharing the database.
+type IJobLockProvider interface {
+	TryLockJob(ctx context.Context, job string) (func(), bool, error)
+}
+
+// TryLockJob takes the advisory lock of the job on a connection of its own and returns whether it
+// did. The lock is held until the returned unlock function is called.
+func (s *SQL) TryLockJob(ctx context.Context, job string) (func(), bool, error) {
+	conn, err := s.DB.Connx(ctx)
+	if err != nil {
+		return nil, false, errory.ProviderErrors.Wrap(err)
+	}
+
+	var locked bool
+	err = conn.GetContext(ctx, &locked, `SELECT pg_try_advisory_lock(hashtext('background_job'), hashtext($1))`, job)
+	if err != nil || !locked {
+		conn.Close() //nolint:errcheck
+		if err != nil {
+			return nil, false, errory.ProviderErrors.Wrap(err)
+		}
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		_, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext('background_job'), hashtext($1))`, job)
+		if err != nil {
+			// the lock belongs to the session, so the connection is dropped instead of returned to the pool
+			conn.Raw(func(interface{}) error { return driver.ErrBadConn }) //nolint:errcheck
+		}
+		conn.Close() //nolint:errcheck
+	}
+	return unlock, true, nil
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"database/sql"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type IMaintenanceWindowProvider interface {
+	CreateMaintenanceWindow(window *model.MaintenanceWindow) error
+	UpdateMaintenanceWindow(window *model.MaintenanceWindow) error
+	DeleteMaintenanceWindow(id int64) error
+	GetMaintenanceWindow(id int64) (*model.MaintenanceWindow, error)
+	GetMaintenanceWindowsByOrgID(orgID int64) ([]*model.MaintenanceWindow, error)
+	GetSloProductID(sloID int64) (*int64, error)
+}
+
+const maintenanceWindowColumns = `id, org_id, slo_id, product_id, description, start_date, end_date, recurrence, recurrence_end`
+
+func (s *SQL) CreateMaintenanceWindow(window *model.MaintenanceWindow) error {
+	rows, err := s.DB.NamedQuery(`INSERT INTO maintenance_window
+		(org_id, slo_id, product_id, description, start_date, end_date, recurrence, recurrence_end)
+		VALUES (:org_id, :slo_id, :product_id, :description, :start_date, :end_date, :recurrence, :recurrence_end)
+		RETURNING id`, window)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&window.ID); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *SQL) UpdateMaintenanceWindow(window *model.MaintenanceWindow) error {
+	result, err := s.DB.NamedExec(`UPDATE maintenance_window SET
+		slo_id = :slo_id, product_id = :product_id, description = :description, start_date = :start_date,
+		end_date = :end_date, recurrence = :recurrence, recurrence_end = :recurrence_end
+		WHERE id = :id AND org_id = :org_id`, window)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return checkMaintenanceWindowAffected(result, window.ID)
+}
+
+func (s *SQL) DeleteMaintenanceWindow(id int64) error {
+	result, err := s.DB.Exec(`DELETE FROM maintenance_window WHERE id = $1`, id)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return checkMaintenanceWindowAffected(result, id)
+}
+
+func (s *SQL) GetMaintenanceWindow(id int64) (*model.MaintenanceWindow, error) {
+	window := &model.MaintenanceWindow{}
+	err := s.DB.Get(window, `SELECT `+maintenanceWindowColumns+` FROM maintenance_window WHERE id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("maintenanceWindow", id).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return window, nil
+}
+
+func (s *SQL) GetMaintenanceWindowsByOrgID(orgID int64) ([]*model.MaintenanceWindow, error) {
+	windows := []*model.MaintenanceWindow{}
+	err := s.DB.Select(&windows, `SELECT `+maintenanceWindowColumns+` FROM maintenance_window
+		WHERE org_id = $1 ORDER BY start_date`, orgID)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return windows, nil
+}
+
+// GetSloProductID returns the product of the solution the SLO belongs to, nil if it has none.
+func (s *SQL) GetSloProductID(sloID int64) (*int64, error) {
+	var productID *int64
+	err := s.DB.Get(&productID, `SELECT sol.product_id FROM slo s
+		JOIN solution sol ON sol.org_id = s.org_id
+		WHERE s.id = $1 AND sol.product_id IS NOT NULL
+		LIMIT 1`, sloID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return productID, nil
+}
+
+func checkMaintenanceWindowAffected(result sql.Result, id int64) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	if affected == 0 {
+		return errory.NotFoundErrors.Builder().WithPayload("maintenanceWindow", id).Create()
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+//go:build integrationTests
+// +build integrationTests
+
+package provider_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// db is the database of the integration test environment, the provider tests are skipped without it.
+var db *sqlx.DB
+
+func TestProvider(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Provider Suite")
+}
+
+var _ = BeforeSuite(func() {
+	postgresURL := os.Getenv("POSTGRES_URL")
+	if postgresURL == "" {
+		return
+	}
+	var err error
+	db, err = sqlx.Connect("postgres", postgresURL)
+	Expect(err).NotTo(HaveOccurred())
+})
+
+var _ = AfterSuite(func() {
+	if db != nil {
+		db.Close()
+	}
+})
+
+func requireDB() {
+	if db == nil {
+		Skip("POSTGRES_URL is not set")
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type ISearchProvider interface {
+	Search(userID int64, query string, types []model.SearchHitType, limit int) ([]*model.SearchHit, error)
+}
+
+// searchVisibleOrgs selects the organizations the user is a member of, Grafana server admins see all.
+const searchVisibleOrgs = `visible AS (
+	SELECT o.id FROM org o
+	WHERE EXISTS (SELECT 1 FROM "user" u WHERE u.id = $1 AND u.is_admin)
+		OR EXISTS (SELECT 1 FROM org_user ou WHERE ou.org_id = o.id AND ou.user_id = $1))`
+
+type searchSource struct {
+	id    string
+	orgID string
+	name  string
+	from  string
+}
+
+var searchSources = map[model.SearchHitType]searchSource{
+	model.SearchHitTypeSlo: {"s.id", "s.org_id", "s.name",
+		"slo s WHERE s.org_id IN (SELECT id FROM visible)"},
+	model.SearchHitTypeOrganization: {"o.id", "o.id", "o.name",
+		"org o WHERE o.id IN (SELECT id FROM visible)"},
+	model.SearchHitTypeSolution: {"sol.id", "sol.org_id", "sol.name",
+		"solution sol WHERE sol.org_id IN (SELECT id FROM visible)"},
+	model.SearchHitTypeProduct: {"p.id", "NULL::bigint", "p.name",
+		"product p WHERE EXISTS (SELECT 1 FROM solution sol WHERE sol.product_id = p.id AND sol.org_id IN (SELECT id FROM visible))"},
+	model.SearchHitTypeDatasource: {"d.id", "d.org_id", "d.name",
+		"data_source d WHERE d.org_id IN (SELECT id FROM visible)"},
+}
+
+// statement matches names containing the query or similar to it by the word similarity of the
+// pg_trgm extension. The names have no trigram index, a search reads all entities of the visible
+// organizations. Exact names rank before prefixes before other matches, then the word similarity decides.
+func (source searchSource) statement(hitType model.SearchHitType) string {
+	return fmt.Sprintf(`SELECT '%[1]s' AS type, %[2]s AS id, %[3]s AS org_id, %[4]s AS name,
+		CASE WHEN lower(%[4]s) = lower($2) THEN 2 WHEN %[4]s ILIKE $4 THEN 1 ELSE 0 END + word_similarity($2, %[4]s) AS score
+		FROM %[5]s AND (%[4]s ILIKE $3 OR $2 <%% %[4]s)`, hitType, source.id, source.orgID, source.name, source.from)
+}
+
+// Search returns the best ranked entities of the types matching the query, limited to the
+// organizations visible to the user.
+func (s *SQL) Search(userID int64, query string, types []model.SearchHitType, limit int) ([]*model.SearchHit, error) {
+	statements := make([]string, 0, len(types))
+	for _, hitType := range types {
+		statements = append(statements, searchSources[hitType].statement(hitType))
+	}
+	statement := `WITH ` + searchVisibleOrgs + ` SELECT type, id, org_id, name, score FROM (` +
+		strings.Join(statements, " UNION ALL ") + `) hits ORDER BY score DESC, name, id LIMIT $5`
+
+	escaped := escapeLike(query)
+	hits := []*model.SearchHit{}
+	if err := s.DB.Select(&hits, statement, userID, query, "%"+escaped+"%", escaped+"%", limit); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return hits, nil
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type ISlaProvider interface {
+	SaveSlaBreach(breach *model.SlaBreach) error
+	DeleteSlaBreach(sloID int64, periodStart time.Time) error
+	GetSlaBreachesByOrgID(orgID int64, from, to time.Time) ([]*model.SlaBreach, error)
+	GetSlaCreditTiers(orgID, solutionID int64) ([]*model.SlaCreditTier, error)
+	GetSlaCreditTiersByOrgID(orgID int64) ([]*model.SlaCreditTier, error)
+	SetSlaCreditTiers(orgID, solutionID int64, tiers []model.SlaCreditTier) error
+}
+
+// SaveSlaBreach stores the breach of the SLA period of the SLO, a breach already recorded for
+// the period is updated.
+func (s *SQL) SaveSlaBreach(breach *model.SlaBreach) error {
+	rows, err := s.DB.NamedQuery(`INSERT INTO sla_breach
+		(slo_id, org_id, solution_id, period_start, period_end, start_date, end_date, sla, achieved, closed)
+		VALUES (:slo_id, :org_id, :solution_id, :period_start, :period_end, :start_date, :end_date, :sla, :achieved, :closed)
+		ON CONFLICT (slo_id, period_start) DO UPDATE SET
+		org_id = EXCLUDED.org_id, solution_id = EXCLUDED.solution_id, period_end = EXCLUDED.period_end,
+		start_date = EXCLUDED.start_date, end_date = EXCLUDED.end_date, sla = EXCLUDED.sla,
+		achieved = EXCLUDED.achieved, closed = EXCLUDED.closed
+		RETURNING id`, breach)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&breach.ID); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// DeleteSlaBreach removes the breach of the SLA period of the SLO if one was recorded.
+func (s *SQL) DeleteSlaBreach(sloID int64, periodStart time.Time) error {
+	if _, err := s.DB.Exec(`DELETE FROM sla_breach WHERE slo_id = $1 AND period_start = $2`, sloID, periodStart); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+// GetSlaBreachesByOrgID returns the breaches of the SLA periods overlapping from to to, the latest first.
+func (s *SQL) GetSlaBreachesByOrgID(orgID int64, from, to time.Time) ([]*model.SlaBreach, error) {
+	breaches := []*model.SlaBreach{}
+	err := s.DB.Select(&breaches, `SELECT b.id, b.slo_id, s.name AS slo_name, b.org_id, b.solution_id, b.period_start,
+		b.period_end, b.start_date, b.end_date, b.sla, b.achieved, b.closed
+		FROM sla_breach b JOIN slo s ON s.id = b.slo_id
+		WHERE b.org_id = $1 AND b.period_start < $3 AND b.period_end > $2
+		ORDER BY b.period_start DESC, b.slo_id`, orgID, from, to)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return breaches, nil
+}
+
+func (s *SQL) GetSlaCreditTiers(orgID, solutionID int64) ([]*model.SlaCreditTier, error) {
+	tiers := []*model.SlaCreditTier{}
+	err := s.DB.Select(&tiers, `SELECT org_id, solution_id, below, credit_percent FROM sla_credit_tier
+		WHERE org_id = $1 AND solution_id = $2 ORDER BY below DESC`, orgID, solutionID)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return tiers, nil
+}
+
+func (s *SQL) GetSlaCreditTiersByOrgID(orgID int64) ([]*model.SlaCreditTier, error) {
+	tiers := []*model.SlaCreditTier{}
+	err := s.DB.Select(&tiers, `SELECT org_id, solution_id, below, credit_percent FROM sla_credit_tier
+		WHERE org_id = $1 ORDER BY solution_id, below DESC`, orgID)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return tiers, nil
+}
+
+// SetSlaCreditTiers replaces the credit table of the solution in a single transaction.
+func (s *SQL) SetSlaCreditTiers(orgID, solutionID int64, tiers []model.SlaCreditTier) error {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err = tx.Exec(`DELETE FROM sla_credit_tier WHERE org_id = $1 AND solution_id = $2`, orgID, solutionID); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	for _, tier := range tiers {
+		if _, err = tx.Exec(`INSERT INTO sla_credit_tier (org_id, solution_id, below, credit_percent) VALUES ($1, $2, $3, $4)`,
+			orgID, solutionID, tier.Below, tier.CreditPercent); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
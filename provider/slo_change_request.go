@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type ISloChangeRequestProvider interface {
+	CreateSloChangeRequest(request *model.SloChangeRequest) error
+	GetSloChangeRequest(id int64) (*model.SloChangeRequest, error)
+	GetSloChangeRequestsByOrgID(orgID int64, status model.SloChangeStatus) ([]*model.SloChangeRequest, error)
+	HasPendingSloChangeRequest(sloID int64) (bool, error)
+	DecideSloChangeRequest(request *model.SloChangeRequest, from model.SloChangeStatus) (bool, error)
+	ExpireSloChangeRequests(now time.Time) (int64, error)
+}
+
+// sloChangeRequest keeps the proposed SLO as JSON, a delete request has none.
+type sloChangeRequest struct {
+	model.SloChangeRequest
+	SloData []byte `db:"slo"`
+}
+
+const sloChangeRequestColumns = `id, slo_id, org_id, action, slo, version, cascade, status, requested_by, requested_by_login,
+	requested_at, expires_at, decided_by, decided_by_login, decided_at, comment, error`
+
+func (r *sloChangeRequest) toModel() (*model.SloChangeRequest, error) {
+	request := r.SloChangeRequest
+	if len(r.SloData) > 0 {
+		request.Slo = &model.Slo{}
+		if err := json.Unmarshal(r.SloData, request.Slo); err != nil {
+			return nil, errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return &request, nil
+}
+
+func (s *SQL) CreateSloChangeRequest(request *model.SloChangeRequest) error {
+	row := &sloChangeRequest{SloChangeRequest: *request}
+	if request.Slo != nil {
+		data, err := json.Marshal(request.Slo)
+		if err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+		row.SloData = data
+	}
+
+	rows, err := s.DB.NamedQuery(`INSERT INTO slo_change_request
+		(slo_id, org_id, action, slo, version, cascade, status, requested_by, requested_by_login, requested_at, expires_at, comment, error)
+		VALUES (:slo_id, :org_id, :action, :slo, :version, :cascade, :status, :requested_by, :requested_by_login, :requested_at,
+		:expires_at, :comment, :error)
+		RETURNING id`, row)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&request.ID); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *SQL) GetSloChangeRequest(id int64) (*model.SloChangeRequest, error) {
+	row := &sloChangeRequest{}
+	err := s.DB.Get(row, `SELECT `+sloChangeRequestColumns+` FROM slo_change_request WHERE id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("sloChangeRequest", id).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return row.toModel()
+}
+
+// GetSloChangeRequestsByOrgID returns the requests of the organization, newest first, an empty
+// status returns requests of every status.
+func (s *SQL) GetSloChangeRequestsByOrgID(orgID int64, status model.SloChangeStatus) ([]*model.SloChangeRequest, error) {
+	rows := []*sloChangeRequest{}
+	err := s.DB.Select(&rows, `SELECT `+sloChangeRequestColumns+` FROM slo_change_request
+		WHERE org_id = $1 AND ($2 = '' OR status = $2) ORDER BY requested_at DESC, id DESC`, orgID, status)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+
+	requests := make([]*model.SloChangeRequest, 0, len(rows))
+	for _, row := range rows {
+		request, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+func (s *SQL) HasPendingSloChangeRequest(sloID int64) (bool, error) {
+	var pending bool
+	err := s.DB.Get(&pending, `SELECT EXISTS (SELECT 1 FROM slo_change_request WHERE slo_id = $1 AND status = $2)`,
+		sloID, model.SloChangeStatusPending)
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return pending, nil
+}
+
+// DecideSloChangeRequest stores the status and decision of the request if it still has the
+// status from, false tells that another decision came first.
+func (s *SQL) DecideSloChangeRequest(request *model.SloChangeRequest, from model.SloChangeStatus) (bool, error) {
+	result, err := s.DB.Exec(`UPDATE slo_change_request
+		SET status = $1, decided_by = $2, decided_by_login = $3, decided_at = $4, comment = $5, error = $6
+		WHERE id = $7 AND status = $8`,
+		request.Status, request.DecidedBy, request.DecidedByLogin, request.DecidedAt, request.Comment, request.Error,
+		request.ID, from)
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errory.ProviderErrors.Wrap(err)
+	}
+	return affected == 1, nil
+}
+
+// ExpireSloChangeRequests marks the pending requests expired before now and returns their count.
+func (s *SQL) ExpireSloChangeRequests(now time.Time) (int64, error) {
+	result, err := s.DB.Exec(`UPDATE slo_change_request SET status = $1 WHERE status = $2 AND expires_at < $3`,
+		model.SloChangeStatusExpired, model.SloChangeStatusPending, now)
+	if err != nil {
+		return 0, errory.ProviderErrors.Wrap(err)
+	}
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, errory.ProviderErrors.Wrap(err)
+	}
+	return expired, nil
+}
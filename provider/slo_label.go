@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+)
+
+type ISloLabelProvider interface {
+	GetSloLabels(sloIDs []int64) (map[int64]map[string]string, error)
+	GetAllowedLabelKeys(orgID int64) ([]string, error)
+	SetAllowedLabelKeys(orgID int64, keys []string) error
+}
+
+type sloLabel struct {
+	SloID int64  `db:"slo_id"`
+	Key   string `db:"key"`
+	Value string `db:"value"`
+}
+
+// GetSloLabels returns the labels of the SLOs by SLO id, SLOs without labels are left out.
+func (s *SQL) GetSloLabels(sloIDs []int64) (map[int64]map[string]string, error) {
+	rows := []*sloLabel{}
+	err := s.DB.Select(&rows, `SELECT slo_id, key, value FROM slo_label WHERE slo_id = ANY($1)`, pq.Array(sloIDs))
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+
+	labels := map[int64]map[string]string{}
+	for _, row := range rows {
+		if labels[row.SloID] == nil {
+			labels[row.SloID] = map[string]string{}
+		}
+		labels[row.SloID][row.Key] = row.Value
+	}
+	return labels, nil
+}
+
+func setSloLabels(tx *sqlx.Tx, sloID int64, labels map[string]string) error {
+	if _, err := tx.Exec(`DELETE FROM slo_label WHERE slo_id = $1`, sloID); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	for key, value := range labels {
+		if _, err := tx.Exec(`INSERT INTO slo_label (slo_id, key, value) VALUES ($1, $2, $3)`, sloID, key, value); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *SQL) GetAllowedLabelKeys(orgID int64) ([]string, error) {
+	keys := []string{}
+	if err := s.DB.Select(&keys, `SELECT key FROM slo_label_key WHERE org_id = $1 ORDER BY key`, orgID); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return keys, nil
+}
+
+// SetAllowedLabelKeys replaces the label keys allowed in the organization in a single transaction.
+func (s *SQL) SetAllowedLabelKeys(orgID int64, keys []string) error {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err = tx.Exec(`DELETE FROM slo_label_key WHERE org_id = $1`, orgID); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	for _, key := range keys {
+		if _, err = tx.Exec(`INSERT INTO slo_label_key (org_id, key) VALUES ($1, $2)`, orgID, key); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
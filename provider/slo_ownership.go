@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type ISloOwnershipProvider interface {
+	GetSloOwnerships(sloIDs []int64) (map[int64]*model.SloOwnership, error)
+}
+
+type sloOwnership struct {
+	SloID int64 `db:"slo_id"`
+	model.SloOwnership
+}
+
+type sloContact struct {
+	SloID int64 `db:"slo_id"`
+	model.SloContact
+}
+
+// GetSloOwnerships returns the ownership of the SLOs by SLO id, SLOs without ownership are left out.
+func (s *SQL) GetSloOwnerships(sloIDs []int64) (map[int64]*model.SloOwnership, error) {
+	owners := []*sloOwnership{}
+	err := s.DB.Select(&owners, `SELECT slo_id, owner_team, owner_team_id, runbook_url, escalation_notes
+		FROM slo_owner WHERE slo_id = ANY($1)`, pq.Array(sloIDs))
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	contacts := []*sloContact{}
+	err = s.DB.Select(&contacts, `SELECT slo_id, type, value FROM slo_contact WHERE slo_id = ANY($1)
+		ORDER BY slo_id, position`, pq.Array(sloIDs))
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+
+	ownerships := map[int64]*model.SloOwnership{}
+	for _, owner := range owners {
+		ownership := owner.SloOwnership
+		ownership.Contacts = []model.SloContact{}
+		ownerships[owner.SloID] = &ownership
+	}
+	for _, contact := range contacts {
+		if ownership, ok := ownerships[contact.SloID]; ok {
+			ownership.Contacts = append(ownership.Contacts, contact.SloContact)
+		}
+	}
+	return ownerships, nil
+}
+
+func setSloOwnership(tx *sqlx.Tx, sloID int64, ownership *model.SloOwnership) error {
+	if _, err := tx.Exec(`DELETE FROM slo_contact WHERE slo_id = $1`, sloID); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	if _, err := tx.Exec(`DELETE FROM slo_owner WHERE slo_id = $1`, sloID); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	if ownership == nil {
+		return nil
+	}
+
+	_, err := tx.Exec(`INSERT INTO slo_owner (slo_id, owner_team, owner_team_id, runbook_url, escalation_notes)
+		VALUES ($1, $2, $3, $4, $5)`, sloID, ownership.OwnerTeam, ownership.OwnerTeamID, ownership.RunbookURL, ownership.EscalationNotes)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	for i, contact := range ownership.Contacts {
+		_, err = tx.Exec(`INSERT INTO slo_contact (slo_id, position, type, value) VALUES ($1, $2, $3, $4)`,
+			sloID, i, contact.Type, contact.Value)
+		if err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type ISloPageProvider interface {
+	GetSloPage(query *model.SloPageQuery) ([]*model.DetailedSlo, int64, error)
+}
+
+// sloPageFrom does not join the solutions of the organization, an organization with several
+// solutions would list its SLOs once per solution.
+const sloPageFrom = `FROM slo s
+	JOIN org o ON o.id = s.org_id`
+
+// sloPageSolution selects the first solution of the organization of the SLO.
+const sloPageSolution = `(SELECT MIN(sol.id) FROM solution sol WHERE sol.org_id = s.org_id) AS solution_id`
+
+var sloSortColumns = map[model.SloSortField]string{
+	model.SloSortFieldName:         "s.name",
+	model.SloSortFieldCreationDate: "s.creation_date",
+	model.SloSortFieldCritical:     "s.critical",
+	model.SloSortFieldOrg:          "o.name",
+}
+
+// GetSloPage returns the SLOs of the page together with the number of all SLOs matching the
+// filters of the query. Pages are ordered by the sort column and then by id, which makes the
+// position behind a cursor stable while SLOs are created or deleted.
+func (s *SQL) GetSloPage(query *model.SloPageQuery) ([]*model.DetailedSlo, int64, error) {
+	column, ok := sloSortColumns[query.Sort]
+	if !ok {
+		column = sloSortColumns[model.SloSortFieldName]
+	}
+
+	where, args := sloPageFilters(query)
+	var total int64
+	if err := s.DB.Get(&total, `SELECT COUNT(*) `+sloPageFrom+where, args...); err != nil {
+		return nil, 0, errory.ProviderErrors.Wrap(err)
+	}
+
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if query.After != nil {
+		value, err := sloCursorValue(query.After)
+		if err != nil {
+			return nil, 0, errory.ParseErrors.Builder().Wrap(err).WithMessage("cursor cannot be parsed").Create()
+		}
+		args = append(args, value, query.After.ID)
+		condition := fmt.Sprintf("(%s, s.id) %s ($%d, $%d)", column, comparison, len(args)-1, len(args))
+		if where == "" {
+			where = " WHERE " + condition
+		} else {
+			where += " AND " + condition
+		}
+	}
+
+	statement := `SELECT s.*, ` + sloPageSolution + `, o.name AS org_name ` + sloPageFrom + where +
+		fmt.Sprintf(" ORDER BY %s %s, s.id %s", column, direction, direction)
+	if query.Limit > 0 {
+		args = append(args, query.Limit)
+		statement += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	slos := []*model.DetailedSlo{}
+	if err := s.DB.Select(&slos, statement, args...); err != nil {
+		return nil, 0, errory.ProviderErrors.Wrap(err)
+	}
+	return slos, total, nil
+}
+
+func sloPageFilters(query *model.SloPageQuery) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	add := func(condition string, values ...interface{}) {
+		placeholders := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			args = append(args, value)
+			placeholders = append(placeholders, len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, placeholders...))
+	}
+
+	if query.OrgID != nil {
+		add("s.org_id = $%d", *query.OrgID)
+	}
+	if query.Name != "" {
+		add(`s.name ILIKE $%d`, "%"+escapeLike(query.Name)+"%")
+	}
+	if query.NamePrefix != "" {
+		add(`s.name LIKE $%d`, escapeLike(query.NamePrefix)+"%")
+	}
+	if query.OrgName != "" {
+		add(`o.name ILIKE $%d`, "%"+escapeLike(query.OrgName)+"%")
+	}
+	if query.Critical != nil {
+		add("s.critical = $%d", *query.Critical)
+	}
+	if query.ExternalType != nil {
+		add("s.external_type = $%d", string(*query.ExternalType))
+	}
+	if query.DatasourceID != nil {
+		add("s.ds_id = $%d", *query.DatasourceID)
+	}
+	if query.SolutionID != nil {
+		add("EXISTS (SELECT 1 FROM solution sol WHERE sol.org_id = s.org_id AND sol.id = $%d)", *query.SolutionID)
+	}
+	for key, value := range query.Labels {
+		add("EXISTS (SELECT 1 FROM slo_label l WHERE l.slo_id = s.id AND l.key = $%d AND l.value = $%d)", key, value)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func sloCursorValue(cursor *model.SloCursor) (interface{}, error) {
+	switch cursor.Sort {
+	case model.SloSortFieldCreationDate:
+		return time.Parse(time.RFC3339Nano, cursor.Value)
+	case model.SloSortFieldCritical:
+		return strconv.ParseBool(cursor.Value)
+	default:
+		return cursor.Value, nil
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
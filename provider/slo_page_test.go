@@ -0,0 +1,77 @@
+//go:build integrationTests
+// +build integrationTests
+
+package provider_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Slo page provider test", func() {
+	logger, _ := logrustest.NewNullLogger()
+	var sql *provider.SQL
+	var orgID, productID, firstSolutionID, secondSolutionID int64
+	var slos []*model.Slo
+
+	BeforeEach(func() {
+		requireDB()
+		sql = &provider.SQL{DB: db, Log: logger}
+		name := fmt.Sprintf("slo-page-test-%d", time.Now().UnixNano())
+
+		Expect(db.Get(&orgID, `INSERT INTO org (version, name, created, updated) VALUES (0, $1, now(), now()) RETURNING id`, name)).To(Succeed())
+		Expect(db.Get(&productID, `INSERT INTO product (name) VALUES ($1) RETURNING id`, name)).To(Succeed())
+		Expect(db.Get(&firstSolutionID, `INSERT INTO solution (org_id, product_id, name) VALUES ($1, $2, $3) RETURNING id`,
+			orgID, productID, name+"-first")).To(Succeed())
+		Expect(db.Get(&secondSolutionID, `INSERT INTO solution (org_id, product_id, name) VALUES ($1, $2, $3) RETURNING id`,
+			orgID, productID, name+"-second")).To(Succeed())
+
+		slos = []*model.Slo{
+			{OrgID: orgID, Name: "Availability", SuccessRateExpectedAvailability: "99", ComplianceExpectedAvailability: "99"},
+			{OrgID: orgID, Name: "Success rate", SuccessRateExpectedAvailability: "99.9", ComplianceExpectedAvailability: "99.9"},
+		}
+		for _, slo := range slos {
+			Expect(sql.CreateSloWithRelations(slo, nil)).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		if db == nil {
+			return
+		}
+		db.MustExec(`DELETE FROM slo WHERE org_id = $1`, orgID)
+		db.MustExec(`DELETE FROM solution WHERE org_id = $1`, orgID)
+		db.MustExec(`DELETE FROM product WHERE id = $1`, productID)
+		db.MustExec(`DELETE FROM org WHERE id = $1`, orgID)
+	})
+
+	Describe("GetSloPage(query *model.SloPageQuery)", func() {
+		Context("When the organization has two solutions", func() {
+			It("Should list every slo once", func() {
+				page, total, err := sql.GetSloPage(&model.SloPageQuery{OrgID: &orgID, Sort: model.SloSortFieldName})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(total).To(Equal(int64(2)))
+				Expect(page).To(HaveLen(2))
+				Expect(page[0].ID).To(Equal(slos[0].ID))
+				Expect(page[1].ID).To(Equal(slos[1].ID))
+				Expect(*page[0].SolutionID).To(Equal(firstSolutionID))
+			})
+
+			It("Should list every slo once when filtered by the second solution", func() {
+				page, total, err := sql.GetSloPage(&model.SloPageQuery{SolutionID: &secondSolutionID, Sort: model.SloSortFieldName})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(total).To(Equal(int64(2)))
+				Expect(page).To(HaveLen(2))
+			})
+		})
+	})
+})
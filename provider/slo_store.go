@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+// ISloStoreProvider writes an SLO together with its children, labels and ownership in one transaction.
+// The webhook deliveries of the event about the change are added in the same transaction.
+type ISloStoreProvider interface {
+	CreateSloWithRelations(slo *model.Slo, event *model.WebhookEvent) error
+	UpdateSloWithRelations(slo *model.Slo, version *int64, event *model.WebhookEvent) (int64, bool, error)
+	DeleteSloWithRelations(slo *model.Slo, event *model.WebhookEvent) error
+}
+
+// CreateSloWithRelations creates the SLO with its children, labels and ownership and sets its
+// id and creation date before the event is published.
+func (s *SQL) CreateSloWithRelations(slo *model.Slo, event *model.WebhookEvent) error {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	err = namedGet(tx, `INSERT INTO slo (org_id, name, success_rate_exp_availability, compliance_exp_availability,
+		autogen, creation_date, critical, ds_id, external_id, external_sla, external_type, window_type, window_days,
+		window_period, latency_percentile, latency_threshold_ms, latency_target, composite)
+		VALUES (:org_id, :name, :success_rate_exp_availability, :compliance_exp_availability,
+		:autogen, now(), :critical, :ds_id, :external_id, :external_sla, :external_type, :window_type, :window_days,
+		:window_period, :latency_percentile, :latency_threshold_ms, :latency_target, :composite)
+		RETURNING id, creation_date`, slo)
+	if err != nil {
+		return err
+	}
+	if err = setSloRelations(tx, slo); err != nil {
+		return err
+	}
+	if err = insertWebhookDeliveries(tx, event); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+// versionedSlo binds the version an update is based on next to the SLO and receives the new version.
+type versionedSlo struct {
+	*model.Slo
+	IfMatch *int64 `db:"if_match"`
+	Version int64  `db:"version"`
+}
+
+// UpdateSloWithRelations updates the SLO with its children and increases its version if it still
+// has the given version, any version matches when version is nil. Labels and ownership are only
+// replaced when the SLO has them. Returns the new version, false when the version did not match
+// or the SLO does not exist.
+func (s *SQL) UpdateSloWithRelations(slo *model.Slo, version *int64, event *model.WebhookEvent) (int64, bool, error) {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return 0, false, errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	stmt, err := tx.PrepareNamed(`UPDATE slo SET name = :name, success_rate_exp_availability = :success_rate_exp_availability,
+		compliance_exp_availability = :compliance_exp_availability, autogen = :autogen, critical = :critical, ds_id = :ds_id,
+		external_id = :external_id, external_sla = :external_sla, external_type = :external_type, window_type = :window_type,
+		window_days = :window_days, window_period = :window_period, latency_percentile = :latency_percentile,
+		latency_threshold_ms = :latency_threshold_ms, latency_target = :latency_target, composite = :composite,
+		version = version + 1
+		WHERE id = :id AND (CAST(:if_match AS bigint) IS NULL OR version = :if_match)
+		RETURNING version`)
+	if err != nil {
+		return 0, false, errory.ProviderErrors.Wrap(err)
+	}
+	defer stmt.Close()
+
+	row := &versionedSlo{Slo: slo, IfMatch: version}
+	if err = stmt.Get(row, row); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, nil
+		}
+		return 0, false, errory.ProviderErrors.Wrap(err)
+	}
+	if err = setSloRelations(tx, slo); err != nil {
+		return 0, false, err
+	}
+	if err = insertWebhookDeliveries(tx, event); err != nil {
+		return 0, false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, false, errory.ProviderErrors.Wrap(err)
+	}
+	return row.Version, true, nil
+}
+
+// DeleteSloWithRelations deletes the SLO with its labels, ownership and children. It is removed
+// from the composite SLOs it is a child of in the same transaction, so they keep it if anything fails.
+func (s *SQL) DeleteSloWithRelations(slo *model.Slo, event *model.WebhookEvent) error {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err = setSloLabels(tx, slo.ID, nil); err != nil {
+		return err
+	}
+	if err = setSloOwnership(tx, slo.ID, nil); err != nil {
+		return err
+	}
+	if err = setSloChildren(tx, slo.ID, nil); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`DELETE FROM slo_child WHERE child_id = $1`, slo.ID); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	result, err := tx.Exec(`DELETE FROM slo WHERE id = $1`, slo.ID)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	if affected == 0 {
+		return errory.NotFoundErrors.Builder().WithPayload("slo", slo.ID).Create()
+	}
+	if err = insertWebhookDeliveries(tx, event); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+// setSloRelations replaces the children of the SLO, only composite SLOs keep them, and its labels
+// and ownership when it has them. An empty ownership removes the stored one.
+func setSloRelations(tx *sqlx.Tx, slo *model.Slo) error {
+	children := []*model.SloChild{}
+	if slo.IsComposite() {
+		children = slo.Children
+	}
+	if err := setSloChildren(tx, slo.ID, children); err != nil {
+		return err
+	}
+	if slo.Labels != nil {
+		if err := setSloLabels(tx, slo.ID, slo.Labels); err != nil {
+			return err
+		}
+	}
+	if slo.Ownership != nil {
+		ownership := slo.Ownership
+		if ownership.IsEmpty() {
+			ownership = nil
+		}
+		if err := setSloOwnership(tx, slo.ID, ownership); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namedGet runs the named query with arg in the transaction and scans the returned row back into arg.
+func namedGet(tx *sqlx.Tx, query string, arg interface{}) error {
+	stmt, err := tx.PrepareNamed(query)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer stmt.Close()
+
+	if err = stmt.Get(arg, arg); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
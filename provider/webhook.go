@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+type IWebhookProvider interface {
+	CreateWebhook(webhook *model.Webhook) error
+	UpdateWebhook(webhook *model.Webhook) error
+	DeleteWebhook(id int64) error
+	GetWebhook(id int64) (*model.Webhook, error)
+	GetWebhooksByOrgID(orgID int64) ([]*model.Webhook, error)
+	PublishWebhookEvent(event *model.WebhookEvent) error
+	ClaimWebhookDeliveries(now, leaseUntil time.Time, limit int) ([]*model.WebhookDelivery, error)
+	UpdateWebhookDelivery(delivery *model.WebhookDelivery) error
+	GetWebhookDeliveries(webhookID int64, status model.WebhookDeliveryStatus, limit int) ([]*model.WebhookDelivery, error)
+}
+
+// webhook keeps the subscribed events as text array.
+type webhook struct {
+	model.Webhook
+	EventData pq.StringArray `db:"events"`
+}
+
+// webhookDelivery keeps the payload as JSON text.
+type webhookDelivery struct {
+	model.WebhookDelivery
+	PayloadData string `db:"payload"`
+}
+
+const webhookColumns = `id, org_id, name, url, secret, events, enabled`
+
+const webhookDeliveryColumns = `id, webhook_id, org_id, event, payload, status, attempts, url, created_at, next_attempt_at,
+	last_attempt_at, response_status, error, delivered_at`
+
+func newWebhook(w *model.Webhook) *webhook {
+	row := &webhook{Webhook: *w, EventData: pq.StringArray{}}
+	for _, event := range w.Events {
+		row.EventData = append(row.EventData, string(event))
+	}
+	return row
+}
+
+func (w *webhook) toModel() *model.Webhook {
+	result := w.Webhook
+	result.Events = []model.WebhookEventType{}
+	for _, event := range w.EventData {
+		result.Events = append(result.Events, model.WebhookEventType(event))
+	}
+	return &result
+}
+
+func (d *webhookDelivery) toModel() *model.WebhookDelivery {
+	result := d.WebhookDelivery
+	result.Payload = json.RawMessage(d.PayloadData)
+	return &result
+}
+
+func (s *SQL) CreateWebhook(w *model.Webhook) error {
+	rows, err := s.DB.NamedQuery(`INSERT INTO webhook (org_id, name, url, secret, events, enabled)
+		VALUES (:org_id, :name, :url, :secret, :events, :enabled)
+		RETURNING id`, newWebhook(w))
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&w.ID); err != nil {
+			return errory.ProviderErrors.Wrap(err)
+		}
+	}
+	return nil
+}
+
+// UpdateWebhook keeps the secret of the webhook when none is given.
+func (s *SQL) UpdateWebhook(w *model.Webhook) error {
+	result, err := s.DB.NamedExec(`UPDATE webhook SET
+		name = :name, url = :url, secret = COALESCE(NULLIF(:secret, ''), secret), events = :events, enabled = :enabled
+		WHERE id = :id AND org_id = :org_id`, newWebhook(w))
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return checkWebhookAffected(result, w.ID)
+}
+
+// DeleteWebhook deletes the webhook together with its deliveries.
+func (s *SQL) DeleteWebhook(id int64) error {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err = tx.Exec(`DELETE FROM webhook_delivery WHERE webhook_id = $1`, id); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	result, err := tx.Exec(`DELETE FROM webhook WHERE id = $1`, id)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	if err = checkWebhookAffected(result, id); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+func (s *SQL) GetWebhook(id int64) (*model.Webhook, error) {
+	row := &webhook{}
+	err := s.DB.Get(row, `SELECT `+webhookColumns+` FROM webhook WHERE id = $1`, id)
+	if err == sql.ErrNoRows {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("webhook", id).Create()
+	}
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+	return row.toModel(), nil
+}
+
+func (s *SQL) GetWebhooksByOrgID(orgID int64) ([]*model.Webhook, error) {
+	rows := []*webhook{}
+	if err := s.DB.Select(&rows, `SELECT `+webhookColumns+` FROM webhook WHERE org_id = $1 ORDER BY id`, orgID); err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+
+	webhooks := make([]*model.Webhook, 0, len(rows))
+	for _, row := range rows {
+		webhooks = append(webhooks, row.toModel())
+	}
+	return webhooks, nil
+}
+
+// PublishWebhookEvent adds a delivery of the event to the outbox of every enabled webhook of its
+// organization subscribed to it. Events of changes stored in the database are published in the
+// transaction of the change instead.
+func (s *SQL) PublishWebhookEvent(event *model.WebhookEvent) error {
+	tx, err := s.DB.Beginx()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err = insertWebhookDeliveries(tx, event); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+// insertWebhookDeliveries adds the deliveries of the event in the transaction, so they are stored
+// if and only if the change the event is about is. Nothing is added for a nil event.
+func insertWebhookDeliveries(tx *sqlx.Tx, event *model.WebhookEvent) error {
+	if event == nil {
+		return nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+
+	_, err = tx.Exec(`INSERT INTO webhook_delivery
+		(webhook_id, org_id, event, payload, status, attempts, url, created_at, next_attempt_at)
+		SELECT id, org_id, $2, $3, $4, 0, url, $5, $5 FROM webhook
+		WHERE org_id = $1 AND enabled AND $2 = ANY(events)`,
+		event.OrgID, string(event.Event), string(payload), model.WebhookDeliveryStatusPending, event.OccurredAt)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries returns up to limit pending deliveries due at now, oldest first. They are
+// not due again before leaseUntil, so other instances of the controller skip them while they are
+// attempted.
+func (s *SQL) ClaimWebhookDeliveries(now, leaseUntil time.Time, limit int) ([]*model.WebhookDelivery, error) {
+	rows := []*webhookDelivery{}
+	err := s.DB.Select(&rows, `UPDATE webhook_delivery SET next_attempt_at = $2
+		WHERE id IN (SELECT id FROM webhook_delivery WHERE status = $3 AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id LIMIT $4 FOR UPDATE SKIP LOCKED)
+		RETURNING `+webhookDeliveryColumns, now, leaseUntil, model.WebhookDeliveryStatusPending, limit)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, row.toModel())
+	}
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery records the outcome of an attempt.
+func (s *SQL) UpdateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	_, err := s.DB.NamedExec(`UPDATE webhook_delivery SET
+		status = :status, attempts = :attempts, url = :url, next_attempt_at = :next_attempt_at,
+		last_attempt_at = :last_attempt_at, response_status = :response_status, error = :error, delivered_at = :delivered_at
+		WHERE id = :id`, delivery)
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	return nil
+}
+
+// GetWebhookDeliveries returns the latest deliveries of the webhook first, of all statuses when
+// status is empty.
+func (s *SQL) GetWebhookDeliveries(webhookID int64, status model.WebhookDeliveryStatus,
+	limit int) ([]*model.WebhookDelivery, error) {
+	rows := []*webhookDelivery{}
+	err := s.DB.Select(&rows, `SELECT `+webhookDeliveryColumns+` FROM webhook_delivery
+		WHERE webhook_id = $1 AND ($2::text = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC LIMIT $3`, webhookID, status, limit)
+	if err != nil {
+		return nil, errory.ProviderErrors.Wrap(err)
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, row.toModel())
+	}
+	return deliveries, nil
+}
+
+func checkWebhookAffected(result sql.Result, id int64) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errory.ProviderErrors.Wrap(err)
+	}
+	if affected == 0 {
+		return errory.NotFoundErrors.Builder().WithPayload("webhook", id).Create()
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/ctx"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+	"github.com/sirupsen/logrus"
+)
+
+// mainOrgID is the Grafana organization every user is a Viewer of.
+const mainOrgID = 1
+
+type IAdminService interface {
+	Login(username, password string) (*auth.UserContext, error)
+	ExportSlos(orgID int64) ([]*model.Slo, error)
+	ImportSlos(userContext *auth.UserContext, slos []*model.Slo, dryRun bool) []*model.AdminTaskResult
+	RerenderDashboards(userContext *auth.UserContext, orgID int64, dryRun bool) ([]*model.AdminTaskResult, error)
+	EnablePlugin(userContext *auth.UserContext, orgIDs []int64, skipDatasources, dryRun bool) ([]*model.AdminTaskResult, error)
+	PurgeOrphanedHistory(dryRun bool) ([]*model.AdminTaskResult, error)
+	SyncUsers(dryRun bool) ([]*model.AdminTaskResult, error)
+}
+
+// IPluginEnabler enables the plugin and its datasources in an organization, it is implemented by plugin.Plugin.
+type IPluginEnabler interface {
+	EnablePluginWithDataSources(org *grafanaModel.Organization, cookie string, skip bool) error
+}
+
+// AdminService runs the bulk tasks of the admin command line tool. A task goes on with the next
+// entity when one fails and returns the outcome of each, with dry run nothing is changed.
+type AdminService struct {
+	Provider         provider.IAdminProvider
+	AuthProvider     provider.IAuthProvider
+	SloService       ISloService
+	Composite        ICompositeSloService
+	DashboardService IDashboardService
+	OrgService       IOrganizationService
+	Plugin           IPluginEnabler
+	Webhooks         IWebhookService
+	Validator        validator.ISLOValidator
+	ElasticClient    elastic.IClient
+	Grafana          grafana.IClient
+	Log              logrus.FieldLogger
+}
+
+// Login signs in to Grafana, the tasks act with the permissions of the user.
+func (s *AdminService) Login(username, password string) (*auth.UserContext, error) {
+	cookie, err := s.Grafana.Login(username, password)
+	if err != nil {
+		return nil, errory.Decorate(err, "admin service login()")
+	}
+	userID, err := s.AuthProvider.AuthenticateUser(cookie)
+	if err != nil {
+		return nil, errory.Decorate(err, "admin service login()")
+	}
+	return &auth.UserContext{ID: userID, Cookie: cookie, Login: username}, nil
+}
+
+// ExportSlos returns the SLOs of the organization with labels, ownership and children, an orgID
+// of 0 exports the SLOs of all organizations.
+func (s *AdminService) ExportSlos(orgID int64) ([]*model.Slo, error) {
+	orgIDs := []int64{orgID}
+	if orgID == 0 {
+		orgs, err := s.Provider.GetOrganizations()
+		if err != nil {
+			return nil, err
+		}
+		orgIDs = orgIDs[:0]
+		for _, org := range orgs {
+			orgIDs = append(orgIDs, org.ID)
+		}
+	}
+
+	slos := []*model.Slo{}
+	for _, id := range orgIDs {
+		orgSlos, err := s.SloService.GetByOrgID(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, slo := range orgSlos {
+			if slo.Composite != model.CompositeAggregationNone {
+				if err = s.Composite.LoadChildren(slo); err != nil {
+					return nil, err
+				}
+			}
+		}
+		slos = append(slos, orgSlos...)
+	}
+	return slos, nil
+}
+
+// ImportSlos creates the SLOs in the organizations they name, IDs of exported SLOs are dropped. A dry run
+// only validates them, names already taken are reported by the import itself.
+func (s *AdminService) ImportSlos(userContext *auth.UserContext, slos []*model.Slo, dryRun bool) []*model.AdminTaskResult {
+	results := []*model.AdminTaskResult{}
+	for _, slo := range slos {
+		slo.ID = 0
+		result := &model.AdminTaskResult{Entity: model.AdminEntitySlo, OrgID: slo.OrgID, Name: slo.Name, Action: "create"}
+		results = append(results, result)
+
+		err := s.Validator.Validate(context.WithValue(context.Background(), ctx.Create, true), *slo)
+		if err == nil && !dryRun {
+			err = s.SloService.Create(userContext, slo)
+		}
+		s.finish(result, dryRun, err)
+		result.ID = slo.ID
+	}
+	return results
+}
+
+// RerenderDashboards renders the dashboards of all SLOs of the organization again from the
+// current templates, overwriting the dashboards in Grafana.
+func (s *AdminService) RerenderDashboards(userContext *auth.UserContext, orgID int64, dryRun bool) ([]*model.AdminTaskResult, error) {
+	slos, err := s.SloService.GetByOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*model.AdminTaskResult{}
+	for _, slo := range slos {
+		result := &model.AdminTaskResult{Entity: model.AdminEntityDashboard, ID: slo.ID, OrgID: slo.OrgID, Name: slo.Name, Action: "render"}
+		results = append(results, result)
+
+		var err error
+		if slo.Composite != model.CompositeAggregationNone {
+			err = s.Composite.LoadChildren(slo)
+		}
+		if err == nil && !dryRun {
+			err = s.DashboardService.UpdateDashboard(userContext, slo)
+		}
+		s.finish(result, dryRun, err)
+	}
+	return results, nil
+}
+
+// EnablePlugin enables the plugin in the organizations, nil orgIDs enable it in all organizations.
+func (s *AdminService) EnablePlugin(userContext *auth.UserContext, orgIDs []int64, skipDatasources, dryRun bool) ([]*model.AdminTaskResult, error) {
+	results := []*model.AdminTaskResult{}
+	enable := func(org *grafanaModel.Organization, err error) {
+		result := &model.AdminTaskResult{Entity: model.AdminEntityOrganization, ID: org.ID, Name: org.Name, Action: "enable plugin"}
+		results = append(results, result)
+		if err == nil && !dryRun {
+			err = s.Plugin.EnablePluginWithDataSources(org, userContext.Cookie, skipDatasources)
+			// the plugin is enabled in Grafana already, a lost event does not fail the organization
+			if err == nil {
+				publishErr := s.Webhooks.Publish(org.ID, model.WebhookEventPluginEnabled,
+					&model.WebhookPluginEnabled{OrgID: org.ID, OrgName: org.Name, DatasourcesSkipped: skipDatasources})
+				if publishErr != nil {
+					s.Log.WithError(publishErr).WithField("orgId", org.ID).Error("plugin enabled, but webhooks cannot be notified")
+				}
+			}
+		}
+		s.finish(result, dryRun, err)
+	}
+
+	if orgIDs == nil {
+		orgs, err := s.Provider.GetOrganizations()
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			enable(org, nil)
+		}
+		return results, nil
+	}
+
+	for _, orgID := range orgIDs {
+		org, err := s.OrgService.GetOrganizationByID(orgID)
+		if org == nil {
+			org = &grafanaModel.Organization{ID: orgID}
+			if err == nil {
+				err = errory.NotFoundErrors.Builder().WithPayload("organization", orgID).Create()
+			}
+		}
+		enable(org, err)
+	}
+	return results, nil
+}
+
+// PurgeOrphanedHistory deletes the history of SLOs which no longer exist. The deletions run as
+// tasks in Elasticsearch, a dry run counts the documents which would be deleted.
+func (s *AdminService) PurgeOrphanedHistory(dryRun bool) ([]*model.AdminTaskResult, error) {
+	historyIDs, err := s.ElasticClient.GetSloHistoryIDs()
+	if err != nil {
+		return nil, err
+	}
+	sloIDs, err := s.Provider.GetSloIDs()
+	if err != nil {
+		return nil, err
+	}
+	existing := map[int64]bool{}
+	for _, id := range sloIDs {
+		existing[id] = true
+	}
+
+	results := []*model.AdminTaskResult{}
+	for _, id := range historyIDs {
+		if existing[id] {
+			continue
+		}
+		result := &model.AdminTaskResult{Entity: model.AdminEntitySloHistory, ID: id, Action: "delete"}
+		results = append(results, result)
+
+		deletion := &elasticModel.SloHistoryDeletion{SloID: id, From: time.Unix(0, 0).UTC(), To: time.Now().UTC(), DryRun: dryRun}
+		err = s.SloService.DeleteSloHistory(deletion)
+		s.finish(result, dryRun, err)
+		if err != nil {
+			continue
+		}
+		if dryRun {
+			result.Message = fmt.Sprintf("%d documents", countDocuments(deletion.Matches))
+		} else {
+			result.Message = "task " + deletion.TaskID
+		}
+	}
+	return results, nil
+}
+
+// SyncUsers applies the organization roles the token login grants to existing users: Grafana
+// server admins are Admin of every organization and all users are at least Viewer of the
+// main organization. Roles of organizations created after the last login of a user are added.
+func (s *AdminService) SyncUsers(dryRun bool) ([]*model.AdminTaskResult, error) {
+	users, err := s.Provider.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []*model.AdminTaskResult{}
+	for _, user := range users {
+		roles, err := s.AuthProvider.GetOrgRoles(user.ID)
+		if err != nil {
+			result := &model.AdminTaskResult{Entity: model.AdminEntityUser, ID: user.ID, Name: user.Login, Action: "load roles"}
+			results = append(results, result)
+			s.finish(result, dryRun, err)
+			continue
+		}
+
+		orgNames := make([]string, 0, len(roles))
+		for name := range roles {
+			orgNames = append(orgNames, name)
+		}
+		sort.Strings(orgNames)
+
+		for _, name := range orgNames {
+			role := roles[name]
+			var grant auth.OrgRole
+			switch {
+			case user.IsAdmin && role.Role != auth.GFAdminRole:
+				grant = auth.OrgRole{OrgID: role.OrgID, Role: auth.GFAdminRole}
+			case role.Role == "" && role.OrgID == mainOrgID:
+				grant = auth.OrgRole{OrgID: role.OrgID, Role: auth.GFViewerRole}
+			default:
+				continue
+			}
+
+			result := &model.AdminTaskResult{
+				Entity: model.AdminEntityUser,
+				ID:     user.ID,
+				OrgID:  role.OrgID,
+				Name:   user.Login,
+				Action: fmt.Sprintf("grant %s role", grant.Role),
+			}
+			results = append(results, result)
+
+			var err error
+			switch {
+			case dryRun:
+			case role.Role == "":
+				err = s.AuthProvider.CreateUserRole(user.ID, grant)
+			default:
+				err = s.AuthProvider.UpdateUserRole(user.ID, grant)
+			}
+			s.finish(result, dryRun, err)
+		}
+	}
+	return results, nil
+}
+
+func (s *AdminService) finish(result *model.AdminTaskResult, dryRun bool, err error) {
+	switch {
+	case err != nil:
+		result.Status = model.AdminTaskStatusFailed
+		result.Message = errory.GetErrorDetails(err).Message
+		s.Log.WithError(err).WithField("entity", result.Entity).WithField("id", result.ID).Warnf("admin task %s failed", result.Action)
+	case dryRun:
+		result.Status = model.AdminTaskStatusPlanned
+	default:
+		result.Status = model.AdminTaskStatusDone
+	}
+}
+
+func countDocuments(counts []*elasticModel.SloHistoryIndexCount) int64 {
+	var total int64
+	for _, count := range counts {
+		total += count.Count
+	}
+	return total
+}
@@ -0,0 +1,297 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	grafanaClient "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Admin service test", func() {
+	var mockController *gomock.Controller
+	var mockAdminProvider *provider.MockIAdminProvider
+	var mockAuthProvider *provider.MockIAuthProvider
+	var mockSloService *service.MockISloService
+	var mockComposite *service.MockICompositeSloService
+	var mockDashboardService *service.MockIDashboardService
+	var mockOrgService *service.MockIOrganizationService
+	var mockPlugin *service.MockIPluginEnabler
+	var mockWebhooks *service.MockIWebhookService
+	var mockValidator *validator.MockISLOValidator
+	var mockElastic *elastic.MockIClient
+	var mockGrafana *grafanaClient.MockIClient
+	var adminService *service.AdminService
+	logger, logHook := logrustest.NewNullLogger()
+
+	userContext := &auth.UserContext{ID: 1, Cookie: "cookie", Login: "admin"}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockAdminProvider = provider.NewMockIAdminProvider(mockController)
+		mockAuthProvider = provider.NewMockIAuthProvider(mockController)
+		mockSloService = service.NewMockISloService(mockController)
+		mockComposite = service.NewMockICompositeSloService(mockController)
+		mockDashboardService = service.NewMockIDashboardService(mockController)
+		mockOrgService = service.NewMockIOrganizationService(mockController)
+		mockPlugin = service.NewMockIPluginEnabler(mockController)
+		mockWebhooks = service.NewMockIWebhookService(mockController)
+		mockValidator = validator.NewMockISLOValidator(mockController)
+		mockElastic = elastic.NewMockIClient(mockController)
+		mockGrafana = grafanaClient.NewMockIClient(mockController)
+
+		adminService = &service.AdminService{
+			Provider:         mockAdminProvider,
+			AuthProvider:     mockAuthProvider,
+			SloService:       mockSloService,
+			Composite:        mockComposite,
+			DashboardService: mockDashboardService,
+			OrgService:       mockOrgService,
+			Plugin:           mockPlugin,
+			Webhooks:         mockWebhooks,
+			Validator:        mockValidator,
+			ElasticClient:    mockElastic,
+			Grafana:          mockGrafana,
+			Log:              logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Login(username, password)", func() {
+		It("Should return the user context of the grafana session", func() {
+			mockGrafana.EXPECT().Login("admin", "secret").Return("cookie", nil)
+			mockAuthProvider.EXPECT().AuthenticateUser("cookie").Return(int64(1), nil)
+
+			result, err := adminService.Login("admin", "secret")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(userContext))
+		})
+
+		It("Should return error when the login fails", func() {
+			mockGrafana.EXPECT().Login("admin", "wrong").Return("", errory.GrafanaClientErrors.New("invalid username or password"))
+
+			result, err := adminService.Login("admin", "wrong")
+			Expect(result).To(BeNil())
+			Expect(errory.IsOfType(err, errory.GrafanaClientErrors)).To(BeTrue())
+		})
+	})
+
+	Describe("ExportSlos(orgID)", func() {
+		It("Should export the slos of all organizations with their children", func() {
+			composite := &model.Slo{ID: 3, OrgID: 2, Composite: model.CompositeAggregationAllMustPass}
+			mockAdminProvider.EXPECT().GetOrganizations().Return([]*grafana.Organization{{ID: 1}, {ID: 2}}, nil)
+			mockSloService.EXPECT().GetByOrgID(int64(1)).Return([]*model.Slo{{ID: 1, OrgID: 1}}, nil)
+			mockSloService.EXPECT().GetByOrgID(int64(2)).Return([]*model.Slo{{ID: 2, OrgID: 2}, composite}, nil)
+			mockComposite.EXPECT().LoadChildren(composite).Return(nil)
+
+			slos, err := adminService.ExportSlos(0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slos).To(HaveLen(3))
+		})
+
+		It("Should export the slos of the organization", func() {
+			mockSloService.EXPECT().GetByOrgID(int64(2)).Return([]*model.Slo{{ID: 2, OrgID: 2}}, nil)
+
+			slos, err := adminService.ExportSlos(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(slos).To(Equal([]*model.Slo{{ID: 2, OrgID: 2}}))
+		})
+	})
+
+	Describe("ImportSlos(userContext, slos, dryRun)", func() {
+		var slos []*model.Slo
+
+		BeforeEach(func() {
+			slos = []*model.Slo{{ID: 7, OrgID: 2, Name: "valid"}, {ID: 8, OrgID: 2, Name: "invalid"}}
+			mockValidator.EXPECT().Validate(gomock.Any(), model.Slo{OrgID: 2, Name: "valid"}).Return(nil)
+			mockValidator.EXPECT().Validate(gomock.Any(), model.Slo{OrgID: 2, Name: "invalid"}).
+				Return(errory.ValidationErrors.New("name is invalid"))
+		})
+
+		It("Should create the valid slos without their exported ids", func() {
+			mockSloService.EXPECT().Create(userContext, slos[0]).DoAndReturn(func(_ *auth.UserContext, slo *model.Slo) error {
+				Expect(slo.ID).To(BeZero())
+				slo.ID = 11
+				return nil
+			})
+
+			results := adminService.ImportSlos(userContext, slos, false)
+			Expect(results).To(HaveLen(2))
+			Expect(*results[0]).To(Equal(model.AdminTaskResult{Entity: model.AdminEntitySlo, ID: 11, OrgID: 2, Name: "valid",
+				Action: "create", Status: model.AdminTaskStatusDone}))
+			Expect(results[1].Status).To(Equal(model.AdminTaskStatusFailed))
+			Expect(results[1].Message).NotTo(BeEmpty())
+		})
+
+		It("Should only validate the slos on dry run", func() {
+			results := adminService.ImportSlos(userContext, slos, true)
+			Expect(results[0].Status).To(Equal(model.AdminTaskStatusPlanned))
+			Expect(results[1].Status).To(Equal(model.AdminTaskStatusFailed))
+		})
+	})
+
+	Describe("RerenderDashboards(userContext, orgID, dryRun)", func() {
+		slo1 := &model.Slo{ID: 1, OrgID: 2, Name: "first"}
+		slo2 := &model.Slo{ID: 2, OrgID: 2, Name: "second"}
+
+		BeforeEach(func() {
+			mockSloService.EXPECT().GetByOrgID(int64(2)).Return([]*model.Slo{slo1, slo2}, nil)
+		})
+
+		It("Should render the dashboards of all slos and go on after a failure", func() {
+			mockDashboardService.EXPECT().UpdateDashboard(userContext, slo1).Return(errory.GrafanaClientErrors.New("unavailable"))
+			mockDashboardService.EXPECT().UpdateDashboard(userContext, slo2).Return(nil)
+
+			results, err := adminService.RerenderDashboards(userContext, 2, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results[0].Status).To(Equal(model.AdminTaskStatusFailed))
+			Expect(*results[1]).To(Equal(model.AdminTaskResult{Entity: model.AdminEntityDashboard, ID: 2, OrgID: 2, Name: "second",
+				Action: "render", Status: model.AdminTaskStatusDone}))
+		})
+
+		It("Should not render on dry run", func() {
+			results, err := adminService.RerenderDashboards(userContext, 2, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Status).To(Equal(model.AdminTaskStatusPlanned))
+		})
+	})
+
+	Describe("EnablePlugin(userContext, orgIDs, skipDatasources, dryRun)", func() {
+		It("Should enable the plugin in all organizations", func() {
+			orgs := []*grafana.Organization{{ID: 1, Name: "Main Org."}, {ID: 2, Name: "Checkout"}}
+			mockAdminProvider.EXPECT().GetOrganizations().Return(orgs, nil)
+			mockPlugin.EXPECT().EnablePluginWithDataSources(orgs[0], "cookie", true).Return(nil)
+			mockPlugin.EXPECT().EnablePluginWithDataSources(orgs[1], "cookie", true).Return(nil)
+			mockWebhooks.EXPECT().Publish(int64(1), model.WebhookEventPluginEnabled, gomock.Any()).Return(nil)
+			mockWebhooks.EXPECT().Publish(int64(2), model.WebhookEventPluginEnabled,
+				&model.WebhookPluginEnabled{OrgID: 2, OrgName: "Checkout", DatasourcesSkipped: true}).Return(nil)
+
+			results, err := adminService.EnablePlugin(userContext, nil, true, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(2))
+			Expect(results[1].Name).To(Equal("Checkout"))
+			Expect(results[1].Status).To(Equal(model.AdminTaskStatusDone))
+		})
+
+		It("Should report organizations which do not exist", func() {
+			org := &grafana.Organization{ID: 2, Name: "Checkout"}
+			mockOrgService.EXPECT().GetOrganizationByID(int64(2)).Return(org, nil)
+			mockOrgService.EXPECT().GetOrganizationByID(int64(9)).Return(nil, nil)
+
+			results, err := adminService.EnablePlugin(userContext, []int64{2, 9}, false, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results[0].Status).To(Equal(model.AdminTaskStatusPlanned))
+			Expect(results[1].ID).To(Equal(int64(9)))
+			Expect(results[1].Status).To(Equal(model.AdminTaskStatusFailed))
+		})
+
+		It("Should enable the plugin of organizations whose webhooks cannot be notified", func() {
+			org := &grafana.Organization{ID: 2, Name: "Checkout"}
+			mockOrgService.EXPECT().GetOrganizationByID(int64(2)).Return(org, nil)
+			mockPlugin.EXPECT().EnablePluginWithDataSources(org, "cookie", false).Return(nil)
+			mockWebhooks.EXPECT().Publish(int64(2), model.WebhookEventPluginEnabled, gomock.Any()).
+				Return(errory.ProviderErrors.New("connection refused"))
+
+			results, err := adminService.EnablePlugin(userContext, []int64{2}, false, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results[0].Status).To(Equal(model.AdminTaskStatusDone))
+			Expect(logHook.LastEntry().Message).To(Equal("plugin enabled, but webhooks cannot be notified"))
+		})
+	})
+
+	Describe("PurgeOrphanedHistory(dryRun)", func() {
+		BeforeEach(func() {
+			mockElastic.EXPECT().GetSloHistoryIDs().Return([]int64{1, 2, 3}, nil)
+			mockAdminProvider.EXPECT().GetSloIDs().Return([]int64{2}, nil)
+		})
+
+		It("Should delete the history of slos which do not exist", func() {
+			mockSloService.EXPECT().DeleteSloHistory(gomock.Any()).DoAndReturn(func(deletion *elasticModel.SloHistoryDeletion) error {
+				Expect(deletion.DryRun).To(BeFalse())
+				Expect(deletion.From).To(Equal(time.Unix(0, 0).UTC()))
+				deletion.TaskID = "node:1"
+				return nil
+			})
+			mockSloService.EXPECT().DeleteSloHistory(gomock.Any()).Return(errors.New("unavailable"))
+
+			results, err := adminService.PurgeOrphanedHistory(false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*results[0]).To(Equal(model.AdminTaskResult{Entity: model.AdminEntitySloHistory, ID: 1,
+				Action: "delete", Status: model.AdminTaskStatusDone, Message: "task node:1"}))
+			Expect(results[1].ID).To(Equal(int64(3)))
+			Expect(results[1].Status).To(Equal(model.AdminTaskStatusFailed))
+		})
+
+		It("Should count the documents on dry run", func() {
+			mockSloService.EXPECT().DeleteSloHistory(gomock.Any()).Times(2).DoAndReturn(func(deletion *elasticModel.SloHistoryDeletion) error {
+				Expect(deletion.DryRun).To(BeTrue())
+				deletion.Matches = []*elasticModel.SloHistoryIndexCount{{Count: 4}, {Count: 6}}
+				return nil
+			})
+
+			results, err := adminService.PurgeOrphanedHistory(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(2))
+			Expect(results[0].Status).To(Equal(model.AdminTaskStatusPlanned))
+			Expect(results[0].Message).To(Equal("10 documents"))
+		})
+	})
+
+	Describe("SyncUsers(dryRun)", func() {
+		BeforeEach(func() {
+			mockAdminProvider.EXPECT().GetUsers().Return([]*model.GrafanaUser{
+				{ID: 1, Login: "admin", IsAdmin: true},
+				{ID: 2, Login: "jane"},
+			}, nil)
+			mockAuthProvider.EXPECT().GetOrgRoles(int64(1)).Return(map[string]*auth.OrgRole{
+				"Main Org.": {OrgID: 1, Role: auth.GFAdminRole},
+				"Checkout":  {OrgID: 2, Role: auth.GFViewerRole},
+				"Payments":  {OrgID: 3},
+			}, nil)
+			mockAuthProvider.EXPECT().GetOrgRoles(int64(2)).Return(map[string]*auth.OrgRole{
+				"Main Org.": {OrgID: 1},
+				"Checkout":  {OrgID: 2},
+			}, nil)
+		})
+
+		It("Should grant the missing roles", func() {
+			mockAuthProvider.EXPECT().UpdateUserRole(int64(1), auth.OrgRole{OrgID: 2, Role: auth.GFAdminRole}).Return(nil)
+			mockAuthProvider.EXPECT().CreateUserRole(int64(1), auth.OrgRole{OrgID: 3, Role: auth.GFAdminRole}).Return(nil)
+			mockAuthProvider.EXPECT().CreateUserRole(int64(2), auth.OrgRole{OrgID: 1, Role: auth.GFViewerRole}).Return(nil)
+
+			results, err := adminService.SyncUsers(false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(3))
+			Expect(*results[2]).To(Equal(model.AdminTaskResult{Entity: model.AdminEntityUser, ID: 2, OrgID: 1, Name: "jane",
+				Action: "grant Viewer role", Status: model.AdminTaskStatusDone}))
+		})
+
+		It("Should not change roles on dry run", func() {
+			results, err := adminService.SyncUsers(true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(3))
+			Expect(results[0].Action).To(Equal("grant Admin role"))
+			Expect(results[0].Status).To(Equal(model.AdminTaskStatusPlanned))
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+// runPeriodically runs the job immediately and then every interval until ctx is done. A run is
+// skipped while another instance holds the lock of the job, so replicas never run it at the same time.
+func runPeriodically(ctx context.Context, lock provider.IJobLockProvider, log logrus.FieldLogger, job string,
+	interval time.Duration, run func(now time.Time)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log = log.WithField("job", job)
+	now := time.Now()
+	for {
+		runLocked(ctx, lock, log, job, now.UTC(), run)
+
+		select {
+		case <-ctx.Done():
+			log.Info("background job stopped")
+			return
+		case now = <-ticker.C:
+		}
+	}
+}
+
+func runLocked(ctx context.Context, lock provider.IJobLockProvider, log logrus.FieldLogger, job string,
+	now time.Time, run func(now time.Time)) {
+	unlock, locked, err := lock.TryLockJob(ctx, job)
+	if err != nil {
+		log.WithError(err).Error("could not lock background job")
+		return
+	}
+	if !locked {
+		log.Debug("background job is running on another instance")
+		return
+	}
+	defer unlock()
+
+	run(now)
+}
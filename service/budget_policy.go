@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+type IBudgetPolicyService interface {
+	Start(ctx context.Context)
+	EvaluateAll(now time.Time)
+	Create(policy *model.BudgetPolicy) error
+	Update(policy *model.BudgetPolicy) error
+	Delete(orgID, id int64) error
+	Get(orgID, id int64) (*model.BudgetPolicy, error)
+	GetByOrgID(orgID int64) ([]*model.BudgetPolicy, error)
+	GetDeployGate(orgID int64, now time.Time) (*model.DeployGate, error)
+	GetDeployFreezes(orgID int64) ([]*model.DeployFreeze, error)
+}
+
+// BudgetPolicyService manages the error budget policies of organizations and evaluates
+// them against the remaining budget of their SLOs. When Enabled the policies of all organizations
+// are evaluated every Interval in the background, which starts and ends deploy freezes and
+// publishes SLOs crossing the threshold of a policy to the webhooks. Requesting the deploy gate
+// only reads.
+type BudgetPolicyService struct {
+	Provider    provider.IBudgetPolicyProvider
+	SloProvider provider.ISLOProvider
+	ErrorBudget IErrorBudgetService
+	Enabled     bool
+	Interval    time.Duration
+	Lock        provider.IJobLockProvider
+	Log         logrus.FieldLogger
+}
+
+// Start evaluates the policies every Interval in the background until ctx is done.
+func (s *BudgetPolicyService) Start(ctx context.Context) {
+	if !s.Enabled {
+		s.Log.Info("budget policy evaluation disabled")
+		return
+	}
+
+	go runPeriodically(ctx, s.Lock, s.Log, "budget_policy_evaluation", s.Interval, s.EvaluateAll)
+}
+
+// EvaluateAll evaluates the deploy gates of all organizations with policies or an active freeze,
+// a failing organization does not stop the others.
+func (s *BudgetPolicyService) EvaluateAll(now time.Time) {
+	orgIDs, err := s.Provider.GetBudgetPolicyOrgIDs()
+	if err != nil {
+		s.Log.WithError(err).Error("could not get organizations for budget policy evaluation")
+		return
+	}
+
+	failed := 0
+	for _, orgID := range orgIDs {
+		if err = s.evaluate(orgID, now); err != nil {
+			failed++
+			s.Log.WithError(err).WithField("orgId", orgID).Error("could not evaluate budget policies")
+		}
+	}
+
+	s.Log.WithField("organizations", len(orgIDs)).WithField("failedOrganizations", failed).Info("budget policy evaluation finished")
+}
+
+func (s *BudgetPolicyService) Create(policy *model.BudgetPolicy) error {
+	if err := s.Provider.CreateBudgetPolicy(policy); err != nil {
+		return errory.Decorate(err, "budget policy service create()")
+	}
+	return nil
+}
+
+func (s *BudgetPolicyService) Update(policy *model.BudgetPolicy) error {
+	if _, err := s.Get(policy.OrgID, policy.ID); err != nil {
+		return err
+	}
+	if err := s.Provider.UpdateBudgetPolicy(policy); err != nil {
+		return errory.Decorate(err, "budget policy service update()")
+	}
+	return nil
+}
+
+func (s *BudgetPolicyService) Delete(orgID, id int64) error {
+	if _, err := s.Get(orgID, id); err != nil {
+		return err
+	}
+	if err := s.Provider.DeleteBudgetPolicy(id); err != nil {
+		return errory.Decorate(err, "budget policy service delete()")
+	}
+	return nil
+}
+
+// Get returns the policy only when it belongs to the organization.
+func (s *BudgetPolicyService) Get(orgID, id int64) (*model.BudgetPolicy, error) {
+	policy, err := s.Provider.GetBudgetPolicy(id)
+	if err != nil {
+		return nil, err
+	}
+	if policy.OrgID != orgID {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("budgetPolicy", id).Create()
+	}
+	return policy, nil
+}
+
+func (s *BudgetPolicyService) GetByOrgID(orgID int64) ([]*model.BudgetPolicy, error) {
+	return s.Provider.GetBudgetPoliciesByOrgID(orgID)
+}
+
+func (s *BudgetPolicyService) GetDeployFreezes(orgID int64) ([]*model.DeployFreeze, error) {
+	return s.Provider.GetDeployFreezesByOrgID(orgID)
+}
+
+// GetDeployGate evaluates the policies of the organization, deploys are denied while a freeze
+// policy is triggered by any SLO or the budget of an SLO it applies to cannot be calculated.
+// SLOs which have no history yet do not trigger policies. FrozenSince is the start of the freeze
+// recorded by the background evaluation.
+func (s *BudgetPolicyService) GetDeployGate(orgID int64, now time.Time) (*model.DeployGate, error) {
+	gate, _, _, err := s.evaluateGate(orgID, now)
+	if err != nil {
+		return nil, err
+	}
+	if gate.Allowed {
+		return gate, nil
+	}
+
+	freeze, err := s.Provider.GetActiveDeployFreeze(orgID)
+	if errory.IsOfType(err, errory.NotFoundErrors) {
+		return gate, nil
+	}
+	if err != nil {
+		return nil, errory.Decorate(err, "deploy gate")
+	}
+	gate.FrozenSince = &freeze.Start
+	return gate, nil
+}
+
+// evaluate records the triggered policies and the freeze of the organization.
+func (s *BudgetPolicyService) evaluate(orgID int64, now time.Time) error {
+	gate, policies, evaluated, err := s.evaluateGate(orgID, now)
+	if err != nil {
+		return err
+	}
+	if err = s.recordTriggers(gate, policies, evaluated, now); err != nil {
+		return err
+	}
+	return s.recordFreeze(gate, now)
+}
+
+func (s *BudgetPolicyService) evaluateGate(orgID int64, now time.Time) (*model.DeployGate, []*model.BudgetPolicy,
+	map[int64]*evaluatedSlo, error) {
+	policies, err := s.Provider.GetBudgetPoliciesByOrgID(orgID)
+	if err != nil {
+		return nil, nil, nil, errory.Decorate(err, "deploy gate")
+	}
+	gate := &model.DeployGate{OrgID: orgID, Allowed: true, Reasons: []model.DeployGateReason{}}
+	evaluated := map[int64]*evaluatedSlo{}
+	if len(policies) > 0 {
+		if evaluated, err = s.evaluatePolicies(gate, policies, now); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return gate, policies, evaluated, nil
+}
+
+// evaluatedSlo is an SLO policies apply to, remaining is nil when its budget is unknown.
+type evaluatedSlo struct {
+	slo       *model.Slo
+	remaining *float64
+}
+
+func (s *BudgetPolicyService) evaluatePolicies(gate *model.DeployGate, policies []*model.BudgetPolicy,
+	now time.Time) (map[int64]*evaluatedSlo, error) {
+	slos, err := s.SloProvider.GetSlosByOrganizationID(gate.OrgID)
+	if err != nil {
+		return nil, errory.Decorate(err, "deploy gate")
+	}
+
+	evaluated := map[int64]*evaluatedSlo{}
+	for _, slo := range slos {
+		applying := []*model.BudgetPolicy{}
+		for _, policy := range policies {
+			if slo.Critical || !policy.CriticalOnly {
+				applying = append(applying, policy)
+			}
+		}
+		if len(applying) == 0 {
+			continue
+		}
+		evaluated[slo.ID] = &evaluatedSlo{slo: slo}
+
+		budget, err := s.ErrorBudget.GetErrorBudget(slo.ID, now)
+		if err != nil {
+			s.Log.WithError(err).WithField("sloId", slo.ID).Warn("could not calculate error budget for deploy gate")
+			blockUnknownBudget(gate, slo, applying)
+			continue
+		}
+		if budget.Remaining == nil {
+			continue
+		}
+		evaluated[slo.ID].remaining = budget.Remaining
+
+		for _, policy := range applying {
+			if *budget.Remaining > policy.Threshold {
+				continue
+			}
+			gate.Reasons = append(gate.Reasons, model.DeployGateReason{
+				PolicyID:  policy.ID,
+				Policy:    policy.Name,
+				Action:    policy.Action,
+				SloID:     slo.ID,
+				SloName:   slo.Name,
+				Remaining: *budget.Remaining,
+				Threshold: policy.Threshold,
+			})
+			if policy.Action == model.BudgetPolicyActionFreeze {
+				gate.Allowed = false
+			}
+		}
+	}
+	return evaluated, nil
+}
+
+// recordTriggers publishes the SLOs which crossed the threshold of a policy since the last
+// evaluation and those which recovered, in the transaction recording or deleting the trigger. The
+// trigger of an SLO whose budget is unknown is kept, triggers of deleted policies or SLOs are
+// dropped without notification.
+func (s *BudgetPolicyService) recordTriggers(gate *model.DeployGate, policies []*model.BudgetPolicy,
+	evaluated map[int64]*evaluatedSlo, now time.Time) error {
+	triggers, err := s.Provider.GetBudgetPolicyTriggers(gate.OrgID)
+	if err != nil {
+		return errory.Decorate(err, "deploy gate")
+	}
+
+	type key struct{ policyID, sloID int64 }
+	recorded := map[key]bool{}
+	for _, trigger := range triggers {
+		recorded[key{trigger.PolicyID, trigger.SloID}] = true
+	}
+
+	triggered := map[key]bool{}
+	for _, reason := range gate.Reasons {
+		if reason.BudgetUnknown {
+			continue
+		}
+		triggered[key{reason.PolicyID, reason.SloID}] = true
+		if recorded[key{reason.PolicyID, reason.SloID}] {
+			continue
+		}
+		trigger := &model.BudgetPolicyTrigger{PolicyID: reason.PolicyID, SloID: reason.SloID, OrgID: gate.OrgID, Since: now.UTC()}
+		event := model.NewWebhookEvent(gate.OrgID, model.WebhookEventBudgetThresholdCrossed, reason)
+		if _, err = s.Provider.CreateBudgetPolicyTrigger(trigger, event); err != nil {
+			return errory.Decorate(err, "deploy gate")
+		}
+	}
+
+	policyByID := map[int64]*model.BudgetPolicy{}
+	for _, policy := range policies {
+		policyByID[policy.ID] = policy
+	}
+	for _, trigger := range triggers {
+		if triggered[key{trigger.PolicyID, trigger.SloID}] {
+			continue
+		}
+		slo := evaluated[trigger.SloID]
+		if slo != nil && slo.remaining == nil {
+			continue
+		}
+		var event *model.WebhookEvent
+		if policy := policyByID[trigger.PolicyID]; slo != nil && policy != nil {
+			event = model.NewWebhookEvent(gate.OrgID, model.WebhookEventBudgetThresholdRecovered, model.DeployGateReason{
+				PolicyID:  policy.ID,
+				Policy:    policy.Name,
+				Action:    policy.Action,
+				SloID:     slo.slo.ID,
+				SloName:   slo.slo.Name,
+				Remaining: *slo.remaining,
+				Threshold: policy.Threshold,
+			})
+		}
+		if _, err = s.Provider.DeleteBudgetPolicyTrigger(trigger.PolicyID, trigger.SloID, event); err != nil {
+			return errory.Decorate(err, "deploy gate")
+		}
+	}
+	return nil
+}
+
+// blockUnknownBudget denies deploys for the freeze policies applying to an SLO whose budget
+// cannot be calculated, so that an outage of the history does not lift deploy freezes.
+func blockUnknownBudget(gate *model.DeployGate, slo *model.Slo, applying []*model.BudgetPolicy) {
+	for _, policy := range applying {
+		if policy.Action != model.BudgetPolicyActionFreeze {
+			continue
+		}
+		gate.Reasons = append(gate.Reasons, model.DeployGateReason{
+			PolicyID:      policy.ID,
+			Policy:        policy.Name,
+			Action:        policy.Action,
+			SloID:         slo.ID,
+			SloName:       slo.Name,
+			Threshold:     policy.Threshold,
+			BudgetUnknown: true,
+		})
+		gate.Allowed = false
+	}
+}
+
+// recordFreeze starts a freeze when deploys become denied and ends it when they are allowed again.
+// Another instance may have started or ended it first.
+func (s *BudgetPolicyService) recordFreeze(gate *model.DeployGate, now time.Time) error {
+	freeze, err := s.Provider.GetActiveDeployFreeze(gate.OrgID)
+	if err != nil && !errory.IsOfType(err, errory.NotFoundErrors) {
+		return errory.Decorate(err, "deploy gate")
+	}
+	active := err == nil
+
+	switch {
+	case !gate.Allowed && !active:
+		freeze = &model.DeployFreeze{OrgID: gate.OrgID, Start: now.UTC(), Reasons: freezeReasons(gate.Reasons)}
+		started, err := s.Provider.StartDeployFreeze(freeze)
+		if err != nil {
+			return errory.Decorate(err, "deploy gate")
+		}
+		if started {
+			s.Log.WithField("orgId", gate.OrgID).Info("deploy freeze started")
+		}
+	case gate.Allowed && active:
+		err = s.Provider.EndDeployFreeze(freeze.ID, now.UTC())
+		if err != nil && !errory.IsOfType(err, errory.NotFoundErrors) {
+			return errory.Decorate(err, "deploy gate")
+		}
+		if err == nil {
+			s.Log.WithField("orgId", gate.OrgID).Info("deploy freeze ended")
+		}
+	}
+	return nil
+}
+
+func freezeReasons(reasons []model.DeployGateReason) string {
+	texts := []string{}
+	for _, reason := range reasons {
+		switch {
+		case reason.Action != model.BudgetPolicyActionFreeze:
+		case reason.BudgetUnknown:
+			texts = append(texts, fmt.Sprintf("%s: error budget of slo %d cannot be calculated", reason.Policy, reason.SloID))
+		default:
+			texts = append(texts, fmt.Sprintf("%s: slo %d has %.4f of its error budget left", reason.Policy, reason.SloID, reason.Remaining))
+		}
+	}
+	return strings.Join(texts, "; ")
+}
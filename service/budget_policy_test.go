@@ -0,0 +1,237 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Budget policy service test", func() {
+	var mockController *gomock.Controller
+	var mockPolicyProvider *provider.MockIBudgetPolicyProvider
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockErrorBudget *service.MockIErrorBudgetService
+	var policyService *service.BudgetPolicyService
+	logger, _ := logrustest.NewNullLogger()
+
+	const orgID int64 = 2
+	now := time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC)
+	remaining := func(v float64) *model.ErrorBudget { return &model.ErrorBudget{Remaining: &v} }
+	freezePolicy := &model.BudgetPolicy{ID: 1, OrgID: orgID, Name: "freeze critical", CriticalOnly: true, Action: model.BudgetPolicyActionFreeze}
+	warnPolicy := &model.BudgetPolicy{ID: 2, OrgID: orgID, Name: "warn half", Threshold: 0.5, Action: model.BudgetPolicyActionWarn}
+	criticalSlo := &model.Slo{ID: 7, OrgID: orgID, Name: "checkout", Critical: true}
+	otherSlo := &model.Slo{ID: 8, OrgID: orgID, Name: "search"}
+	notFound := errory.NotFoundErrors.New("deploy freeze")
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockPolicyProvider = provider.NewMockIBudgetPolicyProvider(mockController)
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockErrorBudget = service.NewMockIErrorBudgetService(mockController)
+		policyService = &service.BudgetPolicyService{
+			Provider:    mockPolicyProvider,
+			SloProvider: mockISLOProvider,
+			ErrorBudget: mockErrorBudget,
+			Log:         logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetDeployGate(orgID int64, now time.Time)", func() {
+		BeforeEach(func() {
+			mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(orgID).Return([]*model.BudgetPolicy{freezePolicy, warnPolicy}, nil)
+			mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{criticalSlo, otherSlo}, nil)
+		})
+
+		Context("When budget of a critical slo is exhausted", func() {
+			It("Should deny deploys without recording anything", func() {
+				start := now.Add(-time.Hour)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(7), now).Return(remaining(-0.2), nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(8), now).Return(remaining(0.9), nil)
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(&model.DeployFreeze{ID: 4, OrgID: orgID, Start: start}, nil)
+
+				gate, err := policyService.GetDeployGate(orgID, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gate.Allowed).To(BeFalse())
+				Expect(*gate.FrozenSince).To(Equal(start))
+				Expect(gate.Reasons).To(Equal([]model.DeployGateReason{
+					{PolicyID: 1, Policy: "freeze critical", Action: model.BudgetPolicyActionFreeze, SloID: 7, SloName: "checkout", Remaining: -0.2},
+					{PolicyID: 2, Policy: "warn half", Action: model.BudgetPolicyActionWarn, SloID: 7, SloName: "checkout", Remaining: -0.2, Threshold: 0.5},
+				}))
+			})
+		})
+
+		Context("When the background evaluation has not started the freeze yet", func() {
+			It("Should deny deploys without a freeze start", func() {
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(7), now).Return(remaining(-0.2), nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(8), now).Return(remaining(0.9), nil)
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(nil, notFound)
+
+				gate, err := policyService.GetDeployGate(orgID, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gate.Allowed).To(BeFalse())
+				Expect(gate.FrozenSince).To(BeNil())
+			})
+		})
+
+		Context("When only a warn policy is triggered", func() {
+			It("Should allow deploys", func() {
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(7), now).Return(remaining(0.8), nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(8), now).Return(remaining(0.3), nil)
+
+				gate, err := policyService.GetDeployGate(orgID, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gate.Allowed).To(BeTrue())
+				Expect(gate.FrozenSince).To(BeNil())
+				Expect(gate.Reasons).To(HaveLen(1))
+				Expect(gate.Reasons[0].SloID).To(Equal(int64(8)))
+			})
+		})
+
+		Context("When budget of a critical slo cannot be calculated", func() {
+			It("Should deny deploys", func() {
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(7), now).Return(nil, errory.ProviderErrors.New("elasticsearch unavailable"))
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(8), now).Return(remaining(0.9), nil)
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(nil, notFound)
+
+				gate, err := policyService.GetDeployGate(orgID, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gate.Allowed).To(BeFalse())
+				Expect(gate.Reasons).To(Equal([]model.DeployGateReason{
+					{PolicyID: 1, Policy: "freeze critical", Action: model.BudgetPolicyActionFreeze, SloID: 7, SloName: "checkout", BudgetUnknown: true},
+				}))
+			})
+		})
+	})
+
+	Describe("EvaluateAll(now time.Time)", func() {
+		BeforeEach(func() {
+			mockPolicyProvider.EXPECT().GetBudgetPolicyOrgIDs().Return([]int64{orgID}, nil)
+		})
+
+		Context("When budget of a critical slo is exhausted", func() {
+			It("Should publish the crossings and start a freeze", func() {
+				mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(orgID).Return([]*model.BudgetPolicy{freezePolicy, warnPolicy}, nil)
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{criticalSlo, otherSlo}, nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(7), now).Return(remaining(-0.2), nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(8), now).Return(remaining(0.9), nil)
+				mockPolicyProvider.EXPECT().GetBudgetPolicyTriggers(orgID).Return([]*model.BudgetPolicyTrigger{}, nil)
+				mockPolicyProvider.EXPECT().CreateBudgetPolicyTrigger(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(trigger *model.BudgetPolicyTrigger, event *model.WebhookEvent) (bool, error) {
+						Expect(event.Event).To(Equal(model.WebhookEventBudgetThresholdCrossed))
+						Expect(event.OrgID).To(Equal(orgID))
+						return true, nil
+					}).Times(2)
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(nil, notFound)
+				mockPolicyProvider.EXPECT().StartDeployFreeze(gomock.Any()).DoAndReturn(func(freeze *model.DeployFreeze) (bool, error) {
+					Expect(freeze.Start).To(Equal(now))
+					Expect(freeze.Reasons).To(Equal("freeze critical: slo 7 has -0.2000 of its error budget left"))
+					return true, nil
+				})
+
+				policyService.EvaluateAll(now)
+			})
+		})
+
+		Context("When only a warn policy is triggered", func() {
+			It("Should publish the recovery and end the running freeze", func() {
+				mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(orgID).Return([]*model.BudgetPolicy{freezePolicy, warnPolicy}, nil)
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{criticalSlo, otherSlo}, nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(7), now).Return(remaining(0.8), nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(8), now).Return(remaining(0.3), nil)
+				mockPolicyProvider.EXPECT().GetBudgetPolicyTriggers(orgID).Return([]*model.BudgetPolicyTrigger{
+					{PolicyID: 1, SloID: 7, OrgID: orgID}, {PolicyID: 2, SloID: 8, OrgID: orgID},
+				}, nil)
+				mockPolicyProvider.EXPECT().DeleteBudgetPolicyTrigger(int64(1), int64(7), gomock.Any()).
+					DoAndReturn(func(_, _ int64, event *model.WebhookEvent) (bool, error) {
+						Expect(event.Event).To(Equal(model.WebhookEventBudgetThresholdRecovered))
+						Expect(event.Data).To(Equal(model.DeployGateReason{
+							PolicyID: 1, Policy: "freeze critical", Action: model.BudgetPolicyActionFreeze, SloID: 7, SloName: "checkout", Remaining: 0.8,
+						}))
+						return true, nil
+					})
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(&model.DeployFreeze{ID: 4, OrgID: orgID}, nil)
+				mockPolicyProvider.EXPECT().EndDeployFreeze(int64(4), now).Return(nil)
+
+				policyService.EvaluateAll(now)
+			})
+		})
+
+		Context("When freeze is running and budget of the critical slo cannot be calculated", func() {
+			It("Should keep the freeze and the trigger of the slo", func() {
+				mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(orgID).Return([]*model.BudgetPolicy{freezePolicy}, nil)
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{criticalSlo}, nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(7), now).Return(nil, errory.ParseErrors.New("target"))
+				mockPolicyProvider.EXPECT().GetBudgetPolicyTriggers(orgID).Return([]*model.BudgetPolicyTrigger{{PolicyID: 1, SloID: 7, OrgID: orgID}}, nil)
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(&model.DeployFreeze{ID: 4, OrgID: orgID}, nil)
+
+				policyService.EvaluateAll(now)
+			})
+		})
+
+		Context("When another instance recorded the crossing first", func() {
+			It("Should not publish it", func() {
+				mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(orgID).Return([]*model.BudgetPolicy{warnPolicy}, nil)
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{otherSlo}, nil)
+				mockErrorBudget.EXPECT().GetErrorBudget(int64(8), now).Return(remaining(0.1), nil)
+				mockPolicyProvider.EXPECT().GetBudgetPolicyTriggers(orgID).Return([]*model.BudgetPolicyTrigger{}, nil)
+				mockPolicyProvider.EXPECT().CreateBudgetPolicyTrigger(gomock.Any(), gomock.Any()).Return(false, nil)
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(nil, notFound)
+
+				policyService.EvaluateAll(now)
+			})
+		})
+
+		Context("When the last policy of the organization was deleted", func() {
+			It("Should drop its trigger without publishing and end the freeze", func() {
+				mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(orgID).Return([]*model.BudgetPolicy{}, nil)
+				mockPolicyProvider.EXPECT().GetBudgetPolicyTriggers(orgID).Return([]*model.BudgetPolicyTrigger{{PolicyID: 3, SloID: 8, OrgID: orgID}}, nil)
+				mockPolicyProvider.EXPECT().DeleteBudgetPolicyTrigger(int64(3), int64(8), nil).Return(true, nil)
+				mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(&model.DeployFreeze{ID: 4, OrgID: orgID}, nil)
+				mockPolicyProvider.EXPECT().EndDeployFreeze(int64(4), now).Return(nil)
+
+				policyService.EvaluateAll(now)
+			})
+		})
+	})
+
+	Describe("EvaluateAll(now time.Time) failures", func() {
+		It("Should evaluate the remaining organizations when one fails", func() {
+			mockPolicyProvider.EXPECT().GetBudgetPolicyOrgIDs().Return([]int64{1, orgID}, nil)
+			mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(int64(1)).Return(nil, errory.ProviderErrors.New("connection refused"))
+			mockPolicyProvider.EXPECT().GetBudgetPoliciesByOrgID(orgID).Return([]*model.BudgetPolicy{}, nil)
+			mockPolicyProvider.EXPECT().GetBudgetPolicyTriggers(orgID).Return([]*model.BudgetPolicyTrigger{}, nil)
+			mockPolicyProvider.EXPECT().GetActiveDeployFreeze(orgID).Return(nil, notFound)
+
+			policyService.EvaluateAll(now)
+		})
+	})
+
+	Describe("Delete(orgID, id int64)", func() {
+		It("Should not delete a policy of another organization", func() {
+			mockPolicyProvider.EXPECT().GetBudgetPolicy(int64(1)).Return(&model.BudgetPolicy{ID: 1, OrgID: 5}, nil)
+
+			err := policyService.Delete(orgID, 1)
+
+			Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+var complianceReportFormats = []model.ComplianceReportFormat{model.ComplianceReportFormatCSV, model.ComplianceReportFormatPDF}
+
+var complianceReportHeader = []string{"solution", "slo_id", "slo", "critical", "metric_type", "target", "achieved",
+	"budget_consumed", "breached_days", "breached", "external_sla", "sla_met"}
+
+type IComplianceReportService interface {
+	Start(ctx context.Context)
+	Generate(orgID int64, request *model.ComplianceReportRequest, now time.Time) (*model.ComplianceReport, error)
+	GenerateScheduled(now time.Time)
+	Get(orgID, id int64) (*model.ComplianceReport, error)
+	GetByOrgID(orgID int64) ([]*model.ComplianceReport, error)
+}
+
+// ComplianceReportService reports how the SLOs of an organization, or of one of its solutions,
+// did in a month. When Enabled it stores the reports of the previous month for every organization
+// and solution in all formats, checking every Interval for missing ones.
+type ComplianceReportService struct {
+	Provider          provider.IComplianceReportProvider
+	SloProvider       provider.ISLOProvider
+	SolutionsProvider provider.ISolutionsProvider
+	ErrorBudget       IErrorBudgetService
+	Enabled           bool
+	Interval          time.Duration
+	Lock              provider.IJobLockProvider
+	Log               logrus.FieldLogger
+}
+
+type complianceReportScope struct {
+	orgID      int64
+	solutionID *int64
+}
+
+// Start generates the missing scheduled reports every Interval in the background until ctx is done.
+func (s *ComplianceReportService) Start(ctx context.Context) {
+	if !s.Enabled {
+		s.Log.Info("scheduled compliance reports disabled")
+		return
+	}
+
+	go runPeriodically(ctx, s.Lock, s.Log, "compliance_report_schedule", s.Interval, s.GenerateScheduled)
+}
+
+// Generate stores the report of the requested month, the month which is not over yet is
+// reported until now.
+func (s *ComplianceReportService) Generate(orgID int64, request *model.ComplianceReportRequest,
+	now time.Time) (*model.ComplianceReport, error) {
+	start, err := time.Parse("2006-01", request.Month)
+	if err != nil {
+		return nil, errory.ValidationErrors.Builder().Wrap(err).WithPayload("month", request.Month).
+			WithMessage("month has to be formatted as YYYY-MM").Create()
+	}
+	if !start.Before(now) {
+		return nil, errory.ValidationErrors.Builder().WithPayload("month", request.Month).
+			WithMessage("month has not started yet").Create()
+	}
+
+	slos, solutions, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	scope := complianceReportScope{orgID: orgID, solutionID: request.SolutionID}
+	if scope.solutionID != nil {
+		if _, ok := solutions[*scope.solutionID]; !ok {
+			return nil, errory.NotFoundErrors.Builder().WithPayload("solution", *scope.solutionID).Create()
+		}
+	}
+
+	end := minTime(start.AddDate(0, 1, 0), now.UTC())
+	rows := s.measure(scope, slos, solutions, start, end)
+	report, err := s.render(scope, rows, solutions, start, end, request.Format, false, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Provider.CreateComplianceReport(report); err != nil {
+		return nil, errory.Decorate(err, "compliance report service generate()")
+	}
+	return report, nil
+}
+
+// GenerateScheduled stores the reports of the previous month which are missing, a failing
+// report does not stop the others.
+func (s *ComplianceReportService) GenerateScheduled(now time.Time) {
+	slos, solutions, err := s.load()
+	if err != nil {
+		s.Log.WithError(err).Error("could not get slos for compliance reports")
+		return
+	}
+
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, -1, 0)
+
+	scopes := []complianceReportScope{}
+	seen := map[string]bool{}
+	addScope := func(scope complianceReportScope) {
+		key := strconv.FormatInt(scope.orgID, 10)
+		if scope.solutionID != nil {
+			key += "/" + strconv.FormatInt(*scope.solutionID, 10)
+		}
+		if !seen[key] {
+			seen[key] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	for _, slo := range slos {
+		addScope(complianceReportScope{orgID: slo.OrgID})
+		if slo.SolutionID != nil {
+			addScope(complianceReportScope{orgID: slo.OrgID, solutionID: slo.SolutionID})
+		}
+	}
+
+	generated, failed := 0, 0
+	for _, scope := range scopes {
+		missing := []model.ComplianceReportFormat{}
+		for _, format := range complianceReportFormats {
+			exists, err := s.Provider.ComplianceReportExists(scope.orgID, scope.solutionID, start, format)
+			if err != nil {
+				failed++
+				s.Log.WithError(err).WithField("orgId", scope.orgID).WithField("solutionId", scope.solutionID).
+					Error("could not check for compliance report")
+				continue
+			}
+			if !exists {
+				missing = append(missing, format)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		rows := s.measure(scope, slos, solutions, start, end)
+		for _, format := range missing {
+			report, err := s.render(scope, rows, solutions, start, end, format, true, now)
+			var created bool
+			if err == nil {
+				created, err = s.Provider.CreateScheduledComplianceReport(report)
+			}
+			if err != nil {
+				failed++
+				s.Log.WithError(err).WithField("orgId", scope.orgID).WithField("solutionId", scope.solutionID).
+					Error("could not generate compliance report")
+				continue
+			}
+			if created {
+				generated++
+			}
+		}
+	}
+
+	s.Log.WithField("reports", generated).WithField("failedReports", failed).Info("scheduled compliance reports finished")
+}
+
+// Get returns the report with its content only when it belongs to the organization.
+func (s *ComplianceReportService) Get(orgID, id int64) (*model.ComplianceReport, error) {
+	report, err := s.Provider.GetComplianceReport(id)
+	if err != nil {
+		return nil, err
+	}
+	if report.OrgID != orgID {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("complianceReport", id).Create()
+	}
+	return report, nil
+}
+
+func (s *ComplianceReportService) GetByOrgID(orgID int64) ([]*model.ComplianceReport, error) {
+	return s.Provider.GetComplianceReportsByOrgID(orgID)
+}
+
+// load returns all SLOs with their solution and the names of the solutions by their id.
+func (s *ComplianceReportService) load() ([]*model.DetailedSlo, map[int64]string, error) {
+	slos, err := s.SloProvider.GetDetailedSlos("", "")
+	if err != nil {
+		return nil, nil, errory.Decorate(err, "compliance report")
+	}
+	solutions, err := s.SolutionsProvider.GetSolutions(true, false, "")
+	if err != nil {
+		return nil, nil, errory.Decorate(err, "compliance report")
+	}
+
+	names := map[int64]string{}
+	for _, solution := range solutions {
+		names[solution.ID] = solution.Name
+	}
+	return slos, names, nil
+}
+
+func (s *ComplianceReportService) render(scope complianceReportScope, rows []*model.SloCompliance, solutions map[int64]string,
+	start, end time.Time, format model.ComplianceReportFormat, scheduled bool, now time.Time) (*model.ComplianceReport, error) {
+	report := &model.ComplianceReport{
+		OrgID:       scope.orgID,
+		SolutionID:  scope.solutionID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Format:      format,
+		Scheduled:   scheduled,
+		CreatedAt:   now.UTC(),
+	}
+
+	switch format {
+	case model.ComplianceReportFormatCSV:
+		content, err := renderComplianceCSV(rows)
+		if err != nil {
+			return nil, errory.ParseErrors.Builder().Wrap(err).WithMessage("compliance report cannot be written").Create()
+		}
+		report.Content = content
+	case model.ComplianceReportFormatPDF:
+		report.Content = renderCompliancePDF(report, solutions, rows)
+	default:
+		return nil, errory.ValidationErrors.Builder().WithPayload("format", format).
+			WithMessage("unknown compliance report format").Create()
+	}
+
+	return report, nil
+}
+
+// measure returns the compliance of the SLOs in scope ordered by solution and name. SLOs which
+// cannot be measured are still listed, without any values.
+func (s *ComplianceReportService) measure(scope complianceReportScope, slos []*model.DetailedSlo,
+	solutions map[int64]string, start, end time.Time) []*model.SloCompliance {
+	rows := []*model.SloCompliance{}
+	for _, slo := range slos {
+		if slo.OrgID != scope.orgID {
+			continue
+		}
+		if scope.solutionID != nil && (slo.SolutionID == nil || *slo.SolutionID != *scope.solutionID) {
+			continue
+		}
+
+		row, err := s.ErrorBudget.GetCompliance(&slo.Slo, start, end)
+		if err != nil {
+			s.Log.WithError(err).WithField("sloId", slo.ID).Warn("could not measure slo compliance")
+			row = &model.SloCompliance{SloID: slo.ID, SloName: slo.Name, Critical: slo.Critical, ExternalSLA: slo.ExternalSLA}
+		}
+		if slo.SolutionID != nil {
+			row.SolutionName = solutions[*slo.SolutionID]
+		}
+		rows = append(rows, row)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].SolutionName != rows[j].SolutionName {
+			return rows[i].SolutionName < rows[j].SolutionName
+		}
+		return rows[i].SloName < rows[j].SloName
+	})
+	return rows
+}
+
+func renderComplianceCSV(rows []*model.SloCompliance) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(complianceReportHeader); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		target := ""
+		if row.MetricType != "" {
+			target = formatReportValue(&row.Target, 3)
+		}
+		record := []string{row.SolutionName, strconv.FormatInt(row.SloID, 10), row.SloName, formatReportBool(&row.Critical),
+			string(row.MetricType), target, formatReportValue(row.Achieved, 3), formatReportValue(row.BudgetConsumed, 4),
+			strconv.Itoa(row.BreachedDays), formatReportBool(&row.Breached), row.ExternalSLA, formatReportBool(row.SlaMet)}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func renderCompliancePDF(report *model.ComplianceReport, solutions map[int64]string, rows []*model.SloCompliance) []byte {
+	scope := fmt.Sprintf("Organization %d", report.OrgID)
+	if report.SolutionID != nil {
+		scope += ", solution " + solutions[*report.SolutionID]
+	}
+	lines := []string{
+		"SLO and SLA compliance report",
+		fmt.Sprintf("%s, %s to %s", scope, report.PeriodStart.Format("2006-01-02"), report.PeriodEnd.Format("2006-01-02")),
+		"Generated " + report.CreatedAt.Format(time.RFC3339),
+		"",
+	}
+
+	const rowFormat = "%-24s %-32s %-4s %-11s %8s %9s %9s %8s %-8s %8s %-7s"
+	lines = append(lines, fmt.Sprintf(rowFormat, "Solution", "SLO", "Crit", "Metric", "Target", "Achieved",
+		"Consumed", "Breached", "Breach", "SLA", "SLA met"))
+
+	breached, slaMissed := 0, 0
+	for _, row := range rows {
+		target, critical := "n/a", ""
+		if row.MetricType != "" {
+			target = formatReportValue(&row.Target, 3)
+		}
+		if row.Critical {
+			critical = "yes"
+		}
+		if row.Breached {
+			breached++
+		}
+		if row.SlaMet != nil && !*row.SlaMet {
+			slaMissed++
+		}
+		line := fmt.Sprintf(rowFormat, truncateReportText(row.SolutionName, 24), truncateReportText(row.SloName, 32), critical,
+			row.MetricType, target, formatReportValue(row.Achieved, 3), formatReportValue(row.BudgetConsumed, 4),
+			fmt.Sprintf("%dd", row.BreachedDays), formatReportBool(&row.Breached), row.ExternalSLA, formatReportBool(row.SlaMet))
+		lines = append(lines, truncateReportText(line, pdfLineWidth))
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%d SLOs, %d breached their target, %d missed their external SLA", len(rows), breached, slaMissed))
+	return renderPDF(lines)
+}
+
+func formatReportValue(value *float64, precision int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', precision, 64)
+}
+
+func formatReportBool(value *bool) string {
+	switch {
+	case value == nil:
+		return ""
+	case *value:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+func truncateReportText(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-1]) + "~"
+}
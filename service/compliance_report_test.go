@@ -0,0 +1,175 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Compliance report test", func() {
+	var mockController *gomock.Controller
+	var mockReportProvider *provider.MockIComplianceReportProvider
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockSolutionsProvider *provider.MockISolutionsProvider
+	var mockErrorBudget *service.MockIErrorBudgetService
+	var reportService *service.ComplianceReportService
+	logger, _ := logrustest.NewNullLogger()
+
+	value := func(v float64) *float64 { return &v }
+	yes := true
+	solutionID, otherSolutionID := int64(5), int64(6)
+	march, april := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, time.April, 2, 8, 0, 0, 0, time.UTC)
+
+	checkout := &model.DetailedSlo{Slo: model.Slo{ID: 1, OrgID: 99, Name: "checkout", Critical: true, ExternalSLA: "99"}, SolutionID: &solutionID}
+	search := &model.DetailedSlo{Slo: model.Slo{ID: 2, OrgID: 99, Name: "search"}, SolutionID: &otherSolutionID}
+	otherOrg := &model.DetailedSlo{Slo: model.Slo{ID: 3, OrgID: 100, Name: "billing"}}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockReportProvider = provider.NewMockIComplianceReportProvider(mockController)
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockSolutionsProvider = provider.NewMockISolutionsProvider(mockController)
+		mockErrorBudget = service.NewMockIErrorBudgetService(mockController)
+		reportService = &service.ComplianceReportService{
+			Provider:          mockReportProvider,
+			SloProvider:       mockISLOProvider,
+			SolutionsProvider: mockSolutionsProvider,
+			ErrorBudget:       mockErrorBudget,
+			Log:               logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	expectSlos := func() {
+		mockISLOProvider.EXPECT().GetDetailedSlos("", "").Return([]*model.DetailedSlo{checkout, search, otherOrg}, nil)
+		mockSolutionsProvider.EXPECT().GetSolutions(true, false, "").Return([]*model.Solution{
+			{ID: solutionID, Name: "Shop"}, {ID: otherSolutionID, Name: "Assortment"},
+		}, nil)
+	}
+
+	Describe("Generate(orgID int64, request *model.ComplianceReportRequest, now time.Time)", func() {
+		Context("When CSV of an organization is requested", func() {
+			It("Should store a row per slo of the organization ordered by solution", func() {
+				expectSlos()
+				mockErrorBudget.EXPECT().GetCompliance(&checkout.Slo, march, april).Return(&model.SloCompliance{
+					SloID: 1, SloName: "checkout", Critical: true, MetricType: model.MetricTypeSuccessRate, Target: 99.5,
+					Achieved: value(99.2), BudgetConsumed: value(1.6), BreachedDays: 4, Breached: true, ExternalSLA: "99", SlaMet: &yes,
+				}, nil)
+				mockErrorBudget.EXPECT().GetCompliance(&search.Slo, march, april).Return(nil, errory.ParseErrors.New("target"))
+				var stored *model.ComplianceReport
+				mockReportProvider.EXPECT().CreateComplianceReport(gomock.Any()).DoAndReturn(func(report *model.ComplianceReport) error {
+					stored = report
+					report.ID = 12
+					return nil
+				})
+
+				report, err := reportService.Generate(99, &model.ComplianceReportRequest{Month: "2021-03", Format: model.ComplianceReportFormatCSV}, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report).To(Equal(stored))
+				Expect(report.ID).To(Equal(int64(12)))
+				Expect(report.PeriodStart).To(Equal(march))
+				Expect(report.PeriodEnd).To(Equal(april))
+				Expect(report.Scheduled).To(BeFalse())
+				Expect(string(report.Content)).To(Equal(
+					"solution,slo_id,slo,critical,metric_type,target,achieved,budget_consumed,breached_days,breached,external_sla,sla_met\n" +
+						"Assortment,2,search,no,,,,,0,no,,\n" +
+						"Shop,1,checkout,yes,successrate,99.500,99.200,1.6000,4,yes,99,yes\n"))
+			})
+		})
+
+		Context("When PDF of a solution of the current month is requested", func() {
+			It("Should report the solution until now", func() {
+				expectSlos()
+				mockErrorBudget.EXPECT().GetCompliance(&checkout.Slo, april, now).Return(&model.SloCompliance{SloID: 1, SloName: "checkout"}, nil)
+				mockReportProvider.EXPECT().CreateComplianceReport(gomock.Any()).Return(nil)
+
+				report, err := reportService.Generate(99, &model.ComplianceReportRequest{
+					SolutionID: &solutionID, Month: "2021-04", Format: model.ComplianceReportFormatPDF,
+				}, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.PeriodEnd).To(Equal(now))
+				Expect(report.FileName()).To(Equal("compliance-org-99-solution-5-2021-04.pdf"))
+				Expect(bytes.HasPrefix(report.Content, []byte("%PDF-1.4"))).To(BeTrue())
+				Expect(string(report.Content)).To(ContainSubstring("(Organization 99, solution Shop, 2021-04-01 to 2021-04-02)"))
+				Expect(bytes.HasSuffix(report.Content, []byte("%%EOF\n"))).To(BeTrue())
+			})
+		})
+
+		Context("When solution does not exist", func() {
+			It("Should return not found error", func() {
+				expectSlos()
+				unknown := int64(404)
+
+				_, err := reportService.Generate(99, &model.ComplianceReportRequest{SolutionID: &unknown, Month: "2021-03", Format: "csv"}, now)
+
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When month is malformed or has not started", func() {
+			It("Should return validation error", func() {
+				_, err := reportService.Generate(99, &model.ComplianceReportRequest{Month: "03/2021", Format: "csv"}, now)
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+
+				_, err = reportService.Generate(99, &model.ComplianceReportRequest{Month: "2021-05", Format: "csv"}, now)
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GenerateScheduled(now time.Time)", func() {
+		It("Should store the missing reports of the previous month for organizations and solutions", func() {
+			expectSlos()
+			mockErrorBudget.EXPECT().GetCompliance(gomock.Any(), march, april).Return(&model.SloCompliance{}, nil).AnyTimes()
+			mockReportProvider.EXPECT().ComplianceReportExists(gomock.Any(), gomock.Any(), march, gomock.Any()).
+				DoAndReturn(func(orgID int64, solutionID *int64, _ time.Time, format model.ComplianceReportFormat) (bool, error) {
+					return orgID == 99 && solutionID == nil, nil
+				}).Times(8)
+
+			created := []string{}
+			mockReportProvider.EXPECT().CreateScheduledComplianceReport(gomock.Any()).DoAndReturn(func(report *model.ComplianceReport) (bool, error) {
+				Expect(report.Scheduled).To(BeTrue())
+				created = append(created, report.FileName())
+				return true, nil
+			}).Times(6)
+
+			reportService.GenerateScheduled(now)
+
+			Expect(created).To(Equal([]string{
+				"compliance-org-99-solution-5-2021-03.csv", "compliance-org-99-solution-5-2021-03.pdf",
+				"compliance-org-99-solution-6-2021-03.csv", "compliance-org-99-solution-6-2021-03.pdf",
+				"compliance-org-100-2021-03.csv", "compliance-org-100-2021-03.pdf",
+			}))
+		})
+	})
+
+	Describe("Get(orgID, id int64)", func() {
+		Context("When report belongs to another organization", func() {
+			It("Should return not found error", func() {
+				mockReportProvider.EXPECT().GetComplianceReport(int64(12)).Return(&model.ComplianceReport{ID: 12, OrgID: 100}, nil)
+
+				_, err := reportService.Get(99, 12)
+
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+	})
+})
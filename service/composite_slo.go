@@ -0,0 +1,267 @@
+package service
+
+import (
+	"strconv"
+
+	elastic "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+type ICompositeSloService interface {
+	ValidateChildren(userContext *auth.UserContext, slo *model.Slo) error
+	LoadChildren(slo *model.Slo) error
+	GetParentsToUnlink(slo *model.Slo, cascade bool) ([]*model.SloChild, error)
+	UpdateParentDashboards(userContext *auth.UserContext, parents []*model.SloChild)
+	GetHistory(slo *model.Slo, timeRange *elasticModel.SloHistoryRange, interval string) ([]*elasticModel.SloHistoryPoint, error)
+}
+
+// ISloAuthorizer checks the permission of a user on an SLO, it is implemented by provider.AuthProvider.
+type ISloAuthorizer interface {
+	AuthorizeForSLO(entityID, userID int64, requiredRole auth.Permission) (bool, error)
+}
+
+// CompositeSloService manages the children of composite SLOs and computes their history
+// from the history of the children.
+type CompositeSloService struct {
+	Provider         provider.ICompositeSloProvider
+	SloProvider      provider.ISLOProvider
+	Authorizer       ISloAuthorizer
+	ElasticClient    elastic.IClient
+	DashboardService IDashboardService
+	Log              logrus.FieldLogger
+}
+
+// ValidateChildren checks that the children of a composite SLO exist, are visible to the user
+// and do not lead back to the SLO. Other SLOs must not have children.
+func (s *CompositeSloService) ValidateChildren(userContext *auth.UserContext, slo *model.Slo) error {
+	if !slo.IsComposite() {
+		if len(slo.Children) > 0 {
+			return errory.ValidationErrors.Builder().WithMessage("only composite slos can have children").Create()
+		}
+		return nil
+	}
+	if slo.ExternalID != "" {
+		return errory.ValidationErrors.Builder().WithPayload("externalId", slo.ExternalID).
+			WithMessage("composite slo cannot reference an external slo").Create()
+	}
+	if len(slo.Children) == 0 {
+		return errory.ValidationErrors.Builder().WithMessage("composite slo needs at least one child").Create()
+	}
+
+	weights, seen := 0.0, map[int64]bool{}
+	for _, child := range slo.Children {
+		if child.ChildID == slo.ID || seen[child.ChildID] {
+			return errory.ValidationErrors.Builder().WithPayload("child", child.ChildID).
+				WithMessage("child slo has to be another slo and must not repeat").Create()
+		}
+		seen[child.ChildID] = true
+		weights += child.Weight
+
+		if err := s.validateChild(userContext, slo, child.ChildID); err != nil {
+			return err
+		}
+	}
+	if slo.Composite == model.CompositeAggregationWeightedAverage && weights <= 0 {
+		return errory.ValidationErrors.Builder().WithMessage("weighted average needs a child with positive weight").Create()
+	}
+
+	if slo.ID != 0 {
+		return s.checkCycle(slo)
+	}
+	return nil
+}
+
+// validateChild accepts children of other organizations only when the user may view them.
+func (s *CompositeSloService) validateChild(userContext *auth.UserContext, slo *model.Slo, childID int64) error {
+	child, err := s.SloProvider.GetSlo(childID)
+	if errory.IsOfType(err, errory.NotFoundErrors) {
+		return errory.ValidationErrors.Builder().Wrap(err).WithPayload("child", childID).WithMessage("child slo does not exist").Create()
+	}
+	if err != nil {
+		return errory.Decorate(err, "composite slo validation")
+	}
+	if child.OrgID == slo.OrgID {
+		return nil
+	}
+
+	allowed, err := s.Authorizer.AuthorizeForSLO(childID, userContext.ID, auth.Viewer)
+	if err != nil {
+		return errory.Decorate(err, "composite slo validation")
+	}
+	if !allowed {
+		return errory.ValidationErrors.Builder().WithPayload("child", childID).
+			WithMessage("child slo belongs to an organization which is not allowed").Create()
+	}
+	return nil
+}
+
+// checkCycle walks down from the new children and fails when it reaches the SLO itself.
+func (s *CompositeSloService) checkCycle(slo *model.Slo) error {
+	links, err := s.Provider.GetAllSloChildren()
+	if err != nil {
+		return errory.Decorate(err, "composite slo validation")
+	}
+	children := map[int64][]int64{}
+	for _, link := range links {
+		if link.ParentID != slo.ID {
+			children[link.ParentID] = append(children[link.ParentID], link.ChildID)
+		}
+	}
+
+	visited := map[int64]bool{}
+	pending := []int64{}
+	for _, child := range slo.Children {
+		pending = append(pending, child.ChildID)
+	}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if id == slo.ID {
+			return errory.ValidationErrors.Builder().WithPayload("sloId", slo.ID).
+				WithMessage("children of composite slo must not contain the slo itself").Create()
+		}
+		if !visited[id] {
+			visited[id] = true
+			pending = append(pending, children[id]...)
+		}
+	}
+	return nil
+}
+
+func (s *CompositeSloService) LoadChildren(slo *model.Slo) error {
+	if !slo.IsComposite() {
+		return nil
+	}
+	children, err := s.Provider.GetSloChildren(slo.ID)
+	if err != nil {
+		return err
+	}
+	slo.Children = children
+	return nil
+}
+
+// GetParentsToUnlink returns the composite SLOs the SLO is a child of before its deletion. An SLO
+// which is a child of composite SLOs is only removed from them with cascade.
+func (s *CompositeSloService) GetParentsToUnlink(slo *model.Slo, cascade bool) ([]*model.SloChild, error) {
+	parents, err := s.Provider.GetSloParents(slo.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(parents) > 0 && !cascade {
+		ids := make([]string, 0, len(parents))
+		for _, parent := range parents {
+			ids = append(ids, strconv.FormatInt(parent.ParentID, 10))
+		}
+		return nil, errory.ValidationErrors.Builder().WithPayload("parents", ids).
+			WithMessage("slo is a child of composite slos, delete it with cascade to remove it from them").Create()
+	}
+	return parents, nil
+}
+
+// UpdateParentDashboards renders the dashboards of the composite SLOs again once a child was
+// removed from them, a failing dashboard is only logged as the child is already deleted.
+func (s *CompositeSloService) UpdateParentDashboards(userContext *auth.UserContext, parents []*model.SloChild) {
+	for _, parent := range parents {
+		parentSlo, err := s.SloProvider.GetSlo(parent.ParentID)
+		if err == nil {
+			err = s.DashboardService.UpdateDashboard(userContext, parentSlo)
+		}
+		if err != nil {
+			s.Log.WithError(err).WithField("sloId", parent.ParentID).Warn("could not update composite dashboard after child deletion")
+		}
+	}
+}
+
+// GetHistory aggregates the history points of the children, which are read the same way, into
+// success rate points. Buckets in which no child has a value have none either.
+func (s *CompositeSloService) GetHistory(slo *model.Slo, timeRange *elasticModel.SloHistoryRange,
+	interval string) ([]*elasticModel.SloHistoryPoint, error) {
+	links, err := s.Provider.GetSloChildren(slo.ID)
+	if err != nil {
+		return nil, errory.Decorate(err, "composite slo history")
+	}
+
+	points := []*elasticModel.SloHistoryPoint{}
+	aggregates := map[int64]*compositeAggregate{}
+	for _, link := range links {
+		child, err := s.SloProvider.GetSlo(link.ChildID)
+		if err != nil {
+			return nil, errory.Decorate(err, "composite slo history")
+		}
+		metricType, target, err := budgetTarget(child)
+		if err != nil {
+			return nil, err
+		}
+		childPoints, err := getHistoryPoints(s.ElasticClient, s, child, timeRange, interval)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, point := range childPoints {
+			key := point.Time.UnixMilli()
+			aggregate, ok := aggregates[key]
+			if !ok {
+				aggregate = &compositeAggregate{passing: true}
+				aggregates[key] = aggregate
+				points = append(points, &elasticModel.SloHistoryPoint{Time: point.Time})
+			}
+			if value := budgetValue(metricType, point); value != nil {
+				aggregate.add(*value, target, link.Weight)
+			}
+		}
+	}
+
+	for _, point := range points {
+		point.SuccessRate = aggregates[point.Time.UnixMilli()].value(slo.Composite)
+	}
+	return points, nil
+}
+
+type compositeAggregate struct {
+	values   int
+	weighted float64
+	weights  float64
+	passing  bool
+}
+
+func (a *compositeAggregate) add(value, target, weight float64) {
+	a.values++
+	a.weighted += value * weight
+	a.weights += weight
+	a.passing = a.passing && value >= target
+}
+
+// value is the weighted average of the children, or 100 when all children met their target and 0 otherwise.
+func (a *compositeAggregate) value(aggregation model.CompositeAggregation) *float64 {
+	value := 0.0
+	if aggregation == model.CompositeAggregationAllMustPass {
+		if a.values == 0 {
+			return nil
+		}
+		if a.passing {
+			value = 100
+		}
+		return &value
+	}
+
+	if a.weights == 0 {
+		return nil
+	}
+	value = a.weighted / a.weights
+	return &value
+}
+
+// getHistoryPoints reads the history of composite SLOs from their children and of all
+// others from the SLO history index.
+func getHistoryPoints(elasticClient elastic.IClient, composite ICompositeSloService, slo *model.Slo,
+	timeRange *elasticModel.SloHistoryRange, interval string) ([]*elasticModel.SloHistoryPoint, error) {
+	if slo.IsComposite() {
+		return composite.GetHistory(slo, timeRange, interval)
+	}
+	return elasticClient.GetSloHistory(slo.ID, timeRange, interval)
+}
@@ -0,0 +1,192 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	client "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Composite slo service test", func() {
+	var mockController *gomock.Controller
+	var mockCompositeProvider *provider.MockICompositeSloProvider
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockAuthorizer *service.MockISloAuthorizer
+	var mockElasticClient *client.MockIClient
+	var mockDashboardService *service.MockIDashboardService
+	var compositeService *service.CompositeSloService
+	logger, logHook := logrustest.NewNullLogger()
+
+	const orgID int64 = 2
+	userContext := &auth.UserContext{ID: 3, Cookie: "cookie"}
+	value := func(v float64) *float64 { return &v }
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockCompositeProvider = provider.NewMockICompositeSloProvider(mockController)
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockAuthorizer = service.NewMockISloAuthorizer(mockController)
+		mockElasticClient = client.NewMockIClient(mockController)
+		mockDashboardService = service.NewMockIDashboardService(mockController)
+		compositeService = &service.CompositeSloService{
+			Provider:         mockCompositeProvider,
+			SloProvider:      mockISLOProvider,
+			Authorizer:       mockAuthorizer,
+			ElasticClient:    mockElasticClient,
+			DashboardService: mockDashboardService,
+			Log:              logger,
+		}
+		logHook.Reset()
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("ValidateChildren(userContext *auth.UserContext, slo *model.Slo)", func() {
+		Context("When a child contains the composite slo", func() {
+			It("Should return validation error", func() {
+				slo := &model.Slo{ID: 1, OrgID: orgID, Composite: model.CompositeAggregationAllMustPass,
+					Children: []*model.SloChild{{ChildID: 2}}}
+				mockISLOProvider.EXPECT().GetSlo(int64(2)).Return(&model.Slo{ID: 2, OrgID: orgID}, nil)
+				mockCompositeProvider.EXPECT().GetAllSloChildren().Return([]*model.SloChild{
+					{ParentID: 1, ChildID: 5},
+					{ParentID: 2, ChildID: 3},
+					{ParentID: 3, ChildID: 1},
+				}, nil)
+
+				err := compositeService.ValidateChildren(userContext, slo)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When a child belongs to an organization the user cannot view", func() {
+			It("Should return validation error", func() {
+				slo := &model.Slo{OrgID: orgID, Composite: model.CompositeAggregationWeightedAverage,
+					Children: []*model.SloChild{{ChildID: 2, Weight: 1}}}
+				mockISLOProvider.EXPECT().GetSlo(int64(2)).Return(&model.Slo{ID: 2, OrgID: 5}, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(2), userContext.ID, auth.Viewer).Return(false, nil)
+
+				err := compositeService.ValidateChildren(userContext, slo)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When slo is not composite but has children", func() {
+			It("Should return validation error", func() {
+				slo := &model.Slo{OrgID: orgID, Children: []*model.SloChild{{ChildID: 2}}}
+
+				err := compositeService.ValidateChildren(userContext, slo)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetParentsToUnlink(slo *model.Slo, cascade bool)", func() {
+		slo := &model.Slo{ID: 2, OrgID: orgID}
+
+		Context("When slo is a child and cascade is not set", func() {
+			It("Should return validation error", func() {
+				mockCompositeProvider.EXPECT().GetSloParents(int64(2)).Return([]*model.SloChild{{ParentID: 1, ChildID: 2}}, nil)
+
+				_, err := compositeService.GetParentsToUnlink(slo, false)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When slo is a child and cascade is set", func() {
+			It("Should return the parents", func() {
+				mockCompositeProvider.EXPECT().GetSloParents(int64(2)).Return([]*model.SloChild{{ParentID: 1, ChildID: 2}}, nil)
+
+				parents, err := compositeService.GetParentsToUnlink(slo, true)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parents).To(Equal([]*model.SloChild{{ParentID: 1, ChildID: 2}}))
+			})
+		})
+	})
+
+	Describe("UpdateParentDashboards(userContext *auth.UserContext, parents []*model.SloChild)", func() {
+		parent := &model.Slo{ID: 1, OrgID: orgID, Composite: model.CompositeAggregationAllMustPass}
+
+		It("Should render the parent dashboards again and log failures", func() {
+			mockISLOProvider.EXPECT().GetSlo(int64(1)).Return(parent, nil)
+			mockDashboardService.EXPECT().UpdateDashboard(userContext, parent).Return(errory.GrafanaClientErrors.New("grafana"))
+
+			compositeService.UpdateParentDashboards(userContext, []*model.SloChild{{ParentID: 1, ChildID: 2}})
+
+			Expect(logHook.LastEntry().Message).To(Equal("could not update composite dashboard after child deletion"))
+		})
+	})
+
+	Describe("GetHistory(slo *model.Slo, timeRange *elasticModel.SloHistoryRange, interval string)", func() {
+		timeRange := &elasticModel.SloHistoryRange{
+			From: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC),
+		}
+		day := func(d int) time.Time { return time.Date(2021, 3, d, 0, 0, 0, 0, time.UTC) }
+
+		expectChildren := func() {
+			mockCompositeProvider.EXPECT().GetSloChildren(int64(1)).Return([]*model.SloChild{
+				{ParentID: 1, ChildID: 2, Weight: 3},
+				{ParentID: 1, ChildID: 3, Weight: 1},
+			}, nil)
+			mockISLOProvider.EXPECT().GetSlo(int64(2)).Return(&model.Slo{ID: 2, SuccessRateExpectedAvailability: "99"}, nil)
+			mockISLOProvider.EXPECT().GetSlo(int64(3)).Return(&model.Slo{ID: 3, SuccessRateExpectedAvailability: "95"}, nil)
+			mockElasticClient.EXPECT().GetSloHistory(int64(2), timeRange, "1d").Return([]*elasticModel.SloHistoryPoint{
+				{Time: day(1), SuccessRate: value(100)},
+				{Time: day(2), SuccessRate: value(98)},
+				{Time: day(3)},
+			}, nil)
+			mockElasticClient.EXPECT().GetSloHistory(int64(3), timeRange, "1d").Return([]*elasticModel.SloHistoryPoint{
+				{Time: day(1), SuccessRate: value(96)},
+				{Time: day(2), SuccessRate: value(98)},
+				{Time: day(3)},
+			}, nil)
+		}
+
+		Context("When slo averages its children by weight", func() {
+			It("Should return the weighted average of the children", func() {
+				expectChildren()
+
+				points, err := compositeService.GetHistory(&model.Slo{ID: 1, Composite: model.CompositeAggregationWeightedAverage}, timeRange, "1d")
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(points).To(HaveLen(3))
+				Expect(*points[0].SuccessRate).To(BeNumerically("~", 99, 1e-9))
+				Expect(*points[1].SuccessRate).To(BeNumerically("~", 98, 1e-9))
+				Expect(points[2].SuccessRate).To(BeNil())
+			})
+		})
+
+		Context("When all children of the slo must pass", func() {
+			It("Should return 100 only for buckets in which every child met its target", func() {
+				expectChildren()
+
+				points, err := compositeService.GetHistory(&model.Slo{ID: 1, Composite: model.CompositeAggregationAllMustPass}, timeRange, "1d")
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*points[0].SuccessRate).To(Equal(100.0))
+				Expect(*points[1].SuccessRate).To(Equal(0.0))
+				Expect(points[2].SuccessRate).To(BeNil())
+			})
+		})
+	})
+})
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 
@@ -28,10 +31,22 @@ var urlRegexp = regexp.MustCompile(`^[^/]*(?:/[^/]*){2}`)
 type DashboardService struct {
 	DatasourceProvider provider.IDatasourceProvider
 	Grafana            grafana.IClient
+	Maintenance        IMaintenancePeriodService
+	SloProvider        provider.ISLOProvider
+	CompositeProvider  provider.ICompositeSloProvider
+	LabelProvider      provider.ISloLabelProvider
+	OwnershipProvider  provider.ISloOwnershipProvider
 	Log                logrus.FieldLogger
 	ResourcePath       string
 }
 
+// maintenanceAnnotation is a Grafana annotation marking maintenance on the dashboard.
+type maintenanceAnnotation struct {
+	Time    int64  `json:"time"`
+	TimeEnd int64  `json:"timeEnd"`
+	Text    string `json:"text"`
+}
+
 func (d *DashboardService) UpdateDashboard(userContext *auth.UserContext, slo *model.Slo) error {
 	return d.CreateDashboard(userContext, slo, true)
 }
@@ -56,7 +71,7 @@ func (d *DashboardService) CreateDashboard(userContext *auth.UserContext, slo *m
 }
 
 func (d *DashboardService) prepareDashboard(slo *model.Slo) (string, error) {
-	dashboardTemplate, err := loadDashboardTemplate(slo.ExternalType, d.ResourcePath)
+	dashboardTemplate, err := loadDashboardTemplate(slo, d.ResourcePath)
 	if err != nil {
 		return "", err
 	}
@@ -66,6 +81,13 @@ func (d *DashboardService) prepareDashboard(slo *model.Slo) (string, error) {
 		return "", err
 	}
 
+	if slo.Labels == nil {
+		labels, err := d.LabelProvider.GetSloLabels([]int64{slo.ID})
+		if err != nil {
+			return "", err
+		}
+		slo.Labels = labels[slo.ID]
+	}
 	tags, err := GetTags(slo)
 	if err != nil {
 		return "", err
@@ -78,24 +100,165 @@ func (d *DashboardService) prepareDashboard(slo *model.Slo) (string, error) {
 	}
 	datasourceLink = urlRegexp.FindString(datasource.URL)
 
+	now := time.Now()
+	window := slo.Window()
+	windowFrom, windowTo := window.Start(now), window.End(now)
+	timeFrom, timeTo := grafanaTimeRange(window)
+	children, err := d.compositeChildren(slo)
+	if err != nil {
+		return "", err
+	}
+
 	data, err := mustache.Render(string(dashboardTemplate), map[string]interface{}{
-		"ORG_ID":           strconv.FormatInt(slo.OrgID, 10),
-		"SLO_SUCCESS_RATE": slo.SuccessRateExpectedAvailability,
-		"SLO_COMPLIANCE":   slo.ComplianceExpectedAvailability,
-		"SLO_ID":           strconv.FormatInt(slo.ID, 10),
-		"DASHBOARD_TITLE":  sloName,
-		"TAGS":             tags,
-		"DASHBOARD_UID":    "eb-dash-" + strconv.FormatInt(slo.ID, 10),
-		"EXTERNAL_ID":      slo.ExternalID,
-		"DS_ID":            strconv.FormatInt(slo.DatasourceID, 10),
-		"DATADOG_URL":      datasourceLink,
+		"ORG_ID":               strconv.FormatInt(slo.OrgID, 10),
+		"SLO_SUCCESS_RATE":     slo.SuccessRateExpectedAvailability,
+		"SLO_COMPLIANCE":       slo.ComplianceExpectedAvailability,
+		"SLO_LATENCY":          slo.LatencyTarget,
+		"LATENCY_PERCENTILE":   strings.TrimPrefix(string(slo.LatencyPercentile), "p"),
+		"LATENCY_THRESHOLD_MS": strconv.FormatInt(slo.LatencyThresholdMs, 10),
+		"SLO_ID":               strconv.FormatInt(slo.ID, 10),
+		"DASHBOARD_TITLE":      sloName,
+		"TAGS":                 tags,
+		"DASHBOARD_UID":        "eb-dash-" + strconv.FormatInt(slo.ID, 10),
+		"EXTERNAL_ID":          slo.ExternalID,
+		"DS_ID":                strconv.FormatInt(slo.DatasourceID, 10),
+		"DATADOG_URL":          datasourceLink,
+		"TIME_FROM":            timeFrom,
+		"TIME_TO":              timeTo,
+		"WINDOW_DAYS":          strconv.Itoa(int(windowTo.Sub(windowFrom).Hours() / 24)),
+		"COMPOSITE":            string(slo.Composite),
+		"CHILDREN":             children,
 	})
 
 	if err != nil {
 		return "", err
 	}
 
-	return data, nil
+	return d.addSloMetadata(data, slo, windowFrom, windowTo)
+}
+
+// addSloMetadata adds the maintenance annotations, the ownership panel and the owner links to the
+// rendered dashboard. They are the same for every SLO type, so they are added here instead of in
+// each dashboard template.
+func (d *DashboardService) addSloMetadata(data string, slo *model.Slo, from, to time.Time) (string, error) {
+	annotations, err := d.maintenanceAnnotations(slo, from, to)
+	if err != nil {
+		return "", err
+	}
+	ownership, ownerLinks, err := d.ownershipPanel(slo)
+	if err != nil {
+		return "", err
+	}
+	if len(annotations) == 0 && ownership == "" {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var dashboard map[string]interface{}
+	if err = decoder.Decode(&dashboard); err != nil {
+		return "", errory.FetchResourceErrors.Wrap(err)
+	}
+
+	if len(annotations) > 0 {
+		list, _ := dashboard["annotations"].(map[string]interface{})
+		if list == nil {
+			list = map[string]interface{}{}
+			dashboard["annotations"] = list
+		}
+		entries, _ := list["list"].([]interface{})
+		list["list"] = append(entries, map[string]interface{}{
+			"datasource":  "-- Grafana --",
+			"enable":      true,
+			"iconColor":   "rgba(255, 152, 48, 1)",
+			"name":        "Maintenance",
+			"type":        "dashboard",
+			"annotations": annotations,
+		})
+	}
+
+	if ownership != "" {
+		links, _ := dashboard["links"].([]interface{})
+		for _, link := range ownerLinks {
+			links = append(links, link)
+		}
+		dashboard["links"] = links
+
+		panels, _ := dashboard["panels"].([]interface{})
+		nextID, bottom := panelLayout(panels)
+		dashboard["panels"] = append(panels, map[string]interface{}{
+			"datasource": nil,
+			"gridPos":    map[string]interface{}{"h": ownershipPanelHeight, "w": 24, "x": 0, "y": bottom},
+			"id":         nextID,
+			"options":    map[string]interface{}{"content": ownership, "mode": "markdown"},
+			"title":      "Ownership",
+			"type":       "text",
+		})
+	}
+
+	result, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", errory.FetchResourceErrors.Wrap(err)
+	}
+	return string(result), nil
+}
+
+// panelLayout returns the id and the grid row a panel added below all panels can use.
+func panelLayout(panels []interface{}) (nextID, bottom int64) {
+	nextID = 1
+	for _, panel := range panels {
+		fields, _ := panel.(map[string]interface{})
+		gridPos, _ := fields["gridPos"].(map[string]interface{})
+		id, y, h := jsonInt(fields["id"]), jsonInt(gridPos["y"]), jsonInt(gridPos["h"])
+		if id >= nextID {
+			nextID = id + 1
+		}
+		if y+h > bottom {
+			bottom = y + h
+		}
+	}
+	return nextID, bottom
+}
+
+// jsonInt returns the integer of a decoded JSON number, 0 for anything else.
+func jsonInt(value interface{}) int64 {
+	number, _ := value.(json.Number)
+	i, _ := number.Int64()
+	return i
+}
+
+// grafanaTimeRange returns the dashboard time range showing the window of the SLO.
+func grafanaTimeRange(window model.SloWindow) (string, string) {
+	if window.Type == model.SloWindowTypeRolling {
+		return "now-" + strconv.FormatInt(window.Days, 10) + "d", "now"
+	}
+	switch window.Period {
+	case model.CalendarPeriodWeek:
+		return "now/w", "now"
+	case model.CalendarPeriodQuarter:
+		return "now/fQ", "now"
+	default:
+		return "now/M", "now"
+	}
+}
+
+// maintenanceAnnotations returns the maintenance of the current window as Grafana annotations.
+// The dashboard is rendered again whenever maintenance windows change.
+func (d *DashboardService) maintenanceAnnotations(slo *model.Slo, from, to time.Time) ([]maintenanceAnnotation, error) {
+	periods, err := d.Maintenance.GetPeriodsForSlo(slo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations := []maintenanceAnnotation{}
+	for _, period := range periods {
+		annotations = append(annotations, maintenanceAnnotation{
+			Time:    period.From.UnixMilli(),
+			TimeEnd: period.To.UnixMilli(),
+			Text:    period.Text,
+		})
+	}
+	return annotations, nil
 }
 
 func jsonEscape(i string) (string, error) {
@@ -106,11 +269,108 @@ func jsonEscape(i string) (string, error) {
 	return string(b[1 : len(b)-1]), nil
 }
 
-func loadDashboardTemplate(exType model.ExternalSloType, pathToResourceDir string) (dashboardTemplate []byte, err error) {
-	if exType == model.ExternalSloTypeMonitor {
+// Child panels of composite dashboards are laid out in rows of compositeChildColumns below the objective.
+const (
+	compositeChildColumns = 3
+	compositeChildWidth   = 8
+	compositeChildHeight  = 8
+	compositeChildrenTop  = 4
+)
+
+const ownershipPanelHeight = 5
+
+// compositeChildren returns the children of a composite SLO as mustache context, each child
+// links to its own dashboard. FIRST allows the template to separate them by commas. Children
+// with a Datadog SLO get a stat panel on their own datasource, composite children and children
+// without ExternalID a text panel linking to their dashboard.
+func (d *DashboardService) compositeChildren(slo *model.Slo) ([]map[string]interface{}, error) {
+	children := []map[string]interface{}{}
+	if !slo.IsComposite() {
+		return children, nil
+	}
+
+	links, err := d.CompositeProvider.GetSloChildren(slo.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i, link := range links {
+		child, err := d.SloProvider.GetSlo(link.ChildID)
+		if err != nil {
+			return nil, err
+		}
+		name, err := jsonEscape(child.Name)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, map[string]interface{}{
+			"FIRST":               i == 0,
+			"CHILD_ID":            strconv.FormatInt(child.ID, 10),
+			"CHILD_NAME":          name,
+			"CHILD_EXTERNAL_ID":   child.ExternalID,
+			"CHILD_WEIGHT":        strconv.FormatFloat(link.Weight, 'f', -1, 64),
+			"CHILD_DASHBOARD_UID": "eb-dash-" + strconv.FormatInt(child.ID, 10),
+			"CHILD_STAT":          child.ExternalID != "" && !child.IsComposite(),
+			"DS_ID":               strconv.FormatInt(child.DatasourceID, 10),
+			"PANEL_ID":            strconv.Itoa(i + 2),
+			"PANEL_X":             strconv.Itoa(i % compositeChildColumns * compositeChildWidth),
+			"PANEL_Y":             strconv.Itoa(compositeChildrenTop + i/compositeChildColumns*compositeChildHeight),
+		})
+	}
+	return children, nil
+}
+
+// ownershipPanel returns the markdown of the ownership text panel and the dashboard links to
+// the runbook and url contacts, both are empty for SLOs without ownership.
+func (d *DashboardService) ownershipPanel(slo *model.Slo) (string, []map[string]interface{}, error) {
+	links := []map[string]interface{}{}
+	ownerships, err := d.OwnershipProvider.GetSloOwnerships([]int64{slo.ID})
+	if err != nil {
+		return "", nil, err
+	}
+	ownership := ownerships[slo.ID]
+	if ownership == nil {
+		return "", links, nil
+	}
+
+	addLink := func(title, icon, url string) {
+		links = append(links, map[string]interface{}{"icon": icon, "targetBlank": true, "title": title, "type": "link", "url": url})
+	}
+
+	owner := ownership.OwnerTeam
+	if owner == "" {
+		owner = "unknown"
+	}
+	lines := []string{"**Owner:** " + owner}
+	if len(ownership.Contacts) > 0 {
+		contacts := make([]string, 0, len(ownership.Contacts))
+		for _, contact := range ownership.Contacts {
+			contacts = append(contacts, string(contact.Type)+" "+contact.Value)
+			if contact.Type == model.SloContactTypeURL {
+				addLink("Contact", "external link", contact.Value)
+			}
+		}
+		lines = append(lines, "**Contacts:** "+strings.Join(contacts, ", "))
+	}
+	if ownership.RunbookURL != "" {
+		lines = append(lines, "**Runbook:** ["+ownership.RunbookURL+"]("+ownership.RunbookURL+")")
+		addLink("Runbook", "doc", ownership.RunbookURL)
+	}
+	if ownership.EscalationNotes != "" {
+		lines = append(lines, "**Escalation:** "+ownership.EscalationNotes)
+	}
+	return strings.Join(lines, "\n\n"), links, nil
+}
+
+func loadDashboardTemplate(slo *model.Slo, pathToResourceDir string) (dashboardTemplate []byte, err error) {
+	exType := slo.ExternalType
+	if slo.IsComposite() {
+		dashboardTemplate, err = ioutil.ReadFile(pathToResourceDir + "dashboard-template-composite.json.mustache")
+	} else if exType == model.ExternalSloTypeMonitor {
 		dashboardTemplate, err = ioutil.ReadFile(pathToResourceDir + "dashboard-template-dd-compliance.json.mustache")
 	} else if exType == model.ExternalSloTypeMetric {
 		dashboardTemplate, err = ioutil.ReadFile(pathToResourceDir + "dashboard-template-dd-success-rate.json.mustache")
+	} else if exType == model.ExternalSloTypeTimeSlice {
+		dashboardTemplate, err = ioutil.ReadFile(pathToResourceDir + "dashboard-template-dd-latency.json.mustache")
 	}
 	err = errory.FetchResourceErrors.Wrap(err)
 	return
@@ -120,8 +380,14 @@ func (d *DashboardService) DeleteDashboard(userContext *auth.UserContext, sloID,
 	return d.Grafana.DeleteDashboard(sloID, orgID, userContext.Cookie)
 }
 
+// GetTags returns the Grafana dashboard tags of the SLO, its labels are tagged as key:value.
 func GetTags(slo *model.Slo) (string, error) {
-	tagSlice := []string{"OMA", "SLO"}
+	labelTags := make([]string, 0, len(slo.Labels))
+	for key, value := range slo.Labels {
+		labelTags = append(labelTags, key+":"+value)
+	}
+	sort.Strings(labelTags)
+	tagSlice := append([]string{"OMA", "SLO"}, labelTags...)
 
 	tags, err := json.Marshal(tagSlice)
 	if err != nil {
@@ -4,8 +4,10 @@
 package service_test
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/grafana"
@@ -28,6 +30,10 @@ var _ = Describe("Dashboard service test", func() {
 	var mockController *gomock.Controller
 	var mockIGrafana *grafana.MockIClient
 	var mockIDatasourceProvider *provider.MockIDatasourceProvider
+	var mockMaintenance *service.MockIMaintenancePeriodService
+	var mockLabelProvider *provider.MockISloLabelProvider
+	var mockOwnershipProvider *provider.MockISloOwnershipProvider
+	var ownerships map[int64]*model.SloOwnership
 	logger, logHook := logrustest.NewNullLogger()
 
 	var dashboardService service.DashboardService
@@ -48,9 +54,20 @@ var _ = Describe("Dashboard service test", func() {
 		mockController = gomock.NewController(GinkgoT())
 		mockIDatasourceProvider = provider.NewMockIDatasourceProvider(mockController)
 		mockIGrafana = grafana.NewMockIClient(mockController)
+		mockMaintenance = service.NewMockIMaintenancePeriodService(mockController)
+		mockLabelProvider = provider.NewMockISloLabelProvider(mockController)
+		mockLabelProvider.EXPECT().GetSloLabels(gomock.Any()).Return(map[int64]map[string]string{}, nil).AnyTimes()
+		mockOwnershipProvider = provider.NewMockISloOwnershipProvider(mockController)
+		ownerships = map[int64]*model.SloOwnership{}
+		mockOwnershipProvider.EXPECT().GetSloOwnerships(gomock.Any()).DoAndReturn(func(sloIDs []int64) (map[int64]*model.SloOwnership, error) {
+			return ownerships, nil
+		}).AnyTimes()
 		dashboardService = service.DashboardService{
 			DatasourceProvider: mockIDatasourceProvider,
 			Grafana:            mockIGrafana,
+			Maintenance:        mockMaintenance,
+			LabelProvider:      mockLabelProvider,
+			OwnershipProvider:  mockOwnershipProvider,
 			Log:                logger,
 			ResourcePath:       "../resource/",
 		}
@@ -115,6 +132,7 @@ var _ = Describe("Dashboard service test", func() {
 					resultDashboard, err = ioutil.ReadFile(filepath.Join("testdata", resultDashboardFilename+".golden"))
 					Expect(err).ToNot(HaveOccurred())
 					mockIDatasourceProvider.EXPECT().GetDatasourceByID(int64(2)).Times(1).Return(&grafanaModel.Datasource{URL: "https://pf-metrodr.datadoghq.com//api/v1"}, nil)
+					mockMaintenance.EXPECT().GetPeriodsForSlo(&slo, gomock.Any(), gomock.Any()).Return([]model.MaintenancePeriod{}, nil)
 					mockIGrafana.EXPECT().GetFolders(slo.OrgID, cookie).Return(folders, nil)
 
 					mockIGrafana.EXPECT().CreateDashboard(gomock.Any(), folderID, slo.OrgID, overwrite, cookie).
@@ -149,6 +167,7 @@ var _ = Describe("Dashboard service test", func() {
 					resultDashboard, err = ioutil.ReadFile(filepath.Join("testdata", resultDashboardFilename+".golden"))
 					Expect(err).ToNot(HaveOccurred())
 					mockIDatasourceProvider.EXPECT().GetDatasourceByID(int64(2)).Times(1).Return(&grafanaModel.Datasource{URL: "https://pf-metrodr.datadoghq.com//api/v1"}, nil)
+					mockMaintenance.EXPECT().GetPeriodsForSlo(&slo, gomock.Any(), gomock.Any()).Return([]model.MaintenancePeriod{}, nil)
 					mockIGrafana.EXPECT().GetFolders(slo.OrgID, cookie).Return([]*grafanaModel.Folder{}, nil)
 					mockIGrafana.EXPECT().CreateFolder(slo.OrgID, cookie, "SLOs").Return(&grafanaModel.Folder{
 						ID: 20,
@@ -172,6 +191,160 @@ var _ = Describe("Dashboard service test", func() {
 		})
 	})
 
+	Describe("CreateDashboard() with ownership", func() {
+		BeforeEach(func() {
+			slo = model.Slo{
+				ID:                 1,
+				OrgID:              2,
+				DatasourceID:       2,
+				Name:               "TestSLO",
+				ExternalID:         "ALAMAKOTA",
+				ExternalType:       model.ExternalSloTypeTimeSlice,
+				LatencyTarget:      "99",
+				LatencyPercentile:  model.LatencyPercentileP99,
+				LatencyThresholdMs: 300,
+			}
+			ownerships[1] = &model.SloOwnership{
+				OwnerTeam:       "checkout",
+				Contacts:        []model.SloContact{{Type: model.SloContactTypeSlack, Value: "#checkout"}, {Type: model.SloContactTypeURL, Value: "https://status.example.com"}},
+				RunbookURL:      "https://wiki.example.com/runbook",
+				EscalationNotes: "page \"on-call\"",
+			}
+		})
+
+		It("Should render the ownership panel and links", func() {
+			mockIDatasourceProvider.EXPECT().GetDatasourceByID(int64(2)).Return(&grafanaModel.Datasource{URL: "https://pf-metrodr.datadoghq.com//api/v1"}, nil)
+			mockMaintenance.EXPECT().GetPeriodsForSlo(&slo, gomock.Any(), gomock.Any()).Return([]model.MaintenancePeriod{}, nil)
+			mockIGrafana.EXPECT().GetFolders(slo.OrgID, cookie).Return([]*grafanaModel.Folder{{ID: 20, Title: "SLOs"}}, nil)
+			mockIGrafana.EXPECT().CreateDashboard(gomock.Any(), int64(20), slo.OrgID, false, cookie).
+				DoAndReturn(func(dashboard string, folderID, orgID int64, overwrite bool, cookie string) (*grafanaModel.DashboardIDDTO, error) {
+					var rendered struct {
+						Links  []map[string]interface{} `json:"links"`
+						Panels []map[string]interface{} `json:"panels"`
+					}
+					Expect(json.Unmarshal([]byte(dashboard), &rendered)).To(Succeed())
+					Expect(rendered.Links).To(HaveLen(3))
+					Expect(rendered.Links[1]["url"]).To(Equal("https://status.example.com"))
+					Expect(rendered.Links[2]["url"]).To(Equal("https://wiki.example.com/runbook"))
+					panel := rendered.Panels[len(rendered.Panels)-1]
+					Expect(panel["title"]).To(Equal("Ownership"))
+					Expect(panel["options"].(map[string]interface{})["content"]).To(Equal("**Owner:** checkout\n\n" +
+						"**Contacts:** slack #checkout, url https://status.example.com\n\n" +
+						"**Runbook:** [https://wiki.example.com/runbook](https://wiki.example.com/runbook)\n\n" +
+						"**Escalation:** page \"on-call\""))
+					return nil, nil
+				})
+
+			err := dashboardService.CreateDashboard(&userContext, &slo, false)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("CreateDashboard() with maintenance", func() {
+		BeforeEach(func() {
+			slo = model.Slo{
+				ID:                 1,
+				OrgID:              2,
+				DatasourceID:       2,
+				Name:               "TestSLO",
+				ExternalID:         "ALAMAKOTA",
+				ExternalType:       model.ExternalSloTypeTimeSlice,
+				LatencyTarget:      "99",
+				LatencyPercentile:  model.LatencyPercentileP99,
+				LatencyThresholdMs: 300,
+			}
+		})
+
+		It("Should add the maintenance periods as annotations", func() {
+			from := time.Date(2021, 3, 2, 10, 0, 0, 0, time.UTC)
+			mockIDatasourceProvider.EXPECT().GetDatasourceByID(int64(2)).Return(&grafanaModel.Datasource{URL: "https://pf-metrodr.datadoghq.com//api/v1"}, nil)
+			mockMaintenance.EXPECT().GetPeriodsForSlo(&slo, gomock.Any(), gomock.Any()).
+				Return([]model.MaintenancePeriod{{From: from, To: from.Add(time.Hour), Text: "database upgrade"}}, nil)
+			mockIGrafana.EXPECT().GetFolders(slo.OrgID, cookie).Return([]*grafanaModel.Folder{{ID: 20, Title: "SLOs"}}, nil)
+			mockIGrafana.EXPECT().CreateDashboard(gomock.Any(), int64(20), slo.OrgID, false, cookie).
+				DoAndReturn(func(dashboard string, folderID, orgID int64, overwrite bool, cookie string) (*grafanaModel.DashboardIDDTO, error) {
+					var rendered struct {
+						Annotations struct {
+							List []map[string]interface{} `json:"list"`
+						} `json:"annotations"`
+					}
+					Expect(json.Unmarshal([]byte(dashboard), &rendered)).To(Succeed())
+					Expect(rendered.Annotations.List).To(HaveLen(2))
+					Expect(rendered.Annotations.List[1]["name"]).To(Equal("Maintenance"))
+					Expect(rendered.Annotations.List[1]["annotations"]).To(Equal([]interface{}{map[string]interface{}{
+						"time": float64(from.UnixMilli()), "timeEnd": float64(from.Add(time.Hour).UnixMilli()), "text": "database upgrade",
+					}}))
+					return nil, nil
+				})
+
+			err := dashboardService.CreateDashboard(&userContext, &slo, false)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("CreateDashboard() for composite slo", func() {
+		var mockSloProvider *provider.MockISLOProvider
+		var mockCompositeProvider *provider.MockICompositeSloProvider
+
+		BeforeEach(func() {
+			mockSloProvider = provider.NewMockISLOProvider(mockController)
+			mockCompositeProvider = provider.NewMockICompositeSloProvider(mockController)
+			dashboardService.SloProvider = mockSloProvider
+			dashboardService.CompositeProvider = mockCompositeProvider
+			slo = model.Slo{
+				ID:                              1,
+				OrgID:                           2,
+				DatasourceID:                    2,
+				Name:                            "Checkout",
+				SuccessRateExpectedAvailability: "99.5",
+				Composite:                       model.CompositeAggregationWeightedAverage,
+			}
+		})
+
+		It("Should render a panel per child on its own datasource and position", func() {
+			mockIDatasourceProvider.EXPECT().GetDatasourceByID(int64(2)).Return(&grafanaModel.Datasource{URL: "https://pf-metrodr.datadoghq.com//api/v1"}, nil)
+			mockMaintenance.EXPECT().GetPeriodsForSlo(&slo, gomock.Any(), gomock.Any()).Return([]model.MaintenancePeriod{}, nil)
+			mockCompositeProvider.EXPECT().GetSloChildren(slo.ID).Return([]*model.SloChild{
+				{ParentID: 1, ChildID: 11, Weight: 2}, {ParentID: 1, ChildID: 12, Weight: 1},
+				{ParentID: 1, ChildID: 13, Weight: 1}, {ParentID: 1, ChildID: 14, Weight: 1},
+			}, nil)
+			mockSloProvider.EXPECT().GetSlo(int64(11)).Return(&model.Slo{ID: 11, Name: "Cart", DatasourceID: 5, ExternalID: "abc"}, nil)
+			mockSloProvider.EXPECT().GetSlo(int64(12)).Return(&model.Slo{ID: 12, Name: "Payment", DatasourceID: 6, ExternalID: "def"}, nil)
+			mockSloProvider.EXPECT().GetSlo(int64(13)).Return(&model.Slo{ID: 13, Name: "Shop", DatasourceID: 2,
+				Composite: model.CompositeAggregationAllMustPass}, nil)
+			mockSloProvider.EXPECT().GetSlo(int64(14)).Return(&model.Slo{ID: 14, Name: "Search", DatasourceID: 7}, nil)
+			mockIGrafana.EXPECT().GetFolders(slo.OrgID, cookie).Return([]*grafanaModel.Folder{{ID: 20, Title: "SLOs"}}, nil)
+			mockIGrafana.EXPECT().CreateDashboard(gomock.Any(), int64(20), slo.OrgID, false, cookie).
+				DoAndReturn(func(dashboard string, folderID, orgID int64, overwrite bool, cookie string) (*grafanaModel.DashboardIDDTO, error) {
+					var rendered struct {
+						Panels []struct {
+							Type       string                 `json:"type"`
+							Datasource map[string]interface{} `json:"datasource"`
+							GridPos    map[string]float64     `json:"gridPos"`
+						} `json:"panels"`
+					}
+					Expect(json.Unmarshal([]byte(dashboard), &rendered)).To(Succeed())
+					Expect(rendered.Panels).To(HaveLen(5))
+					Expect(rendered.Panels[1].Type).To(Equal("stat"))
+					Expect(rendered.Panels[1].Datasource["id"]).To(Equal(float64(5)))
+					Expect(rendered.Panels[1].GridPos).To(Equal(map[string]float64{"h": 8, "w": 8, "x": 0, "y": 4}))
+					Expect(rendered.Panels[2].Datasource["id"]).To(Equal(float64(6)))
+					Expect(rendered.Panels[2].GridPos).To(Equal(map[string]float64{"h": 8, "w": 8, "x": 8, "y": 4}))
+					Expect(rendered.Panels[3].Type).To(Equal("text"))
+					Expect(rendered.Panels[3].GridPos).To(Equal(map[string]float64{"h": 8, "w": 8, "x": 16, "y": 4}))
+					Expect(rendered.Panels[4].Type).To(Equal("text"))
+					Expect(rendered.Panels[4].GridPos).To(Equal(map[string]float64{"h": 8, "w": 8, "x": 0, "y": 12}))
+					return nil, nil
+				})
+
+			err := dashboardService.CreateDashboard(&userContext, &slo, false)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	Describe("DeleteDashboard()", func() {
 		sloIdToDelete := int64(2)
 		orgIdToDelete := int64(3)
@@ -193,5 +366,11 @@ var _ = Describe("Dashboard service test", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(tags).To(Equal(`["OMA","SLO"]`))
 		})
+		It("Should add the labels as sorted key:value tags", func() {
+			slo.Labels = map[string]string{"tier": "1", "team": "checkout"}
+			tags, err := service.GetTags(&slo)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tags).To(Equal(`["OMA","SLO","team:checkout","tier:1"]`))
+		})
 	})
 })
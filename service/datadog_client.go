@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+)
+
+type IDatadogClientFactory interface {
+	ForDatasource(datasourceID int64) (datadog.IClient, error)
+}
+
+// DatadogClientFactory creates Datadog clients calling Datadog with the keys of a Datadog datasource,
+// so every organization only sees the Datadog SLOs of its own Datadog account.
+type DatadogClientFactory struct {
+	Credentials provider.IDatadogCredentialsProvider
+	BaseURL     string
+}
+
+func (f *DatadogClientFactory) ForDatasource(datasourceID int64) (datadog.IClient, error) {
+	credentials, err := f.Credentials.GetDatadogCredentials(datasourceID)
+	if err != nil {
+		return nil, errory.Decorate(err, "datadog client")
+	}
+	client, err := datadog.New(f.BaseURL, credentials.APIKey, credentials.AppKey)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
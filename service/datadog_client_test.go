@@ -0,0 +1,83 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"net/http"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Datadog client factory test", func() {
+	var mockController *gomock.Controller
+	var mockCredentials *provider.MockIDatadogCredentialsProvider
+	var server *ghttp.Server
+	var factory *service.DatadogClientFactory
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockCredentials = provider.NewMockIDatadogCredentialsProvider(mockController)
+		server = ghttp.NewServer()
+		factory = &service.DatadogClientFactory{Credentials: mockCredentials, BaseURL: server.URL()}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockController.Finish()
+	})
+
+	Describe("ForDatasource(datasourceID int64)", func() {
+		Context("When two datasources have different keys", func() {
+			It("Should call datadog with the keys of the datasource of each client", func() {
+				mockCredentials.EXPECT().GetDatadogCredentials(int64(5)).Return(&datadogModel.Credentials{APIKey: "api-5", AppKey: "app-5"}, nil)
+				mockCredentials.EXPECT().GetDatadogCredentials(int64(6)).Return(&datadogModel.Credentials{APIKey: "api-6", AppKey: "app-6"}, nil)
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/slo/abc"),
+						ghttp.VerifyHeaderKV("DD-API-KEY", "api-5"),
+						ghttp.VerifyHeaderKV("DD-APPLICATION-KEY", "app-5"),
+						ghttp.RespondWith(http.StatusOK, `{"data": {"id": "abc", "type": "monitor"}}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/slo/abc"),
+						ghttp.VerifyHeaderKV("DD-API-KEY", "api-6"),
+						ghttp.VerifyHeaderKV("DD-APPLICATION-KEY", "app-6"),
+						ghttp.RespondWith(http.StatusNotFound, `{"errors": ["Not found"]}`),
+					),
+				)
+
+				first, err := factory.ForDatasource(5)
+				Expect(err).NotTo(HaveOccurred())
+				second, err := factory.ForDatasource(6)
+				Expect(err).NotTo(HaveOccurred())
+
+				slo, err := first.GetSLO("abc")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slo.ID).To(Equal("abc"))
+				_, err = second.GetSLO("abc")
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("When the keys of the datasource cannot be read", func() {
+			It("Should return the error", func() {
+				mockCredentials.EXPECT().GetDatadogCredentials(int64(5)).Return(nil, errory.NotFoundErrors.New("datasource not found"))
+
+				client, err := factory.ForDatasource(5)
+
+				Expect(client).To(BeNil())
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+	})
+})
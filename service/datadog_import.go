@@ -0,0 +1,159 @@
+package service
+
+import (
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	grafana "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	datadogTargetTimeframe = "30d"
+	maxSloNameLength       = 188
+	sloTargetPrecision     = 3
+)
+
+type IDatadogImportService interface {
+	Discover(orgID, dsID int64) ([]*datadogModel.SLO, error)
+	Import(userContext *auth.UserContext, orgID, dsID int64, sloImport *model.SloImport) ([]*model.SloImportResult, error)
+}
+
+type DatadogImportService struct {
+	SloService     ISloService
+	SloProvider    provider.ISLOProvider
+	DSProvider     provider.IDatasourceProvider
+	DatadogClients IDatadogClientFactory
+	Log            logrus.FieldLogger
+}
+
+// Discover lists the Datadog SLOs of the account the datasource has the keys of which are not linked to
+// any SLO of the organization on the datasource.
+func (s *DatadogImportService) Discover(orgID, dsID int64) ([]*datadogModel.SLO, error) {
+	return s.getUnlinkedSLOs(orgID, dsID)
+}
+
+// Import creates an SLO with dashboards for each of the given Datadog SLOs, one failing
+// SLO does not stop the others and is reported in its result.
+func (s *DatadogImportService) Import(userContext *auth.UserContext, orgID, dsID int64, sloImport *model.SloImport) ([]*model.SloImportResult, error) {
+	unlinked, err := s.getUnlinkedSLOs(orgID, dsID)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]*datadogModel.SLO{}
+	for _, slo := range unlinked {
+		byID[slo.ID] = slo
+	}
+
+	results := []*model.SloImportResult{}
+	for _, externalID := range sloImport.ExternalIDs {
+		result := &model.SloImportResult{ExternalID: externalID}
+		results = append(results, result)
+
+		ddSlo, ok := byID[externalID]
+		if !ok {
+			result.Error = "datadog slo does not exist or is already linked"
+			continue
+		}
+		delete(byID, externalID)
+
+		slo, err := newImportedSlo(ddSlo, orgID, dsID, sloImport.Critical)
+		if err == nil {
+			err = s.SloService.Create(userContext, slo)
+		}
+		if err != nil {
+			result.Error = errory.GetErrorDetails(err).Message
+			s.Log.WithError(err).WithField("externalId", externalID).Warn("could not import datadog slo")
+			continue
+		}
+		result.ID = slo.ID
+	}
+
+	return results, nil
+}
+
+func (s *DatadogImportService) getUnlinkedSLOs(orgID, dsID int64) ([]*datadogModel.SLO, error) {
+	datasources, err := s.DSProvider.GetDatasourcesByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	var ds *grafana.Datasource
+	for _, cur := range datasources {
+		if cur.ID == dsID {
+			ds = cur
+			break
+		}
+	}
+	if ds == nil {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("datasource", dsID).Create()
+	}
+	if ds.Type != grafana.DatasourceTypeDatadog {
+		return nil, errory.ValidationErrors.Builder().WithPayload("datasource", dsID).WithMessage("datasource is not a datadog datasource").Create()
+	}
+
+	slos, err := s.SloProvider.GetSlosByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	linked := map[string]bool{}
+	for _, slo := range slos {
+		if slo.DatasourceID == dsID && slo.ExternalID != "" {
+			linked[slo.ExternalID] = true
+		}
+	}
+
+	client, err := s.DatadogClients.ForDatasource(dsID)
+	if err != nil {
+		return nil, errory.Decorate(err, "datadog import")
+	}
+	ddSlos, err := client.ListSLOs()
+	if err != nil {
+		return nil, errory.Decorate(err, "datadog import")
+	}
+	unlinked := []*datadogModel.SLO{}
+	for _, ddSlo := range ddSlos {
+		if !linked[ddSlo.ID] {
+			unlinked = append(unlinked, ddSlo)
+		}
+	}
+	return unlinked, nil
+}
+
+// newImportedSlo prefills both targets with the 30 days target of the Datadog SLO,
+// or with its first target when there is none for 30 days. Time slice SLOs are not imported
+// as their latency percentile and threshold cannot be taken over from Datadog.
+func newImportedSlo(ddSlo *datadogModel.SLO, orgID, dsID int64, critical bool) (*model.Slo, error) {
+	if ddSlo.Type == datadogModel.SLOTypeTimeSlice {
+		return nil, errory.ValidationErrors.Builder().WithPayload("externalId", ddSlo.ID).
+			WithMessage("datadog time slice slo has to be created as latency slo").Create()
+	}
+	threshold := ddSlo.Threshold(datadogTargetTimeframe)
+	if threshold == nil {
+		return nil, errory.ValidationErrors.Builder().WithPayload("externalId", ddSlo.ID).WithMessage("datadog slo has no target").Create()
+	}
+	target := decimal.NewFromFloat(threshold.Target).Round(sloTargetPrecision).String()
+
+	externalType := model.ExternalSloTypeMetric
+	if ddSlo.Type == datadogModel.SLOTypeMonitor {
+		externalType = model.ExternalSloTypeMonitor
+	}
+
+	name := []rune(ddSlo.Name)
+	if len(name) > maxSloNameLength {
+		name = name[:maxSloNameLength]
+	}
+
+	return &model.Slo{
+		OrgID:                           orgID,
+		Name:                            string(name),
+		SuccessRateExpectedAvailability: target,
+		ComplianceExpectedAvailability:  target,
+		Critical:                        critical,
+		DatasourceID:                    dsID,
+		ExternalID:                      ddSlo.ID,
+		ExternalType:                    externalType,
+	}, nil
+}
@@ -0,0 +1,195 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Datadog import service test", func() {
+	var mockController *gomock.Controller
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockIDatasourceProvider *provider.MockIDatasourceProvider
+	var mockSloService *service.MockISloService
+	var mockDatadog *datadog.MockIClient
+	var mockDatadogClients *service.MockIDatadogClientFactory
+	var importService *service.DatadogImportService
+	logger, _ := logrustest.NewNullLogger()
+
+	const orgID, dsID int64 = 2, 5
+	userContext := &auth.UserContext{ID: 3}
+	monitorSlo := &datadogModel.SLO{ID: "abc", Name: "Checkout availability", Type: "monitor",
+		Thresholds: []datadogModel.SLOThreshold{{Timeframe: "7d", Target: 99}, {Timeframe: "30d", Target: 99.95}}}
+	metricSlo := &datadogModel.SLO{ID: "def", Name: "Checkout success rate", Type: "metric",
+		Thresholds: []datadogModel.SLOThreshold{{Timeframe: "7d", Target: 99.12345}}}
+	linkedSlo := &datadogModel.SLO{ID: "ghi", Name: "Already linked", Type: "metric"}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockIDatasourceProvider = provider.NewMockIDatasourceProvider(mockController)
+		mockSloService = service.NewMockISloService(mockController)
+		mockDatadog = datadog.NewMockIClient(mockController)
+		mockDatadogClients = service.NewMockIDatadogClientFactory(mockController)
+
+		importService = &service.DatadogImportService{
+			SloService:     mockSloService,
+			SloProvider:    mockISLOProvider,
+			DSProvider:     mockIDatasourceProvider,
+			DatadogClients: mockDatadogClients,
+			Log:            logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	expectDatadogDatasource := func() {
+		mockIDatasourceProvider.EXPECT().GetDatasourcesByOrganizationID(orgID).Return([]*grafana.Datasource{
+			{ID: 4, Type: "elasticsearch"},
+			{ID: dsID, Type: grafana.DatasourceTypeDatadog},
+		}, nil)
+	}
+	expectLinkedSlos := func() {
+		mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{
+			{ID: 1, DatasourceID: dsID, ExternalID: "ghi"},
+			{ID: 2, DatasourceID: 6, ExternalID: "abc"},
+		}, nil)
+		mockDatadogClients.EXPECT().ForDatasource(dsID).Return(mockDatadog, nil)
+		mockDatadog.EXPECT().ListSLOs().Return([]*datadogModel.SLO{monitorSlo, metricSlo, linkedSlo}, nil)
+	}
+
+	Describe("Discover(orgID, dsID int64)", func() {
+		Context("Everything is OK", func() {
+			It("Should return datadog slos not linked on the datasource", func() {
+				expectDatadogDatasource()
+				expectLinkedSlos()
+
+				slos, err := importService.Discover(orgID, dsID)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slos).To(Equal([]*datadogModel.SLO{monitorSlo, metricSlo}))
+			})
+		})
+
+		Context("When datasource does not belong to the organization", func() {
+			It("Should return not found error", func() {
+				mockIDatasourceProvider.EXPECT().GetDatasourcesByOrganizationID(orgID).Return([]*grafana.Datasource{{ID: 4}}, nil)
+
+				_, err := importService.Discover(orgID, dsID)
+
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When the organization has two datadog datasources", func() {
+			It("Should list the datadog slos with the client of the requested datasource", func() {
+				otherDatadog := datadog.NewMockIClient(mockController)
+				mockIDatasourceProvider.EXPECT().GetDatasourcesByOrganizationID(orgID).Return([]*grafana.Datasource{
+					{ID: dsID, Type: grafana.DatasourceTypeDatadog},
+					{ID: 6, Type: grafana.DatasourceTypeDatadog},
+				}, nil)
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{
+					{ID: 2, DatasourceID: dsID, ExternalID: "abc"},
+				}, nil)
+				mockDatadogClients.EXPECT().ForDatasource(int64(6)).Return(otherDatadog, nil)
+				otherDatadog.EXPECT().ListSLOs().Return([]*datadogModel.SLO{monitorSlo}, nil)
+
+				slos, err := importService.Discover(orgID, 6)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slos).To(Equal([]*datadogModel.SLO{monitorSlo}))
+			})
+		})
+
+		Context("When the keys of the datasource cannot be read", func() {
+			It("Should return the error", func() {
+				expectDatadogDatasource()
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{}, nil)
+				mockDatadogClients.EXPECT().ForDatasource(dsID).Return(nil, errory.ValidationErrors.New("no keys"))
+
+				_, err := importService.Discover(orgID, dsID)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When datasource is not a datadog datasource", func() {
+			It("Should return validation error", func() {
+				mockIDatasourceProvider.EXPECT().GetDatasourcesByOrganizationID(orgID).Return([]*grafana.Datasource{{ID: dsID, Type: "prometheus"}}, nil)
+
+				_, err := importService.Discover(orgID, dsID)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Import(userContext, orgID, dsID int64, sloImport *model.SloImport)", func() {
+		It("Should create slos with prefilled targets and report a result per datadog slo", func() {
+			expectDatadogDatasource()
+			expectLinkedSlos()
+			mockSloService.EXPECT().Create(userContext, gomock.Any()).DoAndReturn(func(_ *auth.UserContext, slo *model.Slo) error {
+				Expect(*slo).To(Equal(model.Slo{
+					OrgID:                           orgID,
+					Name:                            "Checkout availability",
+					SuccessRateExpectedAvailability: "99.95",
+					ComplianceExpectedAvailability:  "99.95",
+					Critical:                        true,
+					DatasourceID:                    dsID,
+					ExternalID:                      "abc",
+					ExternalType:                    model.ExternalSloTypeMonitor,
+				}))
+				slo.ID = 10
+				return nil
+			})
+			mockSloService.EXPECT().Create(userContext, gomock.Any()).DoAndReturn(func(_ *auth.UserContext, slo *model.Slo) error {
+				Expect(slo.SuccessRateExpectedAvailability).To(Equal("99.123"))
+				Expect(slo.ExternalType).To(Equal(model.ExternalSloTypeMetric))
+				return errory.NotUniqueErrors.New("name")
+			})
+
+			results, err := importService.Import(userContext, orgID, dsID, &model.SloImport{
+				ExternalIDs: []string{"abc", "def", "ghi", "abc"},
+				Critical:    true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(4))
+			Expect(*results[0]).To(Equal(model.SloImportResult{ExternalID: "abc", ID: 10}))
+			Expect(results[1].ID).To(BeZero())
+			Expect(results[1].Error).NotTo(BeEmpty())
+			Expect(results[2].Error).To(Equal("datadog slo does not exist or is already linked"))
+			Expect(results[3].Error).To(Equal("datadog slo does not exist or is already linked"))
+		})
+
+		It("Should not import time slice slos", func() {
+			timeSliceSlo := &datadogModel.SLO{ID: "jkl", Name: "Checkout latency", Type: datadogModel.SLOTypeTimeSlice,
+				Thresholds: []datadogModel.SLOThreshold{{Timeframe: "30d", Target: 99}}}
+			expectDatadogDatasource()
+			mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{}, nil)
+			mockDatadogClients.EXPECT().ForDatasource(dsID).Return(mockDatadog, nil)
+			mockDatadog.EXPECT().ListSLOs().Return([]*datadogModel.SLO{timeSliceSlo}, nil)
+
+			results, err := importService.Import(userContext, orgID, dsID, &model.SloImport{ExternalIDs: []string{"jkl"}})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results[0].ID).To(BeZero())
+			Expect(results[0].Error).To(ContainSubstring("datadog time slice slo has to be created as latency slo"))
+		})
+	})
+})
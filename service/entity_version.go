@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+type IEntityVersionService interface {
+	GetVersion(entity model.VersionedEntity, id int64) (int64, error)
+	Verify(entity model.VersionedEntity, id int64, version *int64) error
+	Conflict(entity model.VersionedEntity, id int64, version *int64) error
+}
+
+// EntityVersionService guards changes of SLOs and happiness metrics against overwriting changes
+// made since the client read the entity. A nil version stands for If-Match: * and matches any.
+type EntityVersionService struct {
+	Provider provider.IEntityVersionProvider
+	Log      logrus.FieldLogger
+}
+
+func (s *EntityVersionService) GetVersion(entity model.VersionedEntity, id int64) (int64, error) {
+	return s.Provider.GetVersion(entity, id)
+}
+
+// Verify checks that the entity still has the version without changing it, e.g. before a change
+// is only requested.
+func (s *EntityVersionService) Verify(entity model.VersionedEntity, id int64, version *int64) error {
+	current, err := s.Provider.GetVersion(entity, id)
+	if err != nil {
+		return errory.Decorate(err, "entity version service verify()")
+	}
+	if version != nil && *version != current {
+		return versionMismatch(entity, id, *version, current)
+	}
+	return nil
+}
+
+// Conflict returns the error of a versioned update that changed nothing, as the entity was
+// changed meanwhile or does not exist anymore.
+func (s *EntityVersionService) Conflict(entity model.VersionedEntity, id int64, version *int64) error {
+	current, err := s.Provider.GetVersion(entity, id)
+	if err != nil {
+		return errory.Decorate(err, "entity version service conflict()")
+	}
+	if version == nil {
+		return errory.NotFoundErrors.Builder().WithPayload(string(entity), id).Create()
+	}
+	return versionMismatch(entity, id, *version, current)
+}
+
+func versionMismatch(entity model.VersionedEntity, id, version, current int64) error {
+	return errory.PreconditionFailedErrors.Builder().WithPayload(string(entity), id).WithPayload("version", current).
+		WithMessage(fmt.Sprintf("%s %d was changed meanwhile, If-Match has version %d but the current version is %d",
+			entity, id, version, current)).Create()
+}
@@ -0,0 +1,76 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Entity version service test", func() {
+	var mockController *gomock.Controller
+	var mockVersionProvider *provider.MockIEntityVersionProvider
+	var versionService *service.EntityVersionService
+	logger, _ := logrustest.NewNullLogger()
+	version := int64(4)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockVersionProvider = provider.NewMockIEntityVersionProvider(mockController)
+		versionService = &service.EntityVersionService{Provider: mockVersionProvider, Log: logger}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Verify()", func() {
+		It("Should accept the current version", func() {
+			mockVersionProvider.EXPECT().GetVersion(model.VersionedEntitySlo, int64(7)).Return(int64(4), nil)
+
+			Expect(versionService.Verify(model.VersionedEntitySlo, 7, &version)).To(Succeed())
+		})
+
+		It("Should accept any version for If-Match *", func() {
+			mockVersionProvider.EXPECT().GetVersion(model.VersionedEntitySlo, int64(7)).Return(int64(9), nil)
+
+			Expect(versionService.Verify(model.VersionedEntitySlo, 7, nil)).To(Succeed())
+		})
+
+		It("Should return precondition failed error for an outdated version", func() {
+			mockVersionProvider.EXPECT().GetVersion(model.VersionedEntitySlo, int64(7)).Return(int64(5), nil)
+
+			err := versionService.Verify(model.VersionedEntitySlo, 7, &version)
+
+			Expect(errory.IsOfType(err, errory.PreconditionFailedErrors)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("If-Match has version 4 but the current version is 5"))
+		})
+	})
+
+	Describe("Conflict()", func() {
+		It("Should return precondition failed error when the entity was changed meanwhile", func() {
+			mockVersionProvider.EXPECT().GetVersion(model.VersionedEntityHappinessMetric, int64(7)).Return(int64(5), nil)
+
+			err := versionService.Conflict(model.VersionedEntityHappinessMetric, 7, &version)
+
+			Expect(errory.IsOfType(err, errory.PreconditionFailedErrors)).To(BeTrue())
+		})
+
+		It("Should return not found error when the entity does not exist", func() {
+			mockVersionProvider.EXPECT().GetVersion(model.VersionedEntitySlo, int64(7)).
+				Return(int64(0), errory.NotFoundErrors.New("slo"))
+
+			err := versionService.Conflict(model.VersionedEntitySlo, 7, nil)
+
+			Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+		})
+	})
+})
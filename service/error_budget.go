@@ -0,0 +1,407 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	elastic "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	budgetInterval  = "1d"
+	maxForecastDays = 3650
+)
+
+type IErrorBudgetService interface {
+	GetErrorBudget(sloID int64, now time.Time) (*model.ErrorBudget, error)
+	GetForecast(sloID int64, now time.Time, lookbackDays int) (*model.BudgetForecast, error)
+	GetAtRiskSlos(orgID int64, now time.Time, lookbackDays int) ([]*model.BudgetForecast, error)
+	Simulate(sloID int64, simulation *model.SloSimulation) (*model.SloSimulationResult, error)
+	GetCompliance(slo *model.Slo, from, to time.Time) (*model.SloCompliance, error)
+	GetSlaBreach(slo *model.Slo, from, to time.Time) (*model.SlaBreach, error)
+}
+
+// ErrorBudgetService calculates error budgets from the daily SLO history. Forecasts look back
+// ForecastLookbackDays unless another look-back is requested, ForecastEwmaAlpha is the weight
+// of the latest day in the exponentially weighted burn rate.
+type ErrorBudgetService struct {
+	SloProvider          provider.ISLOProvider
+	ElasticClient        elastic.IClient
+	Maintenance          IMaintenancePeriodService
+	Composite            ICompositeSloService
+	ForecastLookbackDays int
+	ForecastEwmaAlpha    float64
+	Log                  logrus.FieldLogger
+}
+
+// GetErrorBudget calculates the budget of the SLO over its window which contains now.
+// Monitor SLOs are measured by their compliance, latency SLOs by their share of good
+// time windows and all others by their success rate.
+func (s *ErrorBudgetService) GetErrorBudget(sloID int64, now time.Time) (*model.ErrorBudget, error) {
+	slo, err := s.SloProvider.GetSlo(sloID)
+	if err != nil {
+		return nil, err
+	}
+
+	window := slo.Window()
+	budget := &model.ErrorBudget{
+		SloID:  slo.ID,
+		Window: window,
+		From:   window.Start(now),
+		To:     now.UTC(),
+	}
+	budget.MetricType, budget.Target, err = budgetTarget(slo)
+	if err != nil {
+		return nil, err
+	}
+
+	points, maintenance, err := s.getBudgetPoints(slo, budget.MetricType, budget.From, budget.To)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []float64{}
+	for _, point := range points {
+		if point.maintenance {
+			budget.ExcludedDays++
+			continue
+		}
+		if point.value != nil {
+			values = append(values, *point.value)
+		}
+	}
+
+	budget.Maintenance = maintenance
+	budget.Actual, budget.Remaining = calculateErrorBudget(budget.Target, values)
+	return budget, nil
+}
+
+// GetCompliance measures the SLO from from to to. A day is breached when it missed the target,
+// the budget of the period holds one burn rate day per day of the period.
+func (s *ErrorBudgetService) GetCompliance(slo *model.Slo, from, to time.Time) (*model.SloCompliance, error) {
+	compliance := &model.SloCompliance{
+		SloID:       slo.ID,
+		SloName:     slo.Name,
+		Critical:    slo.Critical,
+		ExternalSLA: slo.ExternalSLA,
+	}
+	var err error
+	compliance.MetricType, compliance.Target, err = budgetTarget(slo)
+	if err != nil {
+		return nil, err
+	}
+
+	points, _, err := s.getBudgetPoints(slo, compliance.MetricType, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []float64{}
+	for _, point := range points {
+		if point.maintenance || point.value == nil {
+			continue
+		}
+		values = append(values, *point.value)
+		if *point.value < compliance.Target {
+			compliance.BreachedDays++
+		}
+	}
+
+	compliance.Achieved, _ = calculateErrorBudget(compliance.Target, values)
+	if compliance.Achieved == nil {
+		return compliance, nil
+	}
+	compliance.Breached = *compliance.Achieved < compliance.Target
+	if compliance.Target < 100 {
+		periodDays := to.Sub(from).Hours() / 24
+		consumed := (100 - *compliance.Achieved) * float64(len(values)) / (100 - compliance.Target) / periodDays
+		compliance.BudgetConsumed = &consumed
+	}
+	if slo.ExternalSLA != "" {
+		sla, err := strconv.ParseFloat(slo.ExternalSLA, 64)
+		if err != nil {
+			return nil, errory.ParseErrors.Builder().Wrap(err).WithPayload("sloId", slo.ID).
+				WithMessage("slo external sla cannot be parsed").Create()
+		}
+		slaMet := *compliance.Achieved >= sla
+		compliance.SlaMet = &slaMet
+	}
+	return compliance, nil
+}
+
+// GetSlaBreach compares the availability the SLO achieved from from to to with its external SLA,
+// days overlapping maintenance are left out. It returns nil when the SLA was met, when the SLO has
+// no external SLA or no history in the range.
+func (s *ErrorBudgetService) GetSlaBreach(slo *model.Slo, from, to time.Time) (*model.SlaBreach, error) {
+	if slo.ExternalSLA == "" {
+		return nil, nil
+	}
+	sla, err := strconv.ParseFloat(slo.ExternalSLA, 64)
+	if err != nil {
+		return nil, errory.ParseErrors.Builder().Wrap(err).WithPayload("sloId", slo.ID).
+			WithMessage("slo external sla cannot be parsed").Create()
+	}
+
+	metricType, _, err := budgetTarget(slo)
+	if err != nil {
+		return nil, err
+	}
+	points, _, err := s.getBudgetPoints(slo, metricType, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	breach := &model.SlaBreach{SloID: slo.ID, SloName: slo.Name, SLA: sla}
+	values := []float64{}
+	for _, point := range points {
+		if point.maintenance || point.value == nil {
+			continue
+		}
+		values = append(values, *point.value)
+		if *point.value < sla {
+			if breach.Start.IsZero() {
+				breach.Start = point.time
+			}
+			breach.End = point.time.Add(historyDay)
+		}
+	}
+
+	achieved, _ := calculateErrorBudget(sla, values)
+	if achieved == nil || *achieved >= sla {
+		return nil, nil
+	}
+	breach.Achieved = *achieved
+	return breach, nil
+}
+
+// GetForecast projects the exhaustion of the budget of the SLO in its window which contains now.
+// A lookbackDays of 0 uses the default look-back.
+func (s *ErrorBudgetService) GetForecast(sloID int64, now time.Time, lookbackDays int) (*model.BudgetForecast, error) {
+	slo, err := s.SloProvider.GetSlo(sloID)
+	if err != nil {
+		return nil, err
+	}
+	return s.forecast(slo, now, lookbackDays)
+}
+
+// GetAtRiskSlos returns the forecasts of the SLOs of the organization which run out of budget
+// before their horizon, the earliest exhaustion first. SLOs which cannot be forecast are left out.
+func (s *ErrorBudgetService) GetAtRiskSlos(orgID int64, now time.Time, lookbackDays int) ([]*model.BudgetForecast, error) {
+	slos, err := s.SloProvider.GetSlosByOrganizationID(orgID)
+	if err != nil {
+		return nil, errory.Decorate(err, "slos at risk")
+	}
+
+	atRisk := []*model.BudgetForecast{}
+	for _, slo := range slos {
+		forecast, err := s.forecast(slo, now, lookbackDays)
+		if err != nil {
+			s.Log.WithError(err).WithField("sloId", slo.ID).Warn("could not forecast error budget")
+			continue
+		}
+		if forecast.AtRisk() {
+			atRisk = append(atRisk, forecast)
+		}
+	}
+
+	sort.SliceStable(atRisk, func(i, j int) bool {
+		return atRisk[i].Exhaustion().Before(*atRisk[j].Exhaustion())
+	})
+	return atRisk, nil
+}
+
+// forecast measures the burn rate of every day relative to the daily budget of the window, the
+// window holds a budget of one burn rate day per day. Days overlapping maintenance do not burn
+// budget. Days leaving a rolling window are not credited back, which keeps the projection on
+// the safe side.
+func (s *ErrorBudgetService) forecast(slo *model.Slo, now time.Time, lookbackDays int) (*model.BudgetForecast, error) {
+	if lookbackDays <= 0 {
+		lookbackDays = s.ForecastLookbackDays
+	}
+	metricType, target, err := budgetTarget(slo)
+	if err != nil {
+		return nil, err
+	}
+	if target >= 100 {
+		return nil, errory.ValidationErrors.Builder().WithPayload("sloId", slo.ID).
+			WithMessage("slo without error budget cannot be forecast").Create()
+	}
+
+	window := slo.Window()
+	windowStart, horizon := window.Start(now), window.End(now)
+	if window.Type == model.SloWindowTypeRolling {
+		horizon = now.UTC().AddDate(0, 0, int(window.Days))
+	}
+	forecast := &model.BudgetForecast{
+		SloID:        slo.ID,
+		SloName:      slo.Name,
+		Critical:     slo.Critical,
+		Window:       window,
+		Horizon:      horizon,
+		LookbackDays: lookbackDays,
+	}
+
+	lookbackStart := now.UTC().AddDate(0, 0, -lookbackDays)
+	from := windowStart
+	if lookbackStart.Before(from) {
+		from = lookbackStart
+	}
+	points, _, err := s.getBudgetPoints(slo, metricType, from, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, consumed, burnRates := 100-target, 0.0, []float64{}
+	for _, point := range points {
+		if point.maintenance || point.value == nil {
+			continue
+		}
+		burnRate := (100 - *point.value) / allowed
+		if !point.time.Before(windowStart) {
+			consumed += burnRate
+		}
+		if !point.time.Before(lookbackStart) {
+			burnRates = append(burnRates, burnRate)
+		}
+	}
+
+	windowDays := window.End(now).Sub(windowStart).Hours() / 24
+	if window.Type == model.SloWindowTypeRolling {
+		windowDays = float64(window.Days)
+	}
+	remainingDays := windowDays - consumed
+	remaining := remainingDays / windowDays
+	forecast.Remaining = &remaining
+
+	if len(burnRates) > 0 {
+		forecast.Linear = projectExhaustion(linearBurnRate(burnRates), remainingDays, now, horizon)
+		forecast.Ewma = projectExhaustion(ewmaBurnRate(burnRates, s.ForecastEwmaAlpha), remainingDays, now, horizon)
+	}
+	return forecast, nil
+}
+
+// linearBurnRate is the mean burn rate, so the budget is projected to shrink linearly.
+func linearBurnRate(burnRates []float64) float64 {
+	sum := 0.0
+	for _, burnRate := range burnRates {
+		sum += burnRate
+	}
+	return sum / float64(len(burnRates))
+}
+
+// ewmaBurnRate weights the burn rates, oldest first, exponentially towards the latest days.
+func ewmaBurnRate(burnRates []float64, alpha float64) float64 {
+	ewma := burnRates[0]
+	for _, burnRate := range burnRates[1:] {
+		ewma = alpha*burnRate + (1-alpha)*ewma
+	}
+	return ewma
+}
+
+func projectExhaustion(burnRate, remainingDays float64, now, horizon time.Time) *model.BudgetProjection {
+	projection := &model.BudgetProjection{BurnRate: burnRate}
+	daysLeft := 0.0
+	switch {
+	case remainingDays <= 0:
+	case burnRate <= 0:
+		return projection
+	case remainingDays/burnRate > maxForecastDays:
+		return projection
+	default:
+		daysLeft = remainingDays / burnRate
+	}
+
+	exhaustedAt := now.UTC().Add(time.Duration(daysLeft * float64(historyDay)))
+	projection.DaysLeft = &daysLeft
+	projection.ExhaustedAt = &exhaustedAt
+	projection.BeforeHorizon = exhaustedAt.Before(horizon)
+	return projection
+}
+
+// budgetPoint is the value of a day of the SLO history, days overlapping maintenance are marked.
+type budgetPoint struct {
+	time        time.Time
+	value       *float64
+	maintenance bool
+}
+
+func (s *ErrorBudgetService) getBudgetPoints(slo *model.Slo, metricType model.MetricType,
+	from, to time.Time) ([]budgetPoint, []model.MaintenancePeriod, error) {
+	timeRange := &elasticModel.SloHistoryRange{From: from, To: to}
+	points, err := getHistoryPoints(s.ElasticClient, s.Composite, slo, timeRange, budgetInterval)
+	if err != nil {
+		return nil, nil, errory.Decorate(err, "error budget")
+	}
+	maintenance, err := s.Maintenance.GetPeriodsForSlo(slo, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	budgetPoints := make([]budgetPoint, 0, len(points))
+	for _, point := range points {
+		budgetPoints = append(budgetPoints, budgetPoint{
+			time:        point.Time,
+			value:       budgetValue(metricType, point),
+			maintenance: model.Overlaps(maintenance, point.Time, point.Time.Add(historyDay)),
+		})
+	}
+	return budgetPoints, maintenance, nil
+}
+
+func budgetTarget(slo *model.Slo) (model.MetricType, float64, error) {
+	metricType, target := slo.MetricType(), slo.SuccessRateExpectedAvailability
+	switch metricType {
+	case model.MetricTypeCompliance:
+		target = slo.ComplianceExpectedAvailability
+	case model.MetricTypeLatency:
+		target = slo.LatencyTarget
+	}
+
+	value, err := strconv.ParseFloat(target, 64)
+	if err != nil {
+		return metricType, 0, errory.ParseErrors.Builder().Wrap(err).WithPayload("sloId", slo.ID).
+			WithMessage("slo target cannot be parsed").Create()
+	}
+	return metricType, value, nil
+}
+
+func budgetValue(metricType model.MetricType, point *elasticModel.SloHistoryPoint) *float64 {
+	switch metricType {
+	case model.MetricTypeCompliance:
+		return point.Compliance
+	case model.MetricTypeLatency:
+		return point.GoodWindows
+	default:
+		return point.SuccessRate
+	}
+}
+
+// calculateErrorBudget averages the values and returns the share of the budget left,
+// 1 when nothing was consumed and below 0 when the budget is exhausted. Both are nil without values.
+func calculateErrorBudget(target float64, values []float64) (*float64, *float64) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	actual := sum / float64(len(values))
+
+	allowed, consumed := 100-target, 100-actual
+	remaining := 1.0
+	switch {
+	case allowed > 0:
+		remaining = 1 - consumed/allowed
+	case consumed > 0:
+		remaining = 0
+	}
+	return &actual, &remaining
+}
@@ -0,0 +1,309 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	client "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Error budget service test", func() {
+	var mockController *gomock.Controller
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockElasticClient *client.MockIClient
+	var mockMaintenance *service.MockIMaintenancePeriodService
+	var budgetService *service.ErrorBudgetService
+	logger, _ := logrustest.NewNullLogger()
+
+	now := time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC)
+	monthStart := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	value := func(v float64) *float64 { return &v }
+	day := func(d int) time.Time { return time.Date(2021, 3, d, 0, 0, 0, 0, time.UTC) }
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockElasticClient = client.NewMockIClient(mockController)
+		mockMaintenance = service.NewMockIMaintenancePeriodService(mockController)
+		budgetService = &service.ErrorBudgetService{
+			SloProvider:          mockISLOProvider,
+			ElasticClient:        mockElasticClient,
+			Maintenance:          mockMaintenance,
+			ForecastLookbackDays: 7,
+			ForecastEwmaAlpha:    0.5,
+			Log:                  logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("GetErrorBudget(sloID int64, now time.Time)", func() {
+		Context("When slo has a calendar window and maintenance", func() {
+			It("Should calculate the budget from the days without maintenance", func() {
+				slo := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99", WindowType: model.SloWindowTypeCalendar}
+				maintenance := []model.MaintenancePeriod{{From: day(2).Add(2 * time.Hour), To: day(2).Add(3 * time.Hour)}}
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: monthStart, To: now}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: day(1), SuccessRate: value(99.5)},
+						{Time: day(2), SuccessRate: value(90)},
+						{Time: day(3), SuccessRate: value(99.5)},
+						{Time: day(4)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, monthStart, now).Return(maintenance, nil)
+
+				budget, err := budgetService.GetErrorBudget(7, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(budget.From).To(Equal(monthStart))
+				Expect(budget.MetricType).To(Equal(model.MetricTypeSuccessRate))
+				Expect(budget.Target).To(Equal(99.0))
+				Expect(budget.ExcludedDays).To(Equal(1))
+				Expect(*budget.Actual).To(BeNumerically("~", 99.5, 1e-9))
+				Expect(*budget.Remaining).To(BeNumerically("~", 0.5, 1e-9))
+			})
+		})
+
+		Context("When slo is a monitor slo", func() {
+			It("Should calculate the budget from the compliance", func() {
+				slo := &model.Slo{ID: 7, ExternalType: model.ExternalSloTypeMonitor, ComplianceExpectedAvailability: "95",
+					WindowType: model.SloWindowTypeRolling, WindowDays: 3}
+				from := now.AddDate(0, 0, -3)
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: from, To: now}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: day(1), Compliance: value(85), SuccessRate: value(100)},
+						{Time: day(2), Compliance: value(95), SuccessRate: value(100)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, from, now).Return([]model.MaintenancePeriod{}, nil)
+
+				budget, err := budgetService.GetErrorBudget(7, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(budget.MetricType).To(Equal(model.MetricTypeCompliance))
+				Expect(*budget.Actual).To(BeNumerically("~", 90, 1e-9))
+				Expect(*budget.Remaining).To(BeNumerically("~", -1, 1e-9))
+			})
+		})
+
+		Context("When slo is a latency slo", func() {
+			It("Should calculate the budget from the good time windows", func() {
+				slo := &model.Slo{ID: 7, ExternalType: model.ExternalSloTypeTimeSlice, LatencyPercentile: model.LatencyPercentileP95,
+					LatencyThresholdMs: 300, LatencyTarget: "99.5", WindowType: model.SloWindowTypeCalendar}
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: monthStart, To: now}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: day(1), GoodWindows: value(99.75)},
+						{Time: day(2), GoodWindows: value(99.25)},
+						{Time: day(3), GoodWindows: value(99.5)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, monthStart, now).Return([]model.MaintenancePeriod{}, nil)
+
+				budget, err := budgetService.GetErrorBudget(7, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(budget.MetricType).To(Equal(model.MetricTypeLatency))
+				Expect(budget.Target).To(Equal(99.5))
+				Expect(*budget.Actual).To(BeNumerically("~", 99.5, 1e-9))
+				Expect(*budget.Remaining).To(BeNumerically("~", 0, 1e-9))
+			})
+		})
+
+		Context("When there is no history", func() {
+			It("Should return the budget without values", func() {
+				slo := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99"}
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), gomock.Any(), "1d").Return([]*elasticModel.SloHistoryPoint{}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, gomock.Any(), now).Return([]model.MaintenancePeriod{}, nil)
+
+				budget, err := budgetService.GetErrorBudget(7, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(budget.From).To(Equal(now.AddDate(0, 0, -30)))
+				Expect(budget.Actual).To(BeNil())
+				Expect(budget.Remaining).To(BeNil())
+			})
+		})
+
+		Context("When slo target cannot be parsed", func() {
+			It("Should return parse error", func() {
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(&model.Slo{ID: 7, SuccessRateExpectedAvailability: "n/a"}, nil)
+
+				_, err := budgetService.GetErrorBudget(7, now)
+
+				Expect(errory.IsOfType(err, errory.ParseErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetCompliance(slo *model.Slo, from, to time.Time)", func() {
+		Context("When slo has an external sla", func() {
+			It("Should measure the days without maintenance against target and sla", func() {
+				slo := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99", ExternalSLA: "98.5", Critical: true}
+				to := day(5)
+				maintenance := []model.MaintenancePeriod{{From: day(3), To: day(3).Add(time.Hour)}}
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: monthStart, To: to}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: day(1), SuccessRate: value(99.5)},
+						{Time: day(2), SuccessRate: value(98)},
+						{Time: day(3), SuccessRate: value(50)},
+						{Time: day(4), SuccessRate: value(98.5)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, monthStart, to).Return(maintenance, nil)
+
+				compliance, err := budgetService.GetCompliance(slo, monthStart, to)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(compliance.Target).To(Equal(99.0))
+				Expect(*compliance.Achieved).To(BeNumerically("~", 98.666666, 1e-5))
+				Expect(compliance.BreachedDays).To(Equal(2))
+				Expect(compliance.Breached).To(BeTrue())
+				Expect(*compliance.BudgetConsumed).To(BeNumerically("~", 4.0/4, 1e-9))
+				Expect(*compliance.SlaMet).To(BeTrue())
+			})
+		})
+
+		Context("When slo has no history in the period", func() {
+			It("Should return the compliance without values", func() {
+				slo := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99", ExternalSLA: "98.5"}
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), gomock.Any(), "1d").Return([]*elasticModel.SloHistoryPoint{}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, monthStart, now).Return([]model.MaintenancePeriod{}, nil)
+
+				compliance, err := budgetService.GetCompliance(slo, monthStart, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(compliance.Achieved).To(BeNil())
+				Expect(compliance.BudgetConsumed).To(BeNil())
+				Expect(compliance.SlaMet).To(BeNil())
+			})
+		})
+	})
+
+	Describe("GetSlaBreach(slo *model.Slo, from, to time.Time)", func() {
+		Context("When the achieved availability is below the external sla", func() {
+			It("Should return the breach from the first to the last day below the sla", func() {
+				slo := &model.Slo{ID: 7, Name: "checkout", SuccessRateExpectedAvailability: "99", ExternalSLA: "99"}
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: monthStart, To: now}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: day(1), SuccessRate: value(99.5)},
+						{Time: day(2), SuccessRate: value(98)},
+						{Time: day(3), SuccessRate: value(98.5)},
+						{Time: day(4), SuccessRate: value(99.6)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, monthStart, now).Return([]model.MaintenancePeriod{}, nil)
+
+				breach, err := budgetService.GetSlaBreach(slo, monthStart, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(breach.SLA).To(Equal(99.0))
+				Expect(breach.Achieved).To(BeNumerically("~", 98.9, 1e-9))
+				Expect(breach.Start).To(Equal(day(2)))
+				Expect(breach.End).To(Equal(day(4)))
+			})
+		})
+
+		Context("When the external sla is met", func() {
+			It("Should return nil", func() {
+				slo := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99.9", ExternalSLA: "98"}
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), gomock.Any(), "1d").
+					Return([]*elasticModel.SloHistoryPoint{{Time: day(1), SuccessRate: value(97)}, {Time: day(2), SuccessRate: value(100)}}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, monthStart, now).Return([]model.MaintenancePeriod{}, nil)
+
+				breach, err := budgetService.GetSlaBreach(slo, monthStart, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(breach).To(BeNil())
+			})
+		})
+	})
+
+	Describe("GetForecast(sloID int64, now time.Time, lookbackDays int)", func() {
+		slo := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99", WindowType: model.SloWindowTypeCalendar}
+
+		Context("When slo burns budget faster than the window allows", func() {
+			It("Should project the exhaustion linearly and exponentially weighted from the look-back", func() {
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: monthStart, To: now}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: day(1), SuccessRate: value(99.5)},
+						{Time: day(2), SuccessRate: value(98)},
+						{Time: day(3), SuccessRate: value(96)},
+						{Time: day(4), SuccessRate: value(96)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, monthStart, now).Return([]model.MaintenancePeriod{}, nil)
+
+				forecast, err := budgetService.GetForecast(7, now, 3)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(forecast.Horizon).To(Equal(time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)))
+				Expect(forecast.LookbackDays).To(Equal(3))
+				Expect(*forecast.Remaining).To(BeNumerically("~", 20.5/31, 1e-9))
+				Expect(forecast.Linear.BurnRate).To(BeNumerically("~", 10.0/3, 1e-9))
+				Expect(*forecast.Linear.DaysLeft).To(BeNumerically("~", 20.5*3/10, 1e-9))
+				Expect(forecast.Linear.BeforeHorizon).To(BeTrue())
+				Expect(forecast.Ewma.BurnRate).To(BeNumerically("~", 3.5, 1e-9))
+				Expect(*forecast.Ewma.DaysLeft).To(BeNumerically("~", 20.5/3.5, 1e-9))
+				Expect(forecast.AtRisk()).To(BeTrue())
+			})
+		})
+
+		Context("When slo does not burn budget in the look-back", func() {
+			It("Should not project an exhaustion", func() {
+				rolling := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99", WindowType: model.SloWindowTypeRolling, WindowDays: 10}
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(rolling, nil)
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: now.AddDate(0, 0, -10), To: now}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: day(3), SuccessRate: value(97)},
+						{Time: day(4), SuccessRate: value(100)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(rolling, now.AddDate(0, 0, -10), now).Return([]model.MaintenancePeriod{}, nil)
+
+				forecast, err := budgetService.GetForecast(7, now, 1)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(forecast.Horizon).To(Equal(now.AddDate(0, 0, 10)))
+				Expect(*forecast.Remaining).To(BeNumerically("~", 0.7, 1e-9))
+				Expect(forecast.Linear.DaysLeft).To(BeNil())
+				Expect(forecast.Ewma.ExhaustedAt).To(BeNil())
+				Expect(forecast.AtRisk()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("GetAtRiskSlos(orgID int64, now time.Time, lookbackDays int)", func() {
+		It("Should return the slos running out of budget, the earliest first", func() {
+			slow := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99", WindowType: model.SloWindowTypeCalendar}
+			healthy := &model.Slo{ID: 8, SuccessRateExpectedAvailability: "99", WindowType: model.SloWindowTypeCalendar}
+			broken := &model.Slo{ID: 9, SuccessRateExpectedAvailability: "n/a"}
+			fast := &model.Slo{ID: 10, SuccessRateExpectedAvailability: "99", WindowType: model.SloWindowTypeCalendar}
+			mockISLOProvider.EXPECT().GetSlosByOrganizationID(int64(2)).Return([]*model.Slo{slow, healthy, broken, fast}, nil)
+			history := map[int64]float64{7: 97, 8: 99.9, 10: 90}
+			for id, successRate := range history {
+				mockElasticClient.EXPECT().GetSloHistory(id, gomock.Any(), "1d").
+					Return([]*elasticModel.SloHistoryPoint{{Time: day(3), SuccessRate: value(successRate)}}, nil)
+			}
+			mockMaintenance.EXPECT().GetPeriodsForSlo(gomock.Any(), gomock.Any(), now).Times(3).Return([]model.MaintenancePeriod{}, nil)
+
+			forecasts, err := budgetService.GetAtRiskSlos(2, now, 0)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(forecasts).To(HaveLen(2))
+			Expect(forecasts[0].SloID).To(Equal(int64(10)))
+			Expect(forecasts[1].SloID).To(Equal(int64(7)))
+		})
+	})
+})
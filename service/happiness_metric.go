@@ -9,7 +9,7 @@ import (
 
 type IHappinessMetricService interface {
 	Create(userContext *auth.UserContext, metric *model.HappinessMetric) error
-	Update(userContext *auth.UserContext, metric *model.HappinessMetric) error
+	Update(userContext *auth.UserContext, metric *model.HappinessMetric, version *int64) (int64, error)
 	Delete(userContext *auth.UserContext, id int64) error
 	Get(id int64) (*model.HappinessMetric, error)
 	GetAllHappinessMetricsForUser(orgID, userID int64) ([]*model.HappinessMetric, error)
@@ -19,16 +19,27 @@ type IHappinessMetricService interface {
 }
 
 type HappinessMetricService struct {
-	Provider provider.IHappinessMetricProvider
-	Log      logrus.FieldLogger
+	Provider        provider.IHappinessMetricProvider
+	VersionProvider provider.IEntityVersionProvider
+	Versions        IEntityVersionService
+	Log             logrus.FieldLogger
 }
 
 func (s *HappinessMetricService) Create(userContext *auth.UserContext, metric *model.HappinessMetric) error {
 	return s.Provider.CreateHappinessMetric(metric)
 }
 
-func (s *HappinessMetricService) Update(userContext *auth.UserContext, metric *model.HappinessMetric) error {
-	return s.Provider.UpdateHappinessMetric(metric)
+// Update changes the metric if it still has the version, any version matches when it is nil, and
+// returns the increased version.
+func (s *HappinessMetricService) Update(userContext *auth.UserContext, metric *model.HappinessMetric, version *int64) (int64, error) {
+	newVersion, updated, err := s.VersionProvider.UpdateHappinessMetricVersioned(metric, version)
+	if err != nil {
+		return 0, err
+	}
+	if !updated {
+		return 0, s.Versions.Conflict(model.VersionedEntityHappinessMetric, metric.ID, version)
+	}
+	return newVersion, nil
 }
 
 func (s *HappinessMetricService) Delete(userContext *auth.UserContext, id int64) error {
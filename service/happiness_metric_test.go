@@ -20,6 +20,8 @@ import (
 var _ = Describe("Test metric service test", func() {
 	var mockController *gomock.Controller
 	var mockIHappinessMetricProvider *provider.MockIHappinessMetricProvider
+	var mockVersionProvider *provider.MockIEntityVersionProvider
+	var mockVersions *service.MockIEntityVersionService
 	var happinessMetricService *service.HappinessMetricService
 	logger, logHook := logrustest.NewNullLogger()
 	var metric model.HappinessMetric
@@ -28,6 +30,8 @@ var _ = Describe("Test metric service test", func() {
 	BeforeEach(func() {
 		mockController = gomock.NewController(GinkgoT())
 		mockIHappinessMetricProvider = provider.NewMockIHappinessMetricProvider(mockController)
+		mockVersionProvider = provider.NewMockIEntityVersionProvider(mockController)
+		mockVersions = service.NewMockIEntityVersionService(mockController)
 
 		userContext = auth.UserContext{
 			ID:     3,
@@ -45,8 +49,10 @@ var _ = Describe("Test metric service test", func() {
 		}
 
 		happinessMetricService = &service.HappinessMetricService{
-			Provider: mockIHappinessMetricProvider,
-			Log:      logger,
+			Provider:        mockIHappinessMetricProvider,
+			VersionProvider: mockVersionProvider,
+			Versions:        mockVersions,
+			Log:             logger,
 		}
 
 		logHook.Reset()
@@ -77,22 +83,37 @@ var _ = Describe("Test metric service test", func() {
 		})
 	})
 
-	Describe("Update(metric *model.HappinessMetric)", func() {
+	Describe("Update(metric *model.HappinessMetric, version *int64)", func() {
+		version := int64(4)
+
 		Context("When provider updates correctly a metric", func() {
-			It("Should not return an error", func() {
-				mockIHappinessMetricProvider.EXPECT().UpdateHappinessMetric(&metric).Times(1).Return(nil)
+			It("Should return the increased version", func() {
+				mockVersionProvider.EXPECT().UpdateHappinessMetricVersioned(&metric, &version).Times(1).Return(int64(5), true, nil)
 
-				err := happinessMetricService.Update(&userContext, &metric)
+				newVersion, err := happinessMetricService.Update(&userContext, &metric, &version)
 
 				Expect(err).NotTo(HaveOccurred())
+				Expect(newVersion).To(Equal(int64(5)))
+			})
+		})
+
+		Context("When the metric was changed meanwhile", func() {
+			It("Should return the version conflict", func() {
+				mockVersionProvider.EXPECT().UpdateHappinessMetricVersioned(&metric, &version).Times(1).Return(int64(0), false, nil)
+				mockVersions.EXPECT().Conflict(model.VersionedEntityHappinessMetric, int64(12), &version).
+					Return(errory.PreconditionFailedErrors.New("happiness_metric 12 was changed meanwhile"))
+
+				_, err := happinessMetricService.Update(&userContext, &metric, &version)
+
+				Expect(errory.IsOfType(err, errory.PreconditionFailedErrors)).To(BeTrue())
 			})
 		})
 
 		Context("When provider does not update a metric and return an error", func() {
 			It("Should return an error", func() {
-				mockIHappinessMetricProvider.EXPECT().UpdateHappinessMetric(&metric).Times(1).Return(errory.ProviderErrors.New("Provider errory"))
+				mockVersionProvider.EXPECT().UpdateHappinessMetricVersioned(&metric, &version).Times(1).Return(int64(0), false, errory.ProviderErrors.New("Provider errory"))
 
-				err := happinessMetricService.Update(&userContext, &metric)
+				_, err := happinessMetricService.Update(&userContext, &metric, &version)
 
 				Expect(err).To(HaveOccurred())
 			})
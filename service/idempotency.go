@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type IIdempotencyService interface {
+	Start(ctx context.Context)
+	Begin(userID int64, key, method, path string, body []byte) (*model.IdempotentResponse, error)
+	Complete(response *model.IdempotentResponse) error
+	Abort(response *model.IdempotentResponse) error
+	DeleteExpiredAll(now time.Time)
+}
+
+// IdempotencyService remembers the first response to a request with an Idempotency-Key for TTL,
+// retries of the request by the same user get this response instead of being processed again.
+// When Enabled expired keys are deleted every Interval in the background.
+type IdempotencyService struct {
+	Provider provider.IIdempotencyProvider
+	TTL      time.Duration
+	Enabled  bool
+	Interval time.Duration
+	Lock     provider.IJobLockProvider
+	Log      logrus.FieldLogger
+}
+
+// Start deletes expired keys every Interval in the background until ctx is done.
+func (s *IdempotencyService) Start(ctx context.Context) {
+	if !s.Enabled {
+		s.Log.Info("idempotency key cleanup disabled")
+		return
+	}
+
+	go runPeriodically(ctx, s.Lock, s.Log, "idempotency_key_cleanup", s.Interval, s.DeleteExpiredAll)
+}
+
+func (s *IdempotencyService) DeleteExpiredAll(now time.Time) {
+	deleted, err := s.Provider.DeleteExpiredIdempotencyKeys(now)
+	if err != nil {
+		s.Log.WithError(err).Error("could not delete expired idempotency keys")
+		return
+	}
+	if deleted > 0 {
+		s.Log.WithField("deleted", deleted).Info("deleted expired idempotency keys")
+	}
+}
+
+// Begin reserves the key for the request. The returned response has a Status of 0 when the
+// request has to be processed and is then passed to Complete or Abort, otherwise it is the stored
+// response to replay. Reusing a key for a different request, or while the first request is still
+// processed, is a conflict.
+func (s *IdempotencyService) Begin(userID int64, key, method, path string,
+	body []byte) (*model.IdempotentResponse, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, errory.ValidationErrors.Builder().WithPayload("idempotencyKey", key).
+			WithMessage("Idempotency-Key has to have between 1 and 255 characters").Create()
+	}
+
+	now := time.Now().UTC()
+	reservation := &model.IdempotentResponse{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash(method, path, body),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.TTL),
+	}
+	reserved, err := s.Provider.ReserveIdempotencyKey(reservation)
+	if err != nil {
+		return nil, errory.Decorate(err, "idempotency service begin()")
+	}
+	if reserved {
+		return reservation, nil
+	}
+
+	stored, err := s.Provider.GetIdempotentResponse(userID, key)
+	if err != nil {
+		return nil, errory.Decorate(err, "idempotency service begin()")
+	}
+	if stored.RequestHash != reservation.RequestHash {
+		return nil, errory.NotUniqueErrors.Builder().WithPayload("idempotencyKey", key).
+			WithMessage("Idempotency-Key was already used for a different request").Create()
+	}
+	if stored.Status == 0 {
+		return nil, errory.NotUniqueErrors.Builder().WithPayload("idempotencyKey", key).
+			WithMessage("request with this Idempotency-Key is still being processed").Create()
+	}
+	return stored, nil
+}
+
+// Complete stores the response of the reserved request for replay.
+func (s *IdempotencyService) Complete(response *model.IdempotentResponse) error {
+	if err := s.Provider.CompleteIdempotencyKey(response); err != nil {
+		return errory.Decorate(err, "idempotency service complete()")
+	}
+	return nil
+}
+
+// Abort releases the key of a request which failed without a response worth replaying, so that
+// a retry is processed again.
+func (s *IdempotencyService) Abort(response *model.IdempotentResponse) error {
+	if err := s.Provider.DeleteIdempotencyKey(response.UserID, response.Key); err != nil {
+		return errory.Decorate(err, "idempotency service abort()")
+	}
+	return nil
+}
+
+func requestHash(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
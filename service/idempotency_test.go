@@ -0,0 +1,196 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Idempotency service test", func() {
+	var mockController *gomock.Controller
+	var mockIdempotencyProvider *provider.MockIIdempotencyProvider
+	var idempotencyService *service.IdempotencyService
+	logger, _ := logrustest.NewNullLogger()
+	body := []byte(`{"name":"slo"}`)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockIdempotencyProvider = provider.NewMockIIdempotencyProvider(mockController)
+		idempotencyService = &service.IdempotencyService{Provider: mockIdempotencyProvider, TTL: time.Hour, Log: logger}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Begin()", func() {
+		It("Should reserve an unused key", func() {
+			var reserved *model.IdempotentResponse
+			mockIdempotencyProvider.EXPECT().ReserveIdempotencyKey(gomock.Any()).
+				DoAndReturn(func(response *model.IdempotentResponse) (bool, error) {
+					reserved = response
+					return true, nil
+				})
+
+			response, err := idempotencyService.Begin(3, "key", "POST", "/v1/slo", body)
+
+			Expect(err).To(BeNil())
+			Expect(response).To(Equal(reserved))
+			Expect(response.UserID).To(Equal(int64(3)))
+			Expect(response.Key).To(Equal("key"))
+			Expect(response.Status).To(Equal(0))
+			Expect(response.RequestHash).NotTo(BeEmpty())
+			Expect(response.ExpiresAt).To(Equal(response.CreatedAt.Add(time.Hour)))
+		})
+
+		It("Should return the stored response for a retry", func() {
+			var hash string
+			mockIdempotencyProvider.EXPECT().ReserveIdempotencyKey(gomock.Any()).
+				DoAndReturn(func(response *model.IdempotentResponse) (bool, error) {
+					hash = response.RequestHash
+					return false, nil
+				})
+			mockIdempotencyProvider.EXPECT().GetIdempotentResponse(int64(3), "key").
+				DoAndReturn(func(int64, string) (*model.IdempotentResponse, error) {
+					return &model.IdempotentResponse{RequestHash: hash, Status: 201, Body: []byte(`{"id":1}`)}, nil
+				})
+
+			response, err := idempotencyService.Begin(3, "key", "POST", "/v1/slo", body)
+
+			Expect(err).To(BeNil())
+			Expect(response.Status).To(Equal(201))
+			Expect(response.Body).To(Equal([]byte(`{"id":1}`)))
+		})
+
+		It("Should return conflict for a different body", func() {
+			mockIdempotencyProvider.EXPECT().ReserveIdempotencyKey(gomock.Any()).Return(false, nil)
+			mockIdempotencyProvider.EXPECT().GetIdempotentResponse(int64(3), "key").
+				Return(&model.IdempotentResponse{RequestHash: "other", Status: 201}, nil)
+
+			_, err := idempotencyService.Begin(3, "key", "POST", "/v1/slo", body)
+
+			Expect(errory.IsOfType(err, errory.NotUniqueErrors)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("different request"))
+		})
+
+		It("Should return conflict while the first request is processed", func() {
+			var hash string
+			mockIdempotencyProvider.EXPECT().ReserveIdempotencyKey(gomock.Any()).
+				DoAndReturn(func(response *model.IdempotentResponse) (bool, error) {
+					hash = response.RequestHash
+					return false, nil
+				})
+			mockIdempotencyProvider.EXPECT().GetIdempotentResponse(int64(3), "key").
+				DoAndReturn(func(int64, string) (*model.IdempotentResponse, error) {
+					return &model.IdempotentResponse{RequestHash: hash}, nil
+				})
+
+			_, err := idempotencyService.Begin(3, "key", "POST", "/v1/slo", body)
+
+			Expect(errory.IsOfType(err, errory.NotUniqueErrors)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("still being processed"))
+		})
+
+		It("Should treat the same body sent to another endpoint as a different request", func() {
+			hashes := []string{}
+			mockIdempotencyProvider.EXPECT().ReserveIdempotencyKey(gomock.Any()).Times(2).
+				DoAndReturn(func(response *model.IdempotentResponse) (bool, error) {
+					hashes = append(hashes, response.RequestHash)
+					return true, nil
+				})
+
+			_, _ = idempotencyService.Begin(3, "key", "POST", "/v1/slo", body)
+			_, _ = idempotencyService.Begin(3, "key", "POST", "/v1/feedback", body)
+
+			Expect(hashes[0]).NotTo(Equal(hashes[1]))
+		})
+
+		It("Should return validation error for a too long key", func() {
+			_, err := idempotencyService.Begin(3, strings.Repeat("k", 256), "POST", "/v1/slo", body)
+
+			Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+		})
+
+		It("Should return provider errors", func() {
+			mockIdempotencyProvider.EXPECT().ReserveIdempotencyKey(gomock.Any()).Return(false, errors.New("db down"))
+
+			_, err := idempotencyService.Begin(3, "key", "POST", "/v1/slo", body)
+
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Abort()", func() {
+		It("Should release the key", func() {
+			mockIdempotencyProvider.EXPECT().DeleteIdempotencyKey(int64(3), "key").Return(nil)
+
+			Expect(idempotencyService.Abort(&model.IdempotentResponse{UserID: 3, Key: "key"})).To(Succeed())
+		})
+	})
+
+	Describe("DeleteExpiredAll()", func() {
+		It("Should delete keys expired by now", func() {
+			now := time.Now().UTC()
+			mockIdempotencyProvider.EXPECT().DeleteExpiredIdempotencyKeys(now).Return(int64(2), nil)
+
+			idempotencyService.DeleteExpiredAll(now)
+		})
+	})
+
+	Describe("Start(ctx context.Context)", func() {
+		var mockJobLock *provider.MockIJobLockProvider
+		var ctx context.Context
+		var stop context.CancelFunc
+
+		BeforeEach(func() {
+			mockJobLock = provider.NewMockIJobLockProvider(mockController)
+			idempotencyService.Lock = mockJobLock
+			idempotencyService.Enabled = true
+			idempotencyService.Interval = time.Hour
+			ctx, stop = context.WithCancel(context.Background())
+		})
+
+		AfterEach(func() {
+			stop()
+		})
+
+		It("Should delete expired keys while holding the lock of the job", func() {
+			unlocked := make(chan bool, 1)
+			gomock.InOrder(
+				mockJobLock.EXPECT().TryLockJob(gomock.Any(), "idempotency_key_cleanup").Return(func() { unlocked <- true }, true, nil),
+				mockIdempotencyProvider.EXPECT().DeleteExpiredIdempotencyKeys(gomock.Any()).Return(int64(1), nil),
+			)
+
+			idempotencyService.Start(ctx)
+
+			Eventually(unlocked).Should(Receive())
+		})
+
+		It("Should skip the run when another instance holds the lock", func() {
+			locked := make(chan bool, 1)
+			mockJobLock.EXPECT().TryLockJob(gomock.Any(), "idempotency_key_cleanup").DoAndReturn(
+				func(context.Context, string) (func(), bool, error) {
+					locked <- false
+					return nil, false, nil
+				})
+
+			idempotencyService.Start(ctx)
+
+			Eventually(locked).Should(Receive())
+		})
+	})
+})
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+type IMaintenanceWindowService interface {
+	Create(userContext *auth.UserContext, window *model.MaintenanceWindow) error
+	Update(userContext *auth.UserContext, window *model.MaintenanceWindow) error
+	Delete(userContext *auth.UserContext, orgID, id int64) error
+	Get(orgID, id int64) (*model.MaintenanceWindow, error)
+	GetByOrgID(orgID int64) ([]*model.MaintenanceWindow, error)
+}
+
+// IMaintenancePeriodService resolves the maintenance of an SLO, it is separate from
+// IMaintenanceWindowService as the dashboards depend on it.
+type IMaintenancePeriodService interface {
+	GetPeriodsForSlo(slo *model.Slo, from, to time.Time) ([]model.MaintenancePeriod, error)
+}
+
+// MaintenanceWindowService manages maintenance windows and renders the dashboards of
+// the affected SLOs again so that they show the changed maintenance.
+type MaintenanceWindowService struct {
+	Provider         provider.IMaintenanceWindowProvider
+	SloProvider      provider.ISLOProvider
+	DashboardService IDashboardService
+	Log              logrus.FieldLogger
+}
+
+type MaintenancePeriodService struct {
+	Provider provider.IMaintenanceWindowProvider
+}
+
+var maxMaintenanceDuration = map[model.MaintenanceRecurrence]time.Duration{
+	model.MaintenanceRecurrenceDaily:   24 * time.Hour,
+	model.MaintenanceRecurrenceWeekly:  7 * 24 * time.Hour,
+	model.MaintenanceRecurrenceMonthly: 28 * 24 * time.Hour,
+}
+
+func (s *MaintenanceWindowService) Create(userContext *auth.UserContext, window *model.MaintenanceWindow) error {
+	if err := s.validate(window); err != nil {
+		return err
+	}
+	if err := s.Provider.CreateMaintenanceWindow(window); err != nil {
+		return errory.Decorate(err, "maintenance window service create()")
+	}
+
+	s.updateDashboards(userContext, window)
+	return nil
+}
+
+func (s *MaintenanceWindowService) Update(userContext *auth.UserContext, window *model.MaintenanceWindow) error {
+	current, err := s.Get(window.OrgID, window.ID)
+	if err != nil {
+		return err
+	}
+	if err = s.validate(window); err != nil {
+		return err
+	}
+	if err = s.Provider.UpdateMaintenanceWindow(window); err != nil {
+		return errory.Decorate(err, "maintenance window service update()")
+	}
+
+	s.updateDashboards(userContext, current, window)
+	return nil
+}
+
+func (s *MaintenanceWindowService) Delete(userContext *auth.UserContext, orgID, id int64) error {
+	window, err := s.Get(orgID, id)
+	if err != nil {
+		return err
+	}
+	if err = s.Provider.DeleteMaintenanceWindow(id); err != nil {
+		return errory.Decorate(err, "maintenance window service delete()")
+	}
+
+	s.updateDashboards(userContext, window)
+	return nil
+}
+
+// Get returns the maintenance window only when it belongs to the organization.
+func (s *MaintenanceWindowService) Get(orgID, id int64) (*model.MaintenanceWindow, error) {
+	window, err := s.Provider.GetMaintenanceWindow(id)
+	if err != nil {
+		return nil, err
+	}
+	if window.OrgID != orgID {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("maintenanceWindow", id).Create()
+	}
+	return window, nil
+}
+
+func (s *MaintenanceWindowService) GetByOrgID(orgID int64) ([]*model.MaintenanceWindow, error) {
+	return s.Provider.GetMaintenanceWindowsByOrgID(orgID)
+}
+
+func (s *MaintenanceWindowService) validate(window *model.MaintenanceWindow) error {
+	if maxDuration, ok := maxMaintenanceDuration[window.Recurrence]; ok && window.End.Sub(window.Start) >= maxDuration {
+		return errory.ValidationErrors.Builder().WithPayload("recurrence", window.Recurrence).
+			WithMessage("maintenance window has to be shorter than its recurrence").Create()
+	}
+
+	if window.SloID == nil {
+		return nil
+	}
+	slo, err := s.SloProvider.GetSlo(*window.SloID)
+	if err != nil {
+		return errory.Decorate(err, "maintenance window validation")
+	}
+	if slo.OrgID != window.OrgID {
+		return errory.ValidationErrors.Builder().WithPayload("sloId", *window.SloID).
+			WithMessage("slo does not belong to the organization").Create()
+	}
+	return nil
+}
+
+// updateDashboards renders the dashboards of the SLOs affected by the windows again,
+// a failing dashboard is only logged as the maintenance window is already saved.
+func (s *MaintenanceWindowService) updateDashboards(userContext *auth.UserContext, windows ...*model.MaintenanceWindow) {
+	slos, err := s.SloProvider.GetSlosByOrganizationID(windows[0].OrgID)
+	if err != nil {
+		s.Log.WithError(err).Warn("could not update dashboards after maintenance window change")
+		return
+	}
+
+	forProduct := false
+	for _, window := range windows {
+		forProduct = forProduct || window.ProductID != nil
+	}
+
+	for _, slo := range slos {
+		var productID *int64
+		if forProduct {
+			if productID, err = s.Provider.GetSloProductID(slo.ID); err != nil {
+				s.Log.WithError(err).WithField("sloId", slo.ID).Warn("could not update dashboard after maintenance window change")
+				continue
+			}
+		}
+		for _, window := range windows {
+			if !windowAppliesTo(window, slo, productID) {
+				continue
+			}
+			if err := s.DashboardService.UpdateDashboard(userContext, slo); err != nil {
+				s.Log.WithError(err).WithField("sloId", slo.ID).Warn("could not update dashboard after maintenance window change")
+			}
+			break
+		}
+	}
+}
+
+// GetPeriodsForSlo returns the maintenance of the SLO and of its product between from and to.
+func (s *MaintenancePeriodService) GetPeriodsForSlo(slo *model.Slo, from, to time.Time) ([]model.MaintenancePeriod, error) {
+	windows, err := s.Provider.GetMaintenanceWindowsByOrgID(slo.OrgID)
+	if err != nil {
+		return nil, errory.Decorate(err, "maintenance periods")
+	}
+
+	var productID *int64
+	productLoaded := false
+	periods := []model.MaintenancePeriod{}
+	for _, window := range windows {
+		if window.ProductID != nil && !productLoaded {
+			if productID, err = s.Provider.GetSloProductID(slo.ID); err != nil {
+				return nil, errory.Decorate(err, "maintenance periods")
+			}
+			productLoaded = true
+		}
+		if windowAppliesTo(window, slo, productID) {
+			periods = append(periods, window.Periods(from, to)...)
+		}
+	}
+	return periods, nil
+}
+
+// windowAppliesTo tells whether the window is for the SLO or for productID, the product of the SLO.
+func windowAppliesTo(window *model.MaintenanceWindow, slo *model.Slo, productID *int64) bool {
+	if window.SloID != nil {
+		return *window.SloID == slo.ID
+	}
+	return window.ProductID != nil && productID != nil && *productID == *window.ProductID
+}
@@ -0,0 +1,152 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Maintenance window service test", func() {
+	var mockController *gomock.Controller
+	var mockWindowProvider *provider.MockIMaintenanceWindowProvider
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockDashboardService *service.MockIDashboardService
+	var windowService *service.MaintenanceWindowService
+	var periodService *service.MaintenancePeriodService
+	logger, logHook := logrustest.NewNullLogger()
+
+	const orgID int64 = 2
+	userContext := &auth.UserContext{ID: 3, Cookie: "cookie"}
+	sloID, otherSloID, productID := int64(7), int64(8), int64(40)
+	slo := &model.Slo{ID: sloID, OrgID: orgID}
+	otherSlo := &model.Slo{ID: otherSloID, OrgID: orgID}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockWindowProvider = provider.NewMockIMaintenanceWindowProvider(mockController)
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockDashboardService = service.NewMockIDashboardService(mockController)
+
+		windowService = &service.MaintenanceWindowService{
+			Provider:         mockWindowProvider,
+			SloProvider:      mockISLOProvider,
+			DashboardService: mockDashboardService,
+			Log:              logger,
+		}
+		periodService = &service.MaintenancePeriodService{
+			Provider: mockWindowProvider,
+		}
+		logHook.Reset()
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Create(userContext *auth.UserContext, window *model.MaintenanceWindow)", func() {
+		Context("When window is for a product", func() {
+			It("Should create it and render the dashboards of the product slos again", func() {
+				window := &model.MaintenanceWindow{OrgID: orgID, ProductID: &productID,
+					Start: time.Date(2021, 3, 6, 22, 0, 0, 0, time.UTC), End: time.Date(2021, 3, 7, 2, 0, 0, 0, time.UTC)}
+				mockWindowProvider.EXPECT().CreateMaintenanceWindow(window).Return(nil)
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{slo, otherSlo}, nil)
+				mockWindowProvider.EXPECT().GetSloProductID(sloID).Return(&productID, nil)
+				mockWindowProvider.EXPECT().GetSloProductID(otherSloID).Return(nil, nil)
+				mockDashboardService.EXPECT().UpdateDashboard(userContext, slo).Return(nil)
+
+				err := windowService.Create(userContext, window)
+
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("When slo belongs to another organization", func() {
+			It("Should return validation error", func() {
+				window := &model.MaintenanceWindow{OrgID: 5, SloID: &sloID,
+					Start: time.Date(2021, 3, 6, 22, 0, 0, 0, time.UTC), End: time.Date(2021, 3, 7, 2, 0, 0, 0, time.UTC)}
+				mockISLOProvider.EXPECT().GetSlo(sloID).Return(slo, nil)
+
+				err := windowService.Create(userContext, window)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When window is as long as its recurrence", func() {
+			It("Should return validation error", func() {
+				window := &model.MaintenanceWindow{OrgID: orgID, SloID: &sloID, Recurrence: model.MaintenanceRecurrenceDaily,
+					Start: time.Date(2021, 3, 6, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 3, 7, 0, 0, 0, 0, time.UTC)}
+
+				err := windowService.Create(userContext, window)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When dashboard cannot be rendered", func() {
+			It("Should only log a warning", func() {
+				window := &model.MaintenanceWindow{OrgID: orgID, SloID: &sloID,
+					Start: time.Date(2021, 3, 6, 22, 0, 0, 0, time.UTC), End: time.Date(2021, 3, 7, 2, 0, 0, 0, time.UTC)}
+				mockISLOProvider.EXPECT().GetSlo(sloID).Return(slo, nil)
+				mockWindowProvider.EXPECT().CreateMaintenanceWindow(window).Return(nil)
+				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Return([]*model.Slo{slo, otherSlo}, nil)
+				mockDashboardService.EXPECT().UpdateDashboard(userContext, slo).Return(errory.GrafanaClientErrors.New("grafana"))
+
+				err := windowService.Create(userContext, window)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logHook.LastEntry().Message).To(Equal("could not update dashboard after maintenance window change"))
+			})
+		})
+	})
+
+	Describe("Delete(userContext *auth.UserContext, orgID, id int64)", func() {
+		It("Should not delete a window of another organization", func() {
+			mockWindowProvider.EXPECT().GetMaintenanceWindow(int64(3)).Return(&model.MaintenanceWindow{ID: 3, OrgID: 5}, nil)
+
+			err := windowService.Delete(userContext, orgID, 3)
+
+			Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+		})
+	})
+
+	Describe("GetPeriodsForSlo(slo *model.Slo, from, to time.Time)", func() {
+		It("Should return occurrences of slo and product windows in the range", func() {
+			from, to := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+			recurrenceEnd := time.Date(2021, 3, 12, 0, 0, 0, 0, time.UTC)
+			mockWindowProvider.EXPECT().GetMaintenanceWindowsByOrgID(orgID).Return([]*model.MaintenanceWindow{
+				{SloID: &sloID, Description: "weekly", Recurrence: model.MaintenanceRecurrenceWeekly,
+					Start: time.Date(2021, 1, 2, 22, 0, 0, 0, time.UTC), End: time.Date(2021, 1, 3, 2, 0, 0, 0, time.UTC)},
+				{ProductID: &productID, Description: "daily", Recurrence: model.MaintenanceRecurrenceDaily, RecurrenceEnd: &recurrenceEnd,
+					Start: time.Date(2021, 3, 10, 1, 0, 0, 0, time.UTC), End: time.Date(2021, 3, 10, 2, 0, 0, 0, time.UTC)},
+				{SloID: &otherSloID, Description: "other slo",
+					Start: time.Date(2021, 3, 3, 1, 0, 0, 0, time.UTC), End: time.Date(2021, 3, 3, 2, 0, 0, 0, time.UTC)},
+				{SloID: &sloID, Description: "before range",
+					Start: time.Date(2021, 2, 3, 1, 0, 0, 0, time.UTC), End: time.Date(2021, 2, 3, 2, 0, 0, 0, time.UTC)},
+			}, nil)
+			mockWindowProvider.EXPECT().GetSloProductID(sloID).Return(&productID, nil).Times(1)
+
+			periods, err := periodService.GetPeriodsForSlo(slo, from, to)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(periods).To(Equal([]model.MaintenancePeriod{
+				{From: time.Date(2021, 3, 6, 22, 0, 0, 0, time.UTC), To: time.Date(2021, 3, 7, 2, 0, 0, 0, time.UTC), Text: "weekly"},
+				{From: time.Date(2021, 3, 13, 22, 0, 0, 0, time.UTC), To: time.Date(2021, 3, 14, 2, 0, 0, 0, time.UTC), Text: "weekly"},
+				{From: time.Date(2021, 3, 10, 1, 0, 0, 0, time.UTC), To: time.Date(2021, 3, 10, 2, 0, 0, 0, time.UTC), Text: "daily"},
+				{From: time.Date(2021, 3, 11, 1, 0, 0, 0, time.UTC), To: time.Date(2021, 3, 11, 2, 0, 0, 0, time.UTC), Text: "daily"},
+			}))
+		})
+	})
+})
@@ -1,227 +1,189 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service (interfaces: IDashboardService,IDatasourceService,IFeedbackService,IHappinessMetricService,IHealthService,IOrganizationService,IParamExistCheckService,IProductsStatusService,IRecommendationVoteService,ISDAService,ISloService,ISolutionSloService,ISolutionsService,IUserInfoService)
+// Source: github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service (interfaces: IAdminService,IBudgetPolicyService,IComplianceReportService,ICompositeSloService,IDashboardService,IDatadogClientFactory,IDatadogImportService,IDatasourceService,IEntityVersionService,IErrorBudgetService,IFeedbackService,IHappinessMetricService,IHealthService,IIdempotencyService,IMaintenancePeriodService,IMaintenanceWindowService,IOrganizationService,IParamExistCheckService,IPluginEnabler,IProductsStatusService,IRecommendationVoteService,ISDAService,ISearchService,ISlaService,ISloAuthorizer,ISloBatchAuthorizer,ISloBatchService,ISloChangeRequestService,ISloHistoryIngestionService,ISloLabelService,ISloOwnershipService,ISloService,ISolutionSloService,ISolutionsService,IUserInfoService,IWebhookService)
 
 // Package service is a generated GoMock package.
 package service
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	datadog "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
 	model "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	auth "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	datadog0 "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	elastic "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
 	grafana "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
 )
 
-// MockIDashboardService is a mock of IDashboardService interface.
-type MockIDashboardService struct {
+// MockIAdminService is a mock of IAdminService interface.
+type MockIAdminService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIDashboardServiceMockRecorder
+	recorder *MockIAdminServiceMockRecorder
 }
 
-// MockIDashboardServiceMockRecorder is the mock recorder for MockIDashboardService.
-type MockIDashboardServiceMockRecorder struct {
-	mock *MockIDashboardService
+// MockIAdminServiceMockRecorder is the mock recorder for MockIAdminService.
+type MockIAdminServiceMockRecorder struct {
+	mock *MockIAdminService
 }
 
-// NewMockIDashboardService creates a new mock instance.
-func NewMockIDashboardService(ctrl *gomock.Controller) *MockIDashboardService {
-	mock := &MockIDashboardService{ctrl: ctrl}
-	mock.recorder = &MockIDashboardServiceMockRecorder{mock}
+// NewMockIAdminService creates a new mock instance.
+func NewMockIAdminService(ctrl *gomock.Controller) *MockIAdminService {
+	mock := &MockIAdminService{ctrl: ctrl}
+	mock.recorder = &MockIAdminServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIDashboardService) EXPECT() *MockIDashboardServiceMockRecorder {
+func (m *MockIAdminService) EXPECT() *MockIAdminServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateDashboard mocks base method.
-func (m *MockIDashboardService) CreateDashboard(arg0 *auth.UserContext, arg1 *model.Slo, arg2 bool) error {
+// EnablePlugin mocks base method.
+func (m *MockIAdminService) EnablePlugin(arg0 *auth.UserContext, arg1 []int64, arg2, arg3 bool) ([]*model.AdminTaskResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateDashboard", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "EnablePlugin", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.AdminTaskResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateDashboard indicates an expected call of CreateDashboard.
-func (mr *MockIDashboardServiceMockRecorder) CreateDashboard(arg0, arg1, arg2 interface{}) *gomock.Call {
+// EnablePlugin indicates an expected call of EnablePlugin.
+func (mr *MockIAdminServiceMockRecorder) EnablePlugin(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDashboard", reflect.TypeOf((*MockIDashboardService)(nil).CreateDashboard), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePlugin", reflect.TypeOf((*MockIAdminService)(nil).EnablePlugin), arg0, arg1, arg2, arg3)
 }
 
-// DeleteDashboard mocks base method.
-func (m *MockIDashboardService) DeleteDashboard(arg0 *auth.UserContext, arg1, arg2 int64) error {
+// ExportSlos mocks base method.
+func (m *MockIAdminService) ExportSlos(arg0 int64) ([]*model.Slo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteDashboard", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ExportSlos", arg0)
+	ret0, _ := ret[0].([]*model.Slo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteDashboard indicates an expected call of DeleteDashboard.
-func (mr *MockIDashboardServiceMockRecorder) DeleteDashboard(arg0, arg1, arg2 interface{}) *gomock.Call {
+// ExportSlos indicates an expected call of ExportSlos.
+func (mr *MockIAdminServiceMockRecorder) ExportSlos(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDashboard", reflect.TypeOf((*MockIDashboardService)(nil).DeleteDashboard), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSlos", reflect.TypeOf((*MockIAdminService)(nil).ExportSlos), arg0)
 }
 
-// UpdateDashboard mocks base method.
-func (m *MockIDashboardService) UpdateDashboard(arg0 *auth.UserContext, arg1 *model.Slo) error {
+// ImportSlos mocks base method.
+func (m *MockIAdminService) ImportSlos(arg0 *auth.UserContext, arg1 []*model.Slo, arg2 bool) []*model.AdminTaskResult {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateDashboard", arg0, arg1)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "ImportSlos", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.AdminTaskResult)
 	return ret0
 }
 
-// UpdateDashboard indicates an expected call of UpdateDashboard.
-func (mr *MockIDashboardServiceMockRecorder) UpdateDashboard(arg0, arg1 interface{}) *gomock.Call {
+// ImportSlos indicates an expected call of ImportSlos.
+func (mr *MockIAdminServiceMockRecorder) ImportSlos(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDashboard", reflect.TypeOf((*MockIDashboardService)(nil).UpdateDashboard), arg0, arg1)
-}
-
-// MockIDatasourceService is a mock of IDatasourceService interface.
-type MockIDatasourceService struct {
-	ctrl     *gomock.Controller
-	recorder *MockIDatasourceServiceMockRecorder
-}
-
-// MockIDatasourceServiceMockRecorder is the mock recorder for MockIDatasourceService.
-type MockIDatasourceServiceMockRecorder struct {
-	mock *MockIDatasourceService
-}
-
-// NewMockIDatasourceService creates a new mock instance.
-func NewMockIDatasourceService(ctrl *gomock.Controller) *MockIDatasourceService {
-	mock := &MockIDatasourceService{ctrl: ctrl}
-	mock.recorder = &MockIDatasourceServiceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIDatasourceService) EXPECT() *MockIDatasourceServiceMockRecorder {
-	return m.recorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSlos", reflect.TypeOf((*MockIAdminService)(nil).ImportSlos), arg0, arg1, arg2)
 }
 
-// GetDatasourceByID mocks base method.
-func (m *MockIDatasourceService) GetDatasourceByID(arg0 int64) (*grafana.Datasource, error) {
+// Login mocks base method.
+func (m *MockIAdminService) Login(arg0, arg1 string) (*auth.UserContext, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDatasourceByID", arg0)
-	ret0, _ := ret[0].(*grafana.Datasource)
+	ret := m.ctrl.Call(m, "Login", arg0, arg1)
+	ret0, _ := ret[0].(*auth.UserContext)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDatasourceByID indicates an expected call of GetDatasourceByID.
-func (mr *MockIDatasourceServiceMockRecorder) GetDatasourceByID(arg0 interface{}) *gomock.Call {
+// Login indicates an expected call of Login.
+func (mr *MockIAdminServiceMockRecorder) Login(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatasourceByID", reflect.TypeOf((*MockIDatasourceService)(nil).GetDatasourceByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockIAdminService)(nil).Login), arg0, arg1)
 }
 
-// GetDatasourcesByOrganizationID mocks base method.
-func (m *MockIDatasourceService) GetDatasourcesByOrganizationID(arg0 int64) ([]*grafana.Datasource, error) {
+// PurgeOrphanedHistory mocks base method.
+func (m *MockIAdminService) PurgeOrphanedHistory(arg0 bool) ([]*model.AdminTaskResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDatasourcesByOrganizationID", arg0)
-	ret0, _ := ret[0].([]*grafana.Datasource)
+	ret := m.ctrl.Call(m, "PurgeOrphanedHistory", arg0)
+	ret0, _ := ret[0].([]*model.AdminTaskResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDatasourcesByOrganizationID indicates an expected call of GetDatasourcesByOrganizationID.
-func (mr *MockIDatasourceServiceMockRecorder) GetDatasourcesByOrganizationID(arg0 interface{}) *gomock.Call {
+// PurgeOrphanedHistory indicates an expected call of PurgeOrphanedHistory.
+func (mr *MockIAdminServiceMockRecorder) PurgeOrphanedHistory(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatasourcesByOrganizationID", reflect.TypeOf((*MockIDatasourceService)(nil).GetDatasourcesByOrganizationID), arg0)
-}
-
-// MockIFeedbackService is a mock of IFeedbackService interface.
-type MockIFeedbackService struct {
-	ctrl     *gomock.Controller
-	recorder *MockIFeedbackServiceMockRecorder
-}
-
-// MockIFeedbackServiceMockRecorder is the mock recorder for MockIFeedbackService.
-type MockIFeedbackServiceMockRecorder struct {
-	mock *MockIFeedbackService
-}
-
-// NewMockIFeedbackService creates a new mock instance.
-func NewMockIFeedbackService(ctrl *gomock.Controller) *MockIFeedbackService {
-	mock := &MockIFeedbackService{ctrl: ctrl}
-	mock.recorder = &MockIFeedbackServiceMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIFeedbackService) EXPECT() *MockIFeedbackServiceMockRecorder {
-	return m.recorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeOrphanedHistory", reflect.TypeOf((*MockIAdminService)(nil).PurgeOrphanedHistory), arg0)
 }
 
-// Create mocks base method.
-func (m *MockIFeedbackService) Create(arg0 *model.Feedback) error {
+// RerenderDashboards mocks base method.
+func (m *MockIAdminService) RerenderDashboards(arg0 *auth.UserContext, arg1 int64, arg2 bool) ([]*model.AdminTaskResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RerenderDashboards", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.AdminTaskResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockIFeedbackServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+// RerenderDashboards indicates an expected call of RerenderDashboards.
+func (mr *MockIAdminServiceMockRecorder) RerenderDashboards(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIFeedbackService)(nil).Create), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RerenderDashboards", reflect.TypeOf((*MockIAdminService)(nil).RerenderDashboards), arg0, arg1, arg2)
 }
 
-// GetByOrgID mocks base method.
-func (m *MockIFeedbackService) GetByOrgID(arg0 int64) ([]*model.Feedback, error) {
+// SyncUsers mocks base method.
+func (m *MockIAdminService) SyncUsers(arg0 bool) ([]*model.AdminTaskResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByOrgID", arg0)
-	ret0, _ := ret[0].([]*model.Feedback)
+	ret := m.ctrl.Call(m, "SyncUsers", arg0)
+	ret0, _ := ret[0].([]*model.AdminTaskResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByOrgID indicates an expected call of GetByOrgID.
-func (mr *MockIFeedbackServiceMockRecorder) GetByOrgID(arg0 interface{}) *gomock.Call {
+// SyncUsers indicates an expected call of SyncUsers.
+func (mr *MockIAdminServiceMockRecorder) SyncUsers(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockIFeedbackService)(nil).GetByOrgID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncUsers", reflect.TypeOf((*MockIAdminService)(nil).SyncUsers), arg0)
 }
 
-// MockIHappinessMetricService is a mock of IHappinessMetricService interface.
-type MockIHappinessMetricService struct {
+// MockIBudgetPolicyService is a mock of IBudgetPolicyService interface.
+type MockIBudgetPolicyService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIHappinessMetricServiceMockRecorder
+	recorder *MockIBudgetPolicyServiceMockRecorder
 }
 
-// MockIHappinessMetricServiceMockRecorder is the mock recorder for MockIHappinessMetricService.
-type MockIHappinessMetricServiceMockRecorder struct {
-	mock *MockIHappinessMetricService
+// MockIBudgetPolicyServiceMockRecorder is the mock recorder for MockIBudgetPolicyService.
+type MockIBudgetPolicyServiceMockRecorder struct {
+	mock *MockIBudgetPolicyService
 }
 
-// NewMockIHappinessMetricService creates a new mock instance.
-func NewMockIHappinessMetricService(ctrl *gomock.Controller) *MockIHappinessMetricService {
-	mock := &MockIHappinessMetricService{ctrl: ctrl}
-	mock.recorder = &MockIHappinessMetricServiceMockRecorder{mock}
+// NewMockIBudgetPolicyService creates a new mock instance.
+func NewMockIBudgetPolicyService(ctrl *gomock.Controller) *MockIBudgetPolicyService {
+	mock := &MockIBudgetPolicyService{ctrl: ctrl}
+	mock.recorder = &MockIBudgetPolicyServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIHappinessMetricService) EXPECT() *MockIHappinessMetricServiceMockRecorder {
+func (m *MockIBudgetPolicyService) EXPECT() *MockIBudgetPolicyServiceMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockIHappinessMetricService) Create(arg0 *auth.UserContext, arg1 *model.HappinessMetric) error {
+func (m *MockIBudgetPolicyService) Create(arg0 *model.BudgetPolicy) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret := m.ctrl.Call(m, "Create", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIHappinessMetricServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockIBudgetPolicyServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIHappinessMetricService)(nil).Create), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIBudgetPolicyService)(nil).Create), arg0)
 }
 
 // Delete mocks base method.
-func (m *MockIHappinessMetricService) Delete(arg0 *auth.UserContext, arg1 int64) error {
+func (m *MockIBudgetPolicyService) Delete(arg0, arg1 int64) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -229,381 +191,2028 @@ func (m *MockIHappinessMetricService) Delete(arg0 *auth.UserContext, arg1 int64)
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIHappinessMetricServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockIBudgetPolicyServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIHappinessMetricService)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIBudgetPolicyService)(nil).Delete), arg0, arg1)
+}
+
+// EvaluateAll mocks base method.
+func (m *MockIBudgetPolicyService) EvaluateAll(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EvaluateAll", arg0)
+}
+
+// EvaluateAll indicates an expected call of EvaluateAll.
+func (mr *MockIBudgetPolicyServiceMockRecorder) EvaluateAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvaluateAll", reflect.TypeOf((*MockIBudgetPolicyService)(nil).EvaluateAll), arg0)
 }
 
 // Get mocks base method.
-func (m *MockIHappinessMetricService) Get(arg0 int64) (*model.HappinessMetric, error) {
+func (m *MockIBudgetPolicyService) Get(arg0, arg1 int64) (*model.BudgetPolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0)
-	ret0, _ := ret[0].(*model.HappinessMetric)
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.BudgetPolicy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockIHappinessMetricServiceMockRecorder) Get(arg0 interface{}) *gomock.Call {
+func (mr *MockIBudgetPolicyServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIHappinessMetricService)(nil).Get), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIBudgetPolicyService)(nil).Get), arg0, arg1)
 }
 
-// GetAllHappinessMetricsForTeam mocks base method.
-func (m *MockIHappinessMetricService) GetAllHappinessMetricsForTeam(arg0 int64) ([]*model.HappinessMetric, error) {
+// GetByOrgID mocks base method.
+func (m *MockIBudgetPolicyService) GetByOrgID(arg0 int64) ([]*model.BudgetPolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllHappinessMetricsForTeam", arg0)
-	ret0, _ := ret[0].([]*model.HappinessMetric)
+	ret := m.ctrl.Call(m, "GetByOrgID", arg0)
+	ret0, _ := ret[0].([]*model.BudgetPolicy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllHappinessMetricsForTeam indicates an expected call of GetAllHappinessMetricsForTeam.
-func (mr *MockIHappinessMetricServiceMockRecorder) GetAllHappinessMetricsForTeam(arg0 interface{}) *gomock.Call {
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockIBudgetPolicyServiceMockRecorder) GetByOrgID(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHappinessMetricsForTeam", reflect.TypeOf((*MockIHappinessMetricService)(nil).GetAllHappinessMetricsForTeam), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockIBudgetPolicyService)(nil).GetByOrgID), arg0)
 }
 
-// GetAllHappinessMetricsForUser mocks base method.
-func (m *MockIHappinessMetricService) GetAllHappinessMetricsForUser(arg0, arg1 int64) ([]*model.HappinessMetric, error) {
+// GetDeployFreezes mocks base method.
+func (m *MockIBudgetPolicyService) GetDeployFreezes(arg0 int64) ([]*model.DeployFreeze, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllHappinessMetricsForUser", arg0, arg1)
-	ret0, _ := ret[0].([]*model.HappinessMetric)
+	ret := m.ctrl.Call(m, "GetDeployFreezes", arg0)
+	ret0, _ := ret[0].([]*model.DeployFreeze)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllHappinessMetricsForUser indicates an expected call of GetAllHappinessMetricsForUser.
-func (mr *MockIHappinessMetricServiceMockRecorder) GetAllHappinessMetricsForUser(arg0, arg1 interface{}) *gomock.Call {
+// GetDeployFreezes indicates an expected call of GetDeployFreezes.
+func (mr *MockIBudgetPolicyServiceMockRecorder) GetDeployFreezes(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHappinessMetricsForUser", reflect.TypeOf((*MockIHappinessMetricService)(nil).GetAllHappinessMetricsForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployFreezes", reflect.TypeOf((*MockIBudgetPolicyService)(nil).GetDeployFreezes), arg0)
 }
 
-// GetUsersMissingInput mocks base method.
-func (m *MockIHappinessMetricService) GetUsersMissingInput(arg0 int64) ([]*model.UserMissingInput, error) {
+// GetDeployGate mocks base method.
+func (m *MockIBudgetPolicyService) GetDeployGate(arg0 int64, arg1 time.Time) (*model.DeployGate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersMissingInput", arg0)
-	ret0, _ := ret[0].([]*model.UserMissingInput)
+	ret := m.ctrl.Call(m, "GetDeployGate", arg0, arg1)
+	ret0, _ := ret[0].(*model.DeployGate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsersMissingInput indicates an expected call of GetUsersMissingInput.
-func (mr *MockIHappinessMetricServiceMockRecorder) GetUsersMissingInput(arg0 interface{}) *gomock.Call {
+// GetDeployGate indicates an expected call of GetDeployGate.
+func (mr *MockIBudgetPolicyServiceMockRecorder) GetDeployGate(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersMissingInput", reflect.TypeOf((*MockIHappinessMetricService)(nil).GetUsersMissingInput), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeployGate", reflect.TypeOf((*MockIBudgetPolicyService)(nil).GetDeployGate), arg0, arg1)
 }
 
-// SaveTeamAverage mocks base method.
-func (m *MockIHappinessMetricService) SaveTeamAverage(arg0 int64) (int64, error) {
+// Start mocks base method.
+func (m *MockIBudgetPolicyService) Start(arg0 context.Context) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveTeamAverage", arg0)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	m.ctrl.Call(m, "Start", arg0)
 }
 
-// SaveTeamAverage indicates an expected call of SaveTeamAverage.
-func (mr *MockIHappinessMetricServiceMockRecorder) SaveTeamAverage(arg0 interface{}) *gomock.Call {
+// Start indicates an expected call of Start.
+func (mr *MockIBudgetPolicyServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeamAverage", reflect.TypeOf((*MockIHappinessMetricService)(nil).SaveTeamAverage), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIBudgetPolicyService)(nil).Start), arg0)
 }
 
 // Update mocks base method.
-func (m *MockIHappinessMetricService) Update(arg0 *auth.UserContext, arg1 *model.HappinessMetric) error {
+func (m *MockIBudgetPolicyService) Update(arg0 *model.BudgetPolicy) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockIHappinessMetricServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockIBudgetPolicyServiceMockRecorder) Update(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIHappinessMetricService)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIBudgetPolicyService)(nil).Update), arg0)
 }
 
-// MockIHealthService is a mock of IHealthService interface.
-type MockIHealthService struct {
+// MockIComplianceReportService is a mock of IComplianceReportService interface.
+type MockIComplianceReportService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIHealthServiceMockRecorder
+	recorder *MockIComplianceReportServiceMockRecorder
 }
 
-// MockIHealthServiceMockRecorder is the mock recorder for MockIHealthService.
-type MockIHealthServiceMockRecorder struct {
-	mock *MockIHealthService
+// MockIComplianceReportServiceMockRecorder is the mock recorder for MockIComplianceReportService.
+type MockIComplianceReportServiceMockRecorder struct {
+	mock *MockIComplianceReportService
 }
 
-// NewMockIHealthService creates a new mock instance.
-func NewMockIHealthService(ctrl *gomock.Controller) *MockIHealthService {
-	mock := &MockIHealthService{ctrl: ctrl}
-	mock.recorder = &MockIHealthServiceMockRecorder{mock}
+// NewMockIComplianceReportService creates a new mock instance.
+func NewMockIComplianceReportService(ctrl *gomock.Controller) *MockIComplianceReportService {
+	mock := &MockIComplianceReportService{ctrl: ctrl}
+	mock.recorder = &MockIComplianceReportServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIHealthService) EXPECT() *MockIHealthServiceMockRecorder {
+func (m *MockIComplianceReportService) EXPECT() *MockIComplianceReportServiceMockRecorder {
 	return m.recorder
 }
 
-// CheckHealth mocks base method.
-func (m *MockIHealthService) CheckHealth() error {
+// Generate mocks base method.
+func (m *MockIComplianceReportService) Generate(arg0 int64, arg1 *model.ComplianceReportRequest, arg2 time.Time) (*model.ComplianceReport, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckHealth")
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Generate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ComplianceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CheckHealth indicates an expected call of CheckHealth.
-func (mr *MockIHealthServiceMockRecorder) CheckHealth() *gomock.Call {
+// Generate indicates an expected call of Generate.
+func (mr *MockIComplianceReportServiceMockRecorder) Generate(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckHealth", reflect.TypeOf((*MockIHealthService)(nil).CheckHealth))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockIComplianceReportService)(nil).Generate), arg0, arg1, arg2)
 }
 
-// MockIOrganizationService is a mock of IOrganizationService interface.
-type MockIOrganizationService struct {
+// GenerateScheduled mocks base method.
+func (m *MockIComplianceReportService) GenerateScheduled(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GenerateScheduled", arg0)
+}
+
+// GenerateScheduled indicates an expected call of GenerateScheduled.
+func (mr *MockIComplianceReportServiceMockRecorder) GenerateScheduled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateScheduled", reflect.TypeOf((*MockIComplianceReportService)(nil).GenerateScheduled), arg0)
+}
+
+// Get mocks base method.
+func (m *MockIComplianceReportService) Get(arg0, arg1 int64) (*model.ComplianceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.ComplianceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIComplianceReportServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIComplianceReportService)(nil).Get), arg0, arg1)
+}
+
+// GetByOrgID mocks base method.
+func (m *MockIComplianceReportService) GetByOrgID(arg0 int64) ([]*model.ComplianceReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", arg0)
+	ret0, _ := ret[0].([]*model.ComplianceReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockIComplianceReportServiceMockRecorder) GetByOrgID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockIComplianceReportService)(nil).GetByOrgID), arg0)
+}
+
+// Start mocks base method.
+func (m *MockIComplianceReportService) Start(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", arg0)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIComplianceReportServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIComplianceReportService)(nil).Start), arg0)
+}
+
+// MockICompositeSloService is a mock of ICompositeSloService interface.
+type MockICompositeSloService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIOrganizationServiceMockRecorder
+	recorder *MockICompositeSloServiceMockRecorder
 }
 
-// MockIOrganizationServiceMockRecorder is the mock recorder for MockIOrganizationService.
-type MockIOrganizationServiceMockRecorder struct {
-	mock *MockIOrganizationService
+// MockICompositeSloServiceMockRecorder is the mock recorder for MockICompositeSloService.
+type MockICompositeSloServiceMockRecorder struct {
+	mock *MockICompositeSloService
 }
 
-// NewMockIOrganizationService creates a new mock instance.
-func NewMockIOrganizationService(ctrl *gomock.Controller) *MockIOrganizationService {
-	mock := &MockIOrganizationService{ctrl: ctrl}
-	mock.recorder = &MockIOrganizationServiceMockRecorder{mock}
+// NewMockICompositeSloService creates a new mock instance.
+func NewMockICompositeSloService(ctrl *gomock.Controller) *MockICompositeSloService {
+	mock := &MockICompositeSloService{ctrl: ctrl}
+	mock.recorder = &MockICompositeSloServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIOrganizationService) EXPECT() *MockIOrganizationServiceMockRecorder {
+func (m *MockICompositeSloService) EXPECT() *MockICompositeSloServiceMockRecorder {
 	return m.recorder
 }
 
-// GetOrganizationByID mocks base method.
-func (m *MockIOrganizationService) GetOrganizationByID(arg0 int64) (*grafana.Organization, error) {
+// GetHistory mocks base method.
+func (m *MockICompositeSloService) GetHistory(arg0 *model.Slo, arg1 *elastic.SloHistoryRange, arg2 string) ([]*elastic.SloHistoryPoint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrganizationByID", arg0)
-	ret0, _ := ret[0].(*grafana.Organization)
+	ret := m.ctrl.Call(m, "GetHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*elastic.SloHistoryPoint)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrganizationByID indicates an expected call of GetOrganizationByID.
-func (mr *MockIOrganizationServiceMockRecorder) GetOrganizationByID(arg0 interface{}) *gomock.Call {
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockICompositeSloServiceMockRecorder) GetHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByID", reflect.TypeOf((*MockIOrganizationService)(nil).GetOrganizationByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockICompositeSloService)(nil).GetHistory), arg0, arg1, arg2)
 }
 
-// MockIParamExistCheckService is a mock of IParamExistCheckService interface.
-type MockIParamExistCheckService struct {
+// GetParentsToUnlink mocks base method.
+func (m *MockICompositeSloService) GetParentsToUnlink(arg0 *model.Slo, arg1 bool) ([]*model.SloChild, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParentsToUnlink", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SloChild)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParentsToUnlink indicates an expected call of GetParentsToUnlink.
+func (mr *MockICompositeSloServiceMockRecorder) GetParentsToUnlink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentsToUnlink", reflect.TypeOf((*MockICompositeSloService)(nil).GetParentsToUnlink), arg0, arg1)
+}
+
+// LoadChildren mocks base method.
+func (m *MockICompositeSloService) LoadChildren(arg0 *model.Slo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadChildren", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadChildren indicates an expected call of LoadChildren.
+func (mr *MockICompositeSloServiceMockRecorder) LoadChildren(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadChildren", reflect.TypeOf((*MockICompositeSloService)(nil).LoadChildren), arg0)
+}
+
+// UpdateParentDashboards mocks base method.
+func (m *MockICompositeSloService) UpdateParentDashboards(arg0 *auth.UserContext, arg1 []*model.SloChild) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateParentDashboards", arg0, arg1)
+}
+
+// UpdateParentDashboards indicates an expected call of UpdateParentDashboards.
+func (mr *MockICompositeSloServiceMockRecorder) UpdateParentDashboards(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateParentDashboards", reflect.TypeOf((*MockICompositeSloService)(nil).UpdateParentDashboards), arg0, arg1)
+}
+
+// ValidateChildren mocks base method.
+func (m *MockICompositeSloService) ValidateChildren(arg0 *auth.UserContext, arg1 *model.Slo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateChildren", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateChildren indicates an expected call of ValidateChildren.
+func (mr *MockICompositeSloServiceMockRecorder) ValidateChildren(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateChildren", reflect.TypeOf((*MockICompositeSloService)(nil).ValidateChildren), arg0, arg1)
+}
+
+// MockIDashboardService is a mock of IDashboardService interface.
+type MockIDashboardService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDashboardServiceMockRecorder
+}
+
+// MockIDashboardServiceMockRecorder is the mock recorder for MockIDashboardService.
+type MockIDashboardServiceMockRecorder struct {
+	mock *MockIDashboardService
+}
+
+// NewMockIDashboardService creates a new mock instance.
+func NewMockIDashboardService(ctrl *gomock.Controller) *MockIDashboardService {
+	mock := &MockIDashboardService{ctrl: ctrl}
+	mock.recorder = &MockIDashboardServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDashboardService) EXPECT() *MockIDashboardServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateDashboard mocks base method.
+func (m *MockIDashboardService) CreateDashboard(arg0 *auth.UserContext, arg1 *model.Slo, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDashboard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDashboard indicates an expected call of CreateDashboard.
+func (mr *MockIDashboardServiceMockRecorder) CreateDashboard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDashboard", reflect.TypeOf((*MockIDashboardService)(nil).CreateDashboard), arg0, arg1, arg2)
+}
+
+// DeleteDashboard mocks base method.
+func (m *MockIDashboardService) DeleteDashboard(arg0 *auth.UserContext, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDashboard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDashboard indicates an expected call of DeleteDashboard.
+func (mr *MockIDashboardServiceMockRecorder) DeleteDashboard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDashboard", reflect.TypeOf((*MockIDashboardService)(nil).DeleteDashboard), arg0, arg1, arg2)
+}
+
+// UpdateDashboard mocks base method.
+func (m *MockIDashboardService) UpdateDashboard(arg0 *auth.UserContext, arg1 *model.Slo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDashboard", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDashboard indicates an expected call of UpdateDashboard.
+func (mr *MockIDashboardServiceMockRecorder) UpdateDashboard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDashboard", reflect.TypeOf((*MockIDashboardService)(nil).UpdateDashboard), arg0, arg1)
+}
+
+// MockIDatadogClientFactory is a mock of IDatadogClientFactory interface.
+type MockIDatadogClientFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDatadogClientFactoryMockRecorder
+}
+
+// MockIDatadogClientFactoryMockRecorder is the mock recorder for MockIDatadogClientFactory.
+type MockIDatadogClientFactoryMockRecorder struct {
+	mock *MockIDatadogClientFactory
+}
+
+// NewMockIDatadogClientFactory creates a new mock instance.
+func NewMockIDatadogClientFactory(ctrl *gomock.Controller) *MockIDatadogClientFactory {
+	mock := &MockIDatadogClientFactory{ctrl: ctrl}
+	mock.recorder = &MockIDatadogClientFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDatadogClientFactory) EXPECT() *MockIDatadogClientFactoryMockRecorder {
+	return m.recorder
+}
+
+// ForDatasource mocks base method.
+func (m *MockIDatadogClientFactory) ForDatasource(arg0 int64) (datadog.IClient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForDatasource", arg0)
+	ret0, _ := ret[0].(datadog.IClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForDatasource indicates an expected call of ForDatasource.
+func (mr *MockIDatadogClientFactoryMockRecorder) ForDatasource(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForDatasource", reflect.TypeOf((*MockIDatadogClientFactory)(nil).ForDatasource), arg0)
+}
+
+// MockIDatadogImportService is a mock of IDatadogImportService interface.
+type MockIDatadogImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDatadogImportServiceMockRecorder
+}
+
+// MockIDatadogImportServiceMockRecorder is the mock recorder for MockIDatadogImportService.
+type MockIDatadogImportServiceMockRecorder struct {
+	mock *MockIDatadogImportService
+}
+
+// NewMockIDatadogImportService creates a new mock instance.
+func NewMockIDatadogImportService(ctrl *gomock.Controller) *MockIDatadogImportService {
+	mock := &MockIDatadogImportService{ctrl: ctrl}
+	mock.recorder = &MockIDatadogImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDatadogImportService) EXPECT() *MockIDatadogImportServiceMockRecorder {
+	return m.recorder
+}
+
+// Discover mocks base method.
+func (m *MockIDatadogImportService) Discover(arg0, arg1 int64) ([]*datadog0.SLO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discover", arg0, arg1)
+	ret0, _ := ret[0].([]*datadog0.SLO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Discover indicates an expected call of Discover.
+func (mr *MockIDatadogImportServiceMockRecorder) Discover(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discover", reflect.TypeOf((*MockIDatadogImportService)(nil).Discover), arg0, arg1)
+}
+
+// Import mocks base method.
+func (m *MockIDatadogImportService) Import(arg0 *auth.UserContext, arg1, arg2 int64, arg3 *model.SloImport) ([]*model.SloImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.SloImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockIDatadogImportServiceMockRecorder) Import(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockIDatadogImportService)(nil).Import), arg0, arg1, arg2, arg3)
+}
+
+// MockIDatasourceService is a mock of IDatasourceService interface.
+type MockIDatasourceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDatasourceServiceMockRecorder
+}
+
+// MockIDatasourceServiceMockRecorder is the mock recorder for MockIDatasourceService.
+type MockIDatasourceServiceMockRecorder struct {
+	mock *MockIDatasourceService
+}
+
+// NewMockIDatasourceService creates a new mock instance.
+func NewMockIDatasourceService(ctrl *gomock.Controller) *MockIDatasourceService {
+	mock := &MockIDatasourceService{ctrl: ctrl}
+	mock.recorder = &MockIDatasourceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDatasourceService) EXPECT() *MockIDatasourceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDatasourceByID mocks base method.
+func (m *MockIDatasourceService) GetDatasourceByID(arg0 int64) (*grafana.Datasource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatasourceByID", arg0)
+	ret0, _ := ret[0].(*grafana.Datasource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatasourceByID indicates an expected call of GetDatasourceByID.
+func (mr *MockIDatasourceServiceMockRecorder) GetDatasourceByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatasourceByID", reflect.TypeOf((*MockIDatasourceService)(nil).GetDatasourceByID), arg0)
+}
+
+// GetDatasourcesByOrganizationID mocks base method.
+func (m *MockIDatasourceService) GetDatasourcesByOrganizationID(arg0 int64) ([]*grafana.Datasource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDatasourcesByOrganizationID", arg0)
+	ret0, _ := ret[0].([]*grafana.Datasource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDatasourcesByOrganizationID indicates an expected call of GetDatasourcesByOrganizationID.
+func (mr *MockIDatasourceServiceMockRecorder) GetDatasourcesByOrganizationID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatasourcesByOrganizationID", reflect.TypeOf((*MockIDatasourceService)(nil).GetDatasourcesByOrganizationID), arg0)
+}
+
+// MockIEntityVersionService is a mock of IEntityVersionService interface.
+type MockIEntityVersionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEntityVersionServiceMockRecorder
+}
+
+// MockIEntityVersionServiceMockRecorder is the mock recorder for MockIEntityVersionService.
+type MockIEntityVersionServiceMockRecorder struct {
+	mock *MockIEntityVersionService
+}
+
+// NewMockIEntityVersionService creates a new mock instance.
+func NewMockIEntityVersionService(ctrl *gomock.Controller) *MockIEntityVersionService {
+	mock := &MockIEntityVersionService{ctrl: ctrl}
+	mock.recorder = &MockIEntityVersionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEntityVersionService) EXPECT() *MockIEntityVersionServiceMockRecorder {
+	return m.recorder
+}
+
+// Conflict mocks base method.
+func (m *MockIEntityVersionService) Conflict(arg0 model.VersionedEntity, arg1 int64, arg2 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Conflict", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Conflict indicates an expected call of Conflict.
+func (mr *MockIEntityVersionServiceMockRecorder) Conflict(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Conflict", reflect.TypeOf((*MockIEntityVersionService)(nil).Conflict), arg0, arg1, arg2)
+}
+
+// GetVersion mocks base method.
+func (m *MockIEntityVersionService) GetVersion(arg0 model.VersionedEntity, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockIEntityVersionServiceMockRecorder) GetVersion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockIEntityVersionService)(nil).GetVersion), arg0, arg1)
+}
+
+// Verify mocks base method.
+func (m *MockIEntityVersionService) Verify(arg0 model.VersionedEntity, arg1 int64, arg2 *int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockIEntityVersionServiceMockRecorder) Verify(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockIEntityVersionService)(nil).Verify), arg0, arg1, arg2)
+}
+
+// MockIErrorBudgetService is a mock of IErrorBudgetService interface.
+type MockIErrorBudgetService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIErrorBudgetServiceMockRecorder
+}
+
+// MockIErrorBudgetServiceMockRecorder is the mock recorder for MockIErrorBudgetService.
+type MockIErrorBudgetServiceMockRecorder struct {
+	mock *MockIErrorBudgetService
+}
+
+// NewMockIErrorBudgetService creates a new mock instance.
+func NewMockIErrorBudgetService(ctrl *gomock.Controller) *MockIErrorBudgetService {
+	mock := &MockIErrorBudgetService{ctrl: ctrl}
+	mock.recorder = &MockIErrorBudgetServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIErrorBudgetService) EXPECT() *MockIErrorBudgetServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAtRiskSlos mocks base method.
+func (m *MockIErrorBudgetService) GetAtRiskSlos(arg0 int64, arg1 time.Time, arg2 int) ([]*model.BudgetForecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAtRiskSlos", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.BudgetForecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAtRiskSlos indicates an expected call of GetAtRiskSlos.
+func (mr *MockIErrorBudgetServiceMockRecorder) GetAtRiskSlos(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAtRiskSlos", reflect.TypeOf((*MockIErrorBudgetService)(nil).GetAtRiskSlos), arg0, arg1, arg2)
+}
+
+// GetCompliance mocks base method.
+func (m *MockIErrorBudgetService) GetCompliance(arg0 *model.Slo, arg1, arg2 time.Time) (*model.SloCompliance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompliance", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.SloCompliance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompliance indicates an expected call of GetCompliance.
+func (mr *MockIErrorBudgetServiceMockRecorder) GetCompliance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompliance", reflect.TypeOf((*MockIErrorBudgetService)(nil).GetCompliance), arg0, arg1, arg2)
+}
+
+// GetErrorBudget mocks base method.
+func (m *MockIErrorBudgetService) GetErrorBudget(arg0 int64, arg1 time.Time) (*model.ErrorBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErrorBudget", arg0, arg1)
+	ret0, _ := ret[0].(*model.ErrorBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetErrorBudget indicates an expected call of GetErrorBudget.
+func (mr *MockIErrorBudgetServiceMockRecorder) GetErrorBudget(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErrorBudget", reflect.TypeOf((*MockIErrorBudgetService)(nil).GetErrorBudget), arg0, arg1)
+}
+
+// GetForecast mocks base method.
+func (m *MockIErrorBudgetService) GetForecast(arg0 int64, arg1 time.Time, arg2 int) (*model.BudgetForecast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForecast", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.BudgetForecast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForecast indicates an expected call of GetForecast.
+func (mr *MockIErrorBudgetServiceMockRecorder) GetForecast(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecast", reflect.TypeOf((*MockIErrorBudgetService)(nil).GetForecast), arg0, arg1, arg2)
+}
+
+// GetSlaBreach mocks base method.
+func (m *MockIErrorBudgetService) GetSlaBreach(arg0 *model.Slo, arg1, arg2 time.Time) (*model.SlaBreach, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlaBreach", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.SlaBreach)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlaBreach indicates an expected call of GetSlaBreach.
+func (mr *MockIErrorBudgetServiceMockRecorder) GetSlaBreach(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlaBreach", reflect.TypeOf((*MockIErrorBudgetService)(nil).GetSlaBreach), arg0, arg1, arg2)
+}
+
+// Simulate mocks base method.
+func (m *MockIErrorBudgetService) Simulate(arg0 int64, arg1 *model.SloSimulation) (*model.SloSimulationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Simulate", arg0, arg1)
+	ret0, _ := ret[0].(*model.SloSimulationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Simulate indicates an expected call of Simulate.
+func (mr *MockIErrorBudgetServiceMockRecorder) Simulate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Simulate", reflect.TypeOf((*MockIErrorBudgetService)(nil).Simulate), arg0, arg1)
+}
+
+// MockIFeedbackService is a mock of IFeedbackService interface.
+type MockIFeedbackService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFeedbackServiceMockRecorder
+}
+
+// MockIFeedbackServiceMockRecorder is the mock recorder for MockIFeedbackService.
+type MockIFeedbackServiceMockRecorder struct {
+	mock *MockIFeedbackService
+}
+
+// NewMockIFeedbackService creates a new mock instance.
+func NewMockIFeedbackService(ctrl *gomock.Controller) *MockIFeedbackService {
+	mock := &MockIFeedbackService{ctrl: ctrl}
+	mock.recorder = &MockIFeedbackServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFeedbackService) EXPECT() *MockIFeedbackServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIFeedbackService) Create(arg0 *model.Feedback) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIFeedbackServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIFeedbackService)(nil).Create), arg0)
+}
+
+// GetByOrgID mocks base method.
+func (m *MockIFeedbackService) GetByOrgID(arg0 int64) ([]*model.Feedback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", arg0)
+	ret0, _ := ret[0].([]*model.Feedback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockIFeedbackServiceMockRecorder) GetByOrgID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockIFeedbackService)(nil).GetByOrgID), arg0)
+}
+
+// MockIHappinessMetricService is a mock of IHappinessMetricService interface.
+type MockIHappinessMetricService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHappinessMetricServiceMockRecorder
+}
+
+// MockIHappinessMetricServiceMockRecorder is the mock recorder for MockIHappinessMetricService.
+type MockIHappinessMetricServiceMockRecorder struct {
+	mock *MockIHappinessMetricService
+}
+
+// NewMockIHappinessMetricService creates a new mock instance.
+func NewMockIHappinessMetricService(ctrl *gomock.Controller) *MockIHappinessMetricService {
+	mock := &MockIHappinessMetricService{ctrl: ctrl}
+	mock.recorder = &MockIHappinessMetricServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHappinessMetricService) EXPECT() *MockIHappinessMetricServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIHappinessMetricService) Create(arg0 *auth.UserContext, arg1 *model.HappinessMetric) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIHappinessMetricServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIHappinessMetricService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockIHappinessMetricService) Delete(arg0 *auth.UserContext, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIHappinessMetricServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIHappinessMetricService)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockIHappinessMetricService) Get(arg0 int64) (*model.HappinessMetric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(*model.HappinessMetric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIHappinessMetricServiceMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIHappinessMetricService)(nil).Get), arg0)
+}
+
+// GetAllHappinessMetricsForTeam mocks base method.
+func (m *MockIHappinessMetricService) GetAllHappinessMetricsForTeam(arg0 int64) ([]*model.HappinessMetric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllHappinessMetricsForTeam", arg0)
+	ret0, _ := ret[0].([]*model.HappinessMetric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllHappinessMetricsForTeam indicates an expected call of GetAllHappinessMetricsForTeam.
+func (mr *MockIHappinessMetricServiceMockRecorder) GetAllHappinessMetricsForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHappinessMetricsForTeam", reflect.TypeOf((*MockIHappinessMetricService)(nil).GetAllHappinessMetricsForTeam), arg0)
+}
+
+// GetAllHappinessMetricsForUser mocks base method.
+func (m *MockIHappinessMetricService) GetAllHappinessMetricsForUser(arg0, arg1 int64) ([]*model.HappinessMetric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllHappinessMetricsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*model.HappinessMetric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllHappinessMetricsForUser indicates an expected call of GetAllHappinessMetricsForUser.
+func (mr *MockIHappinessMetricServiceMockRecorder) GetAllHappinessMetricsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHappinessMetricsForUser", reflect.TypeOf((*MockIHappinessMetricService)(nil).GetAllHappinessMetricsForUser), arg0, arg1)
+}
+
+// GetUsersMissingInput mocks base method.
+func (m *MockIHappinessMetricService) GetUsersMissingInput(arg0 int64) ([]*model.UserMissingInput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersMissingInput", arg0)
+	ret0, _ := ret[0].([]*model.UserMissingInput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersMissingInput indicates an expected call of GetUsersMissingInput.
+func (mr *MockIHappinessMetricServiceMockRecorder) GetUsersMissingInput(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersMissingInput", reflect.TypeOf((*MockIHappinessMetricService)(nil).GetUsersMissingInput), arg0)
+}
+
+// SaveTeamAverage mocks base method.
+func (m *MockIHappinessMetricService) SaveTeamAverage(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTeamAverage", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTeamAverage indicates an expected call of SaveTeamAverage.
+func (mr *MockIHappinessMetricServiceMockRecorder) SaveTeamAverage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTeamAverage", reflect.TypeOf((*MockIHappinessMetricService)(nil).SaveTeamAverage), arg0)
+}
+
+// Update mocks base method.
+func (m *MockIHappinessMetricService) Update(arg0 *auth.UserContext, arg1 *model.HappinessMetric, arg2 *int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIHappinessMetricServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIHappinessMetricService)(nil).Update), arg0, arg1, arg2)
+}
+
+// MockIHealthService is a mock of IHealthService interface.
+type MockIHealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthServiceMockRecorder
+}
+
+// MockIHealthServiceMockRecorder is the mock recorder for MockIHealthService.
+type MockIHealthServiceMockRecorder struct {
+	mock *MockIHealthService
+}
+
+// NewMockIHealthService creates a new mock instance.
+func NewMockIHealthService(ctrl *gomock.Controller) *MockIHealthService {
+	mock := &MockIHealthService{ctrl: ctrl}
+	mock.recorder = &MockIHealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealthService) EXPECT() *MockIHealthServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckHealth mocks base method.
+func (m *MockIHealthService) CheckHealth() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckHealth")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckHealth indicates an expected call of CheckHealth.
+func (mr *MockIHealthServiceMockRecorder) CheckHealth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckHealth", reflect.TypeOf((*MockIHealthService)(nil).CheckHealth))
+}
+
+// MockIIdempotencyService is a mock of IIdempotencyService interface.
+type MockIIdempotencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyServiceMockRecorder
+}
+
+// MockIIdempotencyServiceMockRecorder is the mock recorder for MockIIdempotencyService.
+type MockIIdempotencyServiceMockRecorder struct {
+	mock *MockIIdempotencyService
+}
+
+// NewMockIIdempotencyService creates a new mock instance.
+func NewMockIIdempotencyService(ctrl *gomock.Controller) *MockIIdempotencyService {
+	mock := &MockIIdempotencyService{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotencyService) EXPECT() *MockIIdempotencyServiceMockRecorder {
+	return m.recorder
+}
+
+// Abort mocks base method.
+func (m *MockIIdempotencyService) Abort(arg0 *model.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Abort", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort.
+func (mr *MockIIdempotencyServiceMockRecorder) Abort(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockIIdempotencyService)(nil).Abort), arg0)
+}
+
+// Begin mocks base method.
+func (m *MockIIdempotencyService) Begin(arg0 int64, arg1, arg2, arg3 string, arg4 []byte) (*model.IdempotentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.IdempotentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIIdempotencyServiceMockRecorder) Begin(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIIdempotencyService)(nil).Begin), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Complete mocks base method.
+func (m *MockIIdempotencyService) Complete(arg0 *model.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIIdempotencyServiceMockRecorder) Complete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIIdempotencyService)(nil).Complete), arg0)
+}
+
+// DeleteExpiredAll mocks base method.
+func (m *MockIIdempotencyService) DeleteExpiredAll(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteExpiredAll", arg0)
+}
+
+// DeleteExpiredAll indicates an expected call of DeleteExpiredAll.
+func (mr *MockIIdempotencyServiceMockRecorder) DeleteExpiredAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredAll", reflect.TypeOf((*MockIIdempotencyService)(nil).DeleteExpiredAll), arg0)
+}
+
+// Start mocks base method.
+func (m *MockIIdempotencyService) Start(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", arg0)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIIdempotencyServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIIdempotencyService)(nil).Start), arg0)
+}
+
+// MockIMaintenancePeriodService is a mock of IMaintenancePeriodService interface.
+type MockIMaintenancePeriodService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMaintenancePeriodServiceMockRecorder
+}
+
+// MockIMaintenancePeriodServiceMockRecorder is the mock recorder for MockIMaintenancePeriodService.
+type MockIMaintenancePeriodServiceMockRecorder struct {
+	mock *MockIMaintenancePeriodService
+}
+
+// NewMockIMaintenancePeriodService creates a new mock instance.
+func NewMockIMaintenancePeriodService(ctrl *gomock.Controller) *MockIMaintenancePeriodService {
+	mock := &MockIMaintenancePeriodService{ctrl: ctrl}
+	mock.recorder = &MockIMaintenancePeriodServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMaintenancePeriodService) EXPECT() *MockIMaintenancePeriodServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPeriodsForSlo mocks base method.
+func (m *MockIMaintenancePeriodService) GetPeriodsForSlo(arg0 *model.Slo, arg1, arg2 time.Time) ([]model.MaintenancePeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPeriodsForSlo", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.MaintenancePeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPeriodsForSlo indicates an expected call of GetPeriodsForSlo.
+func (mr *MockIMaintenancePeriodServiceMockRecorder) GetPeriodsForSlo(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPeriodsForSlo", reflect.TypeOf((*MockIMaintenancePeriodService)(nil).GetPeriodsForSlo), arg0, arg1, arg2)
+}
+
+// MockIMaintenanceWindowService is a mock of IMaintenanceWindowService interface.
+type MockIMaintenanceWindowService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMaintenanceWindowServiceMockRecorder
+}
+
+// MockIMaintenanceWindowServiceMockRecorder is the mock recorder for MockIMaintenanceWindowService.
+type MockIMaintenanceWindowServiceMockRecorder struct {
+	mock *MockIMaintenanceWindowService
+}
+
+// NewMockIMaintenanceWindowService creates a new mock instance.
+func NewMockIMaintenanceWindowService(ctrl *gomock.Controller) *MockIMaintenanceWindowService {
+	mock := &MockIMaintenanceWindowService{ctrl: ctrl}
+	mock.recorder = &MockIMaintenanceWindowServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMaintenanceWindowService) EXPECT() *MockIMaintenanceWindowServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIMaintenanceWindowService) Create(arg0 *auth.UserContext, arg1 *model.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIMaintenanceWindowServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIMaintenanceWindowService)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockIMaintenanceWindowService) Delete(arg0 *auth.UserContext, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIMaintenanceWindowServiceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIMaintenanceWindowService)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Get mocks base method.
+func (m *MockIMaintenanceWindowService) Get(arg0, arg1 int64) (*model.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIMaintenanceWindowServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIMaintenanceWindowService)(nil).Get), arg0, arg1)
+}
+
+// GetByOrgID mocks base method.
+func (m *MockIMaintenanceWindowService) GetByOrgID(arg0 int64) ([]*model.MaintenanceWindow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", arg0)
+	ret0, _ := ret[0].([]*model.MaintenanceWindow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockIMaintenanceWindowServiceMockRecorder) GetByOrgID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockIMaintenanceWindowService)(nil).GetByOrgID), arg0)
+}
+
+// Update mocks base method.
+func (m *MockIMaintenanceWindowService) Update(arg0 *auth.UserContext, arg1 *model.MaintenanceWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIMaintenanceWindowServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIMaintenanceWindowService)(nil).Update), arg0, arg1)
+}
+
+// MockIOrganizationService is a mock of IOrganizationService interface.
+type MockIOrganizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOrganizationServiceMockRecorder
+}
+
+// MockIOrganizationServiceMockRecorder is the mock recorder for MockIOrganizationService.
+type MockIOrganizationServiceMockRecorder struct {
+	mock *MockIOrganizationService
+}
+
+// NewMockIOrganizationService creates a new mock instance.
+func NewMockIOrganizationService(ctrl *gomock.Controller) *MockIOrganizationService {
+	mock := &MockIOrganizationService{ctrl: ctrl}
+	mock.recorder = &MockIOrganizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOrganizationService) EXPECT() *MockIOrganizationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetOrganizationByID mocks base method.
+func (m *MockIOrganizationService) GetOrganizationByID(arg0 int64) (*grafana.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByID", arg0)
+	ret0, _ := ret[0].(*grafana.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByID indicates an expected call of GetOrganizationByID.
+func (mr *MockIOrganizationServiceMockRecorder) GetOrganizationByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByID", reflect.TypeOf((*MockIOrganizationService)(nil).GetOrganizationByID), arg0)
+}
+
+// MockIParamExistCheckService is a mock of IParamExistCheckService interface.
+type MockIParamExistCheckService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIParamExistCheckServiceMockRecorder
+}
+
+// MockIParamExistCheckServiceMockRecorder is the mock recorder for MockIParamExistCheckService.
+type MockIParamExistCheckServiceMockRecorder struct {
+	mock *MockIParamExistCheckService
+}
+
+// NewMockIParamExistCheckService creates a new mock instance.
+func NewMockIParamExistCheckService(ctrl *gomock.Controller) *MockIParamExistCheckService {
+	mock := &MockIParamExistCheckService{ctrl: ctrl}
+	mock.recorder = &MockIParamExistCheckServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIParamExistCheckService) EXPECT() *MockIParamExistCheckServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckOrgByID mocks base method.
+func (m *MockIParamExistCheckService) CheckOrgByID(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOrgByID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckOrgByID indicates an expected call of CheckOrgByID.
+func (mr *MockIParamExistCheckServiceMockRecorder) CheckOrgByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrgByID", reflect.TypeOf((*MockIParamExistCheckService)(nil).CheckOrgByID), arg0)
+}
+
+// CheckSloByID mocks base method.
+func (m *MockIParamExistCheckService) CheckSloByID(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSloByID", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSloByID indicates an expected call of CheckSloByID.
+func (mr *MockIParamExistCheckServiceMockRecorder) CheckSloByID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSloByID", reflect.TypeOf((*MockIParamExistCheckService)(nil).CheckSloByID), arg0)
+}
+
+// MockIPluginEnabler is a mock of IPluginEnabler interface.
+type MockIPluginEnabler struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPluginEnablerMockRecorder
+}
+
+// MockIPluginEnablerMockRecorder is the mock recorder for MockIPluginEnabler.
+type MockIPluginEnablerMockRecorder struct {
+	mock *MockIPluginEnabler
+}
+
+// NewMockIPluginEnabler creates a new mock instance.
+func NewMockIPluginEnabler(ctrl *gomock.Controller) *MockIPluginEnabler {
+	mock := &MockIPluginEnabler{ctrl: ctrl}
+	mock.recorder = &MockIPluginEnablerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPluginEnabler) EXPECT() *MockIPluginEnablerMockRecorder {
+	return m.recorder
+}
+
+// EnablePluginWithDataSources mocks base method.
+func (m *MockIPluginEnabler) EnablePluginWithDataSources(arg0 *grafana.Organization, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnablePluginWithDataSources", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnablePluginWithDataSources indicates an expected call of EnablePluginWithDataSources.
+func (mr *MockIPluginEnablerMockRecorder) EnablePluginWithDataSources(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePluginWithDataSources", reflect.TypeOf((*MockIPluginEnabler)(nil).EnablePluginWithDataSources), arg0, arg1, arg2)
+}
+
+// MockIProductsStatusService is a mock of IProductsStatusService interface.
+type MockIProductsStatusService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIProductsStatusServiceMockRecorder
+}
+
+// MockIProductsStatusServiceMockRecorder is the mock recorder for MockIProductsStatusService.
+type MockIProductsStatusServiceMockRecorder struct {
+	mock *MockIProductsStatusService
+}
+
+// NewMockIProductsStatusService creates a new mock instance.
+func NewMockIProductsStatusService(ctrl *gomock.Controller) *MockIProductsStatusService {
+	mock := &MockIProductsStatusService{ctrl: ctrl}
+	mock.recorder = &MockIProductsStatusServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIProductsStatusService) EXPECT() *MockIProductsStatusServiceMockRecorder {
+	return m.recorder
+}
+
+// GetProductsStatus mocks base method.
+func (m *MockIProductsStatusService) GetProductsStatus() ([]*model.ProductStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductsStatus")
+	ret0, _ := ret[0].([]*model.ProductStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductsStatus indicates an expected call of GetProductsStatus.
+func (mr *MockIProductsStatusServiceMockRecorder) GetProductsStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsStatus", reflect.TypeOf((*MockIProductsStatusService)(nil).GetProductsStatus))
+}
+
+// MockIRecommendationVoteService is a mock of IRecommendationVoteService interface.
+type MockIRecommendationVoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRecommendationVoteServiceMockRecorder
+}
+
+// MockIRecommendationVoteServiceMockRecorder is the mock recorder for MockIRecommendationVoteService.
+type MockIRecommendationVoteServiceMockRecorder struct {
+	mock *MockIRecommendationVoteService
+}
+
+// NewMockIRecommendationVoteService creates a new mock instance.
+func NewMockIRecommendationVoteService(ctrl *gomock.Controller) *MockIRecommendationVoteService {
+	mock := &MockIRecommendationVoteService{ctrl: ctrl}
+	mock.recorder = &MockIRecommendationVoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRecommendationVoteService) EXPECT() *MockIRecommendationVoteServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIRecommendationVoteService) Create(arg0 *model.RecommendationVote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIRecommendationVoteServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRecommendationVoteService)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockIRecommendationVoteService) Delete(arg0 *model.RecommendationVote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIRecommendationVoteServiceMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIRecommendationVoteService)(nil).Delete), arg0)
+}
+
+// Get mocks base method.
+func (m *MockIRecommendationVoteService) Get(arg0 int64, arg1 *int64) ([]*model.RecommendationVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].([]*model.RecommendationVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIRecommendationVoteServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIRecommendationVoteService)(nil).Get), arg0, arg1)
+}
+
+// MockISDAService is a mock of ISDAService interface.
+type MockISDAService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIParamExistCheckServiceMockRecorder
+	recorder *MockISDAServiceMockRecorder
 }
 
-// MockIParamExistCheckServiceMockRecorder is the mock recorder for MockIParamExistCheckService.
-type MockIParamExistCheckServiceMockRecorder struct {
-	mock *MockIParamExistCheckService
+// MockISDAServiceMockRecorder is the mock recorder for MockISDAService.
+type MockISDAServiceMockRecorder struct {
+	mock *MockISDAService
+}
+
+// NewMockISDAService creates a new mock instance.
+func NewMockISDAService(ctrl *gomock.Controller) *MockISDAService {
+	mock := &MockISDAService{ctrl: ctrl}
+	mock.recorder = &MockISDAServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISDAService) EXPECT() *MockISDAServiceMockRecorder {
+	return m.recorder
+}
+
+// GetConfig mocks base method.
+func (m *MockISDAService) GetConfig() ([]map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfig")
+	ret0, _ := ret[0].([]map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfig indicates an expected call of GetConfig.
+func (mr *MockISDAServiceMockRecorder) GetConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockISDAService)(nil).GetConfig))
+}
+
+// GetSDAFeatureByOrg mocks base method.
+func (m *MockISDAService) GetSDAFeatureByOrg(arg0 int64) (*model.SDAFeatures, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSDAFeatureByOrg", arg0)
+	ret0, _ := ret[0].(*model.SDAFeatures)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSDAFeatureByOrg indicates an expected call of GetSDAFeatureByOrg.
+func (mr *MockISDAServiceMockRecorder) GetSDAFeatureByOrg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSDAFeatureByOrg", reflect.TypeOf((*MockISDAService)(nil).GetSDAFeatureByOrg), arg0)
+}
+
+// MockISearchService is a mock of ISearchService interface.
+type MockISearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISearchServiceMockRecorder
+}
+
+// MockISearchServiceMockRecorder is the mock recorder for MockISearchService.
+type MockISearchServiceMockRecorder struct {
+	mock *MockISearchService
+}
+
+// NewMockISearchService creates a new mock instance.
+func NewMockISearchService(ctrl *gomock.Controller) *MockISearchService {
+	mock := &MockISearchService{ctrl: ctrl}
+	mock.recorder = &MockISearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISearchService) EXPECT() *MockISearchServiceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockISearchService) Search(arg0 *auth.UserContext, arg1 string, arg2 []model.SearchHitType, arg3 int) ([]*model.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockISearchServiceMockRecorder) Search(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockISearchService)(nil).Search), arg0, arg1, arg2, arg3)
+}
+
+// MockISlaService is a mock of ISlaService interface.
+type MockISlaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISlaServiceMockRecorder
+}
+
+// MockISlaServiceMockRecorder is the mock recorder for MockISlaService.
+type MockISlaServiceMockRecorder struct {
+	mock *MockISlaService
+}
+
+// NewMockISlaService creates a new mock instance.
+func NewMockISlaService(ctrl *gomock.Controller) *MockISlaService {
+	mock := &MockISlaService{ctrl: ctrl}
+	mock.recorder = &MockISlaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISlaService) EXPECT() *MockISlaServiceMockRecorder {
+	return m.recorder
+}
+
+// GetBreaches mocks base method.
+func (m *MockISlaService) GetBreaches(arg0 int64, arg1, arg2 time.Time) ([]*model.SlaBreach, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBreaches", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.SlaBreach)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBreaches indicates an expected call of GetBreaches.
+func (mr *MockISlaServiceMockRecorder) GetBreaches(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBreaches", reflect.TypeOf((*MockISlaService)(nil).GetBreaches), arg0, arg1, arg2)
+}
+
+// GetCreditTable mocks base method.
+func (m *MockISlaService) GetCreditTable(arg0, arg1 int64) (*model.SlaCreditTable, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreditTable", arg0, arg1)
+	ret0, _ := ret[0].(*model.SlaCreditTable)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreditTable indicates an expected call of GetCreditTable.
+func (mr *MockISlaServiceMockRecorder) GetCreditTable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreditTable", reflect.TypeOf((*MockISlaService)(nil).GetCreditTable), arg0, arg1)
+}
+
+// SetCreditTable mocks base method.
+func (m *MockISlaService) SetCreditTable(arg0 *model.SlaCreditTable) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCreditTable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCreditTable indicates an expected call of SetCreditTable.
+func (mr *MockISlaServiceMockRecorder) SetCreditTable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCreditTable", reflect.TypeOf((*MockISlaService)(nil).SetCreditTable), arg0)
+}
+
+// Start mocks base method.
+func (m *MockISlaService) Start(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", arg0)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockISlaServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockISlaService)(nil).Start), arg0)
+}
+
+// TrackAll mocks base method.
+func (m *MockISlaService) TrackAll(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TrackAll", arg0)
+}
+
+// TrackAll indicates an expected call of TrackAll.
+func (mr *MockISlaServiceMockRecorder) TrackAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackAll", reflect.TypeOf((*MockISlaService)(nil).TrackAll), arg0)
+}
+
+// TrackSlo mocks base method.
+func (m *MockISlaService) TrackSlo(arg0 *model.DetailedSlo, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackSlo", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackSlo indicates an expected call of TrackSlo.
+func (mr *MockISlaServiceMockRecorder) TrackSlo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackSlo", reflect.TypeOf((*MockISlaService)(nil).TrackSlo), arg0, arg1)
+}
+
+// MockISloAuthorizer is a mock of ISloAuthorizer interface.
+type MockISloAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockISloAuthorizerMockRecorder
+}
+
+// MockISloAuthorizerMockRecorder is the mock recorder for MockISloAuthorizer.
+type MockISloAuthorizerMockRecorder struct {
+	mock *MockISloAuthorizer
+}
+
+// NewMockISloAuthorizer creates a new mock instance.
+func NewMockISloAuthorizer(ctrl *gomock.Controller) *MockISloAuthorizer {
+	mock := &MockISloAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockISloAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISloAuthorizer) EXPECT() *MockISloAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeForSLO mocks base method.
+func (m *MockISloAuthorizer) AuthorizeForSLO(arg0, arg1 int64, arg2 auth.Permission) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeForSLO", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeForSLO indicates an expected call of AuthorizeForSLO.
+func (mr *MockISloAuthorizerMockRecorder) AuthorizeForSLO(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeForSLO", reflect.TypeOf((*MockISloAuthorizer)(nil).AuthorizeForSLO), arg0, arg1, arg2)
+}
+
+// MockISloBatchAuthorizer is a mock of ISloBatchAuthorizer interface.
+type MockISloBatchAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockISloBatchAuthorizerMockRecorder
+}
+
+// MockISloBatchAuthorizerMockRecorder is the mock recorder for MockISloBatchAuthorizer.
+type MockISloBatchAuthorizerMockRecorder struct {
+	mock *MockISloBatchAuthorizer
+}
+
+// NewMockISloBatchAuthorizer creates a new mock instance.
+func NewMockISloBatchAuthorizer(ctrl *gomock.Controller) *MockISloBatchAuthorizer {
+	mock := &MockISloBatchAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockISloBatchAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISloBatchAuthorizer) EXPECT() *MockISloBatchAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeForOrganization mocks base method.
+func (m *MockISloBatchAuthorizer) AuthorizeForOrganization(arg0, arg1 int64, arg2 auth.Permission) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeForOrganization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeForOrganization indicates an expected call of AuthorizeForOrganization.
+func (mr *MockISloBatchAuthorizerMockRecorder) AuthorizeForOrganization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeForOrganization", reflect.TypeOf((*MockISloBatchAuthorizer)(nil).AuthorizeForOrganization), arg0, arg1, arg2)
+}
+
+// AuthorizeForSLO mocks base method.
+func (m *MockISloBatchAuthorizer) AuthorizeForSLO(arg0, arg1 int64, arg2 auth.Permission) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeForSLO", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeForSLO indicates an expected call of AuthorizeForSLO.
+func (mr *MockISloBatchAuthorizerMockRecorder) AuthorizeForSLO(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeForSLO", reflect.TypeOf((*MockISloBatchAuthorizer)(nil).AuthorizeForSLO), arg0, arg1, arg2)
+}
+
+// MockISloBatchService is a mock of ISloBatchService interface.
+type MockISloBatchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISloBatchServiceMockRecorder
+}
+
+// MockISloBatchServiceMockRecorder is the mock recorder for MockISloBatchService.
+type MockISloBatchServiceMockRecorder struct {
+	mock *MockISloBatchService
+}
+
+// NewMockISloBatchService creates a new mock instance.
+func NewMockISloBatchService(ctrl *gomock.Controller) *MockISloBatchService {
+	mock := &MockISloBatchService{ctrl: ctrl}
+	mock.recorder = &MockISloBatchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISloBatchService) EXPECT() *MockISloBatchServiceMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockISloBatchService) Execute(arg0 *auth.UserContext, arg1 *model.SloBatch) (*model.SloBatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", arg0, arg1)
+	ret0, _ := ret[0].(*model.SloBatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockISloBatchServiceMockRecorder) Execute(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockISloBatchService)(nil).Execute), arg0, arg1)
+}
+
+// MockISloChangeRequestService is a mock of ISloChangeRequestService interface.
+type MockISloChangeRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISloChangeRequestServiceMockRecorder
+}
+
+// MockISloChangeRequestServiceMockRecorder is the mock recorder for MockISloChangeRequestService.
+type MockISloChangeRequestServiceMockRecorder struct {
+	mock *MockISloChangeRequestService
+}
+
+// NewMockISloChangeRequestService creates a new mock instance.
+func NewMockISloChangeRequestService(ctrl *gomock.Controller) *MockISloChangeRequestService {
+	mock := &MockISloChangeRequestService{ctrl: ctrl}
+	mock.recorder = &MockISloChangeRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISloChangeRequestService) EXPECT() *MockISloChangeRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockISloChangeRequestService) Approve(arg0 *auth.UserContext, arg1, arg2 int64, arg3 *model.SloChangeDecision) (*model.SloChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.SloChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockISloChangeRequestServiceMockRecorder) Approve(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockISloChangeRequestService)(nil).Approve), arg0, arg1, arg2, arg3)
+}
+
+// ExpireAll mocks base method.
+func (m *MockISloChangeRequestService) ExpireAll(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ExpireAll", arg0)
+}
+
+// ExpireAll indicates an expected call of ExpireAll.
+func (mr *MockISloChangeRequestServiceMockRecorder) ExpireAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAll", reflect.TypeOf((*MockISloChangeRequestService)(nil).ExpireAll), arg0)
+}
+
+// GetByOrgID mocks base method.
+func (m *MockISloChangeRequestService) GetByOrgID(arg0 int64, arg1 model.SloChangeStatus) ([]*model.SloChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SloChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockISloChangeRequestServiceMockRecorder) GetByOrgID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockISloChangeRequestService)(nil).GetByOrgID), arg0, arg1)
+}
+
+// Reject mocks base method.
+func (m *MockISloChangeRequestService) Reject(arg0 *auth.UserContext, arg1, arg2 int64, arg3 *model.SloChangeDecision) (*model.SloChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.SloChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockISloChangeRequestServiceMockRecorder) Reject(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockISloChangeRequestService)(nil).Reject), arg0, arg1, arg2, arg3)
 }
 
-// NewMockIParamExistCheckService creates a new mock instance.
-func NewMockIParamExistCheckService(ctrl *gomock.Controller) *MockIParamExistCheckService {
-	mock := &MockIParamExistCheckService{ctrl: ctrl}
-	mock.recorder = &MockIParamExistCheckServiceMockRecorder{mock}
-	return mock
+// RequestIfRequired mocks base method.
+func (m *MockISloChangeRequestService) RequestIfRequired(arg0 *auth.UserContext, arg1 model.SloChangeAction, arg2 int64, arg3 *model.Slo, arg4 *int64, arg5 bool) (*model.SloChangeRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestIfRequired", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*model.SloChangeRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIParamExistCheckService) EXPECT() *MockIParamExistCheckServiceMockRecorder {
-	return m.recorder
+// RequestIfRequired indicates an expected call of RequestIfRequired.
+func (mr *MockISloChangeRequestServiceMockRecorder) RequestIfRequired(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestIfRequired", reflect.TypeOf((*MockISloChangeRequestService)(nil).RequestIfRequired), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
-// CheckOrgByID mocks base method.
-func (m *MockIParamExistCheckService) CheckOrgByID(arg0 int64) error {
+// RequiresApproval mocks base method.
+func (m *MockISloChangeRequestService) RequiresApproval(arg0 *auth.UserContext, arg1 int64, arg2 *model.Slo) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckOrgByID", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RequiresApproval", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CheckOrgByID indicates an expected call of CheckOrgByID.
-func (mr *MockIParamExistCheckServiceMockRecorder) CheckOrgByID(arg0 interface{}) *gomock.Call {
+// RequiresApproval indicates an expected call of RequiresApproval.
+func (mr *MockISloChangeRequestServiceMockRecorder) RequiresApproval(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrgByID", reflect.TypeOf((*MockIParamExistCheckService)(nil).CheckOrgByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresApproval", reflect.TypeOf((*MockISloChangeRequestService)(nil).RequiresApproval), arg0, arg1, arg2)
 }
 
-// CheckSloByID mocks base method.
-func (m *MockIParamExistCheckService) CheckSloByID(arg0 int64) error {
+// Start mocks base method.
+func (m *MockISloChangeRequestService) Start(arg0 context.Context) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckSloByID", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
+	m.ctrl.Call(m, "Start", arg0)
 }
 
-// CheckSloByID indicates an expected call of CheckSloByID.
-func (mr *MockIParamExistCheckServiceMockRecorder) CheckSloByID(arg0 interface{}) *gomock.Call {
+// Start indicates an expected call of Start.
+func (mr *MockISloChangeRequestServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSloByID", reflect.TypeOf((*MockIParamExistCheckService)(nil).CheckSloByID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockISloChangeRequestService)(nil).Start), arg0)
 }
 
-// MockIProductsStatusService is a mock of IProductsStatusService interface.
-type MockIProductsStatusService struct {
+// MockISloHistoryIngestionService is a mock of ISloHistoryIngestionService interface.
+type MockISloHistoryIngestionService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIProductsStatusServiceMockRecorder
+	recorder *MockISloHistoryIngestionServiceMockRecorder
 }
 
-// MockIProductsStatusServiceMockRecorder is the mock recorder for MockIProductsStatusService.
-type MockIProductsStatusServiceMockRecorder struct {
-	mock *MockIProductsStatusService
+// MockISloHistoryIngestionServiceMockRecorder is the mock recorder for MockISloHistoryIngestionService.
+type MockISloHistoryIngestionServiceMockRecorder struct {
+	mock *MockISloHistoryIngestionService
 }
 
-// NewMockIProductsStatusService creates a new mock instance.
-func NewMockIProductsStatusService(ctrl *gomock.Controller) *MockIProductsStatusService {
-	mock := &MockIProductsStatusService{ctrl: ctrl}
-	mock.recorder = &MockIProductsStatusServiceMockRecorder{mock}
+// NewMockISloHistoryIngestionService creates a new mock instance.
+func NewMockISloHistoryIngestionService(ctrl *gomock.Controller) *MockISloHistoryIngestionService {
+	mock := &MockISloHistoryIngestionService{ctrl: ctrl}
+	mock.recorder = &MockISloHistoryIngestionServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIProductsStatusService) EXPECT() *MockIProductsStatusServiceMockRecorder {
+func (m *MockISloHistoryIngestionService) EXPECT() *MockISloHistoryIngestionServiceMockRecorder {
 	return m.recorder
 }
 
-// GetProductsStatus mocks base method.
-func (m *MockIProductsStatusService) GetProductsStatus() ([]*model.ProductStatus, error) {
+// IngestAll mocks base method.
+func (m *MockISloHistoryIngestionService) IngestAll(arg0 time.Time) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductsStatus")
-	ret0, _ := ret[0].([]*model.ProductStatus)
+	m.ctrl.Call(m, "IngestAll", arg0)
+}
+
+// IngestAll indicates an expected call of IngestAll.
+func (mr *MockISloHistoryIngestionServiceMockRecorder) IngestAll(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestAll", reflect.TypeOf((*MockISloHistoryIngestionService)(nil).IngestAll), arg0)
+}
+
+// IngestSlo mocks base method.
+func (m *MockISloHistoryIngestionService) IngestSlo(arg0 *model.Slo, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngestSlo", arg0, arg1)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductsStatus indicates an expected call of GetProductsStatus.
-func (mr *MockIProductsStatusServiceMockRecorder) GetProductsStatus() *gomock.Call {
+// IngestSlo indicates an expected call of IngestSlo.
+func (mr *MockISloHistoryIngestionServiceMockRecorder) IngestSlo(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductsStatus", reflect.TypeOf((*MockIProductsStatusService)(nil).GetProductsStatus))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestSlo", reflect.TypeOf((*MockISloHistoryIngestionService)(nil).IngestSlo), arg0, arg1)
 }
 
-// MockIRecommendationVoteService is a mock of IRecommendationVoteService interface.
-type MockIRecommendationVoteService struct {
+// Start mocks base method.
+func (m *MockISloHistoryIngestionService) Start(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", arg0)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockISloHistoryIngestionServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockISloHistoryIngestionService)(nil).Start), arg0)
+}
+
+// MockISloLabelService is a mock of ISloLabelService interface.
+type MockISloLabelService struct {
 	ctrl     *gomock.Controller
-	recorder *MockIRecommendationVoteServiceMockRecorder
+	recorder *MockISloLabelServiceMockRecorder
 }
 
-// MockIRecommendationVoteServiceMockRecorder is the mock recorder for MockIRecommendationVoteService.
-type MockIRecommendationVoteServiceMockRecorder struct {
-	mock *MockIRecommendationVoteService
+// MockISloLabelServiceMockRecorder is the mock recorder for MockISloLabelService.
+type MockISloLabelServiceMockRecorder struct {
+	mock *MockISloLabelService
 }
 
-// NewMockIRecommendationVoteService creates a new mock instance.
-func NewMockIRecommendationVoteService(ctrl *gomock.Controller) *MockIRecommendationVoteService {
-	mock := &MockIRecommendationVoteService{ctrl: ctrl}
-	mock.recorder = &MockIRecommendationVoteServiceMockRecorder{mock}
+// NewMockISloLabelService creates a new mock instance.
+func NewMockISloLabelService(ctrl *gomock.Controller) *MockISloLabelService {
+	mock := &MockISloLabelService{ctrl: ctrl}
+	mock.recorder = &MockISloLabelServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIRecommendationVoteService) EXPECT() *MockIRecommendationVoteServiceMockRecorder {
+func (m *MockISloLabelService) EXPECT() *MockISloLabelServiceMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockIRecommendationVoteService) Create(arg0 *model.RecommendationVote) error {
+// GetAllowedKeys mocks base method.
+func (m *MockISloLabelService) GetAllowedKeys(arg0 int64) (*model.SloLabelKeys, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	ret := m.ctrl.Call(m, "GetAllowedKeys", arg0)
+	ret0, _ := ret[0].(*model.SloLabelKeys)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllowedKeys indicates an expected call of GetAllowedKeys.
+func (mr *MockISloLabelServiceMockRecorder) GetAllowedKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllowedKeys", reflect.TypeOf((*MockISloLabelService)(nil).GetAllowedKeys), arg0)
+}
+
+// LoadLabels mocks base method.
+func (m *MockISloLabelService) LoadLabels(arg0 ...*model.Slo) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LoadLabels", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockIRecommendationVoteServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+// LoadLabels indicates an expected call of LoadLabels.
+func (mr *MockISloLabelServiceMockRecorder) LoadLabels(arg0 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIRecommendationVoteService)(nil).Create), arg0)
+	varargs := append([]interface{}{}, arg0...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadLabels", reflect.TypeOf((*MockISloLabelService)(nil).LoadLabels), varargs...)
 }
 
-// Delete mocks base method.
-func (m *MockIRecommendationVoteService) Delete(arg0 *model.RecommendationVote) error {
+// SetAllowedKeys mocks base method.
+func (m *MockISloLabelService) SetAllowedKeys(arg0 *model.SloLabelKeys) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret := m.ctrl.Call(m, "SetAllowedKeys", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockIRecommendationVoteServiceMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+// SetAllowedKeys indicates an expected call of SetAllowedKeys.
+func (mr *MockISloLabelServiceMockRecorder) SetAllowedKeys(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIRecommendationVoteService)(nil).Delete), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAllowedKeys", reflect.TypeOf((*MockISloLabelService)(nil).SetAllowedKeys), arg0)
 }
 
-// Get mocks base method.
-func (m *MockIRecommendationVoteService) Get(arg0 int64, arg1 *int64) ([]*model.RecommendationVote, error) {
+// ValidateLabels mocks base method.
+func (m *MockISloLabelService) ValidateLabels(arg0 *model.Slo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", arg0, arg1)
-	ret0, _ := ret[0].([]*model.RecommendationVote)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ValidateLabels", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Get indicates an expected call of Get.
-func (mr *MockIRecommendationVoteServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+// ValidateLabels indicates an expected call of ValidateLabels.
+func (mr *MockISloLabelServiceMockRecorder) ValidateLabels(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIRecommendationVoteService)(nil).Get), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateLabels", reflect.TypeOf((*MockISloLabelService)(nil).ValidateLabels), arg0)
 }
 
-// MockISDAService is a mock of ISDAService interface.
-type MockISDAService struct {
+// MockISloOwnershipService is a mock of ISloOwnershipService interface.
+type MockISloOwnershipService struct {
 	ctrl     *gomock.Controller
-	recorder *MockISDAServiceMockRecorder
+	recorder *MockISloOwnershipServiceMockRecorder
 }
 
-// MockISDAServiceMockRecorder is the mock recorder for MockISDAService.
-type MockISDAServiceMockRecorder struct {
-	mock *MockISDAService
+// MockISloOwnershipServiceMockRecorder is the mock recorder for MockISloOwnershipService.
+type MockISloOwnershipServiceMockRecorder struct {
+	mock *MockISloOwnershipService
 }
 
-// NewMockISDAService creates a new mock instance.
-func NewMockISDAService(ctrl *gomock.Controller) *MockISDAService {
-	mock := &MockISDAService{ctrl: ctrl}
-	mock.recorder = &MockISDAServiceMockRecorder{mock}
+// NewMockISloOwnershipService creates a new mock instance.
+func NewMockISloOwnershipService(ctrl *gomock.Controller) *MockISloOwnershipService {
+	mock := &MockISloOwnershipService{ctrl: ctrl}
+	mock.recorder = &MockISloOwnershipServiceMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockISDAService) EXPECT() *MockISDAServiceMockRecorder {
+func (m *MockISloOwnershipService) EXPECT() *MockISloOwnershipServiceMockRecorder {
 	return m.recorder
 }
 
-// GetConfig mocks base method.
-func (m *MockISDAService) GetConfig() ([]map[string]interface{}, error) {
+// GetGaps mocks base method.
+func (m *MockISloOwnershipService) GetGaps(arg0 *auth.UserContext, arg1 int64) ([]*model.SloOwnershipGap, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConfig")
-	ret0, _ := ret[0].([]map[string]interface{})
+	ret := m.ctrl.Call(m, "GetGaps", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SloOwnershipGap)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetConfig indicates an expected call of GetConfig.
-func (mr *MockISDAServiceMockRecorder) GetConfig() *gomock.Call {
+// GetGaps indicates an expected call of GetGaps.
+func (mr *MockISloOwnershipServiceMockRecorder) GetGaps(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockISDAService)(nil).GetConfig))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGaps", reflect.TypeOf((*MockISloOwnershipService)(nil).GetGaps), arg0, arg1)
 }
 
-// GetSDAFeatureByOrg mocks base method.
-func (m *MockISDAService) GetSDAFeatureByOrg(arg0 int64) (*model.SDAFeatures, error) {
+// LoadOwnership mocks base method.
+func (m *MockISloOwnershipService) LoadOwnership(arg0 ...*model.Slo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSDAFeatureByOrg", arg0)
-	ret0, _ := ret[0].(*model.SDAFeatures)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LoadOwnership", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetSDAFeatureByOrg indicates an expected call of GetSDAFeatureByOrg.
-func (mr *MockISDAServiceMockRecorder) GetSDAFeatureByOrg(arg0 interface{}) *gomock.Call {
+// LoadOwnership indicates an expected call of LoadOwnership.
+func (mr *MockISloOwnershipServiceMockRecorder) LoadOwnership(arg0 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSDAFeatureByOrg", reflect.TypeOf((*MockISDAService)(nil).GetSDAFeatureByOrg), arg0)
+	varargs := append([]interface{}{}, arg0...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOwnership", reflect.TypeOf((*MockISloOwnershipService)(nil).LoadOwnership), varargs...)
+}
+
+// ValidateOwnership mocks base method.
+func (m *MockISloOwnershipService) ValidateOwnership(arg0 *auth.UserContext, arg1 *model.Slo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateOwnership", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateOwnership indicates an expected call of ValidateOwnership.
+func (mr *MockISloOwnershipServiceMockRecorder) ValidateOwnership(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateOwnership", reflect.TypeOf((*MockISloOwnershipService)(nil).ValidateOwnership), arg0, arg1)
 }
 
 // MockISloService is a mock of ISloService interface.
@@ -644,21 +2253,21 @@ func (mr *MockISloServiceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Ca
 }
 
 // Delete mocks base method.
-func (m *MockISloService) Delete(arg0 *auth.UserContext, arg1 int64) error {
+func (m *MockISloService) Delete(arg0 *auth.UserContext, arg1 int64, arg2 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockISloServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockISloServiceMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockISloService)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockISloService)(nil).Delete), arg0, arg1, arg2)
 }
 
 // DeleteSloHistory mocks base method.
-func (m *MockISloService) DeleteSloHistory(arg0 int64) error {
+func (m *MockISloService) DeleteSloHistory(arg0 *elastic.SloHistoryDeletion) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteSloHistory", arg0)
 	ret0, _ := ret[0].(error)
@@ -716,33 +2325,64 @@ func (mr *MockISloServiceMockRecorder) GetByOrgID(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockISloService)(nil).GetByOrgID), arg0)
 }
 
-// GetDetailedSlos mocks base method.
-func (m *MockISloService) GetDetailedSlos(arg0, arg1 string) ([]*model.DetailedSlo, error) {
+// GetSloHistory mocks base method.
+func (m *MockISloService) GetSloHistory(arg0 int64, arg1 *elastic.SloHistoryRange, arg2 string) (*elastic.SloHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*elastic.SloHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloHistory indicates an expected call of GetSloHistory.
+func (mr *MockISloServiceMockRecorder) GetSloHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloHistory", reflect.TypeOf((*MockISloService)(nil).GetSloHistory), arg0, arg1, arg2)
+}
+
+// GetSloHistoryDeletionTask mocks base method.
+func (m *MockISloService) GetSloHistoryDeletionTask(arg0 int64, arg1 string) (*elastic.SloHistoryDeletionTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSloHistoryDeletionTask", arg0, arg1)
+	ret0, _ := ret[0].(*elastic.SloHistoryDeletionTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSloHistoryDeletionTask indicates an expected call of GetSloHistoryDeletionTask.
+func (mr *MockISloServiceMockRecorder) GetSloHistoryDeletionTask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloHistoryDeletionTask", reflect.TypeOf((*MockISloService)(nil).GetSloHistoryDeletionTask), arg0, arg1)
+}
+
+// GetSloPage mocks base method.
+func (m *MockISloService) GetSloPage(arg0 *model.SloPageQuery) (*model.SloPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDetailedSlos", arg0, arg1)
-	ret0, _ := ret[0].([]*model.DetailedSlo)
+	ret := m.ctrl.Call(m, "GetSloPage", arg0)
+	ret0, _ := ret[0].(*model.SloPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetDetailedSlos indicates an expected call of GetDetailedSlos.
-func (mr *MockISloServiceMockRecorder) GetDetailedSlos(arg0, arg1 interface{}) *gomock.Call {
+// GetSloPage indicates an expected call of GetSloPage.
+func (mr *MockISloServiceMockRecorder) GetSloPage(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetailedSlos", reflect.TypeOf((*MockISloService)(nil).GetDetailedSlos), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSloPage", reflect.TypeOf((*MockISloService)(nil).GetSloPage), arg0)
 }
 
 // Update mocks base method.
-func (m *MockISloService) Update(arg0 *auth.UserContext, arg1 *model.Slo) error {
+func (m *MockISloService) Update(arg0 *auth.UserContext, arg1 *model.Slo, arg2 *int64) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockISloServiceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockISloServiceMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockISloService)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockISloService)(nil).Update), arg0, arg1, arg2)
 }
 
 // MockISolutionSloService is a mock of ISolutionSloService interface.
@@ -858,3 +2498,151 @@ func (mr *MockIUserInfoServiceMockRecorder) GetUserInfo(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserInfo", reflect.TypeOf((*MockIUserInfoService)(nil).GetUserInfo), arg0)
 }
+
+// MockIWebhookService is a mock of IWebhookService interface.
+type MockIWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookServiceMockRecorder
+}
+
+// MockIWebhookServiceMockRecorder is the mock recorder for MockIWebhookService.
+type MockIWebhookServiceMockRecorder struct {
+	mock *MockIWebhookService
+}
+
+// NewMockIWebhookService creates a new mock instance.
+func NewMockIWebhookService(ctrl *gomock.Controller) *MockIWebhookService {
+	mock := &MockIWebhookService{ctrl: ctrl}
+	mock.recorder = &MockIWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhookService) EXPECT() *MockIWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIWebhookService) Create(arg0 *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIWebhookServiceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIWebhookService)(nil).Create), arg0)
+}
+
+// Delete mocks base method.
+func (m *MockIWebhookService) Delete(arg0, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIWebhookServiceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIWebhookService)(nil).Delete), arg0, arg1)
+}
+
+// DeliverDue mocks base method.
+func (m *MockIWebhookService) DeliverDue(arg0 time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeliverDue", arg0)
+}
+
+// DeliverDue indicates an expected call of DeliverDue.
+func (mr *MockIWebhookServiceMockRecorder) DeliverDue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDue", reflect.TypeOf((*MockIWebhookService)(nil).DeliverDue), arg0)
+}
+
+// Get mocks base method.
+func (m *MockIWebhookService) Get(arg0, arg1 int64) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIWebhookServiceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIWebhookService)(nil).Get), arg0, arg1)
+}
+
+// GetByOrgID mocks base method.
+func (m *MockIWebhookService) GetByOrgID(arg0 int64) ([]*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOrgID", arg0)
+	ret0, _ := ret[0].([]*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOrgID indicates an expected call of GetByOrgID.
+func (mr *MockIWebhookServiceMockRecorder) GetByOrgID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrgID", reflect.TypeOf((*MockIWebhookService)(nil).GetByOrgID), arg0)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockIWebhookService) GetDeliveries(arg0, arg1 int64, arg2 model.WebhookDeliveryStatus) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockIWebhookServiceMockRecorder) GetDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockIWebhookService)(nil).GetDeliveries), arg0, arg1, arg2)
+}
+
+// Publish mocks base method.
+func (m *MockIWebhookService) Publish(arg0 int64, arg1 model.WebhookEventType, arg2 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIWebhookServiceMockRecorder) Publish(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIWebhookService)(nil).Publish), arg0, arg1, arg2)
+}
+
+// Start mocks base method.
+func (m *MockIWebhookService) Start(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Start", arg0)
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockIWebhookServiceMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockIWebhookService)(nil).Start), arg0)
+}
+
+// Update mocks base method.
+func (m *MockIWebhookService) Update(arg0 *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIWebhookServiceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIWebhookService)(nil).Update), arg0)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	minSearchQueryLength = 2
+	maxSearchQueryLength = 100
+)
+
+type ISearchService interface {
+	Search(userContext *auth.UserContext, query string, types []model.SearchHitType, limit int) ([]*model.SearchHit, error)
+}
+
+// SearchService finds SLOs, organizations, solutions, products and datasources by name across
+// all organizations visible to the user.
+type SearchService struct {
+	Provider provider.ISearchProvider
+	Log      logrus.FieldLogger
+}
+
+// Search returns the hits ranked best first, all types are searched when types is empty.
+func (s *SearchService) Search(userContext *auth.UserContext, query string, types []model.SearchHitType,
+	limit int) ([]*model.SearchHit, error) {
+	query = strings.TrimSpace(query)
+	if length := utf8.RuneCountInString(query); length < minSearchQueryLength || length > maxSearchQueryLength {
+		return nil, errory.ValidationErrors.Builder().WithPayload("q", query).
+			WithMessage(fmt.Sprintf("search query has to have %d to %d characters", minSearchQueryLength, maxSearchQueryLength)).Create()
+	}
+	if len(types) == 0 {
+		types = model.SearchHitTypes
+	}
+
+	hits, err := s.Provider.Search(userContext.ID, query, types, limit)
+	if err != nil {
+		return nil, errory.Decorate(err, "search service search()")
+	}
+	for _, hit := range hits {
+		hit.Link = searchHitLink(hit)
+	}
+	return hits, nil
+}
+
+// searchHitLink points to the API resource of the hit. Solutions and products have no resource
+// of their own, they link to the organization of the solution or the solution list.
+func searchHitLink(hit *model.SearchHit) string {
+	switch hit.Type {
+	case model.SearchHitTypeSlo:
+		return fmt.Sprintf("/v1/slo/%d", hit.ID)
+	case model.SearchHitTypeDatasource:
+		return fmt.Sprintf("/v1/datasource/%d", hit.ID)
+	case model.SearchHitTypeProduct:
+		return "/v1/solutions?long=true"
+	default:
+		if hit.OrgID == nil {
+			return "/v1/solutions"
+		}
+		return fmt.Sprintf("/v1/org/%d", *hit.OrgID)
+	}
+}
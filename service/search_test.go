@@ -0,0 +1,90 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Search service test", func() {
+	var mockController *gomock.Controller
+	var mockSearchProvider *provider.MockISearchProvider
+	var searchService *service.SearchService
+	logger, _ := logrustest.NewNullLogger()
+	userContext := &auth.UserContext{ID: 3, Cookie: cookie}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockSearchProvider = provider.NewMockISearchProvider(mockController)
+		searchService = &service.SearchService{Provider: mockSearchProvider, Log: logger}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Search()", func() {
+		Context("When no type is requested", func() {
+			It("Should search all types and link the hits", func() {
+				orgID := int64(99)
+				mockSearchProvider.EXPECT().Search(userContext.ID, "check", model.SearchHitTypes, 20).Return([]*model.SearchHit{
+					{Type: model.SearchHitTypeSlo, ID: 7, OrgID: &orgID, Name: "checkout", Score: 1.8},
+					{Type: model.SearchHitTypeSolution, ID: 2, OrgID: &orgID, Name: "Checkout", Score: 1.5},
+					{Type: model.SearchHitTypeProduct, ID: 5, Name: "Checkout App", Score: 1.2},
+					{Type: model.SearchHitTypeDatasource, ID: 4, OrgID: &orgID, Name: "checkout-es", Score: 1.1},
+					{Type: model.SearchHitTypeOrganization, ID: 99, OrgID: &orgID, Name: "checkout-team", Score: 1.1},
+				}, nil)
+
+				hits, err := searchService.Search(userContext, " check ", nil, 20)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hits[0].Link).To(Equal("/v1/slo/7"))
+				Expect(hits[1].Link).To(Equal("/v1/org/99"))
+				Expect(hits[2].Link).To(Equal("/v1/solutions?long=true"))
+				Expect(hits[3].Link).To(Equal("/v1/datasource/4"))
+				Expect(hits[4].Link).To(Equal("/v1/org/99"))
+			})
+		})
+
+		Context("When types are requested", func() {
+			It("Should search only them", func() {
+				types := []model.SearchHitType{model.SearchHitTypeDatasource}
+				mockSearchProvider.EXPECT().Search(userContext.ID, "es", types, 5).Return([]*model.SearchHit{}, nil)
+
+				hits, err := searchService.Search(userContext, "es", types, 5)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hits).To(BeEmpty())
+			})
+		})
+
+		Context("When the query is too short", func() {
+			It("Should return validation error", func() {
+				_, err := searchService.Search(userContext, " c ", nil, 20)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When provider returns error", func() {
+			It("Should return the error", func() {
+				mockSearchProvider.EXPECT().Search(userContext.ID, "check", model.SearchHitTypes, 20).
+					Return(nil, errory.ProviderErrors.New("fatal provider error"))
+
+				_, err := searchService.Search(userContext, "check", nil, 20)
+
+				Expect(errory.IsOfType(err, errory.ProviderErrors)).To(BeTrue())
+			})
+		})
+	})
+})
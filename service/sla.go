@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+type ISlaService interface {
+	Start(ctx context.Context)
+	TrackAll(now time.Time)
+	TrackSlo(slo *model.DetailedSlo, now time.Time) error
+	GetBreaches(orgID int64, from, to time.Time) ([]*model.SlaBreach, error)
+	GetCreditTable(orgID, solutionID int64) (*model.SlaCreditTable, error)
+	SetCreditTable(table *model.SlaCreditTable) error
+}
+
+// SlaService records the SLA periods in which SLOs missed their external SLA and calculates the
+// credits of the breaches from the credit tables of their solutions. When Enabled it tracks
+// every Interval in the background.
+type SlaService struct {
+	Provider          provider.ISlaProvider
+	SloProvider       provider.ISLOProvider
+	SolutionsProvider provider.ISolutionsProvider
+	ErrorBudget       IErrorBudgetService
+	Enabled           bool
+	Interval          time.Duration
+	Lock              provider.IJobLockProvider
+	Log               logrus.FieldLogger
+}
+
+// Start tracks the SLAs every Interval in the background until ctx is done.
+func (s *SlaService) Start(ctx context.Context) {
+	if !s.Enabled {
+		s.Log.Info("sla breach tracking disabled")
+		return
+	}
+
+	go runPeriodically(ctx, s.Lock, s.Log, "sla_breach_tracking", s.Interval, s.TrackAll)
+}
+
+// TrackAll tracks every SLO with an external SLA, a failing SLO does not stop the others.
+func (s *SlaService) TrackAll(now time.Time) {
+	slos, err := s.SloProvider.GetDetailedSlos("", "")
+	if err != nil {
+		s.Log.WithError(err).Error("could not get slos for sla breach tracking")
+		return
+	}
+
+	tracked, failed := 0, 0
+	for _, slo := range slos {
+		if slo.ExternalSLA == "" {
+			continue
+		}
+		if err := s.TrackSlo(slo, now); err != nil {
+			failed++
+			s.Log.WithError(err).WithField("sloId", slo.ID).Error("could not track sla breaches")
+			continue
+		}
+		tracked++
+	}
+
+	s.Log.WithField("slos", tracked).WithField("failedSlos", failed).Info("sla breach tracking finished")
+}
+
+// TrackSlo evaluates the current SLA period of the SLO and the one before, which is evaluated
+// again as its last days may have been ingested late. Periods meeting the SLA drop their breach.
+func (s *SlaService) TrackSlo(slo *model.DetailedSlo, now time.Time) error {
+	current, _ := slaPeriod(&slo.Slo, now)
+	for _, periodTime := range []time.Time{current.Add(-time.Nanosecond), now} {
+		start, end := slaPeriod(&slo.Slo, periodTime)
+
+		breach, err := s.ErrorBudget.GetSlaBreach(&slo.Slo, start, minTime(end, now.UTC()))
+		if err != nil {
+			return err
+		}
+		if breach == nil {
+			if err = s.Provider.DeleteSlaBreach(slo.ID, start); err != nil {
+				return errory.Decorate(err, "sla service track()")
+			}
+			continue
+		}
+
+		breach.OrgID = slo.OrgID
+		breach.SolutionID = slo.SolutionID
+		breach.PeriodStart, breach.PeriodEnd = start, end
+		breach.Closed = !end.After(now)
+		if err = s.Provider.SaveSlaBreach(breach); err != nil {
+			return errory.Decorate(err, "sla service track()")
+		}
+	}
+	return nil
+}
+
+// GetBreaches returns the breaches of the SLA periods overlapping from to to with the credit
+// according to the current credit table of their solution.
+func (s *SlaService) GetBreaches(orgID int64, from, to time.Time) ([]*model.SlaBreach, error) {
+	breaches, err := s.Provider.GetSlaBreachesByOrgID(orgID, from, to)
+	if err != nil {
+		return nil, errory.Decorate(err, "sla breaches")
+	}
+	tiers, err := s.Provider.GetSlaCreditTiersByOrgID(orgID)
+	if err != nil {
+		return nil, errory.Decorate(err, "sla breaches")
+	}
+
+	tables := map[int64]*model.SlaCreditTable{}
+	for _, tier := range tiers {
+		if tables[tier.SolutionID] == nil {
+			tables[tier.SolutionID] = &model.SlaCreditTable{OrgID: orgID, SolutionID: tier.SolutionID}
+		}
+		tables[tier.SolutionID].Tiers = append(tables[tier.SolutionID].Tiers, *tier)
+	}
+
+	for _, breach := range breaches {
+		if breach.SolutionID == nil || tables[*breach.SolutionID] == nil {
+			continue
+		}
+		credit := tables[*breach.SolutionID].Credit(breach.Achieved)
+		breach.CreditPercent = &credit
+	}
+	return breaches, nil
+}
+
+func (s *SlaService) GetCreditTable(orgID, solutionID int64) (*model.SlaCreditTable, error) {
+	tiers, err := s.Provider.GetSlaCreditTiers(orgID, solutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &model.SlaCreditTable{OrgID: orgID, SolutionID: solutionID, Tiers: []model.SlaCreditTier{}}
+	for _, tier := range tiers {
+		table.Tiers = append(table.Tiers, *tier)
+	}
+	return table, nil
+}
+
+// SetCreditTable replaces the credit table of an existing solution, an empty table removes it.
+func (s *SlaService) SetCreditTable(table *model.SlaCreditTable) error {
+	solutions, err := s.SolutionsProvider.GetSolutions(true, false, "")
+	if err != nil {
+		return errory.Decorate(err, "sla service set credit table()")
+	}
+	found := false
+	for _, solution := range solutions {
+		found = found || solution.ID == table.SolutionID
+	}
+	if !found {
+		return errory.NotFoundErrors.Builder().WithPayload("solution", table.SolutionID).Create()
+	}
+
+	if err = s.Provider.SetSlaCreditTiers(table.OrgID, table.SolutionID, table.Tiers); err != nil {
+		return errory.Decorate(err, "sla service set credit table()")
+	}
+	return nil
+}
+
+// slaPeriod returns the SLA period containing t, which is the calendar window of the SLO and
+// the calendar month for SLOs with a rolling window.
+func slaPeriod(slo *model.Slo, t time.Time) (time.Time, time.Time) {
+	window := slo.Window()
+	if window.Type == model.SloWindowTypeRolling {
+		window = model.SloWindow{Type: model.SloWindowTypeCalendar, Period: model.CalendarPeriodMonth}
+	}
+	return window.Start(t), window.End(t)
+}
@@ -0,0 +1,140 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Sla service test", func() {
+	var mockController *gomock.Controller
+	var mockSlaProvider *provider.MockISlaProvider
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockSolutionsProvider *provider.MockISolutionsProvider
+	var mockErrorBudget *service.MockIErrorBudgetService
+	var slaService *service.SlaService
+	logger, _ := logrustest.NewNullLogger()
+
+	solutionID := int64(5)
+	march, april := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, time.April, 10, 8, 0, 0, 0, time.UTC)
+	slo := &model.DetailedSlo{Slo: model.Slo{ID: 7, OrgID: 99, Name: "checkout", ExternalSLA: "99.5", Critical: true}, SolutionID: &solutionID}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockSlaProvider = provider.NewMockISlaProvider(mockController)
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockSolutionsProvider = provider.NewMockISolutionsProvider(mockController)
+		mockErrorBudget = service.NewMockIErrorBudgetService(mockController)
+		slaService = &service.SlaService{
+			Provider:          mockSlaProvider,
+			SloProvider:       mockISLOProvider,
+			SolutionsProvider: mockSolutionsProvider,
+			ErrorBudget:       mockErrorBudget,
+			Log:               logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("TrackSlo(slo *model.DetailedSlo, now time.Time)", func() {
+		Context("When the previous month breached and the current one meets the sla", func() {
+			It("Should save the closed breach and drop the one of the current month", func() {
+				mockErrorBudget.EXPECT().GetSlaBreach(&slo.Slo, march, april).Return(&model.SlaBreach{
+					SloID: 7, SLA: 99.5, Achieved: 99.1, Start: march.AddDate(0, 0, 3), End: march.AddDate(0, 0, 5),
+				}, nil)
+				mockErrorBudget.EXPECT().GetSlaBreach(&slo.Slo, april, now).Return(nil, nil)
+				mockSlaProvider.EXPECT().SaveSlaBreach(&model.SlaBreach{
+					SloID: 7, OrgID: 99, SolutionID: &solutionID, PeriodStart: march, PeriodEnd: april, Closed: true,
+					SLA: 99.5, Achieved: 99.1, Start: march.AddDate(0, 0, 3), End: march.AddDate(0, 0, 5),
+				}).Return(nil)
+				mockSlaProvider.EXPECT().DeleteSlaBreach(int64(7), april).Return(nil)
+
+				err := slaService.TrackSlo(slo, now)
+
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("When the history cannot be read", func() {
+			It("Should return the error", func() {
+				mockErrorBudget.EXPECT().GetSlaBreach(&slo.Slo, march, april).Return(nil, errory.FetchResourceErrors.New("elastic"))
+
+				err := slaService.TrackSlo(slo, now)
+
+				Expect(errory.IsOfType(err, errory.FetchResourceErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("TrackAll(now time.Time)", func() {
+		It("Should only track slos with an external sla", func() {
+			withoutSla := &model.DetailedSlo{Slo: model.Slo{ID: 8, OrgID: 99}}
+			mockISLOProvider.EXPECT().GetDetailedSlos("", "").Return([]*model.DetailedSlo{slo, withoutSla}, nil)
+			mockErrorBudget.EXPECT().GetSlaBreach(&slo.Slo, gomock.Any(), gomock.Any()).Times(2).Return(nil, nil)
+			mockSlaProvider.EXPECT().DeleteSlaBreach(int64(7), gomock.Any()).Times(2).Return(nil)
+
+			slaService.TrackAll(now)
+		})
+	})
+
+	Describe("GetBreaches(orgID int64, from, to time.Time)", func() {
+		It("Should calculate the credits from the credit table of the solution", func() {
+			otherSolutionID := int64(6)
+			mockSlaProvider.EXPECT().GetSlaBreachesByOrgID(int64(99), march, now).Return([]*model.SlaBreach{
+				{ID: 1, SloID: 7, SolutionID: &solutionID, Achieved: 99.1},
+				{ID: 2, SloID: 8, SolutionID: &solutionID, Achieved: 94},
+				{ID: 3, SloID: 9, SolutionID: &otherSolutionID, Achieved: 90},
+			}, nil)
+			mockSlaProvider.EXPECT().GetSlaCreditTiersByOrgID(int64(99)).Return([]*model.SlaCreditTier{
+				{OrgID: 99, SolutionID: 5, Below: 99.5, CreditPercent: 10},
+				{OrgID: 99, SolutionID: 5, Below: 99, CreditPercent: 25},
+				{OrgID: 99, SolutionID: 5, Below: 95, CreditPercent: 50},
+			}, nil)
+
+			breaches, err := slaService.GetBreaches(99, march, now)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*breaches[0].CreditPercent).To(Equal(10.0))
+			Expect(*breaches[1].CreditPercent).To(Equal(50.0))
+			Expect(breaches[2].CreditPercent).To(BeNil())
+		})
+	})
+
+	Describe("SetCreditTable(table *model.SlaCreditTable)", func() {
+		Context("When solution does not exist", func() {
+			It("Should return not found error", func() {
+				mockSolutionsProvider.EXPECT().GetSolutions(true, false, "").Return([]*model.Solution{{ID: 6}}, nil)
+
+				err := slaService.SetCreditTable(&model.SlaCreditTable{OrgID: 99, SolutionID: 5})
+
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When solution exists", func() {
+			It("Should replace the tiers", func() {
+				tiers := []model.SlaCreditTier{{Below: 99, CreditPercent: 10}}
+				mockSolutionsProvider.EXPECT().GetSolutions(true, false, "").Return([]*model.Solution{{ID: 5}}, nil)
+				mockSlaProvider.EXPECT().SetSlaCreditTiers(int64(99), int64(5), tiers).Return(nil)
+
+				err := slaService.SetCreditTable(&model.SlaCreditTable{OrgID: 99, SolutionID: 5, Tiers: tiers})
+
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
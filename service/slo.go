@@ -1,37 +1,65 @@
 package service
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	elastic "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
 	grafana "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
 
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
+// ExternalValidationMode decides what happens when the external SLO of an SLO cannot be validated.
+type ExternalValidationMode string
+
+const (
+	// ExternalValidationStrict rejects the SLO with a validation error, or a service unavailable error
+	// when Datadog cannot be reached.
+	ExternalValidationStrict ExternalValidationMode = "strict"
+	// ExternalValidationWarn saves the SLO anyway and returns the problem as a warning.
+	ExternalValidationWarn ExternalValidationMode = "warn"
+)
+
 type ISloService interface {
 	Create(userContext *auth.UserContext, slo *model.Slo) error
-	Update(userContext *auth.UserContext, slo *model.Slo) error
-	Delete(userContext *auth.UserContext, id int64) error
+	Update(userContext *auth.UserContext, slo *model.Slo, version *int64) (int64, error)
+	Delete(userContext *auth.UserContext, id int64, cascade bool) error
 	Get(id int64) (*model.Slo, error)
-	GetDetailedSlos(sloNameQuery, orgNameQuery string) ([]*model.DetailedSlo, error)
+	GetSloPage(query *model.SloPageQuery) (*model.SloPage, error)
 	GetByOrgID(orgID int64) ([]*model.Slo, error)
 	FindSlos(params *model.SloQueryParams) ([]*model.Slo, error)
-	DeleteSloHistory(id int64) error
+	DeleteSloHistory(deletion *elasticModel.SloHistoryDeletion) error
+	GetSloHistoryDeletionTask(sloID int64, taskID string) (*elasticModel.SloHistoryDeletionTask, error)
+	GetSloHistory(sloID int64, timeRange *elasticModel.SloHistoryRange, interval string) (*elasticModel.SloHistory, error)
 }
 
 type SloService struct {
-	SloProvider      provider.ISLOProvider
-	DSProvider       provider.IDatasourceProvider
-	DashboardService IDashboardService
-	Log              logrus.FieldLogger
-	ElasticClient    elastic.IClient
+	SloProvider        provider.ISLOProvider
+	Store              provider.ISloStoreProvider
+	PageProvider       provider.ISloPageProvider
+	DSProvider         provider.IDatasourceProvider
+	DashboardService   IDashboardService
+	Log                logrus.FieldLogger
+	ElasticClient      elastic.IClient
+	DatadogClients     IDatadogClientFactory
+	ExternalValidation ExternalValidationMode
+	Maintenance        IMaintenancePeriodService
+	Composite          ICompositeSloService
+	Labels             ISloLabelService
+	Ownership          ISloOwnershipService
+	Versions           IEntityVersionService
 }
 
+// Create stores the SLO and the webhook deliveries of its creation in one transaction before its
+// dashboard is created.
 func (s *SloService) Create(userContext *auth.UserContext, slo *model.Slo) error {
 	containSlo, err := s.SloProvider.ContainSlosWithSameName(slo.OrgID, slo.Name, 0)
 	if err != nil {
@@ -50,64 +78,340 @@ func (s *SloService) Create(userContext *auth.UserContext, slo *model.Slo) error
 		return errory.CreateExternalSLOWithWrongDSForbiddenErrors.Builder().WithPayload("datasource", slo.DatasourceID).Create()
 	}
 
-	if err = s.SloProvider.CreateSlo(slo); err != nil {
+	if err = s.validateExternalSlo(slo); err != nil {
+		return errory.Decorate(err, "slo service create()")
+	}
+	if err = s.Composite.ValidateChildren(userContext, slo); err != nil {
+		return errory.Decorate(err, "slo service create()")
+	}
+	if err = s.Labels.ValidateLabels(slo); err != nil {
+		return errory.Decorate(err, "slo service create()")
+	}
+	if err = s.Ownership.ValidateOwnership(userContext, slo); err != nil {
 		return errory.Decorate(err, "slo service create()")
 	}
 
-	err = s.DashboardService.CreateDashboard(userContext, slo, false)
+	normalizeSavedRelations(slo)
+	if err = s.Store.CreateSloWithRelations(slo, model.NewWebhookEvent(slo.OrgID, model.WebhookEventSloCreated, slo)); err != nil {
+		return errory.Decorate(err, "slo service create()")
+	}
 
-	return err
+	return s.DashboardService.CreateDashboard(userContext, slo, false)
 }
 
-func (s *SloService) Update(userContext *auth.UserContext, slo *model.Slo) error {
+// Update keeps the stored labels and ownership when the SLO has none, the SLO and its children,
+// labels and ownership are written in one transaction with the webhook deliveries of the update. The
+// SLO has to still have the version, any version matches when it is nil, and the increased version is returned.
+func (s *SloService) Update(userContext *auth.UserContext, slo *model.Slo, version *int64) (int64, error) {
 	containSlo, err := s.SloProvider.ContainSlosWithSameName(slo.OrgID, slo.Name, slo.ID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if containSlo {
-		return errory.NotUniqueErrors.Builder().WithPayload("name", slo.Name).Create()
+		return 0, errory.NotUniqueErrors.Builder().WithPayload("name", slo.Name).Create()
 	}
 
-	if err = s.SloProvider.UpdateSlo(slo); err != nil {
-		return err
+	if err = s.validateExternalSlo(slo); err != nil {
+		return 0, err
+	}
+	if err = s.Composite.ValidateChildren(userContext, slo); err != nil {
+		return 0, err
+	}
+	if err = s.Labels.ValidateLabels(slo); err != nil {
+		return 0, err
+	}
+	if err = s.Ownership.ValidateOwnership(userContext, slo); err != nil {
+		return 0, err
+	}
+
+	// the event shows the stored labels and ownership the update keeps
+	updated := *slo
+	if err = s.loadUnchangedRelations(&updated); err != nil {
+		return 0, err
 	}
+	normalizeSavedRelations(&updated)
 
-	err = s.DashboardService.CreateDashboard(userContext, slo, true)
+	newVersion, ok, err := s.Store.UpdateSloWithRelations(slo, version, model.NewWebhookEvent(slo.OrgID, model.WebhookEventSloUpdated, &updated))
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, s.Versions.Conflict(model.VersionedEntitySlo, slo.ID, version)
+	}
+	slo.Labels, slo.Ownership = updated.Labels, updated.Ownership
 
-	return err
+	if err = s.DashboardService.CreateDashboard(userContext, slo, true); err != nil {
+		return 0, err
+	}
+	return newVersion, nil
 }
 
-func (s *SloService) Delete(userContext *auth.UserContext, id int64) error {
+// Delete fails for a child of composite SLOs unless cascade removes it from them. The SLO, its
+// labels, ownership and children and its links to composite SLOs are deleted in one transaction,
+// which also adds the webhook deliveries of the deletion.
+func (s *SloService) Delete(userContext *auth.UserContext, id int64, cascade bool) error {
 	slo, err := s.SloProvider.GetSlo(id)
 	if err != nil {
 		return err
 	}
 
+	parents, err := s.Composite.GetParentsToUnlink(slo, cascade)
+	if err != nil {
+		return err
+	}
+
 	err = s.DashboardService.DeleteDashboard(userContext, slo.ID, slo.OrgID)
 	if err != nil && !strings.Contains(err.Error(), "Dashboard not found") {
 		return err
 	}
 
-	return s.SloProvider.DeleteSlo(slo)
+	if err = s.Store.DeleteSloWithRelations(slo, model.NewWebhookEvent(slo.OrgID, model.WebhookEventSloDeleted, slo)); err != nil {
+		return err
+	}
+	s.Composite.UpdateParentDashboards(userContext, parents)
+	return nil
+}
+
+// loadUnchangedRelations sets the stored labels and ownership on an SLO updated without them,
+// so the dashboard and the events show them.
+func (s *SloService) loadUnchangedRelations(slo *model.Slo) error {
+	if slo.Labels == nil {
+		if err := s.Labels.LoadLabels(slo); err != nil {
+			return err
+		}
+	}
+	if slo.Ownership == nil {
+		if err := s.Ownership.LoadOwnership(slo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeSavedRelations makes the SLO look like it is loaded after saving it.
+func normalizeSavedRelations(slo *model.Slo) {
+	if slo.Labels == nil {
+		slo.Labels = map[string]string{}
+	}
+	if slo.Ownership != nil && slo.Ownership.IsEmpty() {
+		slo.Ownership = nil
+	}
+	if slo.Ownership != nil && slo.Ownership.Contacts == nil {
+		slo.Ownership.Contacts = []model.SloContact{}
+	}
+}
+
+// validateExternalSlo checks with the keys of the datasource of the SLO that the Datadog SLO
+// referenced by ExternalID exists and is of ExternalType. In warn mode a failed check is added to the warnings of the SLO. A Datadog target
+// different from SuccessRateExpectedAvailability, or LatencyTarget of latency SLOs, is always a warning.
+func (s *SloService) validateExternalSlo(slo *model.Slo) error {
+	slo.Warnings = nil
+	if slo.ExternalID == "" {
+		return nil
+	}
+	log := s.Log.WithField("sloId", slo.ID).WithField("externalId", slo.ExternalID)
+
+	client, err := s.DatadogClients.ForDatasource(slo.DatasourceID)
+	if err != nil {
+		return errory.Decorate(err, "validate external slo")
+	}
+
+	var problem string
+	ddSlo, err := client.GetSLO(slo.ExternalID)
+	switch {
+	case errory.IsOfType(err, errory.NotFoundErrors):
+		problem = "externalId does not reference an existing datadog slo"
+		err = errory.ValidationErrors.Builder().Wrap(err).WithPayload("externalId", slo.ExternalID).WithMessage(problem).Create()
+	case err != nil:
+		problem = "datadog cannot be reached to validate externalId"
+		err = errory.ServiceUnavailableErrors.Builder().Wrap(err).WithPayload("externalId", slo.ExternalID).WithMessage(problem).Create()
+	case slo.ExternalType != model.ExternalSloTypeNoType && ddSlo.Type != string(slo.ExternalType):
+		problem = "externalType " + string(slo.ExternalType) + " does not match datadog slo type " + ddSlo.Type
+		err = errory.ValidationErrors.Builder().WithPayload("externalId", slo.ExternalID).WithPayload("datadogType", ddSlo.Type).
+			WithMessage(problem).Create()
+	}
+	if err != nil {
+		if s.ExternalValidation != ExternalValidationWarn {
+			return err
+		}
+		log.WithError(err).Warn("could not validate external slo")
+		slo.Warnings = append(slo.Warnings, problem)
+		return nil
+	}
+
+	threshold := ddSlo.Threshold(datadogTargetTimeframe)
+	target, targetField, targetName := slo.SuccessRateExpectedAvailability, "successRateExpAvailability", "expected success rate availability"
+	if slo.MetricType() == model.MetricTypeLatency {
+		target, targetField, targetName = slo.LatencyTarget, "latencyTarget", "latency target"
+	}
+	expected, parseErr := decimal.NewFromString(target)
+	if threshold != nil && parseErr == nil && !decimal.NewFromFloat(threshold.Target).Equal(expected) {
+		log.WithField("datadogTarget", threshold.Target).WithField(targetField, target).
+			Warn("datadog slo target differs from " + targetName)
+		slo.Warnings = append(slo.Warnings, fmt.Sprintf("datadog slo target %s differs from %s %s",
+			decimal.NewFromFloat(threshold.Target).String(), targetName, target))
+	}
+	return nil
+}
+
+// DeleteSloHistory only counts the matching documents per index for a dry run,
+// otherwise it starts the deletion task and stores its ID in the deletion.
+func (s *SloService) DeleteSloHistory(deletion *elasticModel.SloHistoryDeletion) error {
+	timeRange := &elasticModel.SloHistoryRange{From: deletion.From, To: deletion.To}
+
+	if deletion.DryRun {
+		matches, err := s.ElasticClient.CountSloHistory(deletion.SloID, timeRange)
+		if err != nil {
+			return err
+		}
+		deletion.Matches = matches
+		return nil
+	}
+
+	taskID, err := s.ElasticClient.DeleteSloHistory(deletion.SloID, timeRange)
+	if err != nil {
+		return err
+	}
+	deletion.TaskID = taskID
+	s.Log.WithField("sloId", deletion.SloID).WithField("task", taskID).Info("slo history deletion started")
+
+	return nil
 }
 
-func (s *SloService) DeleteSloHistory(id int64) error {
-	return s.ElasticClient.DeleteSloHistory(id)
+func (s *SloService) GetSloHistoryDeletionTask(sloID int64, taskID string) (*elasticModel.SloHistoryDeletionTask, error) {
+	return s.ElasticClient.GetDeleteTask(sloID, taskID)
+}
+
+// GetSloHistory leaves out the values of points overlapping maintenance of the SLO.
+func (s *SloService) GetSloHistory(sloID int64, timeRange *elasticModel.SloHistoryRange, interval string) (*elasticModel.SloHistory, error) {
+	slo, err := s.SloProvider.GetSlo(sloID)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := getHistoryPoints(s.ElasticClient, s.Composite, slo, timeRange, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	maintenance, err := s.Maintenance.GetPeriodsForSlo(slo, timeRange.From, timeRange.To)
+	if err != nil {
+		return nil, err
+	}
+	excludeMaintenance(points, maintenance, timeRange.To)
+
+	return &elasticModel.SloHistory{
+		SloID:    sloID,
+		From:     timeRange.From,
+		To:       timeRange.To,
+		Interval: interval,
+		Points:   points,
+	}, nil
+}
+
+func excludeMaintenance(points []*elasticModel.SloHistoryPoint, maintenance []model.MaintenancePeriod, to time.Time) {
+	if len(maintenance) == 0 {
+		return
+	}
+	for i, point := range points {
+		end := to
+		if i+1 < len(points) {
+			end = points[i+1].Time
+		}
+		if model.Overlaps(maintenance, point.Time, end) {
+			point.Maintenance = true
+			point.Compliance = nil
+			point.SuccessRate = nil
+			point.GoodWindows = nil
+		}
+	}
 }
 
 func (s *SloService) Get(id int64) (*model.Slo, error) {
-	return s.SloProvider.GetSlo(id)
+	slo, err := s.SloProvider.GetSlo(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Composite.LoadChildren(slo); err != nil {
+		return nil, err
+	}
+	if err = s.Labels.LoadLabels(slo); err != nil {
+		return nil, err
+	}
+	if err = s.Ownership.LoadOwnership(slo); err != nil {
+		return nil, err
+	}
+	return slo, nil
 }
 
 func (s *SloService) GetByOrgID(orgID int64) ([]*model.Slo, error) {
-	return s.SloProvider.GetSlosByOrganizationID(orgID)
+	slos, err := s.SloProvider.GetSlosByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Labels.LoadLabels(slos...); err != nil {
+		return nil, err
+	}
+	if err = s.Ownership.LoadOwnership(slos...); err != nil {
+		return nil, err
+	}
+	return slos, nil
 }
 
-func (s *SloService) GetDetailedSlos(sloNameQuery, orgNameQuery string) ([]*model.DetailedSlo, error) {
-	slos, err := s.SloProvider.GetDetailedSlos(sloNameQuery, orgNameQuery)
-	return slos, err
+// GetSloPage returns a page of the SLOs matching the query together with their labels and
+// ownership. The next cursor is only set when more SLOs follow the page.
+func (s *SloService) GetSloPage(query *model.SloPageQuery) (*model.SloPage, error) {
+	limited := *query
+	if query.Limit > 0 {
+		limited.Limit = query.Limit + 1
+	}
+	slos, total, err := s.PageProvider.GetSloPage(&limited)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.SloPage{Slos: slos, Total: total}
+	if query.Limit > 0 && len(slos) > query.Limit {
+		page.Slos = slos[:query.Limit]
+		page.NextCursor = model.NewSloCursor(page.Slos[query.Limit-1], query.Sort, query.Descending)
+	}
+
+	plain := make([]*model.Slo, 0, len(page.Slos))
+	for _, slo := range page.Slos {
+		plain = append(plain, &slo.Slo)
+	}
+	if err = s.Labels.LoadLabels(plain...); err != nil {
+		return nil, err
+	}
+	if err = s.Ownership.LoadOwnership(plain...); err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
+// FindSlos filters latency SLOs by their external type, the provider only knows the
+// metric types given by the expected availabilities. Labels are matched after loading them.
 func (s *SloService) FindSlos(params *model.SloQueryParams) ([]*model.Slo, error) {
-	return s.SloProvider.FindSlos(params)
+	query := *params
+	if params.MetricType == model.MetricTypeLatency {
+		query.MetricType = model.MetricTypeNoMetricType
+	}
+	slos, err := s.SloProvider.FindSlos(&query)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Labels.LoadLabels(slos...); err != nil {
+		return nil, err
+	}
+
+	found := []*model.Slo{}
+	for _, slo := range slos {
+		if params.MetricType == model.MetricTypeLatency && slo.MetricType() != model.MetricTypeLatency {
+			continue
+		}
+		if slo.HasLabels(params.Labels) {
+			found = append(found, slo)
+		}
+	}
+	return found, nil
 }
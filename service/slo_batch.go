@@ -0,0 +1,339 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/sirupsen/logrus"
+)
+
+// ISloBatchAuthorizer checks the permissions needed for the operations of a batch, it is
+// implemented by provider.AuthProvider.
+type ISloBatchAuthorizer interface {
+	AuthorizeForSLO(entityID, userID int64, requiredRole auth.Permission) (bool, error)
+	AuthorizeForOrganization(entityID, userID int64, requiredRole auth.Permission) (bool, error)
+}
+
+type ISloBatchService interface {
+	Execute(userContext *auth.UserContext, batch *model.SloBatch) (*model.SloBatchResult, error)
+}
+
+// SloBatchService applies batches of SLO operations. All operations are authorized and checked
+// like on the single-item routes before any is applied, then up to Concurrency operations, and
+// with them their Grafana dashboard calls, run at once.
+//
+// In all or nothing mode creates and updates are applied before deletions, which are only
+// applied when all of them succeeded. When an operation fails the applied creates and updates
+// are rolled back, deleted SLOs cannot be restored. Changes needing the approval of an Admin
+// are rejected in this mode, in best effort mode a change request is returned for them.
+type SloBatchService struct {
+	SloService     ISloService
+	ChangeRequests ISloChangeRequestService
+	Versions       IEntityVersionService
+	Authorizer     ISloBatchAuthorizer
+	Concurrency    int
+	Log            logrus.FieldLogger
+}
+
+// sloBatchItem is an operation of a batch together with its outcome and, for updates in all or
+// nothing mode, the SLO before the update.
+type sloBatchItem struct {
+	operation *model.SloBatchOperation
+	result    *model.SloBatchItemResult
+	previous  *model.Slo
+	err       error
+}
+
+func (s *SloBatchService) Execute(userContext *auth.UserContext, batch *model.SloBatch) (*model.SloBatchResult, error) {
+	if batch.Mode == "" {
+		batch.Mode = model.SloBatchModeAllOrNothing
+	}
+	if batch.Mode != model.SloBatchModeAllOrNothing && batch.Mode != model.SloBatchModeBestEffort {
+		return nil, errory.ValidationErrors.Builder().WithPayload("mode", batch.Mode).
+			WithMessage("mode has to be allOrNothing or bestEffort").Create()
+	}
+	if len(batch.Operations) == 0 || len(batch.Operations) > model.MaxSloBatchSize {
+		return nil, errory.ValidationErrors.Builder().WithPayload("operations", len(batch.Operations)).
+			WithMessage(fmt.Sprintf("batch has to have between 1 and %d operations", model.MaxSloBatchSize)).Create()
+	}
+
+	items := make([]*sloBatchItem, 0, len(batch.Operations))
+	for i, operation := range batch.Operations {
+		items = append(items, &sloBatchItem{
+			operation: operation,
+			result:    &model.SloBatchItemResult{Index: i, Action: operation.Action, ID: operation.ID},
+		})
+	}
+
+	result := &model.SloBatchResult{Mode: batch.Mode}
+	if batch.Mode == model.SloBatchModeBestEffort {
+		s.check(userContext, items, false)
+		s.run(pending(items), func(item *sloBatchItem) { s.apply(userContext, item, true) })
+		return s.summarize(result, items), nil
+	}
+
+	s.check(userContext, items, true)
+	if failed := firstFailed(items); failed != nil {
+		skip(items, failed)
+		return s.summarize(result, items), nil
+	}
+
+	changes, deletions := []*sloBatchItem{}, []*sloBatchItem{}
+	for _, item := range items {
+		if item.operation.Action == model.SloBatchActionDelete {
+			deletions = append(deletions, item)
+		} else {
+			changes = append(changes, item)
+		}
+	}
+	s.run(changes, func(item *sloBatchItem) { s.apply(userContext, item, false) })
+	if firstFailed(changes) == nil {
+		s.run(deletions, func(item *sloBatchItem) { s.apply(userContext, item, false) })
+	}
+	if failed := firstFailed(items); failed != nil {
+		s.rollback(userContext, changes, failed)
+		skip(items, failed)
+		result.RolledBack = true
+	}
+	return s.summarize(result, items), nil
+}
+
+// check authorizes the operations and rejects those which cannot be applied, it does not
+// change anything.
+func (s *SloBatchService) check(userContext *auth.UserContext, items []*sloBatchItem, allOrNothing bool) {
+	changedBy, namedBy := map[int64]int{}, map[string]int{}
+	for i, item := range items {
+		operation := item.operation
+		if operation.Action != model.SloBatchActionCreate {
+			if other, ok := changedBy[operation.ID]; ok {
+				item.err = errory.ValidationErrors.Builder().WithPayload("id", operation.ID).
+					WithMessage(fmt.Sprintf("slo %d is already changed by operation %d", operation.ID, other)).Create()
+				continue
+			}
+			changedBy[operation.ID] = i
+		}
+		if operation.Slo != nil && operation.Action != model.SloBatchActionDelete {
+			name := fmt.Sprintf("%d/%s", operation.Slo.OrgID, operation.Slo.Name)
+			if other, ok := namedBy[name]; ok {
+				item.err = errory.NotUniqueErrors.Builder().WithPayload("name", operation.Slo.Name).
+					WithMessage(fmt.Sprintf("slo name %s is already used by operation %d", operation.Slo.Name, other)).Create()
+				continue
+			}
+			namedBy[name] = i
+		}
+	}
+
+	s.run(pending(items), func(item *sloBatchItem) {
+		item.err = s.checkOperation(userContext, item, allOrNothing)
+	})
+}
+
+func (s *SloBatchService) checkOperation(userContext *auth.UserContext, item *sloBatchItem, allOrNothing bool) error {
+	operation := item.operation
+	if operation.Slo == nil && operation.Action != model.SloBatchActionDelete {
+		return errory.ValidationErrors.Builder().WithPayload("action", operation.Action).
+			WithMessage("slo is required for " + string(operation.Action)).Create()
+	}
+
+	var allowed bool
+	var err error
+	switch operation.Action {
+	case model.SloBatchActionCreate:
+		allowed, err = s.Authorizer.AuthorizeForOrganization(operation.Slo.OrgID, userContext.ID, auth.Editor)
+	case model.SloBatchActionUpdate, model.SloBatchActionDelete:
+		allowed, err = s.Authorizer.AuthorizeForSLO(operation.ID, userContext.ID, auth.Editor)
+	default:
+		return errory.ValidationErrors.Builder().WithPayload("action", operation.Action).
+			WithMessage("action has to be create, update or delete").Create()
+	}
+	if err != nil {
+		return errory.Decorate(err, "slo batch service check()")
+	}
+	if !allowed {
+		return errory.AuthErrors.Builder().WithPayload("action", operation.Action).WithMessage("Not authorized").Create()
+	}
+	if operation.Action == model.SloBatchActionCreate {
+		return nil
+	}
+
+	if operation.Action == model.SloBatchActionUpdate {
+		operation.Slo.ID = operation.ID
+		if err = s.Versions.Verify(model.VersionedEntitySlo, operation.ID, operation.Version); err != nil {
+			return err
+		}
+	}
+	if !allOrNothing {
+		return nil
+	}
+
+	required, err := s.ChangeRequests.RequiresApproval(userContext, operation.ID, operation.Slo)
+	if err != nil {
+		return errory.Decorate(err, "slo batch service check()")
+	}
+	if required {
+		return errory.ValidationErrors.Builder().WithPayload("id", operation.ID).
+			WithMessage("change needs the approval of an organization Admin and cannot be part of an allOrNothing batch").
+			Create()
+	}
+	if operation.Action == model.SloBatchActionUpdate {
+		if item.previous, err = s.SloService.Get(operation.ID); err != nil {
+			return errory.Decorate(err, "slo batch service check()")
+		}
+	}
+	return nil
+}
+
+// apply applies the checked operation, in best effort mode changes needing approval are requested.
+func (s *SloBatchService) apply(userContext *auth.UserContext, item *sloBatchItem, requestApproval bool) {
+	operation, result := item.operation, item.result
+	var err error
+	switch operation.Action {
+	case model.SloBatchActionCreate:
+		if err = s.SloService.Create(userContext, operation.Slo); err == nil {
+			result.ID, result.Status, result.Warnings = operation.Slo.ID, http.StatusCreated, operation.Slo.Warnings
+		}
+	case model.SloBatchActionUpdate:
+		if requestApproval {
+			result.ChangeRequest, err = s.ChangeRequests.RequestIfRequired(userContext, model.SloChangeActionUpdate,
+				operation.ID, operation.Slo, operation.Version, false)
+			if err != nil || result.ChangeRequest != nil {
+				break
+			}
+		}
+		var version int64
+		if version, err = s.SloService.Update(userContext, operation.Slo, operation.Version); err == nil {
+			result.Version, result.Status, result.Warnings = &version, http.StatusOK, operation.Slo.Warnings
+		}
+	case model.SloBatchActionDelete:
+		if requestApproval {
+			result.ChangeRequest, err = s.ChangeRequests.RequestIfRequired(userContext, model.SloChangeActionDelete,
+				operation.ID, nil, nil, operation.Cascade)
+			if err != nil || result.ChangeRequest != nil {
+				break
+			}
+		}
+		if err = s.SloService.Delete(userContext, operation.ID, operation.Cascade); err == nil {
+			result.Status = http.StatusOK
+		}
+	}
+
+	if result.ChangeRequest != nil {
+		result.Status = http.StatusAccepted
+	}
+	item.err = err
+}
+
+// rollback undoes the applied creates and updates after the failed operation. Updates are undone
+// first, as they may have added SLOs created in the batch as children of composite SLOs. An update
+// is only undone while the SLO still has the version the batch gave it.
+func (s *SloBatchService) rollback(userContext *auth.UserContext, changes []*sloBatchItem, failed *sloBatchItem) {
+	undo := func(item *sloBatchItem) {
+		var err error
+		switch item.operation.Action {
+		case model.SloBatchActionCreate:
+			err = s.SloService.Delete(userContext, item.result.ID, true)
+		case model.SloBatchActionUpdate:
+			_, err = s.SloService.Update(userContext, item.previous, item.result.Version)
+		}
+		if err != nil {
+			s.Log.WithError(err).WithField("operation", item.result.Index).Error("could not roll back slo batch operation")
+			item.result.Message = "could not be rolled back: " + errory.GetErrorDetails(err).Message
+			return
+		}
+		if item.operation.Action == model.SloBatchActionCreate {
+			item.result.ID = 0
+		}
+		item.result.Status, item.result.Version = http.StatusFailedDependency, nil
+		item.result.Message = fmt.Sprintf("rolled back as operation %d failed", failed.result.Index)
+	}
+
+	updates, creates := []*sloBatchItem{}, []*sloBatchItem{}
+	for _, item := range changes {
+		if item.err != nil || item.result.Status == 0 {
+			continue
+		}
+		if item.operation.Action == model.SloBatchActionUpdate {
+			updates = append(updates, item)
+		} else {
+			creates = append(creates, item)
+		}
+	}
+	s.run(updates, undo)
+	s.run(creates, undo)
+}
+
+// run calls fn for the items with at most Concurrency calls at once.
+func (s *SloBatchService) run(items []*sloBatchItem, fn func(item *sloBatchItem)) {
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(item *sloBatchItem) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(item)
+		}(item)
+	}
+	wg.Wait()
+}
+
+func (s *SloBatchService) summarize(result *model.SloBatchResult, items []*sloBatchItem) *model.SloBatchResult {
+	result.Results = make([]*model.SloBatchItemResult, 0, len(items))
+	for _, item := range items {
+		if item.err != nil {
+			details := errory.GetErrorDetails(item.err)
+			item.result.Status, item.result.Message = details.Status, details.Message
+		}
+		if item.result.Status < http.StatusBadRequest {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+		result.Results = append(result.Results, item.result)
+	}
+
+	s.Log.WithField("mode", result.Mode).WithField("succeeded", result.Succeeded).WithField("failed", result.Failed).
+		WithField("rolledBack", result.RolledBack).Info("applied slo batch")
+	return result
+}
+
+// pending returns the items which did not fail yet.
+func pending(items []*sloBatchItem) []*sloBatchItem {
+	remaining := make([]*sloBatchItem, 0, len(items))
+	for _, item := range items {
+		if item.err == nil {
+			remaining = append(remaining, item)
+		}
+	}
+	return remaining
+}
+
+func firstFailed(items []*sloBatchItem) *sloBatchItem {
+	var failed *sloBatchItem
+	for _, item := range items {
+		if item.err != nil && (failed == nil || item.result.Index < failed.result.Index) {
+			failed = item
+		}
+	}
+	return failed
+}
+
+// skip marks the operations which were not applied because of the failed operation.
+func skip(items []*sloBatchItem, failed *sloBatchItem) {
+	for _, item := range items {
+		if item.err == nil && item.result.Status == 0 {
+			item.result.Status = http.StatusFailedDependency
+			item.result.Message = fmt.Sprintf("not applied as operation %d failed", failed.result.Index)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"net/http"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Slo batch service test", func() {
+	var mockController *gomock.Controller
+	var mockSloService *service.MockISloService
+	var mockChangeRequests *service.MockISloChangeRequestService
+	var mockVersions *service.MockIEntityVersionService
+	var mockAuthorizer *service.MockISloBatchAuthorizer
+	var batchService *service.SloBatchService
+	logger, _ := logrustest.NewNullLogger()
+	editor := &auth.UserContext{ID: 3, Login: "editor", Cookie: cookie}
+	version := int64(4)
+
+	create := func(orgID int64, name string) *model.SloBatchOperation {
+		return &model.SloBatchOperation{Action: model.SloBatchActionCreate, Slo: &model.Slo{OrgID: orgID, Name: name}}
+	}
+	update := func(id int64, name string) *model.SloBatchOperation {
+		return &model.SloBatchOperation{Action: model.SloBatchActionUpdate, ID: id, Version: &version,
+			Slo: &model.Slo{ID: id, OrgID: 1, Name: name}}
+	}
+	remove := func(id int64) *model.SloBatchOperation {
+		return &model.SloBatchOperation{Action: model.SloBatchActionDelete, ID: id}
+	}
+	statuses := func(result *model.SloBatchResult) []int {
+		codes := []int{}
+		for _, item := range result.Results {
+			codes = append(codes, item.Status)
+		}
+		return codes
+	}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockSloService = service.NewMockISloService(mockController)
+		mockChangeRequests = service.NewMockISloChangeRequestService(mockController)
+		mockVersions = service.NewMockIEntityVersionService(mockController)
+		mockAuthorizer = service.NewMockISloBatchAuthorizer(mockController)
+		batchService = &service.SloBatchService{
+			SloService:     mockSloService,
+			ChangeRequests: mockChangeRequests,
+			Versions:       mockVersions,
+			Authorizer:     mockAuthorizer,
+			Concurrency:    4,
+			Log:            logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Execute()", func() {
+		It("Should reject an empty batch", func() {
+			_, err := batchService.Execute(editor, &model.SloBatch{})
+
+			Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+		})
+
+		It("Should reject an unknown mode", func() {
+			_, err := batchService.Execute(editor, &model.SloBatch{Mode: "sometimes", Operations: []*model.SloBatchOperation{remove(6)}})
+
+			Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+		})
+
+		Context("In best effort mode", func() {
+			It("Should apply every operation which can be applied", func() {
+				mockAuthorizer.EXPECT().AuthorizeForOrganization(int64(1), editor.ID, auth.Editor).Return(true, nil)
+				mockAuthorizer.EXPECT().AuthorizeForOrganization(int64(2), editor.ID, auth.Editor).Return(false, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(5), editor.ID, auth.Editor).Return(true, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(6), editor.ID, auth.Editor).Return(true, nil)
+				mockVersions.EXPECT().Verify(model.VersionedEntitySlo, int64(5), &version).Return(nil)
+
+				mockSloService.EXPECT().Create(editor, gomock.Any()).DoAndReturn(func(_ *auth.UserContext, slo *model.Slo) error {
+					slo.ID = 10
+					return nil
+				})
+				mockChangeRequests.EXPECT().RequestIfRequired(editor, model.SloChangeActionUpdate, int64(5), gomock.Any(), &version, false).Return(nil, nil)
+				mockSloService.EXPECT().Update(editor, gomock.Any(), &version).Return(int64(5), nil)
+				mockChangeRequests.EXPECT().RequestIfRequired(editor, model.SloChangeActionDelete, int64(6), nil, nil, false).
+					Return(&model.SloChangeRequest{ID: 8, SloID: 6}, nil)
+
+				result, err := batchService.Execute(editor, &model.SloBatch{Mode: model.SloBatchModeBestEffort,
+					Operations: []*model.SloBatchOperation{create(1, "new"), create(2, "foreign"), update(5, "changed"), remove(6)}})
+
+				Expect(err).To(BeNil())
+				Expect(statuses(result)).To(Equal([]int{http.StatusCreated, http.StatusUnauthorized, http.StatusOK, http.StatusAccepted}))
+				Expect(result.Results[0].ID).To(Equal(int64(10)))
+				Expect(*result.Results[2].Version).To(Equal(int64(5)))
+				Expect(result.Results[3].ChangeRequest.ID).To(Equal(int64(8)))
+				Expect(result.Succeeded).To(Equal(3))
+				Expect(result.Failed).To(Equal(1))
+				Expect(result.RolledBack).To(BeFalse())
+			})
+
+			It("Should reject operations changing the same slo twice", func() {
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(6), editor.ID, auth.Editor).Return(true, nil)
+				mockChangeRequests.EXPECT().RequestIfRequired(editor, model.SloChangeActionDelete, int64(6), nil, nil, false).Return(nil, nil)
+				mockSloService.EXPECT().Delete(editor, int64(6), false).Return(nil)
+
+				result, err := batchService.Execute(editor, &model.SloBatch{Mode: model.SloBatchModeBestEffort,
+					Operations: []*model.SloBatchOperation{remove(6), remove(6)}})
+
+				Expect(err).To(BeNil())
+				Expect(statuses(result)).To(Equal([]int{http.StatusOK, http.StatusBadRequest}))
+				Expect(result.Results[1].Message).To(ContainSubstring("already changed by operation 0"))
+			})
+		})
+
+		Context("In all or nothing mode", func() {
+			It("Should apply nothing when an operation cannot be applied", func() {
+				mockAuthorizer.EXPECT().AuthorizeForOrganization(int64(1), editor.ID, auth.Editor).Return(true, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(6), editor.ID, auth.Editor).Return(true, nil)
+				mockChangeRequests.EXPECT().RequiresApproval(editor, int64(6), nil).Return(true, nil)
+
+				result, err := batchService.Execute(editor, &model.SloBatch{
+					Operations: []*model.SloBatchOperation{create(1, "new"), remove(6)}})
+
+				Expect(err).To(BeNil())
+				Expect(result.Mode).To(Equal(model.SloBatchModeAllOrNothing))
+				Expect(statuses(result)).To(Equal([]int{http.StatusFailedDependency, http.StatusBadRequest}))
+				Expect(result.Results[0].Message).To(Equal("not applied as operation 1 failed"))
+				Expect(result.Succeeded).To(Equal(0))
+				Expect(result.RolledBack).To(BeFalse())
+			})
+
+			It("Should reject two operations using the same name", func() {
+				mockAuthorizer.EXPECT().AuthorizeForOrganization(int64(1), editor.ID, auth.Editor).Return(true, nil)
+
+				result, err := batchService.Execute(editor, &model.SloBatch{
+					Operations: []*model.SloBatchOperation{create(1, "new"), create(1, "new")}})
+
+				Expect(err).To(BeNil())
+				Expect(statuses(result)).To(Equal([]int{http.StatusFailedDependency, http.StatusConflict}))
+			})
+
+			It("Should apply deletions after all creates and updates succeeded", func() {
+				previous := &model.Slo{ID: 5, OrgID: 1, Name: "old"}
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(5), editor.ID, auth.Editor).Return(true, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(6), editor.ID, auth.Editor).Return(true, nil)
+				mockVersions.EXPECT().Verify(model.VersionedEntitySlo, int64(5), &version).Return(nil)
+				mockChangeRequests.EXPECT().RequiresApproval(editor, int64(5), gomock.Any()).Return(false, nil)
+				mockChangeRequests.EXPECT().RequiresApproval(editor, int64(6), nil).Return(false, nil)
+				mockSloService.EXPECT().Get(int64(5)).Return(previous, nil)
+
+				gomock.InOrder(
+					mockSloService.EXPECT().Update(editor, gomock.Any(), &version).Return(int64(5), nil),
+					mockSloService.EXPECT().Delete(editor, int64(6), false).Return(nil),
+				)
+
+				result, err := batchService.Execute(editor, &model.SloBatch{
+					Operations: []*model.SloBatchOperation{remove(6), update(5, "changed")}})
+
+				Expect(err).To(BeNil())
+				Expect(statuses(result)).To(Equal([]int{http.StatusOK, http.StatusOK}))
+				Expect(result.Succeeded).To(Equal(2))
+			})
+
+			It("Should roll back applied creates and updates when an operation fails", func() {
+				previous := &model.Slo{ID: 5, OrgID: 1, Name: "old"}
+				mockAuthorizer.EXPECT().AuthorizeForOrganization(int64(1), editor.ID, auth.Editor).Return(true, nil).Times(2)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(5), editor.ID, auth.Editor).Return(true, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(6), editor.ID, auth.Editor).Return(true, nil)
+				mockVersions.EXPECT().Verify(model.VersionedEntitySlo, int64(5), &version).Return(nil)
+				mockChangeRequests.EXPECT().RequiresApproval(editor, int64(5), gomock.Any()).Return(false, nil)
+				mockChangeRequests.EXPECT().RequiresApproval(editor, int64(6), nil).Return(false, nil)
+				mockSloService.EXPECT().Get(int64(5)).Return(previous, nil)
+
+				mockSloService.EXPECT().Create(editor, gomock.Any()).DoAndReturn(func(_ *auth.UserContext, slo *model.Slo) error {
+					if slo.Name == "broken" {
+						return errory.NotUniqueErrors.Builder().WithPayload("name", slo.Name).Create()
+					}
+					slo.ID = 10
+					return nil
+				}).Times(2)
+				updated := int64(8)
+				mockSloService.EXPECT().Update(editor, gomock.Any(), &version).Return(updated, nil)
+				mockSloService.EXPECT().Update(editor, previous, &updated).Return(int64(9), nil)
+				mockSloService.EXPECT().Delete(editor, int64(10), true).Return(nil)
+
+				result, err := batchService.Execute(editor, &model.SloBatch{Operations: []*model.SloBatchOperation{
+					create(1, "new"), update(5, "changed"), create(1, "broken"), remove(6)}})
+
+				Expect(err).To(BeNil())
+				Expect(statuses(result)).To(Equal([]int{http.StatusFailedDependency, http.StatusFailedDependency,
+					http.StatusConflict, http.StatusFailedDependency}))
+				Expect(result.Results[0].Message).To(Equal("rolled back as operation 2 failed"))
+				Expect(result.Results[0].ID).To(BeZero())
+				Expect(result.Results[3].Message).To(Equal("not applied as operation 2 failed"))
+				Expect(result.RolledBack).To(BeTrue())
+				Expect(result.Failed).To(Equal(4))
+			})
+		})
+	})
+})
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+type ISloChangeRequestService interface {
+	Start(ctx context.Context)
+	RequestIfRequired(userContext *auth.UserContext, action model.SloChangeAction, sloID int64, slo *model.Slo,
+		version *int64, cascade bool) (*model.SloChangeRequest, error)
+	RequiresApproval(userContext *auth.UserContext, sloID int64, slo *model.Slo) (bool, error)
+	GetByOrgID(orgID int64, status model.SloChangeStatus) ([]*model.SloChangeRequest, error)
+	Approve(userContext *auth.UserContext, orgID, id int64, decision *model.SloChangeDecision) (*model.SloChangeRequest, error)
+	Reject(userContext *auth.UserContext, orgID, id int64, decision *model.SloChangeDecision) (*model.SloChangeRequest, error)
+	ExpireAll(now time.Time)
+}
+
+// SloChangeRequestService holds back changes of critical SLOs, and changes of the criticality
+// or external SLA of any SLO, until an organization Admin approves them. Changes of Admins are
+// applied right away. When Enabled stale requests are expired every Interval in the background.
+type SloChangeRequestService struct {
+	Provider    provider.ISloChangeRequestProvider
+	SloProvider provider.ISLOProvider
+	SloService  ISloService
+	Authorizer  ISloAuthorizer
+	TTL         time.Duration
+	Enabled     bool
+	Interval    time.Duration
+	Lock        provider.IJobLockProvider
+	Log         logrus.FieldLogger
+}
+
+// Start expires stale requests every Interval in the background until ctx is done.
+func (s *SloChangeRequestService) Start(ctx context.Context) {
+	if !s.Enabled {
+		s.Log.Info("slo change request expiry disabled")
+		return
+	}
+
+	go runPeriodically(ctx, s.Lock, s.Log, "slo_change_request_expiry", s.Interval, s.ExpireAll)
+}
+
+func (s *SloChangeRequestService) ExpireAll(now time.Time) {
+	expired, err := s.Provider.ExpireSloChangeRequests(now)
+	if err != nil {
+		s.Log.WithError(err).Error("could not expire slo change requests")
+		return
+	}
+	if expired > 0 {
+		s.Log.WithField("expired", expired).Info("expired slo change requests")
+	}
+}
+
+// RequestIfRequired stores a pending change request when the change needs approval and
+// returns nil when the caller may apply the change itself. The slo is nil for deletions, the
+// version is the If-Match of the change and is checked again when the request is approved.
+func (s *SloChangeRequestService) RequestIfRequired(userContext *auth.UserContext, action model.SloChangeAction,
+	sloID int64, slo *model.Slo, version *int64, cascade bool) (*model.SloChangeRequest, error) {
+	current, required, err := s.approvalRequired(userContext, sloID, slo)
+	if err != nil || !required {
+		return nil, err
+	}
+
+	pending, err := s.Provider.HasPendingSloChangeRequest(sloID)
+	if err != nil {
+		return nil, errory.Decorate(err, "slo change request service request()")
+	}
+	if pending {
+		return nil, errory.NotUniqueErrors.Builder().WithPayload("sloId", sloID).
+			WithMessage("slo already has a pending change request").Create()
+	}
+
+	now := time.Now().UTC()
+	request := &model.SloChangeRequest{
+		SloID:            sloID,
+		OrgID:            current.OrgID,
+		Action:           action,
+		Slo:              slo,
+		Version:          version,
+		Cascade:          cascade,
+		Status:           model.SloChangeStatusPending,
+		RequestedBy:      userContext.ID,
+		RequestedByLogin: userContext.Login,
+		RequestedAt:      now,
+		ExpiresAt:        now.Add(s.TTL),
+	}
+	if err = s.Provider.CreateSloChangeRequest(request); err != nil {
+		return nil, errory.Decorate(err, "slo change request service request()")
+	}
+	s.Log.WithField("sloId", sloID).WithField("changeRequestId", request.ID).Info("slo change waits for approval")
+	return request, nil
+}
+
+// RequiresApproval tells whether the change of the user needs the approval of an Admin without
+// requesting it. The slo is nil for deletions.
+func (s *SloChangeRequestService) RequiresApproval(userContext *auth.UserContext, sloID int64, slo *model.Slo) (bool, error) {
+	_, required, err := s.approvalRequired(userContext, sloID, slo)
+	return required, err
+}
+
+func (s *SloChangeRequestService) approvalRequired(userContext *auth.UserContext, sloID int64,
+	slo *model.Slo) (*model.Slo, bool, error) {
+	current, err := s.SloProvider.GetSlo(sloID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !requiresApproval(current, slo) {
+		return current, false, nil
+	}
+
+	admin, err := s.Authorizer.AuthorizeForSLO(sloID, userContext.ID, auth.Admin)
+	if err != nil {
+		return nil, false, errory.Decorate(err, "slo change request service approval()")
+	}
+	return current, !admin, nil
+}
+
+// requiresApproval tells whether changing current to slo, or deleting it when slo is nil,
+// needs the approval of an Admin.
+func requiresApproval(current, slo *model.Slo) bool {
+	if current.Critical {
+		return true
+	}
+	return slo != nil && (slo.Critical || slo.ExternalSLA != current.ExternalSLA)
+}
+
+func (s *SloChangeRequestService) GetByOrgID(orgID int64, status model.SloChangeStatus) ([]*model.SloChangeRequest, error) {
+	return s.Provider.GetSloChangeRequestsByOrgID(orgID, status)
+}
+
+// Approve applies the change as the approving Admin. Updates increase the version of the SLO like
+// direct updates do and only apply while the SLO has the version the request is based on. A
+// change failing to apply, e.g. as the SLO was changed or its name taken meanwhile, is kept on
+// record as failed.
+func (s *SloChangeRequestService) Approve(userContext *auth.UserContext, orgID, id int64,
+	decision *model.SloChangeDecision) (*model.SloChangeRequest, error) {
+	request, err := s.decide(userContext, orgID, id, decision, model.SloChangeStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	switch request.Action {
+	case model.SloChangeActionUpdate:
+		request.Slo.ID = request.SloID
+		_, err = s.SloService.Update(userContext, request.Slo, request.Version)
+	case model.SloChangeActionDelete:
+		err = s.SloService.Delete(userContext, request.SloID, request.Cascade)
+	}
+	if err == nil {
+		return request, nil
+	}
+
+	request.Status, request.Error = model.SloChangeStatusFailed, err.Error()
+	if _, updateErr := s.Provider.DecideSloChangeRequest(request, model.SloChangeStatusApproved); updateErr != nil {
+		s.Log.WithError(updateErr).WithField("changeRequestId", id).Error("could not record failed slo change request")
+	}
+	return nil, err
+}
+
+func (s *SloChangeRequestService) Reject(userContext *auth.UserContext, orgID, id int64,
+	decision *model.SloChangeDecision) (*model.SloChangeRequest, error) {
+	return s.decide(userContext, orgID, id, decision, model.SloChangeStatusRejected)
+}
+
+// decide records the decision on a pending request of the organization, requests past their
+// expiry are expired instead.
+func (s *SloChangeRequestService) decide(userContext *auth.UserContext, orgID, id int64, decision *model.SloChangeDecision,
+	status model.SloChangeStatus) (*model.SloChangeRequest, error) {
+	request, err := s.Provider.GetSloChangeRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	if request.OrgID != orgID {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("sloChangeRequest", id).Create()
+	}
+	if request.Status != model.SloChangeStatusPending {
+		return nil, errory.ValidationErrors.Builder().WithPayload("status", request.Status).
+			WithMessage("slo change request is not pending").Create()
+	}
+
+	now, decidedBy := time.Now().UTC(), userContext.ID
+	if now.After(request.ExpiresAt) {
+		request.Status = model.SloChangeStatusExpired
+		if _, err = s.Provider.DecideSloChangeRequest(request, model.SloChangeStatusPending); err != nil {
+			return nil, errory.Decorate(err, "slo change request service decide()")
+		}
+		return nil, errory.ValidationErrors.Builder().WithPayload("status", request.Status).
+			WithMessage("slo change request is expired").Create()
+	}
+
+	request.Status = status
+	request.DecidedBy, request.DecidedByLogin, request.DecidedAt = &decidedBy, userContext.Login, &now
+	request.Comment = decision.Comment
+	decided, err := s.Provider.DecideSloChangeRequest(request, model.SloChangeStatusPending)
+	if err != nil {
+		return nil, errory.Decorate(err, "slo change request service decide()")
+	}
+	if !decided {
+		return nil, errory.ValidationErrors.Builder().WithPayload("sloChangeRequest", id).
+			WithMessage("slo change request was decided meanwhile").Create()
+	}
+	return request, nil
+}
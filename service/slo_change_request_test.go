@@ -0,0 +1,236 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Slo change request service test", func() {
+	var mockController *gomock.Controller
+	var mockChangeProvider *provider.MockISloChangeRequestProvider
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockSloService *service.MockISloService
+	var mockAuthorizer *service.MockISloAuthorizer
+	var changeService *service.SloChangeRequestService
+	logger, _ := logrustest.NewNullLogger()
+	editor := &auth.UserContext{ID: 3, Login: "editor", Cookie: cookie}
+	admin := &auth.UserContext{ID: 4, Login: "admin", Cookie: cookie}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockChangeProvider = provider.NewMockISloChangeRequestProvider(mockController)
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockSloService = service.NewMockISloService(mockController)
+		mockAuthorizer = service.NewMockISloAuthorizer(mockController)
+		changeService = &service.SloChangeRequestService{
+			Provider:    mockChangeProvider,
+			SloProvider: mockISLOProvider,
+			SloService:  mockSloService,
+			Authorizer:  mockAuthorizer,
+			TTL:         24 * time.Hour,
+			Log:         logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("RequestIfRequired()", func() {
+		Context("When the slo is not critical and keeps its criticality and sla", func() {
+			It("Should not require approval", func() {
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(&model.Slo{ID: 7, OrgID: 99}, nil)
+
+				request, err := changeService.RequestIfRequired(editor, model.SloChangeActionUpdate, 7, &model.Slo{ID: 7, OrgID: 99, Name: "new"}, nil, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(request).To(BeNil())
+			})
+		})
+
+		Context("When an Admin changes a critical slo", func() {
+			It("Should not require approval", func() {
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(&model.Slo{ID: 7, OrgID: 99, Critical: true}, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(7), admin.ID, auth.Admin).Return(true, nil)
+
+				request, err := changeService.RequestIfRequired(admin, model.SloChangeActionDelete, 7, nil, nil, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(request).To(BeNil())
+			})
+		})
+
+		Context("When an Editor sets an external sla", func() {
+			It("Should store a pending change request", func() {
+				slo := &model.Slo{ID: 7, OrgID: 99, Critical: true, ExternalSLA: "99.5"}
+				version := int64(3)
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(&model.Slo{ID: 7, OrgID: 99}, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(7), editor.ID, auth.Admin).Return(false, nil)
+				mockChangeProvider.EXPECT().HasPendingSloChangeRequest(int64(7)).Return(false, nil)
+				mockChangeProvider.EXPECT().CreateSloChangeRequest(gomock.Any()).DoAndReturn(func(request *model.SloChangeRequest) error {
+					request.ID = 1
+					return nil
+				})
+
+				request, err := changeService.RequestIfRequired(editor, model.SloChangeActionUpdate, 7, slo, &version, false)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(request.ID).To(Equal(int64(1)))
+				Expect(request.OrgID).To(Equal(int64(99)))
+				Expect(request.Slo).To(Equal(slo))
+				Expect(request.Version).To(Equal(&version))
+				Expect(request.Status).To(Equal(model.SloChangeStatusPending))
+				Expect(request.RequestedByLogin).To(Equal("editor"))
+				Expect(request.ExpiresAt.Sub(request.RequestedAt)).To(Equal(24 * time.Hour))
+			})
+		})
+
+		Context("When the slo already has a pending request", func() {
+			It("Should return not unique error", func() {
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(&model.Slo{ID: 7, OrgID: 99, Critical: true}, nil)
+				mockAuthorizer.EXPECT().AuthorizeForSLO(int64(7), editor.ID, auth.Admin).Return(false, nil)
+				mockChangeProvider.EXPECT().HasPendingSloChangeRequest(int64(7)).Return(true, nil)
+
+				_, err := changeService.RequestIfRequired(editor, model.SloChangeActionDelete, 7, nil, nil, false)
+
+				Expect(errory.IsOfType(err, errory.NotUniqueErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("RequiresApproval()", func() {
+		It("Should require approval of changes of critical slos by editors without requesting it", func() {
+			mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(&model.Slo{ID: 7, OrgID: 99, Critical: true}, nil)
+			mockAuthorizer.EXPECT().AuthorizeForSLO(int64(7), editor.ID, auth.Admin).Return(false, nil)
+
+			required, err := changeService.RequiresApproval(editor, 7, nil)
+
+			Expect(err).To(BeNil())
+			Expect(required).To(BeTrue())
+		})
+
+		It("Should not require approval of changes by admins", func() {
+			mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(&model.Slo{ID: 7, OrgID: 99, Critical: true}, nil)
+			mockAuthorizer.EXPECT().AuthorizeForSLO(int64(7), admin.ID, auth.Admin).Return(true, nil)
+
+			required, err := changeService.RequiresApproval(admin, 7, nil)
+
+			Expect(err).To(BeNil())
+			Expect(required).To(BeFalse())
+		})
+	})
+
+	Describe("Approve()", func() {
+		version := int64(3)
+		pending := func() *model.SloChangeRequest {
+			return &model.SloChangeRequest{ID: 1, SloID: 7, OrgID: 99, Action: model.SloChangeActionUpdate, Slo: &model.Slo{Name: "new"},
+				Version: &version, Status: model.SloChangeStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+		}
+
+		Context("When the request is pending", func() {
+			It("Should record the decision and apply the change", func() {
+				mockChangeProvider.EXPECT().GetSloChangeRequest(int64(1)).Return(pending(), nil)
+				mockChangeProvider.EXPECT().DecideSloChangeRequest(gomock.Any(), model.SloChangeStatusPending).
+					DoAndReturn(func(request *model.SloChangeRequest, from model.SloChangeStatus) (bool, error) {
+						Expect(request.Status).To(Equal(model.SloChangeStatusApproved))
+						Expect(*request.DecidedBy).To(Equal(admin.ID))
+						Expect(request.Comment).To(Equal("ok"))
+						return true, nil
+					})
+				mockSloService.EXPECT().Update(admin, &model.Slo{ID: 7, Name: "new"}, &version).Return(int64(4), nil)
+
+				request, err := changeService.Approve(admin, 99, 1, &model.SloChangeDecision{Comment: "ok"})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(request.Status).To(Equal(model.SloChangeStatusApproved))
+			})
+		})
+
+		Context("When the change cannot be applied", func() {
+			It("Should record the request as failed", func() {
+				mockChangeProvider.EXPECT().GetSloChangeRequest(int64(1)).Return(pending(), nil)
+				mockChangeProvider.EXPECT().DecideSloChangeRequest(gomock.Any(), model.SloChangeStatusPending).Return(true, nil)
+				mockSloService.EXPECT().Update(admin, gomock.Any(), &version).Return(int64(0), errory.NotUniqueErrors.New("name"))
+				mockChangeProvider.EXPECT().DecideSloChangeRequest(gomock.Any(), model.SloChangeStatusApproved).
+					DoAndReturn(func(request *model.SloChangeRequest, from model.SloChangeStatus) (bool, error) {
+						Expect(request.Status).To(Equal(model.SloChangeStatusFailed))
+						Expect(request.Error).NotTo(BeEmpty())
+						return true, nil
+					})
+
+				_, err := changeService.Approve(admin, 99, 1, &model.SloChangeDecision{})
+
+				Expect(errory.IsOfType(err, errory.NotUniqueErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When the slo was changed since the request", func() {
+			It("Should record the request as failed", func() {
+				mockChangeProvider.EXPECT().GetSloChangeRequest(int64(1)).Return(pending(), nil)
+				mockChangeProvider.EXPECT().DecideSloChangeRequest(gomock.Any(), model.SloChangeStatusPending).Return(true, nil)
+				mockSloService.EXPECT().Update(admin, gomock.Any(), &version).
+					Return(int64(0), errory.PreconditionFailedErrors.New("slo 7 was changed meanwhile"))
+				mockChangeProvider.EXPECT().DecideSloChangeRequest(gomock.Any(), model.SloChangeStatusApproved).
+					DoAndReturn(func(request *model.SloChangeRequest, from model.SloChangeStatus) (bool, error) {
+						Expect(request.Status).To(Equal(model.SloChangeStatusFailed))
+						return true, nil
+					})
+
+				_, err := changeService.Approve(admin, 99, 1, &model.SloChangeDecision{})
+
+				Expect(errory.IsOfType(err, errory.PreconditionFailedErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When the request is expired", func() {
+			It("Should expire it without applying the change", func() {
+				request := pending()
+				request.ExpiresAt = time.Now().Add(-time.Hour)
+				mockChangeProvider.EXPECT().GetSloChangeRequest(int64(1)).Return(request, nil)
+				mockChangeProvider.EXPECT().DecideSloChangeRequest(gomock.Any(), model.SloChangeStatusPending).
+					DoAndReturn(func(request *model.SloChangeRequest, from model.SloChangeStatus) (bool, error) {
+						Expect(request.Status).To(Equal(model.SloChangeStatusExpired))
+						return true, nil
+					})
+
+				_, err := changeService.Approve(admin, 99, 1, &model.SloChangeDecision{})
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When the request belongs to another organization", func() {
+			It("Should return not found error", func() {
+				mockChangeProvider.EXPECT().GetSloChangeRequest(int64(1)).Return(pending(), nil)
+
+				_, err := changeService.Approve(admin, 100, 1, &model.SloChangeDecision{})
+
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Reject()", func() {
+		It("Should not reject a request decided before", func() {
+			mockChangeProvider.EXPECT().GetSloChangeRequest(int64(1)).Return(&model.SloChangeRequest{ID: 1, OrgID: 99,
+				Status: model.SloChangeStatusApproved}, nil)
+
+			_, err := changeService.Reject(admin, 99, 1, &model.SloChangeDecision{})
+
+			Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+		})
+	})
+})
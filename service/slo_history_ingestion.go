@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
+	elastic "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+const historyDay = 24 * time.Hour
+
+type ISloHistoryIngestionService interface {
+	Start(ctx context.Context)
+	IngestAll(now time.Time)
+	IngestSlo(slo *model.Slo, now time.Time) (int, error)
+}
+
+// SloHistoryIngestionService copies the daily history of Datadog SLOs into the SLO history indices.
+type SloHistoryIngestionService struct {
+	SloProvider    provider.ISLOProvider
+	Datadog        datadog.IClient
+	ElasticClient  elastic.IClient
+	Enabled        bool
+	Interval       time.Duration
+	Lock           provider.IJobLockProvider
+	BackfillPeriod time.Duration
+	Log            logrus.FieldLogger
+}
+
+// Start ingests the Datadog history every Interval in the background until ctx is done.
+func (s *SloHistoryIngestionService) Start(ctx context.Context) {
+	if !s.Enabled {
+		s.Log.Info("slo history ingestion disabled")
+		return
+	}
+
+	go runPeriodically(ctx, s.Lock, s.Log, "slo_history_ingestion", s.Interval, s.IngestAll)
+}
+
+// IngestAll ingests the history of every SLO linked to Datadog, a failing SLO does not stop the others.
+func (s *SloHistoryIngestionService) IngestAll(now time.Time) {
+	slos, err := s.SloProvider.GetDetailedSlos("", "")
+	if err != nil {
+		s.Log.WithError(err).Error("could not get slos for history ingestion")
+		return
+	}
+
+	ingested, failed := 0, 0
+	for _, slo := range slos {
+		if slo.ExternalID == "" {
+			continue
+		}
+
+		count, err := s.IngestSlo(&slo.Slo, now)
+		if err != nil {
+			failed++
+			s.Log.WithError(err).WithField("sloId", slo.ID).Error("could not ingest slo history")
+			continue
+		}
+		ingested += count
+	}
+
+	s.Log.WithField("documents", ingested).WithField("failedSlos", failed).Info("slo history ingestion finished")
+}
+
+// IngestSlo writes a document per day since the latest stored one, which is written again
+// as it may have been ingested before the day was over. SLOs without any history are
+// backfilled for BackfillPeriod.
+func (s *SloHistoryIngestionService) IngestSlo(slo *model.Slo, now time.Time) (int, error) {
+	from := now.Add(-s.BackfillPeriod).Truncate(historyDay)
+
+	latest, err := s.ElasticClient.GetLatestSloHistoryDate(slo.ID)
+	if err != nil {
+		return 0, errory.Decorate(err, "slo history ingestion")
+	}
+	if latest != nil && latest.After(from) {
+		from = latest.Truncate(historyDay)
+	}
+
+	documents := []*elasticModel.SloHistoryDocument{}
+	for date := from; date.Before(now); date = date.Add(historyDay) {
+		to := date.Add(historyDay)
+		if to.After(now) {
+			to = now
+		}
+
+		history, err := s.Datadog.GetSLOHistory(slo.ExternalID, date, to)
+		if err != nil {
+			return 0, errory.Decorate(err, "slo history ingestion")
+		}
+
+		document := &elasticModel.SloHistoryDocument{ID: slo.ID, ExternalID: slo.ExternalID, Date: date}
+		switch slo.MetricType() {
+		case model.MetricTypeCompliance:
+			document.Compliance = history.SLIValue
+		case model.MetricTypeLatency:
+			document.GoodWindows = history.SLIValue
+		default:
+			document.SuccessRate = history.SLIValue
+		}
+		documents = append(documents, document)
+	}
+
+	if err := s.ElasticClient.IndexSloHistory(documents); err != nil {
+		return 0, errory.Decorate(err, "slo history ingestion")
+	}
+	return len(documents), nil
+}
@@ -0,0 +1,158 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
+	client "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Slo history ingestion service test", func() {
+	var mockController *gomock.Controller
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockElasticClient *client.MockIClient
+	var datadogServer *ghttp.Server
+	var ingestionService *service.SloHistoryIngestionService
+	logger, logHook := logrustest.NewNullLogger()
+
+	now := time.Date(2021, 3, 15, 12, 0, 0, 0, time.UTC)
+	monitorSlo := model.Slo{ID: 7, ExternalID: "abc", ExternalType: model.ExternalSloTypeMonitor}
+	metricSlo := model.Slo{ID: 8, ExternalID: "def", ExternalType: model.ExternalSloTypeMetric}
+
+	respondWithSLI := func(sli string) http.HandlerFunc {
+		return ghttp.RespondWith(http.StatusOK, `{"data": {"type": "monitor", "overall": {"sli_value": `+sli+`}}}`)
+	}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockElasticClient = client.NewMockIClient(mockController)
+		datadogServer = ghttp.NewServer()
+		datadogClient, err := datadog.New(datadogServer.URL(), "api-key", "app-key")
+		Expect(err).NotTo(HaveOccurred())
+
+		ingestionService = &service.SloHistoryIngestionService{
+			SloProvider:    mockISLOProvider,
+			Datadog:        datadogClient,
+			ElasticClient:  mockElasticClient,
+			Enabled:        true,
+			Interval:       time.Hour,
+			BackfillPeriod: 2 * 24 * time.Hour,
+			Log:            logger,
+		}
+
+		logHook.Reset()
+	})
+
+	AfterEach(func() {
+		datadogServer.Close()
+		mockController.Finish()
+	})
+
+	Describe("IngestSlo(slo *model.Slo, now time.Time)", func() {
+		Context("When slo has no history yet", func() {
+			It("Should backfill every day of the backfill period", func() {
+				datadogServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/slo/abc/history", "from_ts=1615593600&to_ts=1615680000"),
+						respondWithSLI("99.5"),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/slo/abc/history", "from_ts=1615680000&to_ts=1615766400"),
+						respondWithSLI("100"),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/slo/abc/history", "from_ts=1615766400&to_ts=1615809600"),
+						respondWithSLI("98"),
+					),
+				)
+				mockElasticClient.EXPECT().GetLatestSloHistoryDate(monitorSlo.ID).Return(nil, nil)
+				mockElasticClient.EXPECT().IndexSloHistory(gomock.Any()).DoAndReturn(func(documents []*elasticModel.SloHistoryDocument) error {
+					Expect(documents).To(HaveLen(3))
+					Expect(documents[0].Date).To(Equal(time.Date(2021, 3, 13, 0, 0, 0, 0, time.UTC)))
+					Expect(*documents[0].Compliance).To(Equal(99.5))
+					Expect(documents[0].SuccessRate).To(BeNil())
+					Expect(documents[2].Date).To(Equal(time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)))
+					Expect(documents[2].DocumentID()).To(Equal("7-20210315"))
+					return nil
+				})
+
+				count, err := ingestionService.IngestSlo(&monitorSlo, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(3))
+			})
+		})
+
+		Context("When slo was ingested earlier today", func() {
+			It("Should ingest the current day again", func() {
+				latest := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+				datadogServer.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/slo/def/history", "from_ts=1615766400&to_ts=1615809600"),
+					respondWithSLI("99.9"),
+				))
+				mockElasticClient.EXPECT().GetLatestSloHistoryDate(metricSlo.ID).Return(&latest, nil)
+				mockElasticClient.EXPECT().IndexSloHistory(gomock.Any()).DoAndReturn(func(documents []*elasticModel.SloHistoryDocument) error {
+					Expect(documents).To(HaveLen(1))
+					Expect(documents[0].Date).To(Equal(latest))
+					Expect(*documents[0].SuccessRate).To(Equal(99.9))
+					Expect(documents[0].Compliance).To(BeNil())
+					return nil
+				})
+
+				count, err := ingestionService.IngestSlo(&metricSlo, now)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
+
+		Context("When datadog does not know the slo", func() {
+			It("Should return an error without writing documents", func() {
+				latest := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+				datadogServer.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"errors": ["Not found"]}`))
+				mockElasticClient.EXPECT().GetLatestSloHistoryDate(monitorSlo.ID).Return(&latest, nil)
+
+				_, err := ingestionService.IngestSlo(&monitorSlo, now)
+
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("IngestAll(now time.Time)", func() {
+		It("Should ingest only slos linked to datadog and continue on failures", func() {
+			latest := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+			mockISLOProvider.EXPECT().GetDetailedSlos("", "").Return([]*model.DetailedSlo{
+				{Slo: model.Slo{ID: 1}},
+				{Slo: monitorSlo},
+				{Slo: metricSlo},
+			}, nil)
+			mockElasticClient.EXPECT().GetLatestSloHistoryDate(monitorSlo.ID).Return(nil, errory.ElasticClientErrors.New("unavailable"))
+			mockElasticClient.EXPECT().GetLatestSloHistoryDate(metricSlo.ID).Return(&latest, nil)
+			datadogServer.AppendHandlers(respondWithSLI("99.9"))
+			mockElasticClient.EXPECT().IndexSloHistory(gomock.Any()).Return(nil)
+
+			ingestionService.IngestAll(now)
+
+			Expect(datadogServer.ReceivedRequests()).To(HaveLen(1))
+			Expect(logHook.LastEntry().Data["documents"]).To(Equal(1))
+			Expect(logHook.LastEntry().Data["failedSlos"]).To(Equal(1))
+		})
+	})
+})
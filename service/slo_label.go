@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	maxSloLabels        = 20
+	maxSloLabelValueLen = 128
+)
+
+// label keys are rendered into Grafana tags as key:value, so they must not contain a colon
+var labelKeyRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9_.-]{0,61}[a-z0-9])?$`)
+
+type ISloLabelService interface {
+	ValidateLabels(slo *model.Slo) error
+	LoadLabels(slos ...*model.Slo) error
+	GetAllowedKeys(orgID int64) (*model.SloLabelKeys, error)
+	SetAllowedKeys(keys *model.SloLabelKeys) error
+}
+
+// SloLabelService manages the free-form key/value labels of SLOs and the label keys
+// organizations allow.
+type SloLabelService struct {
+	Provider provider.ISloLabelProvider
+	Log      logrus.FieldLogger
+}
+
+// ValidateLabels checks the format of the labels and that their keys are allowed in the
+// organization of the SLO.
+func (s *SloLabelService) ValidateLabels(slo *model.Slo) error {
+	if len(slo.Labels) == 0 {
+		return nil
+	}
+	if len(slo.Labels) > maxSloLabels {
+		return errory.ValidationErrors.New("slo cannot have more than %d labels", maxSloLabels)
+	}
+	for key, value := range slo.Labels {
+		if !labelKeyRegexp.MatchString(key) {
+			return errory.ValidationErrors.Builder().WithPayload("label", key).
+				WithMessage("label key has to be lower case alphanumeric with _ . or - and at most 63 characters").Create()
+		}
+		if value == "" || len(value) > maxSloLabelValueLen {
+			return errory.ValidationErrors.Builder().WithPayload("label", key).
+				WithMessage(fmt.Sprintf("label value cannot be empty or longer than %d characters", maxSloLabelValueLen)).Create()
+		}
+	}
+
+	allowed, err := s.Provider.GetAllowedLabelKeys(slo.OrgID)
+	if err != nil {
+		return errory.Decorate(err, "slo label service validate()")
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	allowedKeys := map[string]bool{}
+	for _, key := range allowed {
+		allowedKeys[key] = true
+	}
+	for key := range slo.Labels {
+		if !allowedKeys[key] {
+			return errory.ValidationErrors.Builder().WithPayload("label", key).
+				WithMessage("label key is not allowed in the organization").Create()
+		}
+	}
+	return nil
+}
+
+// LoadLabels sets the stored labels on the SLOs, an SLO without labels gets an empty map.
+func (s *SloLabelService) LoadLabels(slos ...*model.Slo) error {
+	if len(slos) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(slos))
+	for _, slo := range slos {
+		ids = append(ids, slo.ID)
+	}
+
+	labels, err := s.Provider.GetSloLabels(ids)
+	if err != nil {
+		return errory.Decorate(err, "slo label service load()")
+	}
+	for _, slo := range slos {
+		slo.Labels = labels[slo.ID]
+		if slo.Labels == nil {
+			slo.Labels = map[string]string{}
+		}
+	}
+	return nil
+}
+
+func (s *SloLabelService) GetAllowedKeys(orgID int64) (*model.SloLabelKeys, error) {
+	keys, err := s.Provider.GetAllowedLabelKeys(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.SloLabelKeys{OrgID: orgID, Keys: keys}, nil
+}
+
+// SetAllowedKeys replaces the allowed label keys of the organization, labels already on SLOs
+// are kept until the SLOs are updated.
+func (s *SloLabelService) SetAllowedKeys(keys *model.SloLabelKeys) error {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, key := range keys.Keys {
+		if !labelKeyRegexp.MatchString(key) {
+			return errory.ValidationErrors.Builder().WithPayload("key", key).
+				WithMessage("label key has to be lower case alphanumeric with _ . or - and at most 63 characters").Create()
+		}
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+	sort.Strings(unique)
+	keys.Keys = unique
+
+	if err := s.Provider.SetAllowedLabelKeys(keys.OrgID, keys.Keys); err != nil {
+		return errory.Decorate(err, "slo label service set allowed keys()")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Slo label service test", func() {
+	var mockController *gomock.Controller
+	var mockLabelProvider *provider.MockISloLabelProvider
+	var labelService *service.SloLabelService
+	logger, _ := logrustest.NewNullLogger()
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockLabelProvider = provider.NewMockISloLabelProvider(mockController)
+		labelService = &service.SloLabelService{
+			Provider: mockLabelProvider,
+			Log:      logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("ValidateLabels(slo *model.Slo)", func() {
+		Context("When a label key is malformed", func() {
+			It("Should return validation error", func() {
+				err := labelService.ValidateLabels(&model.Slo{OrgID: 99, Labels: map[string]string{"Team:x": "checkout"}})
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When a label value is empty", func() {
+			It("Should return validation error", func() {
+				err := labelService.ValidateLabels(&model.Slo{OrgID: 99, Labels: map[string]string{"team": ""}})
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When the organization allows no keys", func() {
+			It("Should accept any key", func() {
+				mockLabelProvider.EXPECT().GetAllowedLabelKeys(int64(99)).Return([]string{}, nil)
+
+				err := labelService.ValidateLabels(&model.Slo{OrgID: 99, Labels: map[string]string{"team": "checkout"}})
+
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("When the key is not allowed in the organization", func() {
+			It("Should return validation error", func() {
+				mockLabelProvider.EXPECT().GetAllowedLabelKeys(int64(99)).Return([]string{"tier"}, nil)
+
+				err := labelService.ValidateLabels(&model.Slo{OrgID: 99, Labels: map[string]string{"team": "checkout"}})
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("LoadLabels(slos ...*model.Slo)", func() {
+		It("Should set the labels and an empty map on slos without labels", func() {
+			labeled, unlabeled := &model.Slo{ID: 7}, &model.Slo{ID: 8}
+			mockLabelProvider.EXPECT().GetSloLabels([]int64{7, 8}).Return(map[int64]map[string]string{7: {"team": "checkout"}}, nil)
+
+			err := labelService.LoadLabels(labeled, unlabeled)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labeled.Labels).To(Equal(map[string]string{"team": "checkout"}))
+			Expect(unlabeled.Labels).To(Equal(map[string]string{}))
+		})
+	})
+
+	Describe("SetAllowedKeys(keys *model.SloLabelKeys)", func() {
+		It("Should store the sorted unique keys", func() {
+			mockLabelProvider.EXPECT().SetAllowedLabelKeys(int64(99), []string{"team", "tier"}).Return(nil)
+
+			keys := &model.SloLabelKeys{OrgID: 99, Keys: []string{"tier", "team", "tier"}}
+			err := labelService.SetAllowedKeys(keys)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys.Keys).To(Equal([]string{"team", "tier"}))
+		})
+
+		It("Should reject malformed keys", func() {
+			err := labelService.SetAllowedKeys(&model.SloLabelKeys{OrgID: 99, Keys: []string{"Team"}})
+
+			Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+		})
+	})
+})
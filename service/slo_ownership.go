@@ -0,0 +1,155 @@
+package service
+
+import (
+	"net/mail"
+	"net/url"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+type ISloOwnershipService interface {
+	ValidateOwnership(userContext *auth.UserContext, slo *model.Slo) error
+	LoadOwnership(slos ...*model.Slo) error
+	GetGaps(userContext *auth.UserContext, orgID int64) ([]*model.SloOwnershipGap, error)
+}
+
+// SloOwnershipService manages the owner team, contacts and runbook of SLOs.
+type SloOwnershipService struct {
+	Provider    provider.ISloOwnershipProvider
+	SloProvider provider.ISLOProvider
+	Grafana     grafana.IClient
+	Log         logrus.FieldLogger
+}
+
+// ValidateOwnership checks the contacts and runbook of the SLO and resolves its owner team in
+// Grafana, the team has to exist in the organization of the SLO.
+func (s *SloOwnershipService) ValidateOwnership(userContext *auth.UserContext, slo *model.Slo) error {
+	ownership := slo.Ownership
+	if ownership == nil {
+		return nil
+	}
+	if ownership.RunbookURL != "" && !isHTTPURL(ownership.RunbookURL) {
+		return errory.ValidationErrors.Builder().WithPayload("runbookUrl", ownership.RunbookURL).
+			WithMessage("runbook url has to be an http or https url").Create()
+	}
+	for _, contact := range ownership.Contacts {
+		if err := validateContact(contact); err != nil {
+			return err
+		}
+	}
+
+	ownership.OwnerTeamID = nil
+	if ownership.OwnerTeam == "" {
+		return nil
+	}
+	team, err := s.Grafana.GetTeam(ownership.OwnerTeam, slo.OrgID, userContext.Cookie)
+	if err != nil {
+		return errory.Decorate(err, "slo ownership service validate()")
+	}
+	if team == nil {
+		return errory.ValidationErrors.Builder().WithPayload("ownerTeam", ownership.OwnerTeam).
+			WithMessage("owner team does not exist in the organization").Create()
+	}
+	ownership.OwnerTeamID = &team.ID
+	return nil
+}
+
+func validateContact(contact model.SloContact) error {
+	valid := contact.Value != ""
+	switch contact.Type {
+	case model.SloContactTypeEmail:
+		_, err := mail.ParseAddress(contact.Value)
+		valid = err == nil
+	case model.SloContactTypeURL:
+		valid = isHTTPURL(contact.Value)
+	case model.SloContactTypeSlack, model.SloContactTypePagerDuty:
+	default:
+		valid = false
+	}
+	if !valid {
+		return errory.ValidationErrors.Builder().WithPayload("contact", contact.Value).
+			WithMessage("contact is not a valid " + string(contact.Type)).Create()
+	}
+	return nil
+}
+
+func isHTTPURL(value string) bool {
+	u, err := url.ParseRequestURI(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// LoadOwnership sets the stored ownership on the SLOs, SLOs without ownership are left nil.
+func (s *SloOwnershipService) LoadOwnership(slos ...*model.Slo) error {
+	if len(slos) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(slos))
+	for _, slo := range slos {
+		ids = append(ids, slo.ID)
+	}
+
+	ownerships, err := s.Provider.GetSloOwnerships(ids)
+	if err != nil {
+		return errory.Decorate(err, "slo ownership service load()")
+	}
+	for _, slo := range slos {
+		slo.Ownership = ownerships[slo.ID]
+	}
+	return nil
+}
+
+// GetGaps returns the SLOs of the organization whose owner cannot be reached, owner teams
+// are looked up in Grafana as teams may have been removed since the SLO was saved.
+func (s *SloOwnershipService) GetGaps(userContext *auth.UserContext, orgID int64) ([]*model.SloOwnershipGap, error) {
+	slos, err := s.SloProvider.GetSlosByOrganizationID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.LoadOwnership(slos...); err != nil {
+		return nil, err
+	}
+
+	teamExists := map[string]bool{}
+	gaps := []*model.SloOwnershipGap{}
+	for _, slo := range slos {
+		gap := &model.SloOwnershipGap{SloID: slo.ID, SloName: slo.Name, Gaps: []model.SloOwnershipGapType{}}
+		ownership := slo.Ownership
+		if ownership == nil {
+			ownership = &model.SloOwnership{}
+		}
+		gap.OwnerTeam = ownership.OwnerTeam
+
+		if ownership.OwnerTeam == "" {
+			gap.Gaps = append(gap.Gaps, model.SloOwnershipGapNoOwner)
+		} else {
+			exists, checked := teamExists[ownership.OwnerTeam]
+			if !checked {
+				team, err := s.Grafana.GetTeam(ownership.OwnerTeam, orgID, userContext.Cookie)
+				if err != nil {
+					return nil, errory.Decorate(err, "slo ownership service get gaps()")
+				}
+				exists = team != nil
+				teamExists[ownership.OwnerTeam] = exists
+			}
+			if !exists {
+				gap.Gaps = append(gap.Gaps, model.SloOwnershipGapUnknownOwner)
+			}
+		}
+		if len(ownership.Contacts) == 0 {
+			gap.Gaps = append(gap.Gaps, model.SloOwnershipGapNoContacts)
+		}
+		if ownership.RunbookURL == "" {
+			gap.Gaps = append(gap.Gaps, model.SloOwnershipGapNoRunbook)
+		}
+
+		if len(gap.Gaps) > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	return gaps, nil
+}
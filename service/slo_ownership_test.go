@@ -0,0 +1,114 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	grafanaModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Slo ownership service test", func() {
+	var mockController *gomock.Controller
+	var mockOwnershipProvider *provider.MockISloOwnershipProvider
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockGrafana *grafana.MockIClient
+	var ownershipService *service.SloOwnershipService
+	logger, _ := logrustest.NewNullLogger()
+	userContext := &auth.UserContext{ID: 3, Cookie: cookie}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockOwnershipProvider = provider.NewMockISloOwnershipProvider(mockController)
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockGrafana = grafana.NewMockIClient(mockController)
+		ownershipService = &service.SloOwnershipService{
+			Provider:    mockOwnershipProvider,
+			SloProvider: mockISLOProvider,
+			Grafana:     mockGrafana,
+			Log:         logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("ValidateOwnership(userContext *auth.UserContext, slo *model.Slo)", func() {
+		Context("When the owner team exists", func() {
+			It("Should resolve the team id", func() {
+				slo := &model.Slo{OrgID: 99, Ownership: &model.SloOwnership{
+					OwnerTeam:  "checkout",
+					Contacts:   []model.SloContact{{Type: model.SloContactTypeEmail, Value: "checkout@example.com"}},
+					RunbookURL: "https://wiki.example.com/runbook",
+				}}
+				mockGrafana.EXPECT().GetTeam("checkout", int64(99), cookie).Return(&grafanaModel.Team{ID: 12, OrgID: 99, Name: "checkout"}, nil)
+
+				err := ownershipService.ValidateOwnership(userContext, slo)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*slo.Ownership.OwnerTeamID).To(Equal(int64(12)))
+			})
+		})
+
+		Context("When the owner team does not exist", func() {
+			It("Should return validation error", func() {
+				slo := &model.Slo{OrgID: 99, Ownership: &model.SloOwnership{OwnerTeam: "gone"}}
+				mockGrafana.EXPECT().GetTeam("gone", int64(99), cookie).Return(nil, nil)
+
+				err := ownershipService.ValidateOwnership(userContext, slo)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When a contact does not match its type", func() {
+			It("Should return validation error", func() {
+				slo := &model.Slo{OrgID: 99, Ownership: &model.SloOwnership{
+					Contacts: []model.SloContact{{Type: model.SloContactTypeURL, Value: "javascript:alert(1)"}},
+				}}
+
+				err := ownershipService.ValidateOwnership(userContext, slo)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetGaps(userContext *auth.UserContext, orgID int64)", func() {
+		It("Should list the slos whose owner cannot be reached", func() {
+			complete := &model.Slo{ID: 1, Name: "complete"}
+			unowned := &model.Slo{ID: 2, Name: "unowned"}
+			removedTeam := &model.Slo{ID: 3, Name: "removed team"}
+			mockISLOProvider.EXPECT().GetSlosByOrganizationID(int64(99)).Return([]*model.Slo{complete, unowned, removedTeam}, nil)
+			mockOwnershipProvider.EXPECT().GetSloOwnerships([]int64{1, 2, 3}).Return(map[int64]*model.SloOwnership{
+				1: {OwnerTeam: "checkout", Contacts: []model.SloContact{{Type: model.SloContactTypeSlack, Value: "#checkout"}}, RunbookURL: "https://wiki"},
+				3: {OwnerTeam: "gone", Contacts: []model.SloContact{}},
+			}, nil)
+			mockGrafana.EXPECT().GetTeam("checkout", int64(99), cookie).Return(&grafanaModel.Team{ID: 12}, nil)
+			mockGrafana.EXPECT().GetTeam("gone", int64(99), cookie).Return(nil, nil)
+
+			gaps, err := ownershipService.GetGaps(userContext, 99)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gaps).To(Equal([]*model.SloOwnershipGap{
+				{SloID: 2, SloName: "unowned", Gaps: []model.SloOwnershipGapType{
+					model.SloOwnershipGapNoOwner, model.SloOwnershipGapNoContacts, model.SloOwnershipGapNoRunbook,
+				}},
+				{SloID: 3, SloName: "removed team", OwnerTeam: "gone", Gaps: []model.SloOwnershipGapType{
+					model.SloOwnershipGapUnknownOwner, model.SloOwnershipGapNoContacts, model.SloOwnershipGapNoRunbook,
+				}},
+			}))
+		})
+	})
+})
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+)
+
+const maxSimulationDays = 366
+
+// burnRateAlert fires when the burn rate over the long and over the short window both reach
+// burnRate. The SLO history is daily, so the windows are whole days.
+type burnRateAlert struct {
+	name      string
+	longDays  int
+	shortDays int
+	burnRate  float64
+}
+
+var simulatedAlerts = []burnRateAlert{
+	{name: "page", longDays: 3, shortDays: 1, burnRate: 3},
+	{name: "ticket", longDays: 7, shortDays: 2, burnRate: 1},
+}
+
+type simulatedDay struct {
+	time     time.Time
+	value    float64
+	burnRate float64
+}
+
+// Simulate replays the stored history of the SLO with the targets of the simulation. Days
+// overlapping maintenance are left out as they are for the error budget.
+func (s *ErrorBudgetService) Simulate(sloID int64, simulation *model.SloSimulation) (*model.SloSimulationResult, error) {
+	from, to := simulation.From.UTC(), simulation.To.UTC()
+	if to.Sub(from) > maxSimulationDays*historyDay {
+		return nil, errory.ValidationErrors.New("simulation cannot span more than %d days", maxSimulationDays)
+	}
+
+	slo, err := s.SloProvider.GetSlo(sloID)
+	if err != nil {
+		return nil, err
+	}
+	metricType, currentTarget, err := budgetTarget(slo)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := *slo
+	if simulation.SuccessRateExpectedAvailability != "" {
+		simulated.SuccessRateExpectedAvailability = simulation.SuccessRateExpectedAvailability
+	}
+	if simulation.ComplianceExpectedAvailability != "" {
+		simulated.ComplianceExpectedAvailability = simulation.ComplianceExpectedAvailability
+	}
+	if simulation.LatencyTarget != "" {
+		simulated.LatencyTarget = simulation.LatencyTarget
+	}
+	_, target, err := budgetTarget(&simulated)
+	if err != nil {
+		return nil, err
+	}
+	if target >= 100 {
+		return nil, errory.ValidationErrors.Builder().WithPayload("target", target).
+			WithMessage("simulated target leaves no error budget").Create()
+	}
+
+	points, _, err := s.getBudgetPoints(slo, metricType, from, to)
+	if err != nil {
+		return nil, err
+	}
+	days := []simulatedDay{}
+	for _, point := range points {
+		if point.maintenance || point.value == nil {
+			continue
+		}
+		days = append(days, simulatedDay{time: point.time, value: *point.value, burnRate: (100 - *point.value) / (100 - target)})
+	}
+
+	result := &model.SloSimulationResult{
+		SloID:         slo.ID,
+		MetricType:    metricType,
+		CurrentTarget: currentTarget,
+		Target:        target,
+		From:          from,
+		To:            to,
+		Periods:       simulatePeriods(slo.Window(), from, to, days, target),
+		Alerts:        simulateAlerts(days),
+	}
+	for _, period := range result.Periods {
+		if period.Breached {
+			result.BreachedPeriods++
+		}
+	}
+	return result, nil
+}
+
+// simulatePeriods splits the range into the calendar periods of the window, or for rolling
+// windows into consecutive windows starting at from, and cuts the first and last to the range.
+func simulatePeriods(window model.SloWindow, from, to time.Time, days []simulatedDay, target float64) []model.SimulatedPeriod {
+	periods := []model.SimulatedPeriod{}
+	start := from
+	if window.Type == model.SloWindowTypeCalendar {
+		start = window.Start(from)
+	}
+
+	for start.Before(to) {
+		end := start.AddDate(0, 0, int(window.Days))
+		if window.Type == model.SloWindowTypeCalendar {
+			end = window.End(start)
+		}
+		periodDays := end.Sub(start).Hours() / 24
+
+		period := model.SimulatedPeriod{From: maxTime(start, from), To: minTime(end, to)}
+		sum, burned, count := 0.0, 0.0, 0
+		for _, day := range days {
+			if !day.time.Before(period.From) && day.time.Before(period.To) {
+				sum += day.value
+				burned += day.burnRate
+				count++
+			}
+		}
+		if count > 0 {
+			actual, consumed := sum/float64(count), burned/periodDays
+			period.Actual, period.Consumed = &actual, &consumed
+			period.Breached = actual < target
+		}
+
+		periods = append(periods, period)
+		start = end
+	}
+	return periods
+}
+
+// simulateAlerts returns the days on which the multi-window alerts would have fired, the burn
+// rate of a window is the mean of its days with history.
+func simulateAlerts(days []simulatedDay) []model.SimulatedAlert {
+	burnRates := map[int64]float64{}
+	for _, day := range days {
+		burnRates[day.time.Unix()] = day.burnRate
+	}
+	windowBurnRate := func(day time.Time, length int) (float64, bool) {
+		sum, count := 0.0, 0
+		for i := 0; i < length; i++ {
+			if burnRate, ok := burnRates[day.AddDate(0, 0, -i).Unix()]; ok {
+				sum += burnRate
+				count++
+			}
+		}
+		if count == 0 {
+			return 0, false
+		}
+		return sum / float64(count), true
+	}
+
+	alerts := []model.SimulatedAlert{}
+	for _, day := range days {
+		for _, alert := range simulatedAlerts {
+			long, _ := windowBurnRate(day.time, alert.longDays)
+			short, ok := windowBurnRate(day.time, alert.shortDays)
+			if ok && long >= alert.burnRate && short >= alert.burnRate {
+				alerts = append(alerts, model.SimulatedAlert{Day: day.time, Alert: alert.name, LongBurnRate: long, ShortBurnRate: short})
+			}
+		}
+	}
+	return alerts
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,105 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	client "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Slo simulation test", func() {
+	var mockController *gomock.Controller
+	var mockISLOProvider *provider.MockISLOProvider
+	var mockElasticClient *client.MockIClient
+	var mockMaintenance *service.MockIMaintenancePeriodService
+	var budgetService *service.ErrorBudgetService
+	logger, _ := logrustest.NewNullLogger()
+
+	value := func(v float64) *float64 { return &v }
+	date := func(month time.Month, d int) time.Time { return time.Date(2021, month, d, 0, 0, 0, 0, time.UTC) }
+	slo := &model.Slo{ID: 7, SuccessRateExpectedAvailability: "99", WindowType: model.SloWindowTypeCalendar}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockElasticClient = client.NewMockIClient(mockController)
+		mockMaintenance = service.NewMockIMaintenancePeriodService(mockController)
+		budgetService = &service.ErrorBudgetService{
+			SloProvider:   mockISLOProvider,
+			ElasticClient: mockElasticClient,
+			Maintenance:   mockMaintenance,
+			Log:           logger,
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Simulate(sloID int64, simulation *model.SloSimulation)", func() {
+		Context("When the history misses the simulated target", func() {
+			It("Should return breached periods and the days alerts would have fired", func() {
+				from, to := date(time.February, 27), date(time.March, 3)
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(int64(7), &elasticModel.SloHistoryRange{From: from, To: to}, "1d").
+					Return([]*elasticModel.SloHistoryPoint{
+						{Time: date(time.February, 27), SuccessRate: value(99.8)},
+						{Time: date(time.February, 28), SuccessRate: value(99)},
+						{Time: date(time.March, 1), SuccessRate: value(98.5)},
+						{Time: date(time.March, 2), SuccessRate: value(98)},
+					}, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, from, to).Return([]model.MaintenancePeriod{}, nil)
+
+				result, err := budgetService.Simulate(7, &model.SloSimulation{SuccessRateExpectedAvailability: "99.5", From: from, To: to})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.CurrentTarget).To(Equal(99.0))
+				Expect(result.Target).To(Equal(99.5))
+				Expect(result.BreachedPeriods).To(Equal(2))
+				Expect(result.Periods).To(HaveLen(2))
+				Expect(result.Periods[0].From).To(Equal(from))
+				Expect(result.Periods[0].To).To(Equal(date(time.March, 1)))
+				Expect(*result.Periods[0].Actual).To(BeNumerically("~", 99.4, 1e-9))
+				Expect(*result.Periods[0].Consumed).To(BeNumerically("~", 2.4/28, 1e-9))
+				Expect(*result.Periods[1].Consumed).To(BeNumerically("~", 7.0/31, 1e-9))
+
+				alerts := []string{}
+				for _, alert := range result.Alerts {
+					alerts = append(alerts, alert.Day.Format("01-02")+" "+alert.Alert)
+				}
+				Expect(alerts).To(Equal([]string{"02-28 ticket", "03-01 ticket", "03-02 page", "03-02 ticket"}))
+			})
+		})
+
+		Context("When simulated target leaves no budget", func() {
+			It("Should return validation error", func() {
+				mockISLOProvider.EXPECT().GetSlo(int64(7)).Return(slo, nil)
+
+				_, err := budgetService.Simulate(7, &model.SloSimulation{SuccessRateExpectedAvailability: "100",
+					From: date(time.March, 1), To: date(time.March, 3)})
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When the range is longer than a year", func() {
+			It("Should return validation error", func() {
+				_, err := budgetService.Simulate(7, &model.SloSimulation{From: date(time.January, 1), To: date(time.January, 1).AddDate(2, 0, 0)})
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -4,11 +4,16 @@
 package service_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
 	client "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/auth"
+	datadogModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/datadog"
+	elasticModel "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/elastic"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model/grafana"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
 	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
@@ -21,9 +26,18 @@ import (
 var _ = Describe("Slo service test", func() {
 	var mockController *gomock.Controller
 	var mockISLOProvider *provider.MockISLOProvider
+	var mockStore *provider.MockISloStoreProvider
+	var mockPageProvider *provider.MockISloPageProvider
 	var mockIDatasourceProvider *provider.MockIDatasourceProvider
 	var mockDashboardService *service.MockIDashboardService
 	var mockElasticClient *client.MockIClient
+	var mockDatadog *datadog.MockIClient
+	var mockDatadogClients *service.MockIDatadogClientFactory
+	var mockMaintenance *service.MockIMaintenancePeriodService
+	var mockComposite *service.MockICompositeSloService
+	var mockLabels *service.MockISloLabelService
+	var mockOwnership *service.MockISloOwnershipService
+	var mockVersions *service.MockIEntityVersionService
 	logger, logHook := logrustest.NewNullLogger()
 	var sloService service.SloService
 	var userContext auth.UserContext
@@ -33,7 +47,16 @@ var _ = Describe("Slo service test", func() {
 	BeforeEach(func() {
 		mockController = gomock.NewController(GinkgoT())
 		mockISLOProvider = provider.NewMockISLOProvider(mockController)
+		mockStore = provider.NewMockISloStoreProvider(mockController)
+		mockPageProvider = provider.NewMockISloPageProvider(mockController)
 		mockElasticClient = client.NewMockIClient(mockController)
+		mockDatadog = datadog.NewMockIClient(mockController)
+		mockDatadogClients = service.NewMockIDatadogClientFactory(mockController)
+		mockMaintenance = service.NewMockIMaintenancePeriodService(mockController)
+		mockComposite = service.NewMockICompositeSloService(mockController)
+		mockLabels = service.NewMockISloLabelService(mockController)
+		mockOwnership = service.NewMockISloOwnershipService(mockController)
+		mockVersions = service.NewMockIEntityVersionService(mockController)
 		mockIDatasourceProvider = provider.NewMockIDatasourceProvider(mockController)
 		mockDashboardService = service.NewMockIDashboardService(mockController)
 
@@ -43,10 +66,19 @@ var _ = Describe("Slo service test", func() {
 		}
 
 		sloService = service.SloService{SloProvider: mockISLOProvider,
-			DSProvider:       mockIDatasourceProvider,
-			DashboardService: mockDashboardService,
-			ElasticClient:    mockElasticClient,
-			Log:              logger}
+			Store:              mockStore,
+			PageProvider:       mockPageProvider,
+			DSProvider:         mockIDatasourceProvider,
+			DashboardService:   mockDashboardService,
+			ElasticClient:      mockElasticClient,
+			DatadogClients:     mockDatadogClients,
+			ExternalValidation: service.ExternalValidationStrict,
+			Maintenance:        mockMaintenance,
+			Composite:          mockComposite,
+			Labels:             mockLabels,
+			Ownership:          mockOwnership,
+			Versions:           mockVersions,
+			Log:                logger}
 
 		logHook.Reset()
 	})
@@ -95,7 +127,10 @@ var _ = Describe("Slo service test", func() {
 		Context("When provider tries to create new SLO but fails", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, int64(0)).Times(1).Return(false, nil)
-				mockISLOProvider.EXPECT().CreateSlo(&slo).Times(1).Return(errory.ProviderErrors.New("provider create slo error"))
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockStore.EXPECT().CreateSloWithRelations(&slo, gomock.Any()).Times(1).Return(errory.ProviderErrors.New("provider create slo error"))
 				mockIDatasourceProvider.EXPECT().GetDatasourceByID(slo.DatasourceID).Return(&ds, nil)
 				err := sloService.Create(&userContext, &slo)
 
@@ -106,7 +141,10 @@ var _ = Describe("Slo service test", func() {
 		Context("When grafana client tries to create new dashboard but fails", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, int64(0)).Times(1).Return(false, nil)
-				mockISLOProvider.EXPECT().CreateSlo(&slo).Times(1).Return(nil)
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockStore.EXPECT().CreateSloWithRelations(&slo, gomock.Any()).Times(1).Return(nil)
 				//assign id to freshly created slo
 				slo.ID = sloID
 				mockDashboardService.EXPECT().CreateDashboard(&userContext, gomock.Any(), false).Return(errory.ProviderErrors.New("dashboard error"))
@@ -120,7 +158,16 @@ var _ = Describe("Slo service test", func() {
 		Context("When no error during creation occurred", func() {
 			It("Should correctly create an SLO", func() {
 				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, int64(0)).Times(1).Return(false, nil)
-				mockISLOProvider.EXPECT().CreateSlo(&slo).Times(1).Return(nil)
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockStore.EXPECT().CreateSloWithRelations(&slo, gomock.Any()).Times(1).DoAndReturn(
+					func(created *model.Slo, event *model.WebhookEvent) error {
+						Expect(event.Event).To(Equal(model.WebhookEventSloCreated))
+						Expect(event.OrgID).To(Equal(orgID))
+						Expect(event.Data).To(BeIdenticalTo(created))
+						return nil
+					})
 				//assign id to freshly created slo
 				slo.ID = sloID
 				mockDashboardService.EXPECT().CreateDashboard(&userContext, gomock.Any(), false).Return(nil)
@@ -132,7 +179,24 @@ var _ = Describe("Slo service test", func() {
 		})
 	})
 
-	Describe("Update(slo *model.Slo)", func() {
+	Describe("Create(slo *model.Slo) with labels", func() {
+		slo := model.Slo{OrgID: 2, Name: "TestSLO", Labels: map[string]string{"Team": "checkout"}}
+
+		Context("When labels are invalid", func() {
+			It("Should not create the slo", func() {
+				mockISLOProvider.EXPECT().ContainSlosWithSameName(slo.OrgID, slo.Name, int64(0)).Return(false, nil)
+				mockIDatasourceProvider.EXPECT().GetDatasourceByID(slo.DatasourceID).Return(&grafana.Datasource{Type: grafana.DatasourceTypeDatadog}, nil)
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(errory.ValidationErrors.New("label key"))
+
+				err := sloService.Create(&userContext, &slo)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Update(slo *model.Slo, version *int64)", func() {
 
 		orgID := int64(2)
 
@@ -142,13 +206,14 @@ var _ = Describe("Slo service test", func() {
 			Name:                            "TestSLO",
 			SuccessRateExpectedAvailability: "99",
 			ComplianceExpectedAvailability:  "99",
+			Labels:                          map[string]string{},
 		}
 
 		Context("When provider tries to find slo with the same name but returns an error", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, slo.ID).Times(1).Return(false, errory.NotUniqueErrors.New("duplicated slo name"))
 
-				err := sloService.Update(&userContext, &slo)
+				_, err := sloService.Update(&userContext, &slo, nil)
 
 				Expect(err).To(HaveOccurred())
 			})
@@ -158,7 +223,7 @@ var _ = Describe("Slo service test", func() {
 			It("Should return proper error", func() {
 				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, slo.ID).Times(1).Return(true, nil)
 
-				err := sloService.Update(&userContext, &slo)
+				_, err := sloService.Update(&userContext, &slo, nil)
 
 				Expect(err).To(HaveOccurred())
 				Expect(errory.IsOfType(err, errory.NotUniqueErrors)).To(BeTrue())
@@ -168,28 +233,227 @@ var _ = Describe("Slo service test", func() {
 		Context("When provider tries to create new SLO but fails", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, slo.ID).Times(1).Return(false, nil)
-				mockISLOProvider.EXPECT().UpdateSlo(&slo).Times(1).Return(errory.ProviderErrors.New("provider update slo error"))
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockOwnership.EXPECT().LoadOwnership(&slo).Return(nil)
+				mockStore.EXPECT().UpdateSloWithRelations(&slo, nil, gomock.Any()).Return(int64(0), false, errory.ProviderErrors.New("provider update slo error"))
 
-				err := sloService.Update(&userContext, &slo)
+				_, err := sloService.Update(&userContext, &slo, nil)
 
 				Expect(err).To(HaveOccurred())
 			})
 		})
 
+		Context("When slo was changed since the version", func() {
+			It("Should return the version conflict without updating the dashboard", func() {
+				version := int64(4)
+				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, slo.ID).Times(1).Return(false, nil)
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockOwnership.EXPECT().LoadOwnership(&slo).Return(nil)
+				mockStore.EXPECT().UpdateSloWithRelations(&slo, &version, gomock.Any()).Return(int64(0), false, nil)
+				mockVersions.EXPECT().Conflict(model.VersionedEntitySlo, slo.ID, &version).
+					Return(errory.PreconditionFailedErrors.New("slo 66 was changed meanwhile"))
+
+				_, err := sloService.Update(&userContext, &slo, &version)
+
+				Expect(errory.IsOfType(err, errory.PreconditionFailedErrors)).To(BeTrue())
+			})
+		})
+
 		Context("When grafana client tries to update a dashboard but fails", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().ContainSlosWithSameName(orgID, slo.Name, slo.ID).Times(1).Return(false, nil)
-				mockISLOProvider.EXPECT().UpdateSlo(&slo).Times(1).Return(nil)
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockStore.EXPECT().UpdateSloWithRelations(&slo, nil, gomock.Any()).Return(int64(2), true, nil)
+				mockOwnership.EXPECT().LoadOwnership(&slo).Return(nil)
 
 				mockDashboardService.EXPECT().CreateDashboard(&userContext, gomock.Any(), true).Return(errory.ProviderErrors.New("dashboard error"))
 
-				err := sloService.Update(&userContext, &slo)
+				_, err := sloService.Update(&userContext, &slo, nil)
 
 				Expect(err).To(HaveOccurred())
 			})
 		})
 	})
 
+	Describe("Update(slo *model.Slo) labels and ownership", func() {
+		var slo model.Slo
+
+		BeforeEach(func() {
+			slo = model.Slo{ID: 66, OrgID: 2, Name: "TestSLO"}
+			mockISLOProvider.EXPECT().ContainSlosWithSameName(slo.OrgID, slo.Name, slo.ID).Return(false, nil)
+			mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+			mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+			mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+		})
+
+		Context("When slo is updated without labels and ownership", func() {
+			It("Should keep the stored ones and render them on the dashboard", func() {
+				ownership := &model.SloOwnership{OwnerTeam: "checkout", Contacts: []model.SloContact{}}
+				mockStore.EXPECT().UpdateSloWithRelations(&slo, nil, gomock.Any()).DoAndReturn(
+					func(stored *model.Slo, _ *int64, event *model.WebhookEvent) (int64, bool, error) {
+						Expect(stored.Labels).To(BeNil())
+						Expect(event.Event).To(Equal(model.WebhookEventSloUpdated))
+						Expect(event.Data.(*model.Slo).Labels).To(Equal(map[string]string{"team": "checkout"}))
+						Expect(event.Data.(*model.Slo).Ownership).To(Equal(ownership))
+						return 2, true, nil
+					})
+				mockLabels.EXPECT().LoadLabels(&slo).DoAndReturn(func(slos ...*model.Slo) error {
+					slos[0].Labels = map[string]string{"team": "checkout"}
+					return nil
+				})
+				mockOwnership.EXPECT().LoadOwnership(gomock.Any()).DoAndReturn(func(slos ...*model.Slo) error {
+					slos[0].Ownership = ownership
+					return nil
+				})
+				mockDashboardService.EXPECT().CreateDashboard(&userContext, &slo, true).DoAndReturn(
+					func(_ *auth.UserContext, saved *model.Slo, _ bool) error {
+						Expect(saved.Labels).To(Equal(map[string]string{"team": "checkout"}))
+						Expect(saved.Ownership).To(Equal(ownership))
+						return nil
+					})
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("When slo is updated with empty labels and ownership", func() {
+			It("Should remove them without loading the stored ones", func() {
+				slo.Labels = map[string]string{}
+				slo.Ownership = &model.SloOwnership{}
+				mockStore.EXPECT().UpdateSloWithRelations(&slo, nil, gomock.Any()).Return(int64(2), true, nil)
+				mockDashboardService.EXPECT().CreateDashboard(&userContext, &slo, true).Return(nil)
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(slo.Labels).To(BeEmpty())
+				Expect(slo.Ownership).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Validation of external slo on Update(slo *model.Slo)", func() {
+		slo := model.Slo{
+			ID:                              66,
+			OrgID:                           2,
+			Name:                            "TestSLO",
+			SuccessRateExpectedAvailability: "99.9",
+			ComplianceExpectedAvailability:  "99.9",
+			DatasourceID:                    7,
+			ExternalID:                      "abc",
+			ExternalType:                    model.ExternalSloTypeMonitor,
+			Labels:                          map[string]string{},
+		}
+		BeforeEach(func() {
+			mockISLOProvider.EXPECT().ContainSlosWithSameName(slo.OrgID, slo.Name, slo.ID).Return(false, nil)
+		})
+
+		Context("When the keys of the datasource cannot be read", func() {
+			It("Should return the error without calling datadog", func() {
+				mockDatadogClients.EXPECT().ForDatasource(int64(7)).Return(nil, errory.NotFoundErrors.New("datasource not found"))
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When the slo is on another datadog datasource", func() {
+			It("Should validate it with the client of that datasource", func() {
+				otherDatadog := datadog.NewMockIClient(mockController)
+				otherSlo := slo
+				otherSlo.DatasourceID = 8
+				mockDatadogClients.EXPECT().ForDatasource(int64(8)).Return(otherDatadog, nil)
+				otherDatadog.EXPECT().GetSLO("abc").Return(nil, errory.NotFoundErrors.New("not found"))
+
+				_, err := sloService.Update(&userContext, &otherSlo, nil)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When datadog slo matches and has a different target", func() {
+			It("Should update the slo and warn about the target", func() {
+				mockDatadogClients.EXPECT().ForDatasource(int64(7)).Return(mockDatadog, nil)
+				mockDatadog.EXPECT().GetSLO("abc").Return(&datadogModel.SLO{ID: "abc", Type: "monitor",
+					Thresholds: []datadogModel.SLOThreshold{{Timeframe: "30d", Target: 99.5}}}, nil)
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockStore.EXPECT().UpdateSloWithRelations(&slo, nil, gomock.Any()).Return(int64(2), true, nil)
+				mockOwnership.EXPECT().LoadOwnership(&slo).Return(nil)
+				mockDashboardService.EXPECT().CreateDashboard(&userContext, &slo, true).Return(nil)
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logHook.LastEntry().Message).To(Equal("datadog slo target differs from expected success rate availability"))
+				Expect(slo.Warnings).To(Equal([]string{"datadog slo target 99.5 differs from expected success rate availability 99.9"}))
+			})
+		})
+
+		Context("When datadog slo does not exist", func() {
+			It("Should return validation error", func() {
+				mockDatadogClients.EXPECT().ForDatasource(int64(7)).Return(mockDatadog, nil)
+				mockDatadog.EXPECT().GetSLO("abc").Return(nil, errory.NotFoundErrors.New("not found"))
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When datadog cannot be reached", func() {
+			It("Should return service unavailable error", func() {
+				mockDatadogClients.EXPECT().ForDatasource(int64(7)).Return(mockDatadog, nil)
+				mockDatadog.EXPECT().GetSLO("abc").Return(nil, errory.FetchResourceErrors.New("connection refused"))
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(errory.IsOfType(err, errory.ServiceUnavailableErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When datadog slo is of another type", func() {
+			It("Should return validation error", func() {
+				mockDatadogClients.EXPECT().ForDatasource(int64(7)).Return(mockDatadog, nil)
+				mockDatadog.EXPECT().GetSLO("abc").Return(&datadogModel.SLO{ID: "abc", Type: "metric"}, nil)
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When validation is in warn mode", func() {
+			It("Should update the slo and log the validation error", func() {
+				sloService.ExternalValidation = service.ExternalValidationWarn
+				mockDatadogClients.EXPECT().ForDatasource(int64(7)).Return(mockDatadog, nil)
+				mockDatadog.EXPECT().GetSLO("abc").Return(nil, errory.NotFoundErrors.New("not found"))
+				mockComposite.EXPECT().ValidateChildren(&userContext, &slo).Return(nil)
+				mockLabels.EXPECT().ValidateLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().ValidateOwnership(&userContext, &slo).Return(nil)
+				mockStore.EXPECT().UpdateSloWithRelations(&slo, nil, gomock.Any()).Return(int64(2), true, nil)
+				mockOwnership.EXPECT().LoadOwnership(&slo).Return(nil)
+				mockDashboardService.EXPECT().CreateDashboard(&userContext, &slo, true).Return(nil)
+
+				_, err := sloService.Update(&userContext, &slo, nil)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logHook.LastEntry().Message).To(Equal("could not validate external slo"))
+				Expect(slo.Warnings).To(Equal([]string{"externalId does not reference an existing datadog slo"}))
+			})
+		})
+	})
+
 	Describe("Delete(id int64)", func() {
 		searchedID := int64(66)
 		orgID := int64(2)
@@ -205,7 +469,7 @@ var _ = Describe("Slo service test", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(nil, errory.NotFoundErrors.New("Cannot find slo with given id"))
 
-				err := sloService.Delete(&userContext, searchedID)
+				err := sloService.Delete(&userContext, searchedID, false)
 
 				Expect(err).To(HaveOccurred())
 			})
@@ -214,9 +478,10 @@ var _ = Describe("Slo service test", func() {
 		Context("When grafana client fails with deletion of the dashboard", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(&slo, nil)
+				mockComposite.EXPECT().GetParentsToUnlink(&slo, false).Return([]*model.SloChild{}, nil)
 				mockDashboardService.EXPECT().DeleteDashboard(&userContext, slo.ID, slo.OrgID).Times(1).Return(errory.ProviderErrors.New("Cannot delete dashboard with given sloID"))
 
-				err := sloService.Delete(&userContext, searchedID)
+				err := sloService.Delete(&userContext, searchedID, false)
 
 				Expect(err).To(HaveOccurred())
 			})
@@ -225,34 +490,56 @@ var _ = Describe("Slo service test", func() {
 		Context("When provider fails with deletion of the slo", func() {
 			It("Should return an error", func() {
 				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(&slo, nil)
+				mockComposite.EXPECT().GetParentsToUnlink(&slo, false).Return([]*model.SloChild{}, nil)
 				mockDashboardService.EXPECT().DeleteDashboard(&userContext, slo.ID, slo.OrgID).Times(1).Return(nil)
-				mockISLOProvider.EXPECT().DeleteSlo(&slo).Times(1).Return(errory.ProviderErrors.New("Cannot delete slo"))
+				mockStore.EXPECT().DeleteSloWithRelations(&slo, gomock.Any()).Times(1).Return(errory.ProviderErrors.New("Cannot delete slo"))
 
-				err := sloService.Delete(&userContext, searchedID)
+				err := sloService.Delete(&userContext, searchedID, false)
 
 				Expect(err).To(HaveOccurred())
 			})
 		})
 
-		Context("When provider fails with deletion of the slo", func() {
-			It("Should return an error", func() {
+		Context("When grafana client fails with error dashboard not found", func() {
+			It("Should succeeded", func() {
 				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(&slo, nil)
-				mockDashboardService.EXPECT().DeleteDashboard(&userContext, slo.ID, slo.OrgID).Times(1).Return(nil)
-				mockISLOProvider.EXPECT().DeleteSlo(&slo).Times(1).Return(errory.ProviderErrors.New("Cannot delete slo"))
+				mockComposite.EXPECT().GetParentsToUnlink(&slo, false).Return([]*model.SloChild{}, nil)
+				mockDashboardService.EXPECT().DeleteDashboard(&userContext, slo.ID, slo.OrgID).Times(1).Return(errory.ProviderErrors.New("Dashboard not found"))
+				mockStore.EXPECT().DeleteSloWithRelations(&slo, gomock.Any()).Times(1).DoAndReturn(
+					func(deleted *model.Slo, event *model.WebhookEvent) error {
+						Expect(event.Event).To(Equal(model.WebhookEventSloDeleted))
+						Expect(event.Data).To(BeIdenticalTo(deleted))
+						return nil
+					})
+				mockComposite.EXPECT().UpdateParentDashboards(&userContext, []*model.SloChild{})
 
-				err := sloService.Delete(&userContext, searchedID)
+				err := sloService.Delete(&userContext, searchedID, false)
 
-				Expect(err).To(HaveOccurred())
+				Expect(err).NotTo(HaveOccurred())
 			})
 		})
 
-		Context("When grafana client fails with error dashboard not found", func() {
-			It("Should succeeded", func() {
+		Context("When slo is a child of a composite slo", func() {
+			It("Should not delete it", func() {
 				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(&slo, nil)
-				mockDashboardService.EXPECT().DeleteDashboard(&userContext, slo.ID, slo.OrgID).Times(1).Return(errory.ProviderErrors.New("Dashboard not found"))
-				mockISLOProvider.EXPECT().DeleteSlo(&slo).Times(1).Return(nil)
+				mockComposite.EXPECT().GetParentsToUnlink(&slo, false).Return(nil, errory.ValidationErrors.New("slo is a child of composite slos"))
 
-				err := sloService.Delete(&userContext, searchedID)
+				err := sloService.Delete(&userContext, searchedID, false)
+
+				Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+			})
+		})
+
+		Context("When slo is a child of a composite slo and cascade is set", func() {
+			It("Should render the parent dashboards again after the deletion", func() {
+				parents := []*model.SloChild{{ParentID: 1, ChildID: slo.ID}}
+				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(&slo, nil)
+				mockComposite.EXPECT().GetParentsToUnlink(&slo, true).Return(parents, nil)
+				mockDashboardService.EXPECT().DeleteDashboard(&userContext, slo.ID, slo.OrgID).Times(1).Return(nil)
+				deleted := mockStore.EXPECT().DeleteSloWithRelations(&slo, gomock.Any()).Times(1).Return(nil)
+				mockComposite.EXPECT().UpdateParentDashboards(&userContext, parents).After(deleted)
+
+				err := sloService.Delete(&userContext, searchedID, true)
 
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -261,10 +548,17 @@ var _ = Describe("Slo service test", func() {
 		Context("When there is no error during deletion of the slo", func() {
 			It("Should succeeded", func() {
 				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(&slo, nil)
+				mockComposite.EXPECT().GetParentsToUnlink(&slo, false).Return([]*model.SloChild{}, nil)
 				mockDashboardService.EXPECT().DeleteDashboard(&userContext, slo.ID, slo.OrgID).Times(1).Return(nil)
-				mockISLOProvider.EXPECT().DeleteSlo(&slo).Times(1).Return(nil)
+				mockStore.EXPECT().DeleteSloWithRelations(&slo, gomock.Any()).Times(1).DoAndReturn(
+					func(deleted *model.Slo, event *model.WebhookEvent) error {
+						Expect(event.Event).To(Equal(model.WebhookEventSloDeleted))
+						Expect(event.Data).To(BeIdenticalTo(deleted))
+						return nil
+					})
+				mockComposite.EXPECT().UpdateParentDashboards(&userContext, []*model.SloChild{})
 
-				err := sloService.Delete(&userContext, searchedID)
+				err := sloService.Delete(&userContext, searchedID, false)
 
 				Expect(err).NotTo(HaveOccurred())
 			})
@@ -294,6 +588,9 @@ var _ = Describe("Slo service test", func() {
 		Context("When provider finds correct slo", func() {
 			It("Should succeed", func() {
 				mockISLOProvider.EXPECT().GetSlo(searchedID).Times(1).Return(&slo, nil)
+				mockComposite.EXPECT().LoadChildren(&slo).Return(nil)
+				mockLabels.EXPECT().LoadLabels(&slo).Return(nil)
+				mockOwnership.EXPECT().LoadOwnership(&slo).Return(nil)
 
 				foundSlo, err := sloService.Get(searchedID)
 
@@ -305,26 +602,110 @@ var _ = Describe("Slo service test", func() {
 
 	})
 
-	Describe("DeleteSloHistory(id int64)", func() {
+	Describe("DeleteSloHistory(deletion *elasticModel.SloHistoryDeletion)", func() {
 		sloID := int64(66)
+		from := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+		timeRange := &elasticModel.SloHistoryRange{From: from, To: to}
+		var deletion elasticModel.SloHistoryDeletion
+
+		BeforeEach(func() {
+			deletion = elasticModel.SloHistoryDeletion{SloID: sloID, From: from, To: to}
+		})
 
 		Context("Everything is OK", func() {
-			It("Should succeed", func() {
-				mockElasticClient.EXPECT().DeleteSloHistory(sloID).Return(nil)
-				err := sloService.DeleteSloHistory(sloID)
+			It("Should start deletion task", func() {
+				mockElasticClient.EXPECT().DeleteSloHistory(sloID, timeRange).Return("node:1", nil)
+				err := sloService.DeleteSloHistory(&deletion)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deletion.TaskID).To(Equal("node:1"))
+			})
+		})
+
+		Context("When it is a dry run", func() {
+			It("Should only count matching documents", func() {
+				deletion.DryRun = true
+				matches := []*elasticModel.SloHistoryIndexCount{{Index: "oma-datadog-slo-2021.03", Count: 3}}
+				mockElasticClient.EXPECT().CountSloHistory(sloID, timeRange).Return(matches, nil)
+				err := sloService.DeleteSloHistory(&deletion)
 				Expect(err).NotTo(HaveOccurred())
+				Expect(deletion.Matches).To(Equal(matches))
+				Expect(deletion.TaskID).To(BeEmpty())
 			})
 		})
 
 		Context("could not send request", func() {
 			It("Should return an error", func() {
-				mockElasticClient.EXPECT().DeleteSloHistory(sloID).Return(errory.ElasticClientErrors.New("could not send request"))
-				err := sloService.DeleteSloHistory(sloID)
+				mockElasticClient.EXPECT().DeleteSloHistory(sloID, timeRange).Return("", errory.ElasticClientErrors.New("could not send request"))
+				err := sloService.DeleteSloHistory(&deletion)
 				Expect(err).To(HaveOccurred())
 			})
 		})
 	})
 
+	Describe("GetSloHistoryDeletionTask(sloID int64, taskID string)", func() {
+		It("Should return task of the SLO from elastic", func() {
+			task := &elasticModel.SloHistoryDeletionTask{TaskID: "node:1", Completed: true}
+			mockElasticClient.EXPECT().GetDeleteTask(int64(66), "node:1").Return(task, nil)
+			result, err := sloService.GetSloHistoryDeletionTask(66, "node:1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(task))
+		})
+	})
+
+	Describe("GetSloHistory(sloID int64, timeRange *elasticModel.SloHistoryRange, interval string)", func() {
+		sloID := int64(66)
+		timeRange := &elasticModel.SloHistoryRange{
+			From: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+		}
+
+		Context("Everything is OK", func() {
+			It("Should return history points from elastic", func() {
+				points := []*elasticModel.SloHistoryPoint{{Time: timeRange.From}}
+				slo := &model.Slo{ID: sloID}
+				mockISLOProvider.EXPECT().GetSlo(sloID).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(sloID, timeRange, "1d").Return(points, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, timeRange.From, timeRange.To).Return([]model.MaintenancePeriod{}, nil)
+				history, err := sloService.GetSloHistory(sloID, timeRange, "1d")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*history).To(Equal(elasticModel.SloHistory{SloID: sloID, From: timeRange.From, To: timeRange.To, Interval: "1d", Points: points}))
+			})
+		})
+
+		Context("When slo has maintenance in the range", func() {
+			It("Should leave out the values of points overlapping maintenance", func() {
+				value := 99.5
+				points := []*elasticModel.SloHistoryPoint{
+					{Time: time.Date(2021, 3, 13, 0, 0, 0, 0, time.UTC), SuccessRate: &value},
+					{Time: time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC), SuccessRate: &value},
+				}
+				slo := &model.Slo{ID: sloID}
+				mockISLOProvider.EXPECT().GetSlo(sloID).Return(slo, nil)
+				mockElasticClient.EXPECT().GetSloHistory(sloID, timeRange, "1d").Return(points, nil)
+				mockMaintenance.EXPECT().GetPeriodsForSlo(slo, timeRange.From, timeRange.To).Return([]model.MaintenancePeriod{{
+					From: time.Date(2021, 3, 14, 22, 0, 0, 0, time.UTC),
+					To:   time.Date(2021, 3, 15, 2, 0, 0, 0, time.UTC),
+				}}, nil)
+
+				history, err := sloService.GetSloHistory(sloID, timeRange, "1d")
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*history.Points[0]).To(Equal(elasticModel.SloHistoryPoint{Time: points[0].Time, SuccessRate: &value}))
+				Expect(*history.Points[1]).To(Equal(elasticModel.SloHistoryPoint{Time: points[1].Time, Maintenance: true}))
+			})
+		})
+
+		Context("When slo does not exist", func() {
+			It("Should return an error without querying elastic", func() {
+				mockISLOProvider.EXPECT().GetSlo(sloID).Return(nil, errory.NotFoundErrors.New("slo"))
+				history, err := sloService.GetSloHistory(sloID, timeRange, "1d")
+				Expect(history).To(BeNil())
+				Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("GetByOrgID(orgID int64)", func() {
 		orgID := int64(2)
 		sloList := []*model.Slo{{
@@ -353,6 +734,8 @@ var _ = Describe("Slo service test", func() {
 		Context("When provider lists SLOs by organization", func() {
 			It("Should list proper list of SLOs", func() {
 				mockISLOProvider.EXPECT().GetSlosByOrganizationID(orgID).Times(1).Return(sloList, nil)
+				mockLabels.EXPECT().LoadLabels(sloList[0], sloList[1]).Return(nil)
+				mockOwnership.EXPECT().LoadOwnership(sloList[0], sloList[1]).Return(nil)
 
 				foundSloList, err := sloService.GetByOrgID(orgID)
 
@@ -390,6 +773,7 @@ var _ = Describe("Slo service test", func() {
 		Context("When provider finds correct slo", func() {
 			It("Should succeed", func() {
 				mockISLOProvider.EXPECT().FindSlos(&sloParams).Times(1).Return([]*model.Slo{slo}, nil)
+				mockLabels.EXPECT().LoadLabels(slo).Return(nil)
 
 				foundSlos, err := sloService.FindSlos(&sloParams)
 
@@ -397,11 +781,47 @@ var _ = Describe("Slo service test", func() {
 				Expect(foundSlos[0]).To(Equal(slo))
 			})
 		})
+		Context("When latency slos are queried", func() {
+			It("Should query the provider without metric type and keep only latency slos", func() {
+				latencyParams := sloParams
+				latencyParams.MetricType = model.MetricTypeLatency
+				providerParams := sloParams
+				providerParams.MetricType = model.MetricTypeNoMetricType
+				latencySlo := &model.Slo{ID: 67, OrgID: orgID, ExternalType: model.ExternalSloTypeTimeSlice, LatencyTarget: "99"}
+				mockISLOProvider.EXPECT().FindSlos(&providerParams).Times(1).Return([]*model.Slo{slo, latencySlo}, nil)
+				mockLabels.EXPECT().LoadLabels(slo, latencySlo).Return(nil)
+
+				foundSlos, err := sloService.FindSlos(&latencyParams)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundSlos).To(Equal([]*model.Slo{latencySlo}))
+			})
+		})
+		Context("When labels are queried", func() {
+			It("Should keep only slos carrying all labels", func() {
+				labelParams := sloParams
+				labelParams.Labels = map[string]string{"team": "checkout", "tier": "1"}
+				labeledSlo := &model.Slo{ID: 67, OrgID: orgID}
+				otherSlo := &model.Slo{ID: 68, OrgID: orgID}
+				mockISLOProvider.EXPECT().FindSlos(&labelParams).Times(1).Return([]*model.Slo{labeledSlo, otherSlo}, nil)
+				mockLabels.EXPECT().LoadLabels(labeledSlo, otherSlo).DoAndReturn(func(slos ...*model.Slo) error {
+					slos[0].Labels = map[string]string{"team": "checkout", "tier": "1", "env": "prod"}
+					slos[1].Labels = map[string]string{"team": "checkout"}
+					return nil
+				})
+
+				foundSlos, err := sloService.FindSlos(&labelParams)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundSlos).To(Equal([]*model.Slo{labeledSlo}))
+			})
+		})
 
 	})
 
-	Describe("GetDetailedSlos", func() {
+	Describe("GetSloPage", func() {
 		var slos []*model.DetailedSlo
+		var query *model.SloPageQuery
 
 		BeforeEach(func() {
 			slos = []*model.DetailedSlo{
@@ -422,35 +842,74 @@ var _ = Describe("Slo service test", func() {
 					OrgName: "test-org",
 				},
 			}
+			query = &model.SloPageQuery{Name: "test-", OrgName: "test-org", Sort: model.SloSortFieldName}
 		})
 
 		Context("When provider return slos", func() {
 			It("Should return detailed slos", func() {
-				mockISLOProvider.EXPECT().GetDetailedSlos("test-%", "test-org").Times(1).Return(slos, nil)
+				mockPageProvider.EXPECT().GetSloPage(query).Times(1).Return(slos, int64(2), nil)
+				mockLabels.EXPECT().LoadLabels(&slos[0].Slo, &slos[1].Slo).Return(nil)
+				mockOwnership.EXPECT().LoadOwnership(&slos[0].Slo, &slos[1].Slo).Return(nil)
 
-				foundSlos, err := sloService.GetDetailedSlos("test-%", "test-org")
+				page, err := sloService.GetSloPage(query)
 
 				Expect(err).NotTo(HaveOccurred())
-				Expect(len(foundSlos)).To(Equal(2))
+				Expect(page.Total).To(Equal(int64(2)))
+				Expect(page.NextCursor).To(BeNil())
+				Expect(len(page.Slos)).To(Equal(2))
+
+				Expect(page.Slos[0].ID).To(Equal(int64(5)))
+				Expect(page.Slos[0].OrgID).To(Equal(int64(25)))
+				Expect(page.Slos[0].Name).To(Equal("test-mcc9"))
+				Expect(page.Slos[0].OrgName).To(Equal("test-org"))
+
+				Expect(page.Slos[1].ID).To(Equal(int64(12)))
+				Expect(page.Slos[1].OrgID).To(Equal(int64(48)))
+				Expect(page.Slos[1].Name).To(Equal("test-mcc11"))
+				Expect(page.Slos[1].OrgName).To(Equal("test-org"))
+			})
+		})
+
+		Context("When more slos follow the page", func() {
+			It("Should return the cursor behind the last slo of the page", func() {
+				query.Limit, query.Descending = 1, true
+				mockPageProvider.EXPECT().GetSloPage(gomock.Any()).DoAndReturn(func(limited *model.SloPageQuery) ([]*model.DetailedSlo, int64, error) {
+					Expect(limited.Limit).To(Equal(2))
+					return slos, int64(7), nil
+				})
+				mockLabels.EXPECT().LoadLabels(&slos[0].Slo).Return(nil)
+				mockOwnership.EXPECT().LoadOwnership(&slos[0].Slo).Return(nil)
+
+				page, err := sloService.GetSloPage(query)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Total).To(Equal(int64(7)))
+				Expect(page.Slos).To(Equal(slos[:1]))
+				Expect(page.NextCursor).To(Equal(&model.SloCursor{Sort: model.SloSortFieldName, Descending: true, Value: "test-mcc9", ID: 5}))
+				Expect(query.Limit).To(Equal(1))
+			})
+		})
 
-				Expect(foundSlos[0].ID).To(Equal(int64(5)))
-				Expect(foundSlos[0].OrgID).To(Equal(int64(25)))
-				Expect(foundSlos[0].Name).To(Equal("test-mcc9"))
-				Expect(foundSlos[0].OrgName).To(Equal("test-org"))
+		Context("When the page is the last one", func() {
+			It("Should not return a cursor", func() {
+				query.Limit = 2
+				mockPageProvider.EXPECT().GetSloPage(gomock.Any()).Return(slos, int64(2), nil)
+				mockLabels.EXPECT().LoadLabels(&slos[0].Slo, &slos[1].Slo).Return(nil)
+				mockOwnership.EXPECT().LoadOwnership(&slos[0].Slo, &slos[1].Slo).Return(nil)
 
-				Expect(foundSlos[1].ID).To(Equal(int64(12)))
-				Expect(foundSlos[1].OrgID).To(Equal(int64(48)))
-				Expect(foundSlos[1].Name).To(Equal("test-mcc11"))
-				Expect(foundSlos[1].OrgName).To(Equal("test-org"))
+				page, err := sloService.GetSloPage(query)
 
+				Expect(err).NotTo(HaveOccurred())
+				Expect(page.Slos).To(HaveLen(2))
+				Expect(page.NextCursor).To(BeNil())
 			})
 		})
 
 		Context("When provider return error", func() {
 			It("Should return error", func() {
-				mockISLOProvider.EXPECT().GetDetailedSlos("test-%", "test-org").Times(1).Return(nil, errory.ProviderErrors.New("fatal provider error"))
+				mockPageProvider.EXPECT().GetSloPage(query).Times(1).Return(nil, int64(0), errory.ProviderErrors.New("fatal provider error"))
 
-				_, err := sloService.GetDetailedSlos("test-%", "test-org")
+				_, err := sloService.GetSloPage(query)
 				Expect(err).To(HaveOccurred())
 			})
 		})
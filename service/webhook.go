@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/webhook"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookDeliveryLeaseMargin is added to the time the attempts of a batch may take to lease the
+	// claimed deliveries, it covers looking up the webhooks and recording the attempts.
+	webhookDeliveryLeaseMargin = time.Minute
+	webhookDeliveryLogLimit    = 100
+	webhookSecretBytes         = 32
+)
+
+// Headers of the webhook deliveries.
+const (
+	WebhookEventHeader     = "X-Cruiser-Event"
+	WebhookDeliveryHeader  = "X-Cruiser-Delivery"
+	WebhookTimestampHeader = "X-Cruiser-Timestamp"
+	WebhookSignatureHeader = "X-Cruiser-Signature"
+)
+
+type IWebhookService interface {
+	Start(ctx context.Context)
+	DeliverDue(now time.Time)
+	Publish(orgID int64, event model.WebhookEventType, data interface{}) error
+	Create(webhook *model.Webhook) error
+	Update(webhook *model.Webhook) error
+	Delete(orgID, id int64) error
+	Get(orgID, id int64) (*model.Webhook, error)
+	GetByOrgID(orgID int64) ([]*model.Webhook, error)
+	GetDeliveries(orgID, webhookID int64, status model.WebhookDeliveryStatus) ([]*model.WebhookDelivery, error)
+}
+
+// WebhookService notifies the webhooks of organizations of events. Published events are stored
+// as deliveries in an outbox, which is sent every Interval in the background when Enabled.
+// Failed attempts are repeated after Backoff, doubled with every attempt up to MaxBackoff, until
+// MaxAttempts were made.
+type WebhookService struct {
+	Provider    provider.IWebhookProvider
+	Client      webhook.IClient
+	Enabled     bool
+	Interval    time.Duration
+	Lock        provider.IJobLockProvider
+	BatchSize   int
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Log         logrus.FieldLogger
+}
+
+// Start sends the due deliveries every Interval in the background until ctx is done.
+func (s *WebhookService) Start(ctx context.Context) {
+	if !s.Enabled {
+		s.Log.Info("webhook delivery disabled")
+		return
+	}
+
+	go runPeriodically(ctx, s.Lock, s.Log, "webhook_delivery", s.Interval, s.DeliverDue)
+}
+
+// Publish adds a delivery of the event to the outbox of every enabled webhook of the organization
+// subscribed to it. It is used for changes made outside the database, changes stored in it publish
+// their events in their own transaction.
+func (s *WebhookService) Publish(orgID int64, event model.WebhookEventType, data interface{}) error {
+	if err := s.Provider.PublishWebhookEvent(model.NewWebhookEvent(orgID, event, data)); err != nil {
+		return errory.Decorate(err, "webhook service publish()")
+	}
+	return nil
+}
+
+// DeliverDue sends up to BatchSize deliveries due at now and records the outcome of each attempt.
+// The deliveries are leased for as long as all attempts may take. Attempts which could not end
+// before the lease runs out are not started, the deliveries are claimed again after it.
+func (s *WebhookService) DeliverDue(now time.Time) {
+	lease := time.Duration(s.BatchSize)*webhook.DeliveryTimeout + webhookDeliveryLeaseMargin
+	deadline := time.Now().Add(lease)
+	deliveries, err := s.Provider.ClaimWebhookDeliveries(now, now.Add(lease), s.BatchSize)
+	if err != nil {
+		s.Log.WithError(err).Error("could not claim webhook deliveries")
+		return
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+
+	webhooks := map[int64]*model.Webhook{}
+	counts := map[model.WebhookDeliveryStatus]int{}
+	for i, delivery := range deliveries {
+		if time.Now().Add(webhook.DeliveryTimeout).After(deadline) {
+			s.Log.WithField("left", len(deliveries)-i).Warn("webhook delivery lease ran out, the rest is sent later")
+			break
+		}
+
+		w, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			w, err = s.Provider.GetWebhook(delivery.WebhookID)
+			if err != nil {
+				s.Log.WithError(err).WithField("deliveryId", delivery.ID).Error("could not get webhook of delivery")
+				continue
+			}
+			webhooks[delivery.WebhookID] = w
+		}
+
+		s.attempt(w, delivery)
+		if err = s.Provider.UpdateWebhookDelivery(delivery); err != nil {
+			s.Log.WithError(err).WithField("deliveryId", delivery.ID).Error("could not record webhook delivery")
+		}
+		counts[delivery.Status]++
+	}
+
+	s.Log.WithField("delivered", counts[model.WebhookDeliveryStatusDelivered]).
+		WithField("retried", counts[model.WebhookDeliveryStatusPending]).
+		WithField("failed", counts[model.WebhookDeliveryStatusFailed]).
+		Info("webhook deliveries sent")
+}
+
+// attempt sends the delivery to the current URL of the webhook. Deliveries of disabled webhooks
+// fail without being sent.
+func (s *WebhookService) attempt(w *model.Webhook, delivery *model.WebhookDelivery) {
+	if !w.Enabled {
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = "webhook is disabled"
+		return
+	}
+
+	attemptAt := time.Now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &attemptAt
+	delivery.URL = w.URL
+
+	status, err := s.Client.Post(w.URL, signatureHeaders(w.Secret, delivery, attemptAt), delivery.Payload)
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = model.WebhookDeliveryStatusDelivered
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &attemptAt
+		delivery.Error = ""
+	case delivery.Attempts >= s.MaxAttempts:
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.Error = errory.GetErrorDetails(err).Message
+	default:
+		next := attemptAt.Add(s.backoff(delivery.Attempts))
+		delivery.NextAttemptAt = &next
+		delivery.Error = errory.GetErrorDetails(err).Message
+	}
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func (s *WebhookService) backoff(attempts int) time.Duration {
+	delay := s.Backoff
+	for i := 1; i < attempts && delay < s.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.MaxBackoff {
+		return s.MaxBackoff
+	}
+	return delay
+}
+
+// signatureHeaders signs the timestamp and the payload, joined by a dot, with HMAC-SHA256 so that
+// receivers can verify the delivery and reject replays of old ones.
+func signatureHeaders(secret string, delivery *model.WebhookDelivery, at time.Time) map[string]string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(delivery.Payload)
+
+	return map[string]string{
+		WebhookEventHeader:     string(delivery.Event),
+		WebhookDeliveryHeader:  strconv.FormatInt(delivery.ID, 10),
+		WebhookTimestampHeader: timestamp,
+		WebhookSignatureHeader: "sha256=" + hex.EncodeToString(mac.Sum(nil)),
+	}
+}
+
+// Create generates a secret when none is given, the created webhook is the only one returned
+// with its secret. The URL must not point to an internal address.
+func (s *WebhookService) Create(w *model.Webhook) error {
+	if err := s.Client.VerifyURL(w.URL); err != nil {
+		return err
+	}
+	if w.Secret == "" {
+		secret := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(secret); err != nil {
+			return errory.ProcessingErrors.Wrap(err)
+		}
+		w.Secret = hex.EncodeToString(secret)
+	}
+
+	if err := s.Provider.CreateWebhook(w); err != nil {
+		return errory.Decorate(err, "webhook service create()")
+	}
+	return nil
+}
+
+// Update keeps the secret of the webhook when none is given. The URL must not point to an internal address.
+func (s *WebhookService) Update(w *model.Webhook) error {
+	if _, err := s.Get(w.OrgID, w.ID); err != nil {
+		return err
+	}
+	if err := s.Client.VerifyURL(w.URL); err != nil {
+		return err
+	}
+	if err := s.Provider.UpdateWebhook(w); err != nil {
+		return errory.Decorate(err, "webhook service update()")
+	}
+	return nil
+}
+
+// Delete deletes the webhook with its deliveries.
+func (s *WebhookService) Delete(orgID, id int64) error {
+	if _, err := s.Get(orgID, id); err != nil {
+		return err
+	}
+	if err := s.Provider.DeleteWebhook(id); err != nil {
+		return errory.Decorate(err, "webhook service delete()")
+	}
+	return nil
+}
+
+// Get returns the webhook without its secret, only when it belongs to the organization.
+func (s *WebhookService) Get(orgID, id int64) (*model.Webhook, error) {
+	w, err := s.Provider.GetWebhook(id)
+	if err != nil {
+		return nil, err
+	}
+	if w.OrgID != orgID {
+		return nil, errory.NotFoundErrors.Builder().WithPayload("webhook", id).Create()
+	}
+	w.Secret = ""
+	return w, nil
+}
+
+// GetByOrgID returns the webhooks of the organization without their secrets.
+func (s *WebhookService) GetByOrgID(orgID int64) ([]*model.Webhook, error) {
+	webhooks, err := s.Provider.GetWebhooksByOrgID(orgID)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range webhooks {
+		w.Secret = ""
+	}
+	return webhooks, nil
+}
+
+// GetDeliveries returns the latest deliveries of the webhook, of all statuses when status is empty.
+func (s *WebhookService) GetDeliveries(orgID, webhookID int64, status model.WebhookDeliveryStatus) ([]*model.WebhookDelivery, error) {
+	if _, err := s.Get(orgID, webhookID); err != nil {
+		return nil, err
+	}
+	return s.Provider.GetWebhookDeliveries(webhookID, status, webhookDeliveryLogLimit)
+}
@@ -0,0 +1,212 @@
+//go:build unitTests
+// +build unitTests
+
+package service_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/webhook"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/errory"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/model"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+)
+
+var _ = Describe("Webhook service test", func() {
+	var mockController *gomock.Controller
+	var mockWebhookProvider *provider.MockIWebhookProvider
+	var mockClient *webhook.MockIClient
+	var webhookService *service.WebhookService
+	logger, _ := logrustest.NewNullLogger()
+
+	const orgID int64 = 2
+	const secret = "0123456789abcdef0123456789abcdef"
+	now := time.Date(2021, 3, 4, 12, 0, 0, 0, time.UTC)
+	payload := json.RawMessage(`{"event":"slo.created","orgId":2}`)
+	var chat *model.Webhook
+	var delivery *model.WebhookDelivery
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		mockWebhookProvider = provider.NewMockIWebhookProvider(mockController)
+		mockClient = webhook.NewMockIClient(mockController)
+		webhookService = &service.WebhookService{
+			Provider:    mockWebhookProvider,
+			Client:      mockClient,
+			BatchSize:   10,
+			MaxAttempts: 3,
+			Backoff:     time.Minute,
+			MaxBackoff:  90 * time.Second,
+			Log:         logger,
+		}
+
+		chat = &model.Webhook{ID: 3, OrgID: orgID, Name: "chat", URL: "https://chat.example.com", Secret: secret,
+			Events: []model.WebhookEventType{model.WebhookEventSloCreated}, Enabled: true}
+		delivery = &model.WebhookDelivery{ID: 12, WebhookID: 3, OrgID: orgID, Event: model.WebhookEventSloCreated, Payload: payload,
+			Status: model.WebhookDeliveryStatusPending, URL: "https://old.example.com", CreatedAt: now}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	Describe("Publish(orgID, event, data)", func() {
+		It("Should add the event to the outbox", func() {
+			mockWebhookProvider.EXPECT().PublishWebhookEvent(gomock.Any()).DoAndReturn(func(event *model.WebhookEvent) error {
+				Expect(event.Event).To(Equal(model.WebhookEventPluginEnabled))
+				Expect(event.OrgID).To(Equal(orgID))
+				Expect(event.Data).To(Equal(map[string]int{"id": 7}))
+				return nil
+			})
+
+			Expect(webhookService.Publish(orgID, model.WebhookEventPluginEnabled, map[string]int{"id": 7})).To(Succeed())
+		})
+
+		It("Should return the error when the event cannot be stored", func() {
+			mockWebhookProvider.EXPECT().PublishWebhookEvent(gomock.Any()).Return(errory.ProviderErrors.New("connection refused"))
+
+			err := webhookService.Publish(orgID, model.WebhookEventPluginEnabled, nil)
+
+			Expect(errory.IsOfType(err, errory.ProviderErrors)).To(BeTrue())
+		})
+	})
+
+	Describe("DeliverDue(now time.Time)", func() {
+		BeforeEach(func() {
+			mockWebhookProvider.EXPECT().ClaimWebhookDeliveries(now, gomock.Any(), 10).
+				DoAndReturn(func(_, leaseUntil time.Time, limit int) ([]*model.WebhookDelivery, error) {
+					Expect(leaseUntil).To(BeTemporally(">", now.Add(time.Duration(limit)*webhook.DeliveryTimeout)))
+					return []*model.WebhookDelivery{delivery}, nil
+				})
+		})
+
+		It("Should send a signed delivery to the current url of the webhook", func() {
+			mockWebhookProvider.EXPECT().GetWebhook(int64(3)).Return(chat, nil)
+			mockClient.EXPECT().Post("https://chat.example.com", gomock.Any(), []byte(payload)).
+				DoAndReturn(func(_ string, headers map[string]string, body []byte) (int, error) {
+					Expect(headers[service.WebhookEventHeader]).To(Equal("slo.created"))
+					Expect(headers[service.WebhookDeliveryHeader]).To(Equal("12"))
+					mac := hmac.New(sha256.New, []byte(secret))
+					mac.Write([]byte(headers[service.WebhookTimestampHeader] + "."))
+					mac.Write(body)
+					Expect(headers[service.WebhookSignatureHeader]).To(Equal("sha256=" + hex.EncodeToString(mac.Sum(nil))))
+					return 204, nil
+				})
+			mockWebhookProvider.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+			webhookService.DeliverDue(now)
+
+			Expect(delivery.Status).To(Equal(model.WebhookDeliveryStatusDelivered))
+			Expect(delivery.Attempts).To(Equal(1))
+			Expect(delivery.URL).To(Equal("https://chat.example.com"))
+			Expect(*delivery.ResponseStatus).To(Equal(204))
+			Expect(delivery.DeliveredAt).To(Equal(delivery.LastAttemptAt))
+			Expect(delivery.NextAttemptAt).To(BeNil())
+		})
+
+		It("Should retry a failed attempt with exponential backoff", func() {
+			delivery.Attempts = 1
+			mockWebhookProvider.EXPECT().GetWebhook(int64(3)).Return(chat, nil)
+			mockClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any()).Return(0, errory.FetchResourceErrors.New("connection refused"))
+			mockWebhookProvider.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+			webhookService.DeliverDue(now)
+
+			Expect(delivery.Status).To(Equal(model.WebhookDeliveryStatusPending))
+			Expect(delivery.Attempts).To(Equal(2))
+			Expect(delivery.ResponseStatus).To(BeNil())
+			Expect(delivery.Error).To(ContainSubstring("connection refused"))
+			Expect(delivery.NextAttemptAt.Sub(*delivery.LastAttemptAt)).To(Equal(90 * time.Second))
+		})
+
+		It("Should fail the delivery after the last attempt", func() {
+			delivery.Attempts = 2
+			mockWebhookProvider.EXPECT().GetWebhook(int64(3)).Return(chat, nil)
+			mockClient.EXPECT().Post(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(500, errory.FetchResourceErrors.Builder().WithMessage("internal error").Create())
+			mockWebhookProvider.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+			webhookService.DeliverDue(now)
+
+			Expect(delivery.Status).To(Equal(model.WebhookDeliveryStatusFailed))
+			Expect(delivery.Attempts).To(Equal(3))
+			Expect(*delivery.ResponseStatus).To(Equal(500))
+			Expect(delivery.NextAttemptAt).To(BeNil())
+		})
+
+		It("Should fail deliveries of disabled webhooks without sending them", func() {
+			chat.Enabled = false
+			mockWebhookProvider.EXPECT().GetWebhook(int64(3)).Return(chat, nil)
+			mockWebhookProvider.EXPECT().UpdateWebhookDelivery(delivery).Return(nil)
+
+			webhookService.DeliverDue(now)
+
+			Expect(delivery.Status).To(Equal(model.WebhookDeliveryStatusFailed))
+			Expect(delivery.Attempts).To(Equal(0))
+			Expect(delivery.Error).To(Equal("webhook is disabled"))
+		})
+	})
+
+	Describe("Create(webhook *model.Webhook)", func() {
+		It("Should generate a secret when none is given", func() {
+			created := &model.Webhook{OrgID: orgID, Name: "chat", URL: "https://chat.example.com"}
+			mockClient.EXPECT().VerifyURL("https://chat.example.com").Return(nil)
+			mockWebhookProvider.EXPECT().CreateWebhook(created).Return(nil)
+
+			err := webhookService.Create(created)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.Secret).To(MatchRegexp("^[0-9a-f]{64}$"))
+		})
+
+		It("Should not store a webhook with an internal url", func() {
+			created := &model.Webhook{OrgID: orgID, Name: "metadata", URL: "http://169.254.169.254"}
+			mockClient.EXPECT().VerifyURL("http://169.254.169.254").Return(errory.ValidationErrors.New("internal"))
+
+			err := webhookService.Create(created)
+
+			Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+		})
+	})
+
+	Describe("Update(webhook *model.Webhook)", func() {
+		It("Should not store an internal url", func() {
+			updated := &model.Webhook{ID: 3, OrgID: orgID, Name: "chat", URL: "http://10.0.0.1"}
+			mockWebhookProvider.EXPECT().GetWebhook(int64(3)).Return(chat, nil)
+			mockClient.EXPECT().VerifyURL("http://10.0.0.1").Return(errory.ValidationErrors.New("internal"))
+
+			err := webhookService.Update(updated)
+
+			Expect(errory.IsOfType(err, errory.ValidationErrors)).To(BeTrue())
+		})
+	})
+
+	Describe("Get(orgID, id int64)", func() {
+		It("Should return the webhook without its secret", func() {
+			mockWebhookProvider.EXPECT().GetWebhook(int64(3)).Return(chat, nil)
+
+			result, err := webhookService.Get(orgID, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Secret).To(BeEmpty())
+		})
+
+		It("Should not return a webhook of another organization", func() {
+			mockWebhookProvider.EXPECT().GetWebhook(int64(3)).Return(chat, nil)
+
+			_, err := webhookService.Get(5, 3)
+
+			Expect(errory.IsOfType(err, errory.NotFoundErrors)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package wiring
+
+import (
+	"github.com/google/wire"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/datadog"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/elastic"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/grafana"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/idam"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/client/webhook"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/middleware"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/provider"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service"
+	pluginService "github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/service/plugin"
+	"github.com/metro-digital-inner-source/errorbudget-grafana-controller/grafana-controller/validator"
+)
+
+var ConfigsSet = wire.NewSet(
+	ReadInPluginConfig,
+	CreateLoggerWithStandardFields,
+)
+
+var ClientsSet = wire.NewSet(
+	NewGrafanaClient, wire.Bind(new(grafana.IClient), new(*grafana.Client)),
+	NewSDAElasticClient, wire.Bind(new(elastic.IClient), new(*elastic.Client)),
+	NewIDAMClient, wire.Bind(new(idam.IIDAMClient), new(*idam.IDAMRestClient)),
+	NewDatadogClient, wire.Bind(new(datadog.IClient), new(*datadog.Client)),
+	NewWebhookClient, wire.Bind(new(webhook.IClient), new(*webhook.Client)),
+	NewDatadogClientFactory, wire.Bind(new(service.IDatadogClientFactory), new(*service.DatadogClientFactory)),
+)
+
+var ServicesSet = wire.NewSet(
+	NewSloService, wire.Bind(new(service.ISloService), new(*service.SloService)),
+	wire.Struct(new(service.DatasourceService), "*"), wire.Bind(new(service.IDatasourceService), new(*service.DatasourceService)),
+	wire.Struct(new(service.OrganizationService), "*"), wire.Bind(new(service.IOrganizationService), new(*service.OrganizationService)),
+	NewDashboardService, wire.Bind(new(service.IDashboardService), new(*service.DashboardService)),
+	wire.Struct(new(service.ParamExistCheckService), "*"), wire.Bind(new(service.IParamExistCheckService), new(*service.ParamExistCheckService)),
+	NewPluginService, wire.Bind(new(pluginService.IPluginService), new(*pluginService.Plugin)),
+	NewDSParser, wire.Bind(new(pluginService.IDatasourceParser), new(*pluginService.DatasourceParser)),
+	wire.Struct(new(service.SDAService), "*"), wire.Bind(new(service.ISDAService), new(*service.SDAService)),
+	wire.Struct(new(service.HealthService), "*"), wire.Bind(new(service.IHealthService), new(*service.HealthService)),
+	wire.Struct(new(service.FeedbackService), "*"), wire.Bind(new(service.IFeedbackService), new(*service.FeedbackService)),
+	wire.Struct(new(service.HappinessMetricService), "*"), wire.Bind(new(service.IHappinessMetricService), new(*service.HappinessMetricService)),
+	wire.Struct(new(service.UserInfoService), "*"), wire.Bind(new(service.IUserInfoService), new(*service.UserInfoService)),
+	wire.Struct(new(service.SolutionsService), "*"), wire.Bind(new(service.ISolutionsService), new(*service.SolutionsService)),
+	wire.Struct(new(service.SolutionSloService), "*"), wire.Bind(new(service.ISolutionSloService), new(*service.SolutionSloService)),
+	wire.Struct(new(service.RecommendationVoteService), "*"), wire.Bind(new(service.IRecommendationVoteService), new(*service.RecommendationVoteService)),
+	wire.Struct(new(service.ProductsStatusService), "*"), wire.Bind(new(service.IProductsStatusService), new(*service.ProductsStatusService)),
+	wire.Struct(new(service.DatadogImportService), "*"), wire.Bind(new(service.IDatadogImportService), new(*service.DatadogImportService)),
+	NewSloHistoryIngestionService, wire.Bind(new(service.ISloHistoryIngestionService), new(*service.SloHistoryIngestionService)),
+	wire.Struct(new(service.MaintenanceWindowService), "*"), wire.Bind(new(service.IMaintenanceWindowService), new(*service.MaintenanceWindowService)),
+	wire.Struct(new(service.MaintenancePeriodService), "*"), wire.Bind(new(service.IMaintenancePeriodService), new(*service.MaintenancePeriodService)),
+	NewErrorBudgetService, wire.Bind(new(service.IErrorBudgetService), new(*service.ErrorBudgetService)),
+	wire.Struct(new(service.CompositeSloService), "*"), wire.Bind(new(service.ICompositeSloService), new(*service.CompositeSloService)),
+	NewBudgetPolicyService, wire.Bind(new(service.IBudgetPolicyService), new(*service.BudgetPolicyService)),
+	NewComplianceReportService, wire.Bind(new(service.IComplianceReportService), new(*service.ComplianceReportService)),
+	NewSlaService, wire.Bind(new(service.ISlaService), new(*service.SlaService)),
+	wire.Struct(new(service.SloLabelService), "*"), wire.Bind(new(service.ISloLabelService), new(*service.SloLabelService)),
+	wire.Struct(new(service.SloOwnershipService), "*"), wire.Bind(new(service.ISloOwnershipService), new(*service.SloOwnershipService)),
+	NewSloChangeRequestService, wire.Bind(new(service.ISloChangeRequestService), new(*service.SloChangeRequestService)),
+	wire.Struct(new(service.SearchService), "*"), wire.Bind(new(service.ISearchService), new(*service.SearchService)),
+	wire.Struct(new(service.EntityVersionService), "*"), wire.Bind(new(service.IEntityVersionService), new(*service.EntityVersionService)),
+	NewIdempotencyService, wire.Bind(new(service.IIdempotencyService), new(*service.IdempotencyService)),
+	NewSloBatchService, wire.Bind(new(service.ISloBatchService), new(*service.SloBatchService)),
+	NewWebhookService, wire.Bind(new(service.IWebhookService), new(*service.WebhookService)),
+)
+
+var ValidatorsSet = wire.NewSet(
+	validator.NewSLOValidator, wire.Bind(new(validator.ISLOValidator), new(*validator.SLOValidator)),
+	validator.NewFeedbackValidator, wire.Bind(new(validator.IFeedbackValidator), new(*validator.FeedbackValidator)),
+	validator.NewHappinessMetricValidator, wire.Bind(new(validator.IHappinessMetricValidator), new(*validator.HappinessMetricValidator)),
+	validator.NewValidator, wire.Bind(new(validator.ITranslatedValidator), new(*validator.TranslatedValidator)),
+)
+
+var ProviderSet = wire.NewSet(
+	NewDBConnection,
+	wire.Struct(new(provider.SQL), "*"),
+	wire.Bind(new(provider.IOrganizationProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISLOProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IDatasourceProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISDAProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISolutionsProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IHealthProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IFeedbackProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IRecommendationVoteProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IHappinessMetricProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IUserInfoProvider), new(*provider.SQL)),
+	NewAuthProvider, wire.Bind(new(provider.IAuthProvider), new(*provider.AuthProvider)),
+	wire.Bind(new(middleware.IAuthorizer), new(*provider.AuthProvider)),
+	NewDatadogCredentialsProvider, wire.Bind(new(provider.IDatadogCredentialsProvider), new(*provider.DatadogCredentialsProvider)),
+	wire.Bind(new(provider.ISolutionSloProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IProductsStatusProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IMaintenanceWindowProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ICompositeSloProvider), new(*provider.SQL)),
+	wire.Bind(new(service.ISloAuthorizer), new(*provider.AuthProvider)),
+	wire.Bind(new(service.ISloBatchAuthorizer), new(*provider.AuthProvider)),
+	wire.Bind(new(provider.IBudgetPolicyProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IComplianceReportProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISlaProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISloLabelProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISloStoreProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISloOwnershipProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISloChangeRequestProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISloPageProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.ISearchProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IEntityVersionProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IIdempotencyProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IJobLockProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IAdminProvider), new(*provider.SQL)),
+	wire.Bind(new(provider.IWebhookProvider), new(*provider.SQL)),
+)